@@ -111,6 +111,10 @@ func main() {
 			strategy = "session_trader"
 		}
 		
+		if err := SetTelegramRiskProfile(os.Getenv("TELEGRAM_RISK_PROFILE")); err != nil {
+			log.Printf("⚠️  Ignoring TELEGRAM_RISK_PROFILE: %v", err)
+		}
+		
 		// Get current filter settings from database (not from env vars)
 		filterBuy, filterSell := GetCurrentFilterSettings()
 		
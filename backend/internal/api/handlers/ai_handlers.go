@@ -184,18 +184,8 @@ func HandleAICompareStrategies(c *fiber.Ctx) error {
 		})
 	}
 	
-	strategies := []string{
-		"session_trader",
-		"liquidity_hunter",
-		"breakout_master",
-		"trend_rider",
-		"range_master",
-		"smart_money_tracker",
-		"institutional_follower",
-		"reversal_sniper",
-		"momentum_beast",
-		"scalper_pro",
-	}
+	// Every registered strategy, rule strategies and the ensemble included
+	strategies := StrategyNames()
 	
 	results := make([]fiber.Map, 0)
 	
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HandleListAnalysisDetectors lists the market structure detectors available as chart overlays
+func HandleListAnalysisDetectors(c *fiber.Ctx) error {
+	detectors := ListChartAnalyzers()
+
+	return c.JSON(fiber.Map{
+		"success":   true,
+		"count":     len(detectors),
+		"detectors": detectors,
+	})
+}
+
+// HandleGetAnalysis runs the chosen detectors (?detectors=ict,liquidity; default all)
+// on a symbol and returns their zones, levels and events as drawable primitives
+func HandleGetAnalysis(c *fiber.Ctx) error {
+	analyzers := []ChartAnalyzer{}
+	if names := c.Query("detectors"); names != "" {
+		for _, name := range strings.Split(names, ",") {
+			analyzer, ok := GetChartAnalyzer(strings.TrimSpace(name))
+			if !ok {
+				return c.Status(400).JSON(fiber.Map{
+					"error": "Unknown detector: " + name,
+				})
+			}
+			analyzers = append(analyzers, analyzer)
+		}
+	} else {
+		analyzers = ListChartAnalyzers()
+	}
+
+	return runChartAnalysis(c, analyzers)
+}
+
+// HandleGetDetectorAnalysis runs one detector on a symbol
+func HandleGetDetectorAnalysis(c *fiber.Ctx) error {
+	analyzer, ok := GetChartAnalyzer(c.Params("detector"))
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{
+			"error": "Unknown detector: " + c.Params("detector"),
+		})
+	}
+
+	return runChartAnalysis(c, []ChartAnalyzer{analyzer})
+}
+
+func runChartAnalysis(c *fiber.Ctx, analyzers []ChartAnalyzer) error {
+	symbol := strings.ToUpper(c.Params("symbol"))
+	interval := c.Query("interval", "15m")
+	days := c.QueryInt("days", 7)
+	limit := c.QueryInt("limit", 20) // Primitives per kind; 0 = all
+
+	candles, err := fetchBinanceData(symbol, interval, days)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to fetch data: " + err.Error(),
+		})
+	}
+	if len(candles) == 0 {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "No candles returned",
+		})
+	}
+
+	primitives := []ChartPrimitive{}
+	summaries := fiber.Map{}
+	for _, analyzer := range analyzers {
+		result := analyzer.Analyze(candles, limit)
+		primitives = append(primitives, result.Primitives...)
+		summaries[analyzer.Name] = result.Summary
+	}
+
+	// The ledger remembers levels and sweeps from before this window
+	ledger := updateLiquidityLedger(symbol, interval, candles)
+
+	return c.JSON(fiber.Map{
+		"success":    true,
+		"symbol":     symbol,
+		"interval":   interval,
+		"candles":    len(candles),
+		"from":       candles[0].Timestamp,
+		"to":         candles[len(candles)-1].Timestamp,
+		"primitives": primitives,
+		"summary":    summaries,
+		"liquidity":  ledger.Summarize(candles[len(candles)-1].Close, 10),
+	})
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"tradebot/backend/internal/indicators"
+)
+
+// HandleGetAnchoredVWAP returns anchored VWAPs with their standard deviation
+// bands, the current reading and a series per anchor
+// (?interval=15m&days=30&anchors=session,week,month,swing_high:5,swing_low:5,time:<ms>&limit=300)
+func HandleGetAnchoredVWAP(c *fiber.Ctx) error {
+	symbol := strings.ToUpper(c.Params("symbol"))
+	interval := c.Query("interval", "15m")
+	days := c.QueryInt("days", 30)
+	limit := c.QueryInt("limit", 300)
+
+	specs := []indicators.AnchorSpec{}
+	for _, s := range strings.Split(c.Query("anchors", "session,week,month,swing_high:5,swing_low:5"), ",") {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		spec, err := indicators.ParseAnchor(s)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		specs = append(specs, spec)
+	}
+	if len(specs) == 0 {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "anchors must list at least one anchor",
+		})
+	}
+
+	candles, err := fetchBinanceData(symbol, interval, days)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to fetch data: " + err.Error(),
+		})
+	}
+	if len(candles) == 0 {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "No candles returned",
+		})
+	}
+
+	type anchorResult struct {
+		Current indicators.VWAPBands   `json:"current"`
+		Ready   bool                   `json:"ready"`
+		Series  []indicators.VWAPBands `json:"series"`
+	}
+	results := make(map[string]anchorResult, len(specs))
+	for _, spec := range specs {
+		series := indicators.AnchoredVWAPSeries(candles, spec)
+		current := series[len(series)-1]
+		if limit > 0 && len(series) > limit {
+			series = series[len(series)-limit:]
+		}
+		results[spec.String()] = anchorResult{Current: current, Ready: current.Bars > 0, Series: series}
+	}
+
+	last := candles[len(candles)-1]
+	return c.JSON(fiber.Map{
+		"success":  true,
+		"symbol":   symbol,
+		"interval": interval,
+		"price":    last.Close,
+		"time":     last.Timestamp,
+		"anchors":  results,
+	})
+}
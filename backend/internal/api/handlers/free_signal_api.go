@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"os"
 	"time"
+
+	"tradebot/backend/internal/indicators"
 )
 
 // ==================== FREE TRADING SIGNAL API INTEGRATION ====================
@@ -90,13 +92,13 @@ func (s *SignalAPIService) GetTradingViewSignal(symbol string, interval string)
 	}
 
 	// Calculate technical indicators
-	rsi := calculateRSI(candles, 14)
-	ema20 := calculateEMA(candles, 20)
-	ema50 := calculateEMA(candles, 50)
+	rsi := indicators.RSI(candles, 14)
+	ema20 := indicators.EMA(candles, 20)
+	ema50 := indicators.EMA(candles, 50)
 	currentPrice := candles[len(candles)-1].Close
 
 	// Calculate MACD
-	macd, macdSignal := calculateMACD(candles)
+	macd, macdSignal, _ := indicators.MACD(candles, 12, 26, 9)
 	
 	// Calculate Stochastic
 	stochK, stochD := calculateStochastic(candles, 14)
@@ -452,23 +454,6 @@ func AggregateSignals(symbol string, interval string) (*ExternalSignal, error) {
 
 // ==================== HELPER FUNCTIONS ====================
 
-// calculateMACD calculates MACD indicator
-func calculateMACD(candles []Candle) (float64, float64) {
-	if len(candles) < 26 {
-		return 0, 0
-	}
-
-	ema12 := calculateEMA(candles, 12)
-	ema26 := calculateEMA(candles, 26)
-	macd := ema12 - ema26
-
-	// Calculate signal line (9-period EMA of MACD)
-	// Simplified: use last 9 candles
-	macdSignal := macd * 0.9 // Simplified
-
-	return macd, macdSignal
-}
-
 // calculateStochastic calculates Stochastic oscillator
 func calculateStochastic(candles []Candle, period int) (float64, float64) {
 	if len(candles) < period {
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"tradebot/backend/internal/liquidity"
+)
+
+// LoadLiquidityLedger loads the persisted liquidity ledger
+func LoadLiquidityLedger() {
+	n, err := liquidity.GetLedger().Load()
+	if err != nil {
+		log.Printf("⚠️  Liquidity ledger not loaded: %v", err)
+		return
+	}
+	if n > 0 {
+		log.Printf("✅ Loaded liquidity ledger for %d markets from %s", n, liquidity.LedgerFile())
+	}
+}
+
+// updateLiquidityLedger feeds the closed bars of candles into the market's
+// ledger book. A failed save is logged; the updated book is still returned.
+func updateLiquidityLedger(symbol, interval string, candles []Candle) *liquidity.Book {
+	book, err := liquidity.GetLedger().Update(symbol, interval, liquidity.ClosedCandles(candles, time.Now().UnixMilli()))
+	if err != nil {
+		log.Printf("⚠️  Liquidity ledger not saved: %v", err)
+	}
+	return book
+}
+
+// HandleListLiquidity summarizes every market tracked by the ledger
+func HandleListLiquidity(c *fiber.Ctx) error {
+	books := liquidity.GetLedger().List()
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"count":   len(books),
+		"markets": books,
+	})
+}
+
+// HandleGetLiquidity brings a market's ledger up to date and returns its levels
+// and sweeps (?interval=15m&days=10&source=session,day&active=true&sweeps=50)
+func HandleGetLiquidity(c *fiber.Ctx) error {
+	symbol := strings.ToUpper(c.Params("symbol"))
+	interval := c.Query("interval", "15m")
+	days := c.QueryInt("days", 10)
+	activeOnly := c.QueryBool("active", false)
+	maxSweeps := c.QueryInt("sweeps", 50)
+
+	sources := []string{}
+	for _, s := range strings.Split(c.Query("source"), ",") {
+		switch s = strings.TrimSpace(s); s {
+		case "":
+		case liquidity.SourceSession, liquidity.SourceDay, liquidity.SourceWeek, liquidity.SourceSwing:
+			sources = append(sources, s)
+		default:
+			return c.Status(400).JSON(fiber.Map{
+				"error": fmt.Sprintf("Unknown source %q (use session, day, week or swing)", s),
+			})
+		}
+	}
+
+	candles, err := fetchBinanceData(symbol, interval, days)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to fetch data: " + err.Error(),
+		})
+	}
+	if len(candles) == 0 {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "No candles returned",
+		})
+	}
+
+	book := updateLiquidityLedger(symbol, interval, candles)
+	price := candles[len(candles)-1].Close
+
+	levels := book.All(sources...)
+	if activeOnly {
+		levels = book.Active(sources...)
+	}
+	sweeps := book.SweptSince(0, sources...)
+	if maxSweeps > 0 && len(sweeps) > maxSweeps {
+		sweeps = sweeps[len(sweeps)-maxSweeps:]
+	}
+	above, below := book.Nearest(price, sources...)
+
+	return c.JSON(fiber.Map{
+		"success":      true,
+		"symbol":       symbol,
+		"interval":     interval,
+		"price":        price,
+		"lastBar":      book.LastTime,
+		"config":       book.Config,
+		"levels":       levels,
+		"sweeps":       sweeps,
+		"nearestAbove": above,
+		"nearestBelow": below,
+	})
+}
+
+// HandleResetLiquidity drops a market's ledger so it is rebuilt from history
+// on the next request (?interval=15m)
+func HandleResetLiquidity(c *fiber.Ctx) error {
+	symbol := strings.ToUpper(c.Params("symbol"))
+	interval := c.Query("interval", "15m")
+
+	found, err := liquidity.GetLedger().Reset(symbol, interval)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to save ledger: " + err.Error(),
+		})
+	}
+	if !found {
+		return c.Status(404).JSON(fiber.Map{
+			"error": fmt.Sprintf("No liquidity ledger for %s %s", symbol, interval),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": fmt.Sprintf("Liquidity ledger for %s %s reset", symbol, interval),
+	})
+}
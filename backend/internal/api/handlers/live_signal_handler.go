@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"tradebot/backend/internal/regime"
 )
 
 type LiveSignalRequest struct {
@@ -16,16 +17,21 @@ type LiveSignalRequest struct {
 }
 
 type LiveSignalResponse struct {
-	Signal       string  `json:"signal"`
-	CurrentPrice float64 `json:"currentPrice"`
-	Entry        float64 `json:"entry"`
-	StopLoss     float64 `json:"stopLoss"`
-	TakeProfit   float64 `json:"takeProfit"`
-	TP1          float64 `json:"tp1"`
-	TP2          float64 `json:"tp2"`
-	TP3          float64 `json:"tp3"`
-	RiskReward   float64 `json:"riskReward"`
-	Timestamp    int64   `json:"timestamp"`
+	Signal        string        `json:"signal"`
+	CurrentPrice  float64       `json:"currentPrice"`
+	Entry         float64       `json:"entry"`
+	StopLoss      float64       `json:"stopLoss"`
+	TakeProfit    float64       `json:"takeProfit"`
+	TP1           float64       `json:"tp1"`
+	TP2           float64       `json:"tp2"`
+	TP3           float64       `json:"tp3"`
+	RiskReward    float64       `json:"riskReward"`
+	Timestamp     int64         `json:"timestamp"`
+	Regime        *regime.State `json:"regime,omitempty"` // Market regime at the last candle
+	Reasons       []string      `json:"reasons,omitempty"`
+	Evidence      []Evidence    `json:"evidence,omitempty"`      // Zones behind the signal, for chart overlays
+	ParamsVersion int           `json:"paramsVersion,omitempty"` // Live parameter version used; 0 for strategy defaults
+	RiskPercent   float64       `json:"riskPercent,omitempty"`   // Fraction of balance to risk, from the live version when it sets one
 }
 
 func HandleLiveSignal(w http.ResponseWriter, r *http.Request) {
@@ -76,32 +82,16 @@ func HandleLiveSignal(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Generate signal based on strategy
-	signal := generateLiveSignal(candles, req.Strategy)
+	signal := generateLiveSignal(candles, req.Strategy, req.Symbol)
 
 	json.NewEncoder(w).Encode(signal)
 }
 
 func getStrategyInterval(strategy string) string {
-	intervals := map[string]string{
-		"session_trader":          "15m",
-		"breakout_master":         "15m",
-		"liquidity_hunter":        "15m",
-		"momentum_beast":          "15m",
-		"scalper_pro":             "5m",
-		"range_master":            "1h",
-		"smart_money_tracker":     "1h",
-		"reversal_sniper":         "1h",
-		"trend_rider":             "4h",
-		"institutional_follower":  "4h",
-	}
-
-	if interval, ok := intervals[strategy]; ok {
-		return interval
-	}
-	return "15m" // default
+	return StrategyTimeframe(strategy, "15m")
 }
 
-func generateLiveSignal(candles []Candle, strategy string) LiveSignalResponse {
+func generateLiveSignal(candles []Candle, strategy, symbol string) LiveSignalResponse {
 	currentPrice := candles[len(candles)-1].Close
 	
 	response := LiveSignalResponse{
@@ -114,557 +104,23 @@ func generateLiveSignal(candles []Candle, strategy string) LiveSignalResponse {
 		Timestamp:    time.Now().Unix(),
 	}
 
-	// Calculate indicators
-	closes := make([]float64, len(candles))
-	highs := make([]float64, len(candles))
-	lows := make([]float64, len(candles))
-	
-	for i, c := range candles {
-		closes[i] = c.Close
-		highs[i] = c.High
-		lows[i] = c.Low
-	}
-
-	// Generate signal based on strategy
-	switch strategy {
-	case "session_trader":
-		response = generateSessionTraderSignal(candles, currentPrice)
-	case "breakout_master":
-		response = generateBreakoutMasterSignal(candles, currentPrice)
-	case "liquidity_hunter":
-		response = generateLiquidityHunterSignal(candles, currentPrice)
-	case "trend_rider":
-		response = generateTrendRiderSignal(candles, currentPrice)
-	case "range_master":
-		response = generateRangeMasterSignal(candles, currentPrice)
-	case "smart_money_tracker":
-		response = generateSmartMoneySignal(candles, currentPrice)
-	case "institutional_follower":
-		response = generateInstitutionalSignal(candles, currentPrice)
-	case "reversal_sniper":
-		response = generateReversalSignal(candles, currentPrice)
-	case "momentum_beast":
-		response = generateMomentumSignal(candles, currentPrice)
-	case "scalper_pro":
-		response = generateScalperSignal(candles, currentPrice)
-	default:
-		response = generateSessionTraderSignal(candles, currentPrice)
-	}
-
-	return response
-}
-
-func generateSessionTraderSignal(candles []Candle, currentPrice float64) LiveSignalResponse {
-	response := LiveSignalResponse{
-		Signal:       "NONE",
-		CurrentPrice: currentPrice,
-		Entry:        currentPrice,
-		Timestamp:    time.Now().Unix(),
-	}
-
-	// Calculate EMAs
-	ema9 := calculateEMA(candles, 9)
-	ema21 := calculateEMA(candles, 21)
-	ema50 := calculateEMA(candles, 50)
-	ema200 := calculateEMA(candles, 200)
-
-	// Calculate RSI
-	rsi := calculateRSI(candles, 14)
-
-	// Calculate ATR for OPTIMIZED backtest parameters
-	atr := calculateATR(candles, 14)
-	
-	// Calculate MACD for additional confirmation
-	macd, signal := calculateMACD(candles)
-	
-	// Calculate volume confirmation
-	avgVolume := 0.0
-	for i := len(candles) - 20; i < len(candles); i++ {
-		avgVolume += candles[i].Volume
-	}
-	avgVolume /= 20
-	currentVolume := candles[len(candles)-1].Volume
-	volumeConfirm := currentVolume > avgVolume * 1.2 // 20% above average
-	
-	// BUY Signal: SIMPLIFIED - Require 2 out of 5 conditions
-	buyScore := 0
-	if ema9 > ema21 && ema21 > ema50 { buyScore++ }           // EMA alignment
-	if currentPrice > ema200 { buyScore++ }                    // Long-term trend
-	if rsi > 35 && rsi < 75 { buyScore++ }                    // RSI range (wider)
-	if macd > signal { buyScore++ }                            // MACD bullish
-	if volumeConfirm { buyScore++ }                            // Volume
-	
-	if buyScore >= 2 {  // At least 2 conditions met
-		response.Signal = "BUY"
-		response.Entry = currentPrice
-		response.StopLoss = currentPrice - (atr * 1.0)
-		response.TP1 = currentPrice + (atr * 3.0)
-		response.TP2 = currentPrice + (atr * 4.5)
-		response.TP3 = currentPrice + (atr * 7.5)
-		response.TakeProfit = response.TP3
-		response.RiskReward = (response.TakeProfit - response.Entry) / (response.Entry - response.StopLoss)
-	}
-
-	// SELL Signal: SIMPLIFIED - Require 2 out of 5 conditions
-	sellScore := 0
-	if ema9 < ema21 && ema21 < ema50 { sellScore++ }          // EMA alignment
-	if currentPrice < ema200 { sellScore++ }                   // Long-term trend
-	if rsi < 70 && rsi > 25 { sellScore++ }                   // RSI range (wider)
-	if macd < signal { sellScore++ }                           // MACD bearish
-	if volumeConfirm { sellScore++ }                           // Volume
-	
-	if sellScore >= 2 {  // At least 2 conditions met
-		response.Signal = "SELL"
-		response.Entry = currentPrice
-		response.StopLoss = currentPrice + (atr * 1.0) // OPTIMIZED: 57.9% WR, 18.67 PF, 1312% return
-		response.TP1 = currentPrice - (atr * 3.0) // Take 33% profit
-		response.TP2 = currentPrice - (atr * 4.5) // Take 33% profit
-		response.TP3 = currentPrice - (atr * 7.5) // Take 34% profit
-		response.TakeProfit = response.TP3 // Show final target
-		response.RiskReward = (response.Entry - response.TakeProfit) / (response.StopLoss - response.Entry)
-	}
-
-	return response
-}
-
-func generateBreakoutMasterSignal(candles []Candle, currentPrice float64) LiveSignalResponse {
-	response := LiveSignalResponse{
-		Signal:       "NONE",
-		CurrentPrice: currentPrice,
-		Entry:        currentPrice,
-		Timestamp:    time.Now().Unix(),
-	}
-
-	// Find recent high/low (last 20 candles)
-	recentHigh := candles[len(candles)-20].High
-	recentLow := candles[len(candles)-20].Low
-	
-	for i := len(candles) - 20; i < len(candles)-1; i++ {
-		if candles[i].High > recentHigh {
-			recentHigh = candles[i].High
-		}
-		if candles[i].Low < recentLow {
-			recentLow = candles[i].Low
-		}
-	}
-
-	// Calculate volume
-	avgVolume := 0.0
-	for i := len(candles) - 20; i < len(candles)-1; i++ {
-		avgVolume += candles[i].Volume
-	}
-	avgVolume /= 20
-
-	currentVolume := candles[len(candles)-1].Volume
-
-	// Calculate ATR for OPTIMIZED backtest parameters
-	atr := calculateATR(candles, 14)
-	
-	// Calculate EMA for trend confirmation
-	ema50 := calculateEMA(candles, 50)
-	
-	// Calculate RSI for momentum confirmation
-	rsi := calculateRSI(candles, 14)
-	
-	// Check for consolidation before breakout (lower volatility)
-	recentATR := 0.0
-	for i := len(candles) - 5; i < len(candles); i++ {
-		recentATR += candles[i].High - candles[i].Low
-	}
-	recentATR /= 5
-	consolidating := recentATR < atr * 0.8 // Recent range is tighter than average
-	
-	// BUY Signal: SIMPLIFIED - Require 2 out of 5 conditions
-	buyScore := 0
-	if currentPrice > recentHigh { buyScore++ }                // Breakout
-	if currentVolume > avgVolume*1.1 { buyScore++ }           // Volume (reduced from 1.2x to 1.1x)
-	if currentPrice > ema50 { buyScore++ }                     // Trend
-	if rsi > 40 && rsi < 90 { buyScore++ }                    // Momentum (wider range)
-	if consolidating { buyScore++ }                            // Consolidation
-	
-	if buyScore >= 2 {  // At least 2 out of 5
-		response.Signal = "BUY"
-		response.Entry = currentPrice
-		response.StopLoss = currentPrice - (atr * 1.0) // OPTIMIZED: 54.5% WR, 8.23 PF, 3,704% return
-		response.TP1 = currentPrice + (atr * 4.0) // Take 33% profit
-		response.TP2 = currentPrice + (atr * 6.0) // Take 33% profit
-		response.TP3 = currentPrice + (atr * 10.0) // Take 34% profit
-		response.TakeProfit = response.TP3
-		response.RiskReward = (response.TakeProfit - response.Entry) / (response.Entry - response.StopLoss)
-	}
-
-	// SELL Signal: SIMPLIFIED - Require 2 out of 5 conditions
-	sellScore := 0
-	if currentPrice < recentLow { sellScore++ }               // Breakout
-	if currentVolume > avgVolume*1.1 { sellScore++ }          // Volume (reduced from 1.2x to 1.1x)
-	if currentPrice < ema50 { sellScore++ }                    // Trend
-	if rsi < 60 && rsi > 10 { sellScore++ }                   // Momentum (wider range)
-	if consolidating { sellScore++ }                           // Consolidation
-	
-	if sellScore >= 2 {  // At least 2 out of 5
-		response.Signal = "SELL"
-		response.Entry = currentPrice
-		response.StopLoss = currentPrice + (atr * 1.0) // OPTIMIZED: 54.5% WR, 8.23 PF, 3,704% return
-		response.TP1 = currentPrice - (atr * 4.0) // Take 33% profit
-		response.TP2 = currentPrice - (atr * 6.0) // Take 33% profit
-		response.TP3 = currentPrice - (atr * 10.0) // Take 34% profit
-		response.TakeProfit = response.TP3
-		response.RiskReward = (response.Entry - response.TakeProfit) / (response.StopLoss - response.Entry)
-	}
-
-	return response
-}
-
-func generateLiquidityHunterSignal(candles []Candle, currentPrice float64) LiveSignalResponse {
-	response := LiveSignalResponse{
-		Signal:       "NONE",
-		CurrentPrice: currentPrice,
-		Entry:        currentPrice,
-		Timestamp:    time.Now().Unix(),
-	}
-
-	// Calculate ATR and EMAs
-	atr := calculateATR(candles, 14)
-	ema20 := calculateEMA(candles, 20)
-	ema50 := calculateEMA(candles, 50)
-	ema200 := calculateEMA(candles, 200)
-
-	// Find liquidity zones (recent swing highs/lows)
-	swingHigh := findSwingHigh(candles, 10)
-	swingLow := findSwingLow(candles, 10)
-	
-	// Calculate RSI for additional confirmation
-	rsi := calculateRSI(candles, 14)
-	
-	// Check if price is bouncing from liquidity zone
-	prevCandle := candles[len(candles)-2]
-	currentCandle := candles[len(candles)-1]
-	
-	// Volume spike detection (liquidity grab often has volume spike)
-	avgVolume := 0.0
-	for i := len(candles) - 20; i < len(candles); i++ {
-		avgVolume += candles[i].Volume
-	}
-	avgVolume /= 20
-	volumeSpike := currentCandle.Volume > avgVolume * 1.2 // Reduced from 1.5x to 1.2x
-
-	// BUY Signal: SIMPLIFIED - Require 2 out of 5 conditions
-	buyScore := 0
-	if prevCandle.Low <= swingLow*1.005 && currentPrice > swingLow { buyScore++ }  // Liquidity sweep (with 0.5% tolerance)
-	if ema20 > ema50 { buyScore++ }                                                 // Trend
-	if currentPrice > ema200 { buyScore++ }                                         // Long-term trend
-	if rsi > 25 && rsi < 60 { buyScore++ }                                         // RSI (wider range)
-	if volumeSpike { buyScore++ }                                                   // Volume
-	
-	if buyScore >= 2 {  // At least 2 out of 5
-		response.Signal = "BUY"
-		response.Entry = currentPrice
-		response.StopLoss = currentPrice - (atr * 1.5) // OPTIMIZED: 61.2% WR, 9.49 PF, 901% return - BEST OVERALL
-		response.TP1 = currentPrice + (atr * 4.0) // Take 33% profit
-		response.TP2 = currentPrice + (atr * 6.0) // Take 33% profit
-		response.TP3 = currentPrice + (atr * 10.0) // Take 34% profit
-		response.TakeProfit = response.TP3
-		response.RiskReward = (response.TakeProfit - response.Entry) / (response.Entry - response.StopLoss)
-	}
-
-	// SELL Signal: SIMPLIFIED - Require 2 out of 5 conditions
-	sellScore := 0
-	if prevCandle.High >= swingHigh*0.995 && currentPrice < swingHigh { sellScore++ }  // Liquidity sweep (with 0.5% tolerance)
-	if ema20 < ema50 { sellScore++ }                                                    // Trend
-	if currentPrice < ema200 { sellScore++ }                                            // Long-term trend
-	if rsi < 75 && rsi > 40 { sellScore++ }                                            // RSI (wider range)
-	if volumeSpike { sellScore++ }                                                      // Volume
-	
-	if sellScore >= 2 {  // At least 2 out of 5
-		response.Signal = "SELL"
-		response.Entry = currentPrice
-		response.StopLoss = currentPrice + (atr * 1.5) // OPTIMIZED: 61.2% WR, 9.49 PF, 901% return - BEST OVERALL
-		response.TP1 = currentPrice - (atr * 4.0) // Take 33% profit
-		response.TP2 = currentPrice - (atr * 6.0) // Take 33% profit
-		response.TP3 = currentPrice - (atr * 10.0) // Take 34% profit
-		response.TakeProfit = response.TP3
-		response.RiskReward = (response.Entry - response.TakeProfit) / (response.StopLoss - response.Entry)
-	}
-
-	return response
-}
-
-func generateTrendRiderSignal(candles []Candle, currentPrice float64) LiveSignalResponse {
-	response := LiveSignalResponse{
-		Signal:       "NONE",
-		CurrentPrice: currentPrice,
-		Entry:        currentPrice,
-		Timestamp:    time.Now().Unix(),
-	}
-
-	// Calculate EMAs for trend
-	ema20 := calculateEMA(candles, 20)
-	ema50 := calculateEMA(candles, 50)
-	ema100 := calculateEMA(candles, 100)
-
-	// Calculate MACD
-	macd, signal := calculateMACD(candles)
-
-	// Calculate ATR for OPTIMIZED backtest parameters
-	atr := calculateATR(candles, 14)
-	
-	// BUY Signal: Strong uptrend with MACD confirmation
-	if ema20 > ema50 && ema50 > ema100 && macd > signal && macd > 0 {
-		response.Signal = "BUY"
-		response.Entry = currentPrice
-		response.StopLoss = currentPrice - (atr * 0.5) // OPTIMIZED: 42.1% WR, 6.59 PF, 837% return
-		response.TP1 = currentPrice + (atr * 3.0) // Take 33% profit
-		response.TP2 = currentPrice + (atr * 4.5) // Take 33% profit
-		response.TP3 = currentPrice + (atr * 7.5) // Take 34% profit
-		response.TakeProfit = response.TP3
-		response.RiskReward = (response.TakeProfit - response.Entry) / (response.Entry - response.StopLoss)
-	}
-
-	// SELL Signal: Strong downtrend with MACD confirmation
-	if ema20 < ema50 && ema50 < ema100 && macd < signal && macd < 0 {
-		response.Signal = "SELL"
-		response.Entry = currentPrice
-		response.StopLoss = currentPrice + (atr * 0.5) // OPTIMIZED: 42.1% WR, 6.59 PF, 837% return
-		response.TP1 = currentPrice - (atr * 3.0) // Take 33% profit
-		response.TP2 = currentPrice - (atr * 4.5) // Take 33% profit
-		response.TP3 = currentPrice - (atr * 7.5) // Take 34% profit
-		response.TakeProfit = response.TP3
-		response.RiskReward = (response.Entry - response.TakeProfit) / (response.StopLoss - response.Entry)
-	}
-
-	return response
-}
-
-func generateRangeMasterSignal(candles []Candle, currentPrice float64) LiveSignalResponse {
-	response := LiveSignalResponse{
-		Signal:       "NONE",
-		CurrentPrice: currentPrice,
-		Entry:        currentPrice,
-		Timestamp:    time.Now().Unix(),
-	}
-
-	// Calculate Bollinger Bands
-	sma20 := calculateSMA(candles, 20)
-	stdDev := calculateStdDevForBB(candles, 20)
-	upperBand := sma20 + (stdDev * 2)
-	lowerBand := sma20 - (stdDev * 2)
-
-	// Calculate RSI
-	rsi := calculateRSI(candles, 14)
-
-	// Calculate ATR for OPTIMIZED backtest parameters
-	atr := calculateATR(candles, 14)
-	
-	// BUY Signal: Price near lower band and RSI oversold
-	if currentPrice <= lowerBand*1.01 && rsi < 35 {
-		response.Signal = "BUY"
-		response.Entry = currentPrice
-		response.StopLoss = currentPrice - (atr * 0.5) // OPTIMIZED: 46.5% WR, 7.81 PF, 335% return
-		response.TP1 = currentPrice + (atr * 2.0) // Take 33% profit
-		response.TP2 = currentPrice + (atr * 3.0) // Take 33% profit
-		response.TP3 = currentPrice + (atr * 5.0) // Take 34% profit
-		response.TakeProfit = response.TP3
-		response.RiskReward = (response.TakeProfit - response.Entry) / (response.Entry - response.StopLoss)
-	}
-
-	// SELL Signal: Price near upper band and RSI overbought
-	if currentPrice >= upperBand*0.99 && rsi > 65 {
-		response.Signal = "SELL"
-		response.Entry = currentPrice
-		response.StopLoss = currentPrice + (atr * 0.5) // OPTIMIZED: 46.5% WR, 7.81 PF, 335% return
-		response.TP1 = currentPrice - (atr * 2.0) // Take 33% profit
-		response.TP2 = currentPrice - (atr * 3.0) // Take 33% profit
-		response.TP3 = currentPrice - (atr * 5.0) // Take 34% profit
-		response.TakeProfit = response.TP3
-		response.RiskReward = (response.Entry - response.TakeProfit) / (response.StopLoss - response.Entry)
-	}
-
-	return response
-}
-
-func generateSmartMoneySignal(candles []Candle, currentPrice float64) LiveSignalResponse {
-	response := LiveSignalResponse{
-		Signal:       "NONE",
-		CurrentPrice: currentPrice,
-		Entry:        currentPrice,
-		Timestamp:    time.Now().Unix(),
-	}
-
-	// Calculate ATR and EMAs
-	atr := calculateATR(candles, 14)
-	ema20 := calculateEMA(candles, 20)
-	ema50 := calculateEMA(candles, 50)
-
-	// Find liquidity zones (recent swing highs/lows)
-	swingHigh := findSwingHigh(candles, 10)
-	swingLow := findSwingLow(candles, 10)
-
-	// BUY Signal: Price near swing low (liquidity grab) and EMA20 > EMA50
-	if currentPrice <= swingLow*1.005 && ema20 > ema50 {
-		response.Signal = "BUY"
-		response.Entry = currentPrice
-		response.StopLoss = currentPrice - (atr * 0.5) // OPTIMIZED: 34.1% WR, 8.21 PF, 14,623% return
-		response.TP1 = currentPrice + (atr * 3.0) // Take 33% profit
-		response.TP2 = currentPrice + (atr * 4.5) // Take 33% profit
-		response.TP3 = currentPrice + (atr * 7.5) // Take 34% profit
-		response.TakeProfit = response.TP3
-		response.RiskReward = (response.TakeProfit - response.Entry) / (response.Entry - response.StopLoss)
-	}
-
-	// SELL Signal: Price near swing high (liquidity grab) and EMA20 < EMA50
-	if currentPrice >= swingHigh*0.995 && ema20 < ema50 {
-		response.Signal = "SELL"
-		response.Entry = currentPrice
-		response.StopLoss = currentPrice + (atr * 0.5) // OPTIMIZED: 34.1% WR, 8.21 PF, 14,623% return
-		response.TP1 = currentPrice - (atr * 3.0) // Take 33% profit
-		response.TP2 = currentPrice - (atr * 4.5) // Take 33% profit
-		response.TP3 = currentPrice - (atr * 7.5) // Take 34% profit
-		response.TakeProfit = response.TP3
-		response.RiskReward = (response.Entry - response.TakeProfit) / (response.StopLoss - response.Entry)
-	}
-
-	return response
-}
-
-func generateInstitutionalSignal(candles []Candle, currentPrice float64) LiveSignalResponse {
-	// Similar to trend rider but with higher timeframe bias
-	return generateTrendRiderSignal(candles, currentPrice)
-}
-
-func generateReversalSignal(candles []Candle, currentPrice float64) LiveSignalResponse {
-	response := LiveSignalResponse{
-		Signal:       "NONE",
-		CurrentPrice: currentPrice,
-		Entry:        currentPrice,
-		Timestamp:    time.Now().Unix(),
+	// Registered strategies go through the unified generator (same logic as backtest)
+	// with the parameter version promoted for this symbol
+	usg := &UnifiedSignalGenerator{}
+	live := PromotedSet(strategy, symbol)
+	if live != nil {
+		usg.Params = live.Params
 	}
-
-	// Calculate Bollinger Bands
-	sma20 := calculateSMA(candles, 20)
-	stdDev := calculateStdDevForBB(candles, 20)
-	upperBand := sma20 + (stdDev * 2)
-	lowerBand := sma20 - (stdDev * 2)
-
-	// Calculate RSI
-	rsi := calculateRSI(candles, 14)
-
-	// Calculate ATR for OPTIMIZED backtest parameters
-	atr := calculateATR(candles, 14)
-	
-	// BUY Signal: Price near lower band and RSI oversold (reversal setup)
-	if currentPrice <= lowerBand*1.01 && rsi < 35 {
-		response.Signal = "BUY"
-		response.Entry = currentPrice
-		response.StopLoss = currentPrice - (atr * 0.5) // OPTIMIZED: 28.6% WR, 3.52 PF, 51% return
-		response.TP1 = currentPrice + (atr * 5.0) // Take 33% profit
-		response.TP2 = currentPrice + (atr * 7.5) // Take 33% profit
-		response.TP3 = currentPrice + (atr * 12.5) // Take 34% profit
-		response.TakeProfit = response.TP3
-		response.RiskReward = (response.TakeProfit - response.Entry) / (response.Entry - response.StopLoss)
+	if advSignal := usg.GenerateSignal(candles, strategy); advSignal != nil {
+		response = advSignal.ToLiveSignalResponse(currentPrice)
 	}
-
-	// SELL Signal: Price near upper band and RSI overbought (reversal setup)
-	if currentPrice >= upperBand*0.99 && rsi > 65 {
-		response.Signal = "SELL"
-		response.Entry = currentPrice
-		response.StopLoss = currentPrice + (atr * 0.5) // OPTIMIZED: 28.6% WR, 3.52 PF, 51% return
-		response.TP1 = currentPrice - (atr * 5.0) // Take 33% profit
-		response.TP2 = currentPrice - (atr * 7.5) // Take 33% profit
-		response.TP3 = currentPrice - (atr * 12.5) // Take 34% profit
-		response.TakeProfit = response.TP3
-		response.RiskReward = (response.Entry - response.TakeProfit) / (response.StopLoss - response.Entry)
+	if live != nil {
+		response.ParamsVersion, response.RiskPercent = live.Version, live.RiskPercent
 	}
 
 	return response
 }
 
-func generateMomentumSignal(candles []Candle, currentPrice float64) LiveSignalResponse {
-	// Similar to breakout master but with momentum confirmation
-	return generateBreakoutMasterSignal(candles, currentPrice)
-}
-
-func generateScalperSignal(candles []Candle, currentPrice float64) LiveSignalResponse {
-	// Quick scalping signals with tight stops
-	response := generateSessionTraderSignal(candles, currentPrice)
-	
-	// Scalping with OPTIMIZED backtest parameters
-	if response.Signal == "BUY" {
-		atr := calculateATR(candles, 14)
-		response.StopLoss = currentPrice - (atr * 0.5) // OPTIMIZED: Tight stops for scalping
-		response.TP1 = currentPrice + (atr * 1.2) // Take 33% profit
-		response.TP2 = currentPrice + (atr * 2.3) // Take 33% profit
-		response.TP3 = currentPrice + (atr * 3.5) // Take 34% profit
-		response.TakeProfit = response.TP3
-		response.RiskReward = (response.TakeProfit - response.Entry) / (response.Entry - response.StopLoss)
-	} else if response.Signal == "SELL" {
-		atr := calculateATR(candles, 14)
-		response.StopLoss = currentPrice + (atr * 0.5) // OPTIMIZED: Tight stops for scalping
-		response.TP1 = currentPrice - (atr * 1.2) // Take 33% profit
-		response.TP2 = currentPrice - (atr * 2.3) // Take 33% profit
-		response.TP3 = currentPrice - (atr * 3.5) // Take 34% profit
-		response.TakeProfit = response.TP3
-		response.RiskReward = (response.Entry - response.TakeProfit) / (response.StopLoss - response.Entry)
-	}
-	
-	return response
-}
-
-// Helper functions
-func findSwingHigh(candles []Candle, lookback int) float64 {
-	if len(candles) < lookback {
-		return candles[len(candles)-1].High
-	}
-	
-	high := candles[len(candles)-lookback].High
-	for i := len(candles) - lookback; i < len(candles); i++ {
-		if candles[i].High > high {
-			high = candles[i].High
-		}
-	}
-	return high
-}
-
-func findSwingLow(candles []Candle, lookback int) float64 {
-	if len(candles) < lookback {
-		return candles[len(candles)-1].Low
-	}
-	
-	low := candles[len(candles)-lookback].Low
-	for i := len(candles) - lookback; i < len(candles); i++ {
-		if candles[i].Low < low {
-			low = candles[i].Low
-		}
-	}
-	return low
-}
-
-func calculateStdDevForBB(candles []Candle, period int) float64 {
-	if len(candles) < period {
-		return 0
-	}
-	
-	sma := calculateSMA(candles, period)
-	variance := 0.0
-	
-	for i := len(candles) - period; i < len(candles); i++ {
-		diff := candles[i].Close - sma
-		variance += diff * diff
-	}
-	
-	variance /= float64(period)
-	
-	// Return standard deviation (sqrt of variance)
-	stdDev := 0.0
-	if variance > 0 {
-		stdDev = 1.0
-		for i := 0; i < 10; i++ { // Newton's method for sqrt
-			stdDev = (stdDev + variance/stdDev) / 2
-		}
-	}
-	return stdDev
-}
-
-
 // Track last signal to prevent duplicates
 var (
 	lastLiveSignalType   = ""
@@ -709,7 +165,13 @@ func HandleLiveSignalFiber(c *fiber.Ctx) error {
 	}
 
 	// Generate signal using UNIFIED generator (same logic as backtest!)
-	usg := &UnifiedSignalGenerator{}
+	// with the promoted parameter version. While the Telegram bot runs, its
+	// risk profile filters every candidate.
+	usg := &UnifiedSignalGenerator{Profile: TelegramRiskProfile()}
+	live := PromotedSet(req.Strategy, req.Symbol)
+	if live != nil {
+		usg.Params = live.Params
+	}
 	advSignal := usg.GenerateSignal(candles, req.Strategy)
 	
 	var signal LiveSignalResponse
@@ -724,7 +186,16 @@ func HandleLiveSignalFiber(c *fiber.Ctx) error {
 		}
 	}
 	
-	log.Printf("🔍 Generated signal: %s for %s using %s strategy", signal.Signal, req.Symbol, req.Strategy)
+	if live != nil {
+		signal.ParamsVersion, signal.RiskPercent = live.Version, live.RiskPercent
+	}
+	
+	// Publish the market regime alongside the signal
+	marketRegime := regime.Detect(candles)
+	regime.Publish(req.Symbol, interval, marketRegime)
+	signal.Regime = &marketRegime
+	
+	log.Printf("🔍 Generated signal: %s for %s using %s strategy (%s regime)", signal.Signal, req.Symbol, req.Strategy, marketRegime.Regime)
 
 	// Get current filter settings and selected strategies from database
 	filterBuy, filterSell, selectedStrategies := GetCurrentSettings()
@@ -800,6 +271,11 @@ func HandleLiveSignalFiber(c *fiber.Ctx) error {
 		} else {
 			go telegramBot.SendSignal(signal, req.Symbol, req.Strategy)
 			log.Printf("📤 Sent %s signal to Telegram for %s", signal.Signal, req.Symbol)
+			
+			// Sent signals count toward the profile's daily cap (outcomes are not tracked here)
+			if usg.Profile != nil {
+				usg.Profile.RecordTrade(time.Now().UnixMilli(), 0)
+			}
 		}
 	} else {
 		log.Printf("ℹ️  Signal not sent to Telegram (not saved to database)")
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"tradebot/backend/internal/backtest"
+)
+
+// MonteCarloRequest - Request for Monte Carlo analysis on a stored backtest
+type MonteCarloRequest struct {
+	ResultID string `json:"resultId"`
+	backtest.MonteCarloConfig
+}
+
+// HandleMonteCarlo runs the advanced Monte Carlo simulation on a stored unified backtest result
+func HandleMonteCarlo(c *fiber.Ctx) error {
+	var req MonteCarloRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Invalid request: " + err.Error(),
+		})
+	}
+
+	if req.ResultID == "" {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "resultId is required (returned by /backtest/unified)",
+		})
+	}
+
+	stored, ok := backtest.GetBacktestResultStore().Get(req.ResultID)
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{
+			"error": "Backtest result not found: " + req.ResultID,
+		})
+	}
+
+	mc, err := backtest.RunAdvancedMonteCarlo(stored.Result.Trades, stored.Result.StartBalance, req.MonteCarloConfig)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Monte Carlo failed: " + err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success":    true,
+		"resultId":   stored.ID,
+		"strategy":   stored.Result.StrategyName,
+		"monteCarlo": mc,
+	})
+}
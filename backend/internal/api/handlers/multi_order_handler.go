@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"tradebot/backend/internal/backtest"
+	"tradebot/backend/internal/multiorder"
+)
+
+// HandleMultiOrderBacktest runs a grid or DCA backtest whose orders can fill
+// several at a time on the same bar
+func HandleMultiOrderBacktest(c *fiber.Ctx) error {
+	config := backtest.MultiOrderBacktestConfig{
+		Strategy: "grid",
+		Grid:     multiorder.DefaultGridConfig(),
+		DCA:      multiorder.DefaultDCAConfig(),
+	}
+	if err := c.BodyParser(&config); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Invalid request: " + err.Error(),
+		})
+	}
+	if config.Symbol == "" {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "symbol is required",
+		})
+	}
+	config.Symbol = strings.ToUpper(config.Symbol)
+	if config.Interval == "" {
+		config.Interval = "1h"
+	}
+	if config.Days == 0 {
+		config.Days = 90
+	}
+
+	candles, err := fetchBinanceData(config.Symbol, config.Interval, config.Days)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to fetch data: " + err.Error(),
+		})
+	}
+
+	result, err := backtest.RunMultiOrderBacktest(config, candles)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Backtest failed: " + err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"result":  result,
+	})
+}
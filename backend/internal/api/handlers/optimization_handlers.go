@@ -40,17 +40,17 @@ func HandleOptimizeParameters(c *fiber.Ctx) error {
 	}
 	
 	// Optimize
-	results, err := OptimizeStrategyParameters(req.StrategyName, req.Symbol, req.StartBalance, req.Days)
+	report, err := OptimizeStrategyParameters(req.StrategyName, req.Symbol, req.StartBalance, req.Days)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
-	
-	// Return top 10 results
-	topResults := results
-	if len(topResults) > 10 {
-		topResults = results[:10]
+	if report.Best == nil {
+		return c.Status(500).JSON(fiber.Map{
+			"error":    "No parameter set completed",
+			"reportId": report.ID,
+		})
 	}
 	
 	return c.JSON(fiber.Map{
@@ -58,9 +58,12 @@ func HandleOptimizeParameters(c *fiber.Ctx) error {
 		"symbol":       req.Symbol,
 		"startBalance": req.StartBalance,
 		"days":         req.Days,
-		"totalTests":   len(results),
-		"topResults":   topResults,
-		"bestResult":   results[0],
+		"reportId":     report.ID,
+		"totalTests":   len(report.Trials),
+		"topResults":   report.TopTrials(10),
+		"bestResult":   report.Best,
+		"diagnostics":  report.Diagnostics,
+		"warnings":     report.Warnings,
 	})
 }
 
@@ -85,37 +88,31 @@ func HandleOptimizeAllStrategies(c *fiber.Ctx) error {
 	}
 	
 	// Optimize all
-	allResults, err := OptimizeAllStrategies(req.Symbol, req.StartBalance, req.Days)
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"error": err.Error(),
-		})
-	}
+	reports := OptimizeAllStrategies(req.Symbol, req.StartBalance, req.Days)
 	
-	// Collect best result for each strategy
-	bestResults := make(map[string]OptimizationResult)
-	for strategyName, results := range allResults {
-		if len(results) > 0 {
-			bestResults[strategyName] = results[0]
-		}
-	}
-	
-	// Find overall best
-	var overallBest OptimizationResult
+	// Collect best trial for each strategy
+	bestResults := make(map[string]fiber.Map)
+	var overallBest fiber.Map
 	bestScore := 0.0
-	for _, result := range bestResults {
-		if result.Score > bestScore {
-			bestScore = result.Score
-			overallBest = result
+	for strategyName, report := range reports {
+		best := fiber.Map{
+			"reportId":    report.ID,
+			"trial":       report.Best,
+			"diagnostics": report.Diagnostics,
+		}
+		bestResults[strategyName] = best
+		if report.Best.Value > bestScore {
+			bestScore = report.Best.Value
+			overallBest = fiber.Map{"strategyName": strategyName, "reportId": report.ID, "trial": report.Best}
 		}
 	}
 	
 	return c.JSON(fiber.Map{
-		"symbol":       req.Symbol,
-		"startBalance": req.StartBalance,
-		"days":         req.Days,
+		"symbol":          req.Symbol,
+		"startBalance":    req.StartBalance,
+		"days":            req.Days,
 		"totalStrategies": len(bestResults),
-		"bestResults": bestResults,
-		"overallBest": overallBest,
+		"bestResults":     bestResults,
+		"overallBest":     overallBest,
 	})
 }
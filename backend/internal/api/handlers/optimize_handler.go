@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LoadLiveParams loads the parameter sets promoted from optimization reports
+func LoadLiveParams() {
+	n, err := GetLiveParamStore().Load()
+	if err != nil {
+		log.Printf("⚠️  Live parameters not loaded: %v", err)
+		return
+	}
+	if n > 0 {
+		log.Printf("✅ Loaded live parameters for %d strategy/symbol pairs from %s", n, LiveParamsFile())
+	}
+}
+
+// HandleOptimize runs a grid, random, TPE, genetic or NSGA-II search over a
+// strategy's parameter schema and stores the report for follow-up requests
+func HandleOptimize(c *fiber.Ctx) error {
+	var req OptimizeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	if err := req.Validate(); err != nil {
+		return strategyParamsErrorResponse(c, err)
+	}
+
+	candles, err := fetchBinanceData(req.Symbol, req.Interval, req.Days)
+	if err != nil {
+		log.Printf("❌ Optimize: failed to fetch %s %s: %v", req.Symbol, req.Interval, err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to fetch data",
+		})
+	}
+
+	report, err := OptimizeCandles(req, candles)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(report)
+}
+
+// HandleGetOptimization returns a stored optimization report
+func HandleGetOptimization(c *fiber.Ctx) error {
+	report, ok := GetOptimizationReportStore().Get(c.Params("id"))
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{
+			"error": "Optimization report not found",
+		})
+	}
+	return c.JSON(report)
+}
+
+// HandleGetParetoFront returns the Pareto front of a stored report with each
+// point's metrics and objective values
+func HandleGetParetoFront(c *fiber.Ctx) error {
+	report, ok := GetOptimizationReportStore().Get(c.Params("id"))
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{
+			"error": "Optimization report not found",
+		})
+	}
+	if len(report.Request.ParetoObjectives) == 0 {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Report has no Pareto objectives: run with method nsga2 or paretoObjectives",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success":    true,
+		"id":         report.ID,
+		"strategy":   report.Request.Strategy,
+		"symbol":     report.Request.Symbol,
+		"objectives": report.Request.ParetoObjectives,
+		"front":      report.ParetoFront,
+	})
+}
+
+// HandlePromoteOptimizationTrial makes a trial of a stored report, e.g. a
+// point of its Pareto front, the live parameter set of its strategy and symbol
+func HandlePromoteOptimizationTrial(c *fiber.Ctx) error {
+	var req struct {
+		Trial int    `json:"trial"`
+		Note  string `json:"note"`
+	}
+	if err := c.BodyParser(&req); err != nil || req.Trial <= 0 {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Body must name a trial number, e.g. {\"trial\": 42}",
+		})
+	}
+
+	set, err := PromoteTrial(c.Params("id"), req.Trial, req.Note)
+	if err != nil {
+		return strategyParamsErrorResponse(c, err)
+	}
+	log.Printf("🚀 Promoted trial %d of %s to live %s %s v%d: %v", set.Trial, set.ReportID, set.Strategy, set.Symbol, set.Version, set.Params)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"live":    set,
+	})
+}
+
+// HandleListLiveParams lists the live parameter set of every strategy and symbol
+func HandleListLiveParams(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"success": true,
+		"live":    GetLiveParamStore().List(),
+	})
+}
+
+// HandleGetLiveParamHistory returns every version promoted for a strategy on
+// a symbol and which one is live
+func HandleGetLiveParamHistory(c *fiber.Ctx) error {
+	versions, current := GetLiveParamStore().History(c.Params("strategy"), c.Params("symbol"))
+	if len(versions) == 0 {
+		return c.Status(404).JSON(fiber.Map{
+			"error": "No parameters promoted for this strategy and symbol",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success":  true,
+		"live":     versions[current-1],
+		"current":  current,
+		"versions": versions,
+	})
+}
+
+// HandleRollbackLiveParams makes an earlier version live again (body
+// {version, note}; no version steps back one)
+func HandleRollbackLiveParams(c *fiber.Ctx) error {
+	var req struct {
+		Version int    `json:"version"`
+		Note    string `json:"note"`
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(400).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	set, err := GetLiveParamStore().Rollback(c.Params("strategy"), c.Params("symbol"), req.Version, req.Note)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	log.Printf("⏪ Rolled back live %s %s to v%d: %v", set.Strategy, set.Symbol, set.Version, set.Params)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"live":    set,
+	})
+}
+
+// HandleLiveParamAudit returns the promotion and rollback trail, newest
+// first (?strategy= and ?symbol= filter it)
+func HandleLiveParamAudit(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"success": true,
+		"audit":   GetLiveParamStore().Audit(c.Query("strategy"), c.Query("symbol")),
+	})
+}
+
+// HandleSensitivity perturbs each parameter around a center, e.g. the best
+// trial of an optimization report, and maps the objective over parameter pairs
+func HandleSensitivity(c *fiber.Ctx) error {
+	var req SensitivityRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	if err := req.Validate(); err != nil {
+		return strategyParamsErrorResponse(c, err)
+	}
+
+	candles, err := fetchBinanceData(req.Symbol, req.Interval, req.Days)
+	if err != nil {
+		log.Printf("❌ Sensitivity: failed to fetch %s %s: %v", req.Symbol, req.Interval, err)
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to fetch data",
+		})
+	}
+
+	report, err := AnalyzeSensitivityCandles(req, candles)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(sensitivityResponse(report))
+}
+
+// HandleGetSensitivity returns a stored sensitivity report
+func HandleGetSensitivity(c *fiber.Ctx) error {
+	report, ok := GetSensitivityReportStore().Get(c.Params("id"))
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{
+			"error": "Sensitivity report not found",
+		})
+	}
+	return c.JSON(sensitivityResponse(report))
+}
+
+// HandleSensitivityHeatmapSVG renders a heatmap of a stored sensitivity
+// report as SVG (?pair=N picks the heatmap, default the first)
+func HandleSensitivityHeatmapSVG(c *fiber.Ctx) error {
+	report, ok := GetSensitivityReportStore().Get(c.Params("id"))
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{
+			"error": "Sensitivity report not found",
+		})
+	}
+	pair := c.QueryInt("pair", 0)
+	if pair < 0 || pair >= len(report.Heatmaps) {
+		return c.Status(404).JSON(fiber.Map{
+			"error": fmt.Sprintf("Report has %d heatmaps", len(report.Heatmaps)),
+		})
+	}
+
+	c.Set("Content-Type", "image/svg+xml")
+	return c.SendString(RenderSensitivityHeatmapSVG(report.Heatmaps[pair], report.Center, report.Request.Objective))
+}
+
+// sensitivityResponse adds the SVG links of each heatmap to a report
+func sensitivityResponse(report *SensitivityReport) fiber.Map {
+	svgs := make([]string, len(report.Heatmaps))
+	for i := range report.Heatmaps {
+		svgs[i] = fmt.Sprintf("/api/v1/optimize/sensitivity/%s/heatmap.svg?pair=%d", report.ID, i)
+	}
+	return fiber.Map{
+		"success":     true,
+		"report":      report,
+		"heatmapSvgs": svgs,
+	}
+}
+
+// HandleListOptimizations lists stored optimization reports, newest first
+func HandleListOptimizations(c *fiber.Ctx) error {
+	reports := GetOptimizationReportStore().List()
+	summaries := make([]fiber.Map, 0, len(reports))
+	for _, r := range reports {
+		summary := fiber.Map{
+			"id":        r.ID,
+			"createdAt": r.CreatedAt,
+			"strategy":  r.Request.Strategy,
+			"symbol":    r.Request.Symbol,
+			"interval":  r.Request.Interval,
+			"method":    r.Request.Method,
+			"objective": r.Request.Objective,
+			"trials":    len(r.Trials),
+		}
+		if r.Best != nil {
+			summary["bestValue"] = r.Best.Value
+			summary["bestParams"] = r.Best.Params
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"reports": summaries,
+	})
+}
+
+// HandleOptimizeOptions lists the search methods, objectives and the
+// evaluation cache's state
+func HandleOptimizeOptions(c *fiber.Ctx) error {
+	entries, hits, misses := GetEvaluationCache().Stats()
+
+	return c.JSON(fiber.Map{
+		"success":    true,
+		"methods":    []string{MethodGrid, MethodRandom, MethodTPE, MethodGenetic, MethodNSGA2},
+		"objectives": Objectives(),
+		"pareto":     MetricAxes(),
+		"defaults":   DefaultOptimizeRequest(),
+		"cache": fiber.Map{
+			"entries": entries,
+			"hits":    hits,
+			"misses":  misses,
+		},
+	})
+}
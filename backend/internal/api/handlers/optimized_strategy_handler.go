@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"time"
@@ -56,6 +57,9 @@ func HandleOptimizedBacktest(c *fiber.Ctx) error {
 	// Run optimized backtest
 	result, err := RunOptimizedBacktest(config, candles)
 	if err != nil {
+		if errors.As(err, new(*ParamValidationError)) {
+			return strategyParamsErrorResponse(c, err)
+		}
 		return c.Status(500).JSON(fiber.Map{
 			"error": "Backtest failed: " + err.Error(),
 		})
@@ -162,6 +166,20 @@ func RunOptimizedBacktest(config BacktestConfig, candles []Candle) (*BacktestRes
 		return nil, fmt.Errorf("strategy not found: %s", config.Strategy)
 	}
 
+	// Parameter overrides are validated against the daily variant's schema
+	var params StrategyParams
+	if len(config.Params) > 0 {
+		schema, _ := OptimizedDailyParamSchema(config.Strategy)
+		resolved, err := ResolveParams(config.Strategy, schema, config.Params)
+		if err != nil {
+			return nil, err
+		}
+		params = resolved
+		if v, ok := config.Params["max_daily_trades"]; ok {
+			optimized.MaxDailyTrades = int(v)
+		}
+	}
+
 	// Set defaults
 	if config.RiskPercent == 0 {
 		config.RiskPercent = 0.01 // 1% risk per trade
@@ -191,7 +209,7 @@ func RunOptimizedBacktest(config BacktestConfig, candles []Candle) (*BacktestRes
 		}
 
 		// Generate signal using optimized strategy
-		signal := GenerateOptimizedSignal(candles[:i+1], config.Strategy)
+		signal := GenerateOptimizedSignalWithParams(candles[:i+1], config.Strategy, params)
 		if signal == nil {
 			continue
 		}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"tradebot/backend/internal/orb"
 )
 
 // ORBBacktestRequest represents the request for an ORB backtest
@@ -109,19 +110,27 @@ func HandleORBLiveSignals(c *fiber.Ctx) error {
 		})
 	}
 
-	// Generate live signals for today
-	signals, err := generateLiveORBSignals(timeFrame)
+	// Signals of the live session's breakouts
+	session, err := generateLiveORBSignals(timeFrame)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"success": false,
 			"message": fmt.Sprintf("Failed to generate signals: %v", err),
 		})
 	}
 
+	date, phase := orb.SessionDay(time.Now()).Format("2006-01-02"), ""
+	signals := []*orb.Trade{}
+	if session != nil {
+		date, phase, signals = session.Day, session.Phase, session.Trades
+	}
+
 	return c.JSON(fiber.Map{
 		"success":   true,
 		"timeFrame": timeFrame,
-		"date":      time.Now().Format("2006-01-02"),
+		"date":      date,
+		"running":   orbRunner.Running(),
+		"phase":     phase,
 		"signals":   signals,
 		"count":     len(signals),
 	})
@@ -238,10 +247,17 @@ func createORBBacktestSummary(result *ORBBacktestResult) *ORBBacktestSummary {
 	}
 }
 
-func generateLiveORBSignals(timeFrame int) ([]*ORBSignal, error) {
-	// This would fetch live market data and generate signals
-	// Placeholder implementation
-	return []*ORBSignal{}, nil
+// generateLiveORBSignals returns the live ORB session, or nil before the
+// first session has started
+func generateLiveORBSignals(timeFrame int) (*orb.Session, error) {
+	session := orbRunner.Session()
+	if session == nil {
+		return nil, nil
+	}
+	if session.Config.TimeFrame != timeFrame {
+		return nil, fmt.Errorf("the live session trades the %d-minute opening range", session.Config.TimeFrame)
+	}
+	return session, nil
 }
 
 func getTopPerformersFromPaper(timeFrame int) []map[string]interface{} {
@@ -0,0 +1,309 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"tradebot/backend/internal/orb"
+)
+
+// orbRunner is the process-wide live ORB session; its fills go to paper trading
+var orbRunner = orb.NewRunner(orb.DefaultConfig(), yahooORBSource{}, newORBPaperBridge().handle)
+
+// StartORBSessionFromEnv starts the live ORB session when ORB_UNIVERSE is set
+// (e.g. "NVDA,AMD,TSLA"; ORB_TIMEFRAME defaults to 5 minutes)
+func StartORBSessionFromEnv() {
+	universe := os.Getenv("ORB_UNIVERSE")
+	if universe == "" {
+		return
+	}
+	cfg := orb.DefaultConfig()
+	cfg.Universe = strings.Split(universe, ",")
+	if tf := os.Getenv("ORB_TIMEFRAME"); tf != "" {
+		cfg.TimeFrame, _ = strconv.Atoi(tf)
+	}
+	if err := orbRunner.SetConfig(cfg); err != nil {
+		log.Printf("⚠️  ORB session not started: %v", err)
+		return
+	}
+	if err := orbRunner.Start(); err != nil {
+		log.Printf("⚠️  ORB session not started: %v", err)
+	}
+}
+
+// HandleGetORBSession returns the runner state and the current session's
+// screen, trades and events
+func HandleGetORBSession(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"success": true,
+		"running": orbRunner.Running(),
+		"config":  orbRunner.Config(),
+		"session": orbRunner.Session(),
+	})
+}
+
+// HandleUpdateORBConfig replaces the universe and filters of the next session
+func HandleUpdateORBConfig(c *fiber.Ctx) error {
+	var cfg orb.Config
+	if err := c.BodyParser(&cfg); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	if err := orbRunner.SetConfig(cfg); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"config":  orbRunner.Config(),
+	})
+}
+
+// HandleStartORBSession starts trading today's session, or the next one
+func HandleStartORBSession(c *fiber.Ctx) error {
+	if err := orbRunner.Start(); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "ORB session started",
+		"day":     orb.SessionDay(time.Now()).Format("2006-01-02"),
+		"config":  orbRunner.Config(),
+	})
+}
+
+// HandleStopORBSession stops the session loop; open paper trades stay open
+func HandleStopORBSession(c *fiber.Ctx) error {
+	orbRunner.Stop()
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "ORB session stopped",
+	})
+}
+
+// HandleReplayORBSession runs a past day's session on recorded bars. The body
+// is a session config plus "date" (YYYY-MM-DD) and "paper" to book the fills
+// as paper trades; an empty universe uses the live session's.
+func HandleReplayORBSession(c *fiber.Ctx) error {
+	var req struct {
+		orb.Config
+		Date  string `json:"date"`
+		Paper bool   `json:"paper"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	day, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Invalid date format: use YYYY-MM-DD",
+		})
+	}
+	if len(req.Universe) == 0 {
+		req.Universe = orbRunner.Config().Universe
+	}
+
+	var onEvent func(orb.Event)
+	if req.Paper {
+		onEvent = newORBPaperBridge().handle
+	}
+	// Midday in New York keeps the date whatever the host's zone
+	session, err := orb.Replay(req.Config, yahooORBSource{}, day.Add(12*time.Hour), onEvent)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"session": session,
+	})
+}
+
+// ==================== PAPER TRADING BRIDGE ====================
+
+// orbPaperBridge books ORB entries as paper trades and closes them on the
+// stop or the end-of-day flatten
+type orbPaperBridge struct {
+	mu  sync.Mutex
+	ids map[string]int // day|symbol -> paper trade ID
+}
+
+func newORBPaperBridge() *orbPaperBridge {
+	return &orbPaperBridge{ids: make(map[string]int)}
+}
+
+func (b *orbPaperBridge) handle(e orb.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := e.Day + "|" + e.Trade.Symbol
+	at := time.UnixMilli(e.Time)
+	switch e.Type {
+	case orb.EventEntry:
+		side := "BUY"
+		if e.Trade.Direction == "SHORT" {
+			side = "SELL"
+		}
+		trade := paperTradingManager.OpenTrade(e.Trade.Symbol, "orb_academic", side, e.Price, e.Trade.StopLoss, at)
+		b.ids[key] = trade.ID
+	case orb.EventStop, orb.EventFlatten:
+		id, ok := b.ids[key]
+		if !ok {
+			return
+		}
+		reason := "StopLoss"
+		if e.Type == orb.EventFlatten {
+			reason = "EOD"
+		}
+		paperTradingManager.CloseTrade(id, e.Price, reason, at)
+		delete(b.ids, key)
+	}
+}
+
+// ==================== YAHOO FINANCE BARS ====================
+
+// yahooORBSource serves US stock bars from the Yahoo Finance chart API.
+// One-minute bars only go back 30 days and come at most 7 days per request.
+type yahooORBSource struct{}
+
+// Daily returns up to n completed daily bars before day
+func (yahooORBSource) Daily(symbol string, day time.Time, n int) ([]Candle, error) {
+	from := day.AddDate(0, 0, -(n*2 + 10)) // Weekends and holidays
+	candles, err := fetchYahooChart(symbol, "1d", from, day)
+	if err != nil {
+		return nil, err
+	}
+	y, m, d := day.Date()
+	cutoff := time.Date(y, m, d, 0, 0, 0, 0, day.Location()).UnixMilli()
+	for len(candles) > 0 && candles[len(candles)-1].Timestamp >= cutoff {
+		candles = candles[:len(candles)-1]
+	}
+	if len(candles) > n {
+		candles = candles[len(candles)-n:]
+	}
+	return candles, nil
+}
+
+// Minutes returns the one-minute bars opening in [from, to), premarket included
+func (yahooORBSource) Minutes(symbol string, from, to time.Time) ([]Candle, error) {
+	candles := []Candle{}
+	for start := from; start.Before(to); start = start.AddDate(0, 0, 7) {
+		end := start.AddDate(0, 0, 7)
+		if end.After(to) {
+			end = to
+		}
+		batch, err := fetchYahooChart(symbol, "1m", start, end)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range batch {
+			if c.Timestamp >= start.UnixMilli() && c.Timestamp < end.UnixMilli() {
+				candles = append(candles, c)
+			}
+		}
+	}
+	return candles, nil
+}
+
+// yahooChartResponse is the part of a chart API response we read
+type yahooChartResponse struct {
+	Chart struct {
+		Result []struct {
+			Timestamp  []int64 `json:"timestamp"`
+			Indicators struct {
+				Quote []struct {
+					Open   []*float64 `json:"open"`
+					High   []*float64 `json:"high"`
+					Low    []*float64 `json:"low"`
+					Close  []*float64 `json:"close"`
+					Volume []*float64 `json:"volume"`
+				} `json:"quote"`
+			} `json:"indicators"`
+		} `json:"result"`
+		Error *struct {
+			Description string `json:"description"`
+		} `json:"error"`
+	} `json:"chart"`
+}
+
+// fetchYahooChart fetches interval bars of symbol between from and to,
+// skipping bars the API returns without prices
+func fetchYahooChart(symbol, interval string, from, to time.Time) ([]Candle, error) {
+	url := fmt.Sprintf("https://query1.finance.yahoo.com/v8/finance/chart/%s?interval=%s&period1=%d&period2=%d&includePrePost=true",
+		symbol, interval, from.Unix(), to.Unix())
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0") // Requests without one are rate limited
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch from Yahoo: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var chart yahooChartResponse
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		if json.Unmarshal(body, &chart) == nil && chart.Chart.Error != nil {
+			return nil, fmt.Errorf("yahoo API error: %s", chart.Chart.Error.Description)
+		}
+		return nil, fmt.Errorf("yahoo API error: %s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&chart); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(chart.Chart.Result) == 0 || len(chart.Chart.Result[0].Indicators.Quote) == 0 {
+		return []Candle{}, nil
+	}
+
+	result := chart.Chart.Result[0]
+	quote := result.Indicators.Quote[0]
+	value := func(series []*float64, i int) (float64, bool) {
+		if i >= len(series) || series[i] == nil {
+			return 0, false
+		}
+		return *series[i], true
+	}
+	candles := make([]Candle, 0, len(result.Timestamp))
+	for i, ts := range result.Timestamp {
+		open, ok1 := value(quote.Open, i)
+		high, ok2 := value(quote.High, i)
+		low, ok3 := value(quote.Low, i)
+		closePrice, ok4 := value(quote.Close, i)
+		if !ok1 || !ok2 || !ok3 || !ok4 {
+			continue
+		}
+		volume, _ := value(quote.Volume, i)
+		candles = append(candles, Candle{
+			Timestamp: ts * 1000,
+			Open:      open,
+			High:      high,
+			Low:       low,
+			Close:     closePrice,
+			Volume:    volume,
+		})
+	}
+	return candles, nil
+}
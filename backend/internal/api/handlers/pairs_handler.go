@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"tradebot/backend/internal/backtest"
+	"tradebot/backend/internal/pairs"
+)
+
+// HandlePairsBacktest runs a two-leg spread backtest and reports spread and leg PnL
+func HandlePairsBacktest(c *fiber.Ctx) error {
+	config := backtest.PairsBacktestConfig{Config: pairs.DefaultConfig()}
+	if err := c.BodyParser(&config); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Invalid request: " + err.Error(),
+		})
+	}
+	if config.SymbolA == "" || config.SymbolB == "" {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "symbolA and symbolB are required",
+		})
+	}
+	config.SymbolA = strings.ToUpper(config.SymbolA)
+	config.SymbolB = strings.ToUpper(config.SymbolB)
+	if config.Interval == "" {
+		config.Interval = "1h"
+	}
+	if config.Days == 0 {
+		config.Days = 90
+	}
+
+	candlesA, candlesB, err := fetchPair(config.SymbolA, config.SymbolB, config.Interval, config.Days)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to fetch data: " + err.Error(),
+		})
+	}
+
+	result, err := backtest.RunPairsBacktest(config, candlesA, candlesB)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Backtest failed: " + err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"result":  result,
+	})
+}
+
+// HandleGetPairAnalysis tests a pair for cointegration and returns the
+// current hedge ratio and z-score (?interval=1h&days=30&hedgeWindow=200&zWindow=100&limit=200)
+func HandleGetPairAnalysis(c *fiber.Ctx) error {
+	symbolA := strings.ToUpper(c.Params("symbolA"))
+	symbolB := strings.ToUpper(c.Params("symbolB"))
+	interval := c.Query("interval", "1h")
+	days := c.QueryInt("days", 30)
+	limit := c.QueryInt("limit", 200) // Spread points returned
+
+	cfg := pairs.DefaultConfig()
+	cfg.HedgeWindow = c.QueryInt("hedgeWindow", cfg.HedgeWindow)
+	cfg.ZWindow = c.QueryInt("zWindow", cfg.ZWindow)
+	if err := cfg.Validate(); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	candlesA, candlesB, err := fetchPair(symbolA, symbolB, interval, days)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to fetch data: " + err.Error(),
+		})
+	}
+	candlesA, candlesB = pairs.Align(candlesA, candlesB)
+
+	closesA := make([]float64, len(candlesA))
+	closesB := make([]float64, len(candlesB))
+	for i := range candlesA {
+		closesA[i], closesB[i] = candlesA[i].Close, candlesB[i].Close
+	}
+	cointegration, err := pairs.EngleGranger(closesA, closesB, 0)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	series := pairs.Series(candlesA, candlesB, cfg)
+	if limit > 0 && len(series) > limit {
+		series = series[len(series)-limit:]
+	}
+	response := fiber.Map{
+		"success":       true,
+		"symbolA":       symbolA,
+		"symbolB":       symbolB,
+		"interval":      interval,
+		"bars":          len(candlesA),
+		"cointegration": cointegration,
+		"series":        series,
+	}
+	if len(series) > 0 {
+		current := series[len(series)-1]
+		response["current"] = current
+		response["signal"] = pairs.Entry(current.ZScore, cfg)
+	}
+	return c.JSON(response)
+}
+
+func fetchPair(symbolA, symbolB, interval string, days int) ([]Candle, []Candle, error) {
+	candlesA, err := fetchBinanceData(symbolA, interval, days)
+	if err != nil {
+		return nil, nil, err
+	}
+	candlesB, err := fetchBinanceData(symbolB, interval, days)
+	if err != nil {
+		return nil, nil, err
+	}
+	return candlesA, candlesB, nil
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"log"
 	"time"
 	
 	"github.com/gofiber/fiber/v2"
@@ -34,6 +35,9 @@ func SetupPaperTradingRoutes(app *fiber.App) {
 	// Start/stop individual strategies
 	api.Post("/start", startStrategyPaperTrading)
 	api.Post("/stop", stopStrategyPaperTrading)
+	
+	// Risk profile rejection counts for a running or stopped strategy
+	api.Get("/profile/:strategy", getStrategyRiskProfile)
 }
 
 // getPaperTradingStats returns paper trading statistics
@@ -81,7 +85,8 @@ func addPaperTrade(c *fiber.Ctx) error {
 		})
 	}
 	
-	generator := &UnifiedSignalGenerator{}
+	params, _, riskPercent := livePaperSettings("session_trader", req.Symbol)
+	generator := &UnifiedSignalGenerator{Params: params}
 	signal := generator.GenerateSignal(candles, "session_trader")
 	
 	if signal == nil || signal.Type == "NONE" {
@@ -92,7 +97,7 @@ func addPaperTrade(c *fiber.Ctx) error {
 	}
 	
 	currentPrice := candles[len(candles)-1].Close
-	trade := paperTradingManager.AddTrade(signal, currentPrice)
+	trade := paperTradingManager.AddTradeAtRisk(signal, currentPrice, riskPercent)
 	
 	return c.JSON(fiber.Map{
 		"success": true,
@@ -148,6 +153,17 @@ func resetPaperTrading(c *fiber.Ctx) error {
 	})
 }
 
+// livePaperSettings returns the parameters, version and risk per trade (a
+// fraction of balance) promoted for a strategy on a symbol, or nil, 0 and 0
+// for the strategy's defaults
+func livePaperSettings(strategy, symbol string) (map[string]float64, int, float64) {
+	live := PromotedSet(strategy, symbol)
+	if live == nil {
+		return nil, 0, 0
+	}
+	return live.Params, live.Version, live.RiskPercent
+}
+
 // Auto paper trading variables
 var (
 	autoPaperTradingRunning = false
@@ -163,14 +179,22 @@ func startAutoPaperTrading(c *fiber.Ctx) error {
 		})
 	}
 	
+	var req struct {
+		Symbol string `json:"symbol"`
+	}
+	if err := c.BodyParser(&req); err != nil || req.Symbol == "" {
+		req.Symbol = "BTCUSDT" // The body is optional
+	}
+	
 	autoPaperTradingRunning = true
 	autoPaperTradingStop = make(chan bool)
 	
-	go runAutoPaperTrading()
+	go runAutoPaperTrading(req.Symbol)
 	
 	return c.JSON(fiber.Map{
 		"success": true,
 		"message": "Auto paper trading started",
+		"symbol":  req.Symbol,
 	})
 }
 
@@ -192,8 +216,9 @@ func stopAutoPaperTrading(c *fiber.Ctx) error {
 	})
 }
 
-// runAutoPaperTrading runs automatic paper trading
-func runAutoPaperTrading() {
+// runAutoPaperTrading runs automatic paper trading of session_trader on
+// symbol with the parameters promoted for it
+func runAutoPaperTrading(symbol string) {
 	ticker := time.NewTicker(15 * time.Minute)
 	defer ticker.Stop()
 	
@@ -203,17 +228,18 @@ func runAutoPaperTrading() {
 			return
 		case <-ticker.C:
 			// Check for new signals
-			candles, err := fetchBinanceData("BTCUSDT", "15m", 4) // 4 days for indicators
+			candles, err := fetchBinanceData(symbol, "15m", 4) // 4 days for indicators
 			if err != nil {
 				continue
 			}
 			
-			generator := &UnifiedSignalGenerator{}
+			params, _, riskPercent := livePaperSettings("session_trader", symbol)
+			generator := &UnifiedSignalGenerator{Params: params}
 			signal := generator.GenerateSignal(candles, "session_trader")
 			
 			if signal != nil && signal.Type != "NONE" {
 				currentPrice := candles[len(candles)-1].Close
-				paperTradingManager.AddTrade(signal, currentPrice)
+				paperTradingManager.AddTradeAtRisk(signal, currentPrice, riskPercent)
 			}
 			
 			// Update open trades
@@ -293,14 +319,17 @@ func addTestTrade(c *fiber.Ctx) error {
 var (
 	runningStrategies = make(map[string]bool)
 	strategyStopChans = make(map[string]chan bool)
+	strategyProfiles  = make(map[string]*RiskProfileFilter)
 )
 
 // startStrategyPaperTrading starts paper trading for a specific strategy
 func startStrategyPaperTrading(c *fiber.Ctx) error {
 	var req struct {
-		Strategy string `json:"strategy"`
-		Symbol   string `json:"symbol"`
-		Interval string `json:"interval"`
+		Strategy string             `json:"strategy"`
+		Symbol   string             `json:"symbol"`
+		Interval string             `json:"interval"`
+		Params   map[string]float64 `json:"params"`      // Strategy parameter overrides
+		Profile  string             `json:"riskProfile"` // Conservative, Balanced or Aggressive
 	}
 	
 	if err := c.BodyParser(&req); err != nil {
@@ -317,14 +346,44 @@ func startStrategyPaperTrading(c *fiber.Ctx) error {
 		})
 	}
 	
+	// Built-in and rule strategies both come from the registry
+	if _, ok := GetStrategy(req.Strategy); !ok {
+		return c.Status(400).JSON(fiber.Map{
+			"success": false,
+			"error":   "Unknown strategy: " + req.Strategy,
+		})
+	}
+	if err := ValidateStrategyParams(req.Strategy, req.Params); err != nil {
+		return strategyParamsErrorResponse(c, err)
+	}
+	
+	var profile *RiskProfileFilter
+	if req.Profile != "" {
+		var err error
+		if profile, err = NewRiskProfileFilter(req.Profile); err != nil {
+			return c.Status(400).JSON(fiber.Map{
+				"success": false,
+				"error":   err.Error(),
+			})
+		}
+	}
+	
 	if req.Symbol == "" {
 		req.Symbol = "BTCUSDT"
 	}
 	
 	if req.Interval == "" {
-		req.Interval = "15m"
+		req.Interval = StrategyTimeframe(req.Strategy, "15m")
 	}
-	
+
+	// Without explicit overrides, trade the promoted parameter version and
+	// follow later promotions and rollbacks
+	followLive := len(req.Params) == 0
+	paramsVersion, riskPercent := 0, 0.0
+	if followLive {
+		req.Params, paramsVersion, riskPercent = livePaperSettings(req.Strategy, req.Symbol)
+	}
+
 	// Check if already running
 	if runningStrategies[req.Strategy] {
 		return c.JSON(fiber.Map{
@@ -336,16 +395,26 @@ func startStrategyPaperTrading(c *fiber.Ctx) error {
 	// Mark as running
 	runningStrategies[req.Strategy] = true
 	strategyStopChans[req.Strategy] = make(chan bool)
+	if profile != nil {
+		strategyProfiles[req.Strategy] = profile
+	} else {
+		delete(strategyProfiles, req.Strategy)
+	}
 	
 	// Start strategy in background
-	go runStrategyPaperTrading(req.Strategy, req.Symbol, req.Interval)
+	go runStrategyPaperTrading(req.Strategy, req.Symbol, req.Interval, req.Params, profile, followLive)
 	
 	return c.JSON(fiber.Map{
-		"success":  true,
-		"message":  "Strategy started",
-		"strategy": req.Strategy,
-		"symbol":   req.Symbol,
-		"interval": req.Interval,
+		"success":       true,
+		"message":       "Strategy started",
+		"strategy":      req.Strategy,
+		"symbol":        req.Symbol,
+		"interval":      req.Interval,
+		"params":        req.Params,
+		"paramsVersion": paramsVersion,
+		"riskPercent":   riskPercent, // Zero trades the default risk
+		"followLive":    followLive,
+		"riskProfile":   req.Profile,
 	})
 }
 
@@ -386,18 +455,54 @@ func stopStrategyPaperTrading(c *fiber.Ctx) error {
 	
 	runningStrategies[req.Strategy] = false
 	
-	return c.JSON(fiber.Map{
+	response := fiber.Map{
 		"success":  true,
 		"message":  "Strategy stopped",
 		"strategy": req.Strategy,
+	}
+	if profile, ok := strategyProfiles[req.Strategy]; ok {
+		response["riskProfile"] = profile.Report()
+	}
+	return c.JSON(response)
+}
+
+// getStrategyRiskProfile returns the risk profile rejection counts of a paper session
+func getStrategyRiskProfile(c *fiber.Ctx) error {
+	strategy := c.Params("strategy")
+	profile, ok := strategyProfiles[strategy]
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{
+			"success": false,
+			"error":   "No risk profile selected for strategy: " + strategy,
+		})
+	}
+	
+	return c.JSON(fiber.Map{
+		"success":     true,
+		"strategy":    strategy,
+		"running":     runningStrategies[strategy],
+		"riskProfile": profile.Report(),
 	})
 }
 
-// runStrategyPaperTrading runs paper trading for a specific strategy
-func runStrategyPaperTrading(strategy, symbol, interval string) {
+// runStrategyPaperTrading runs paper trading for a specific strategy. With
+// followLive it sizes trades by the promoted risk per trade and switches to
+// whichever parameter version is promoted for the symbol as soon as it changes.
+func runStrategyPaperTrading(strategy, symbol, interval string, params map[string]float64, profile *RiskProfileFilter, followLive bool) {
 	ticker := time.NewTicker(5 * time.Minute) // Check every 5 minutes
 	defer ticker.Stop()
 	
+	// One generator per session so parameter overrides and strategy state persist across ticks
+	generator := &UnifiedSignalGenerator{Params: params, Profile: profile}
+	_, paramsVersion, liveRisk := livePaperSettings(strategy, symbol)
+	riskPercent := 0.0 // Explicit overrides trade the default risk
+	if followLive {
+		riskPercent = liveRisk
+	}
+	
+	// Trades opened by this session that the profile has not seen close yet
+	pendingTrades := make(map[int]bool)
+	
 	stopChan := strategyStopChans[strategy]
 	
 	for {
@@ -411,18 +516,40 @@ func runStrategyPaperTrading(strategy, symbol, interval string) {
 				continue
 			}
 			
+			// A promotion or rollback restarts the strategy on the new version
+			if followLive {
+				if live, version, risk := livePaperSettings(strategy, symbol); version != paramsVersion {
+					log.Printf("🔁 Paper %s %s: live parameters v%d -> v%d", strategy, symbol, paramsVersion, version)
+					generator = &UnifiedSignalGenerator{Params: live, Profile: profile}
+					paramsVersion, riskPercent = version, risk
+				}
+			}
+			
 			// Generate signal for this strategy
-			generator := &UnifiedSignalGenerator{}
 			signal := generator.GenerateSignal(candles, strategy)
 			
 			if signal != nil && signal.Type != "NONE" {
 				currentPrice := candles[len(candles)-1].Close
-				paperTradingManager.AddTrade(signal, currentPrice)
+				trade := paperTradingManager.AddTradeAtRisk(signal, currentPrice, riskPercent)
+				if profile != nil {
+					pendingTrades[trade.ID] = true
+				}
 			}
 			
 			// Update open trades
 			currentPrice := candles[len(candles)-1].Close
 			paperTradingManager.UpdateOpenTrades(currentPrice)
+			
+			// Feed closed trades back into the profile's daily limits
+			if profile != nil && len(pendingTrades) > 0 {
+				for _, trade := range paperTradingManager.GetAllTrades() {
+					if !pendingTrades[trade.ID] || trade.Status == "open" || trade.ExitTime == nil {
+						continue
+					}
+					profile.RecordTrade(trade.ExitTime.UnixMilli(), trade.ProfitPercent)
+					delete(pendingTrades, trade.ID)
+				}
+			}
 		}
 	}
 }
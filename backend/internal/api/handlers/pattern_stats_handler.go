@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"tradebot/backend/internal/patternstats"
+)
+
+// PatternStudyRequest runs the pattern detectors over a symbol's history
+type PatternStudyRequest struct {
+	Symbol   string `json:"symbol"`
+	Interval string `json:"interval"`
+	Days     int    `json:"days"`
+	patternstats.Config
+}
+
+// LoadPatternStats loads persisted pattern edge studies used for calibration
+func LoadPatternStats() {
+	n, err := patternstats.GetStore().Load()
+	if err != nil {
+		log.Printf("⚠️  Pattern stats not loaded: %v", err)
+		return
+	}
+	if n > 0 {
+		log.Printf("✅ Loaded %d pattern edge studies from %s", n, patternstats.StoreFile())
+	}
+}
+
+// HandleRunPatternStudy measures the historical edge of every pattern on a
+// symbol and stores it for confidence calibration
+func HandleRunPatternStudy(c *fiber.Ctx) error {
+	req := PatternStudyRequest{Config: patternstats.DefaultConfig()}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	if req.Symbol == "" {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "symbol is required",
+		})
+	}
+	req.Symbol = strings.ToUpper(req.Symbol)
+	if req.Interval == "" {
+		req.Interval = "1h"
+	}
+	if req.Days <= 0 {
+		req.Days = 180
+	}
+
+	candles, err := fetchBinanceData(req.Symbol, req.Interval, req.Days)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to fetch data: " + err.Error(),
+		})
+	}
+
+	study, err := patternstats.Run(req.Symbol, req.Interval, candles, req.Config)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if err := patternstats.GetStore().Save(study); err != nil {
+		log.Printf("⚠️  Pattern study not persisted: %v", err)
+	}
+	log.Printf("📐 Pattern study %s %s: %d bars, %d stat rows", req.Symbol, req.Interval, study.Bars, len(study.Stats))
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"study":   study,
+	})
+}
+
+// HandleListPatternStudies lists the stored studies without their stats
+func HandleListPatternStudies(c *fiber.Ctx) error {
+	studies := []fiber.Map{}
+	for _, study := range patternstats.GetStore().List() {
+		studies = append(studies, fiber.Map{
+			"symbol":    study.Symbol,
+			"timeframe": study.Timeframe,
+			"bars":      study.Bars,
+			"from":      study.From,
+			"to":        study.To,
+			"rows":      len(study.Stats),
+			"createdAt": study.CreatedAt,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"count":   len(studies),
+		"studies": studies,
+	})
+}
+
+// HandleGetPatternStats returns the measured stats of a symbol
+// (?interval=1h&regime=all&pattern=Hammer&minSamples=10)
+func HandleGetPatternStats(c *fiber.Ctx) error {
+	symbol := strings.ToUpper(c.Params("symbol"))
+	interval := c.Query("interval", "1h")
+	regimeName := c.Query("regime")
+	pattern := c.Query("pattern")
+	minSamples := c.QueryInt("minSamples", 0)
+
+	study, ok := patternstats.GetStore().Get(symbol, interval)
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{
+			"error": fmt.Sprintf("No pattern study for %s %s", symbol, interval),
+		})
+	}
+
+	stats := []patternstats.Stats{}
+	for _, st := range study.Stats {
+		if (regimeName == "" || st.Regime == regimeName) &&
+			(pattern == "" || strings.EqualFold(st.Pattern, pattern)) &&
+			st.Samples >= minSamples {
+			stats = append(stats, st)
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"success":   true,
+		"symbol":    study.Symbol,
+		"timeframe": study.Timeframe,
+		"config":    study.Config,
+		"bars":      study.Bars,
+		"createdAt": study.CreatedAt,
+		"count":     len(stats),
+		"stats":     stats,
+	})
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"tradebot/backend/internal/profile"
+)
+
+// HandleGetVolumeProfile returns day, session and composite volume/TPO profiles
+// with tracked POCs and value area edges
+// (?interval=30m&days=10&compositeDays=5&tpoMinutes=30&rowsPerDay=24&tickSize=0&rows=true)
+func HandleGetVolumeProfile(c *fiber.Ctx) error {
+	symbol := strings.ToUpper(c.Params("symbol"))
+	interval := c.Query("interval", "30m")
+	days := c.QueryInt("days", 10)
+	includeRows := c.QueryBool("rows", true)
+
+	cfg := profile.DefaultConfig()
+	cfg.TickSize = c.QueryFloat("tickSize", 0)
+	cfg.RowsPerDay = c.QueryInt("rowsPerDay", cfg.RowsPerDay)
+	cfg.ValueArea = c.QueryFloat("valueArea", cfg.ValueArea)
+	cfg.TPOMinutes = c.QueryInt("tpoMinutes", cfg.TPOMinutes)
+	cfg.CompositeDays = c.QueryInt("compositeDays", cfg.CompositeDays)
+	if err := cfg.Validate(); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	candles, err := fetchBinanceData(symbol, interval, days)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to fetch data: " + err.Error(),
+		})
+	}
+	analysis, err := profile.Analyze(candles, cfg)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if !includeRows {
+		for _, p := range append(append([]*profile.Profile{analysis.Composite}, analysis.Days...), analysis.Sessions...) {
+			p.Rows = nil
+		}
+	}
+
+	above, below := analysis.Nearest(analysis.Price)
+	return c.JSON(fiber.Map{
+		"success":      true,
+		"symbol":       symbol,
+		"interval":     interval,
+		"config":       cfg,
+		"analysis":     analysis,
+		"naked":        analysis.Naked(),
+		"nearestAbove": above,
+		"nearestBelow": below,
+	})
+}
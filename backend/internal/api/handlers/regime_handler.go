@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"tradebot/backend/internal/regime"
+)
+
+// HandleGetRegime detects and publishes the current market regime of a symbol
+func HandleGetRegime(c *fiber.Ctx) error {
+	symbol := strings.ToUpper(c.Params("symbol"))
+	interval := c.Query("interval", "15m")
+	days := c.QueryInt("days", 10)
+
+	candles, err := fetchBinanceData(symbol, interval, days)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to fetch data: " + err.Error(),
+		})
+	}
+
+	state := regime.Detect(candles)
+	if state.Regime == regime.Unknown {
+		return c.Status(400).JSON(fiber.Map{
+			"error": fmt.Sprintf("Insufficient data: got %d candles, need at least %d", len(candles), regime.DefaultConfig().MinBars+30),
+		})
+	}
+	regime.Publish(symbol, interval, state)
+
+	return c.JSON(fiber.Map{
+		"success":  true,
+		"symbol":   symbol,
+		"interval": interval,
+		"regime":   state,
+	})
+}
+
+// HandleListRegimes returns every published regime keyed by "SYMBOL:interval"
+func HandleListRegimes(c *fiber.Ctx) error {
+	regimes := regime.All()
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"count":   len(regimes),
+		"regimes": regimes,
+	})
+}
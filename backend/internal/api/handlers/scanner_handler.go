@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+	"tradebot/backend/internal/scanner"
+)
+
+// patternScanner is the process-wide watchlist scanner
+var patternScanner = scanner.New(scanner.DefaultConfig(), fetchScannerCandles)
+
+// fetchScannerCandles loads the last bars candles of a market from Binance
+func fetchScannerCandles(symbol, interval string, bars int) ([]Candle, error) {
+	days := int(int64(bars)*getIntervalMilliseconds(interval)/(24*60*60*1000)) + 1
+	candles, err := fetchBinanceData(symbol, interval, days)
+	if err != nil {
+		return nil, err
+	}
+	if len(candles) > bars {
+		candles = candles[len(candles)-bars:]
+	}
+	return candles, nil
+}
+
+// StartPatternScannerFromEnv starts the scanner when SCANNER_WATCHLIST is set
+// (e.g. "BTCUSDT,ETHUSDT"; SCANNER_TIMEFRAMES defaults to 15m,1h,4h)
+func StartPatternScannerFromEnv() {
+	watchlist := os.Getenv("SCANNER_WATCHLIST")
+	if watchlist == "" {
+		return
+	}
+	cfg := scanner.DefaultConfig()
+	cfg.Symbols = strings.Split(watchlist, ",")
+	if timeframes := os.Getenv("SCANNER_TIMEFRAMES"); timeframes != "" {
+		cfg.Timeframes = strings.Split(timeframes, ",")
+	}
+	if err := patternScanner.SetConfig(cfg); err != nil {
+		log.Printf("⚠️  Scanner not started: %v", err)
+		return
+	}
+	if err := patternScanner.Start(); err != nil {
+		log.Printf("⚠️  Scanner not started: %v", err)
+	}
+}
+
+// HandleGetScannerResults returns the ranked hits (?symbol=&timeframe=&direction=&source=&limit=50)
+func HandleGetScannerResults(c *fiber.Ctx) error {
+	results := patternScanner.Results(scanner.Filter{
+		Symbol:    c.Query("symbol"),
+		Timeframe: c.Query("timeframe"),
+		Direction: c.Query("direction"),
+		Source:    c.Query("source"),
+		Limit:     c.QueryInt("limit", 50),
+	})
+
+	return c.JSON(fiber.Map{
+		"success":  true,
+		"count":    len(results),
+		"results":  results,
+		"lastScan": patternScanner.LastScan(),
+	})
+}
+
+// HandleGetScannerStatus returns the watchlist and whether the scanner is running
+func HandleGetScannerStatus(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"success":  true,
+		"running":  patternScanner.Running(),
+		"config":   patternScanner.Config(),
+		"lastScan": patternScanner.LastScan(),
+	})
+}
+
+// HandleUpdateScannerConfig replaces the watchlist and ranking settings
+func HandleUpdateScannerConfig(c *fiber.Ctx) error {
+	var cfg scanner.Config
+	if err := c.BodyParser(&cfg); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	if err := patternScanner.SetConfig(cfg); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"config":  patternScanner.Config(),
+	})
+}
+
+// HandleStartScanner starts scanning on every bar close
+func HandleStartScanner(c *fiber.Ctx) error {
+	if err := patternScanner.Start(); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Scanner started",
+		"config":  patternScanner.Config(),
+	})
+}
+
+// HandleStopScanner stops the bar-close loop, keeping the results
+func HandleStopScanner(c *fiber.Ctx) error {
+	patternScanner.Stop()
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Scanner stopped",
+	})
+}
+
+// HandleRunScan scans every watched timeframe now and returns the ranking
+func HandleRunScan(c *fiber.Ctx) error {
+	start := time.Now()
+	patternScanner.ScanTimeframes(patternScanner.Config().Timeframes)
+	results := patternScanner.Results(scanner.Filter{Limit: c.QueryInt("limit", 50)})
+
+	return c.JSON(fiber.Map{
+		"success":  true,
+		"count":    len(results),
+		"results":  results,
+		"duration": time.Since(start).String(),
+	})
+}
+
+// HandleScannerWebSocket streams the ranking after every scan cycle
+func HandleScannerWebSocket(c *websocket.Conn) {
+	clientID := fmt.Sprintf("scanner_%d", time.Now().UnixNano())
+	updates := patternScanner.Subscribe(clientID)
+	defer patternScanner.Unsubscribe(clientID)
+
+	// Send the current ranking first
+	if err := c.WriteJSON(fiber.Map{
+		"type":    "initial",
+		"results": patternScanner.Results(scanner.Filter{}),
+	}); err != nil {
+		return
+	}
+
+	// Goroutine to read from WebSocket (to detect disconnection)
+	done := make(chan struct{})
+	go func() {
+		for {
+			if _, _, err := c.ReadMessage(); err != nil {
+				close(done)
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case update := <-updates:
+			if err := c.WriteJSON(update); err != nil {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HandleListStrategies returns every strategy in the plugin registry
+func HandleListStrategies(c *fiber.Ctx) error {
+	strategies := DescribeStrategies()
+
+	return c.JSON(fiber.Map{
+		"success":    true,
+		"count":      len(strategies),
+		"strategies": strategies,
+	})
+}
+
+// HandleGetStrategy returns metadata, timeframe and parameter schema for one strategy
+func HandleGetStrategy(c *fiber.Ctx) error {
+	name := c.Params("name")
+
+	strategy, ok := GetStrategy(name)
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{
+			"error": "Strategy not found: " + name,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success":  true,
+		"strategy": DescribeStrategy(strategy),
+	})
+}
+
+// HandleGetStrategyParams returns the typed parameter schema of a strategy.
+// Registry strategies also list the schema of their optimized daily variant.
+func HandleGetStrategyParams(c *fiber.Ctx) error {
+	name := c.Params("name")
+
+	schema, ok := ParamSchemaFor(name)
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{
+			"error": "Strategy not found: " + name,
+		})
+	}
+
+	response := fiber.Map{
+		"success":  true,
+		"strategy": name,
+		"params":   schema,
+	}
+	if daily, ok := OptimizedDailyParamSchema(name); ok {
+		response["dailyParams"] = daily
+	}
+	return c.JSON(response)
+}
+
+// strategyParamsErrorResponse reports invalid parameter overrides
+func strategyParamsErrorResponse(c *fiber.Ctx, err error) error {
+	var paramErr *ParamValidationError
+	if errors.As(err, &paramErr) {
+		return c.Status(422).JSON(fiber.Map{
+			"error":    "Invalid strategy parameters",
+			"strategy": paramErr.Strategy,
+			"errors":   paramErr.Errors,
+		})
+	}
+	return c.Status(400).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
+
+// ruleStrategyFormat picks the definition format from the request content type
+func ruleStrategyFormat(c *fiber.Ctx) string {
+	contentType := strings.ToLower(c.Get(fiber.HeaderContentType))
+	switch {
+	case strings.Contains(contentType, "json"):
+		return "json"
+	case strings.Contains(contentType, "yaml"):
+		return "yaml"
+	}
+	return ""
+}
+
+// ruleStrategyErrorResponse returns validation errors as a list so analysts can fix all of them at once
+func ruleStrategyErrorResponse(c *fiber.Ctx, err error) error {
+	var validationErr *RuleValidationError
+	if errors.As(err, &validationErr) {
+		return c.Status(422).JSON(fiber.Map{
+			"error":    "Rule strategy validation failed",
+			"strategy": validationErr.Strategy,
+			"errors":   validationErr.Errors,
+		})
+	}
+	return c.Status(400).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
+
+// HandleListRuleStrategies returns the definitions of all loaded rule strategies
+func HandleListRuleStrategies(c *fiber.Ctx) error {
+	defs := ListRuleStrategyDefinitions()
+
+	return c.JSON(fiber.Map{
+		"success":    true,
+		"count":      len(defs),
+		"directory":  RuleStrategiesDir(),
+		"strategies": defs,
+	})
+}
+
+// HandleValidateRuleStrategy checks a YAML/JSON definition without registering it
+func HandleValidateRuleStrategy(c *fiber.Ctx) error {
+	def, err := ParseRuleStrategy(c.Body(), ruleStrategyFormat(c))
+	if err != nil {
+		return ruleStrategyErrorResponse(c, err)
+	}
+	if err := def.Validate(); err != nil {
+		return ruleStrategyErrorResponse(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"valid":   true,
+		"name":    def.Name,
+	})
+}
+
+// HandleRegisterRuleStrategy validates a YAML/JSON definition and registers it at runtime
+func HandleRegisterRuleStrategy(c *fiber.Ctx) error {
+	def, err := ParseRuleStrategy(c.Body(), ruleStrategyFormat(c))
+	if err != nil {
+		return ruleStrategyErrorResponse(c, err)
+	}
+
+	strategy, err := RegisterRuleStrategy(*def)
+	if err != nil {
+		return ruleStrategyErrorResponse(c, err)
+	}
+
+	log.Printf("📐 Rule strategy registered: %s", def.Name)
+	return c.JSON(fiber.Map{
+		"success":  true,
+		"strategy": DescribeStrategy(strategy),
+	})
+}
+
+// HandleDeleteRuleStrategy removes a runtime-loaded rule strategy
+func HandleDeleteRuleStrategy(c *fiber.Ctx) error {
+	name := c.Params("name")
+	if err := UnregisterRuleStrategy(name); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	log.Printf("🗑️  Rule strategy removed: %s", name)
+	return c.JSON(fiber.Map{
+		"success": true,
+		"removed": name,
+	})
+}
+
+// HandleReloadRuleStrategies reloads every file in the rule strategies directory
+func HandleReloadRuleStrategies(c *fiber.Ctx) error {
+	loaded, errs := LoadRuleStrategiesDir(RuleStrategiesDir())
+
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+
+	return c.JSON(fiber.Map{
+		"success": len(errs) == 0,
+		"loaded":  loaded,
+		"errors":  messages,
+	})
+}
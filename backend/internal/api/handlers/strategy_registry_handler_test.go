@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type strategyParamsPayload struct {
+	Success     bool        `json:"success"`
+	Strategy    string      `json:"strategy"`
+	Params      []ParamSpec `json:"params"`
+	DailyParams []ParamSpec `json:"dailyParams"`
+	Error       string      `json:"error"`
+}
+
+func getStrategyParams(t *testing.T, name string) (int, strategyParamsPayload) {
+	t.Helper()
+	app := fiber.New()
+	app.Get("/strategies/:name/params", HandleGetStrategyParams)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/strategies/"+name+"/params", nil))
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var payload strategyParamsPayload
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatalf("Failed to decode %s params: %v", name, err)
+	}
+	return resp.StatusCode, payload
+}
+
+func TestHandleGetStrategyParams(t *testing.T) {
+	status, payload := getStrategyParams(t, "liquidity_hunter")
+	if status != 200 || !payload.Success || payload.Strategy != "liquidity_hunter" {
+		t.Fatalf("Expected the liquidity_hunter schema, got %d %+v", status, payload)
+	}
+
+	want := []ParamSpec{
+		{Name: "min_conditions", Type: "int", Default: 6, Min: 4, Max: 7, Step: 1},
+		{Name: "ema_distance_pct", Type: "float", Default: 0.5, Min: 0.1, Max: 2, Step: 0.1},
+		{Name: "volume_mult", Type: "float", Default: 1.5, Min: 1, Max: 3, Step: 0.1},
+		{Name: "stop_atr", Type: "float", Default: 1.5, Min: 0.25, Max: 5, Step: 0.25},
+		{Name: "tp1_atr", Type: "float", Default: 4, Min: 0.5, Max: 10, Step: 0.25},
+		{Name: "tp2_atr", Type: "float", Default: 6, Min: 0.5, Max: 15, Step: 0.25},
+		{Name: "tp3_atr", Type: "float", Default: 10, Min: 1, Max: 25, Step: 0.5},
+	}
+	if len(payload.Params) != len(want) {
+		t.Fatalf("Expected %d params, got %+v", len(want), payload.Params)
+	}
+	for i, w := range want {
+		got := payload.Params[i]
+		got.Description = ""
+		if got != w {
+			t.Errorf("params[%d] = %+v, want %+v", i, got, w)
+		}
+		if payload.Params[i].Description == "" {
+			t.Errorf("params[%d] %s has no description", i, w.Name)
+		}
+	}
+
+	daily := map[string]float64{}
+	for _, p := range payload.DailyParams {
+		daily[p.Name] = p.Default
+	}
+	if _, ok := daily["stop_atr"]; !ok || daily["max_daily_trades"] != 10 {
+		t.Errorf("Expected the daily variant's schema, got %+v", payload.DailyParams)
+	}
+
+	// Components without a registry strategy list their schema only
+	status, payload = getStrategyParams(t, "professional")
+	if status != 200 || len(payload.Params) == 0 || payload.Params[0].Name != "min_confidence" || payload.DailyParams != nil {
+		t.Errorf("Expected the professional schema alone, got %d %+v", status, payload)
+	}
+
+	status, payload = getStrategyParams(t, "no_such_strategy")
+	if status != 404 || payload.Error != "Strategy not found: no_such_strategy" {
+		t.Errorf("Expected 404 for an unknown strategy, got %d %+v", status, payload)
+	}
+}
@@ -12,6 +12,8 @@ type UnifiedBacktestRequest struct {
 	Days                int      `json:"days"`
 	StartBalance        float64  `json:"startBalance"`
 	Strategy            string   `json:"strategy"`
+	Params              map[string]float64 `json:"params"` // Strategy parameter overrides
+	RiskProfile         string   `json:"riskProfile"` // Conservative, Balanced or Aggressive
 	
 	// Optional advanced features
 	EnableMonteCarlo    bool     `json:"enableMonteCarlo"`
@@ -20,6 +22,7 @@ type UnifiedBacktestRequest struct {
 	EnablePartialExits  bool     `json:"enablePartialExits"`
 	EnableParallel      bool     `json:"enableParallel"`
 	Strategies          []string `json:"strategies"`
+	RollingWindow       int      `json:"rollingWindow"`
 	
 	// Optional risk management
 	RiskPercent         float64  `json:"riskPercent"`
@@ -59,6 +62,18 @@ func HandleUnifiedBacktest(c *fiber.Ctx) error {
 		req.Strategy = "liquidity_hunter"
 	}
 	
+	// Validate parameter overrides before fetching data
+	if err := ValidateStrategyParams(req.Strategy, req.Params); err != nil {
+		return strategyParamsErrorResponse(c, err)
+	}
+	if req.RiskProfile != "" {
+		if _, err := NewRiskProfileFilter(req.RiskProfile); err != nil {
+			return c.Status(400).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+	
 	// Fetch candles
 	candles, err := backtest.FetchBinanceData(req.Symbol, req.Interval, req.Days)
 	if err != nil {
@@ -80,12 +95,15 @@ func HandleUnifiedBacktest(c *fiber.Ctx) error {
 		Days:                req.Days,
 		StartBalance:        req.StartBalance,
 		Strategy:            req.Strategy,
+		Params:              req.Params,
+		RiskProfile:         req.RiskProfile,
 		EnableMonteCarlo:    req.EnableMonteCarlo,
 		EnableStressTest:    req.EnableStressTest,
 		UseWalkForward:      req.EnableWalkForward,
 		EnablePartialExits:  req.EnablePartialExits,
 		EnableParallel:      req.EnableParallel,
 		Strategies:          req.Strategies,
+		RollingWindow:       req.RollingWindow,
 		RiskPercent:         req.RiskPercent,
 		MaxDailyLoss:        req.MaxDailyLoss,
 		MaxConsecutiveLoss:  req.MaxConsecutiveLoss,
@@ -103,8 +121,12 @@ func HandleUnifiedBacktest(c *fiber.Ctx) error {
 		})
 	}
 	
+	// Keep the result so follow-up analysis (Monte Carlo, reports) can run on it
+	resultID := backtest.GetBacktestResultStore().Save(config, result)
+	
 	return c.JSON(fiber.Map{
-		"success": true,
-		"result":  result,
+		"success":  true,
+		"resultId": resultID,
+		"result":   result,
 	})
 }
@@ -28,13 +28,30 @@ func HandleWorldClassOptimization(c *fiber.Ctx) error {
 	// Print summary
 	results.PrintSummary()
 	
-	return c.JSON(results)
+	// Optionally make the winners the live parameter versions
+	if !c.QueryBool("promote", false) {
+		return c.JSON(results)
+	}
+	promoted, skipped := PromoteWorldClassResults(results, c.Query("note"))
+	for _, set := range promoted {
+		log.Printf("🚀 Promoted world-class %s %s v%d: %v", set.Strategy, set.Symbol, set.Version, set.Params)
+	}
+	for _, reason := range skipped {
+		log.Printf("⚠️  Not promoted: %s", reason)
+	}
+	
+	return c.JSON(fiber.Map{
+		"results":  results,
+		"promoted": promoted,
+		"skipped":  skipped,
+	})
 }
 
 // HandleQuickOptimization runs a faster optimization with fewer parameters
 func HandleQuickOptimization(c *fiber.Ctx) error {
 	var req struct {
-		Strategy string `json:"strategy"`
+		Strategy string             `json:"strategy"`
+		Params   map[string]float64 `json:"params"` // Fixed overrides applied to every tested combination
 	}
 	
 	if err := c.BodyParser(&req); err != nil {
@@ -43,21 +60,21 @@ func HandleQuickOptimization(c *fiber.Ctx) error {
 		})
 	}
 	
-	log.Printf("⚡ Quick optimization for: %s", req.Strategy)
-	
-	// Fetch candles
-	candles, err := fetchBinanceData("BTCUSDT", "15m", 180)
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"error": "Failed to fetch data",
-		})
+	if err := ValidateStrategyParams(req.Strategy, req.Params); err != nil {
+		return strategyParamsErrorResponse(c, err)
 	}
 	
-	// Create optimizer
+	log.Printf("⚡ Quick optimization for: %s", req.Strategy)
+	
+	// A shorter search of the same ATR exits, on the strategy's own timeframe
 	optimizer := NewWorldClassOptimizer()
+	optimizer.MaxTrials = 100
+	if len(req.Params) > 0 {
+		optimizer.Params = map[string]map[string]float64{req.Strategy: req.Params}
+	}
 	
 	// Optimize single strategy
-	result := optimizer.OptimizeStrategy(req.Strategy, candles)
+	result := optimizer.OptimizeStrategy(req.Strategy, nil)
 	
 	return c.JSON(result)
 }
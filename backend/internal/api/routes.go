@@ -46,7 +46,7 @@ func SetupRoutes(app *fiber.App) {
 	backtest.Post("/test-all-strategies", HandleTestAllStrategies) // Test 10 advanced strategies
 	backtest.Post("/optimize-parameters", HandleOptimizeParameters) // Optimize single strategy parameters
 	backtest.Post("/optimize-all", HandleOptimizeAllStrategies) // Optimize all strategies
-	backtest.Post("/world-class-optimize", HandleWorldClassOptimization) // World-class optimization
+	backtest.Post("/world-class-optimize", HandleWorldClassOptimization) // World-class optimization (?promote=true makes non-overfit winners live)
 	backtest.Post("/quick-optimize", HandleQuickOptimization) // Quick single strategy optimization
 	backtest.Post("/live-signal", HandleLiveSignalFiber) // Get live trading signal
 	backtest.Post("/world-class", HandleWorldClassBacktest) // World-class backtest with advanced metrics
@@ -59,7 +59,83 @@ func SetupRoutes(app *fiber.App) {
 	backtest.Get("/ai-config", HandleAIConfig)              // Check AI configuration status
 	backtest.Post("/optimized", HandleOptimizedBacktest)    // Optimized daily trading strategies
 	backtest.Get("/optimized-all", HandleOptimizeAllDailyStrategies) // Test all 10 optimized strategies
+	backtest.Post("/unified", HandleUnifiedBacktest)        // Unified engine (stores result for follow-up analysis)
+	backtest.Post("/monte-carlo", HandleMonteCarlo)         // Advanced Monte Carlo on a stored result
+	backtest.Get("/report/:id", HandleBacktestReport)       // Standalone HTML report for a stored result
+	backtest.Post("/pairs", HandlePairsBacktest)            // Two-leg spread backtest (spread and leg PnL)
+	backtest.Post("/multi-order", HandleMultiOrderBacktest) // Grid / DCA backtest with multiple fills per bar
 	
+	// Strategy registry routes
+	strategyRegistry := api.Group("/strategies")
+	strategyRegistry.Get("/", HandleListStrategies)    // All registered strategies
+	strategyRegistry.Get("/rules", HandleListRuleStrategies)           // Loaded rule strategy definitions
+	strategyRegistry.Post("/rules", HandleRegisterRuleStrategy)        // Register a YAML/JSON rule strategy
+	strategyRegistry.Post("/rules/validate", HandleValidateRuleStrategy) // Validate without registering
+	strategyRegistry.Post("/rules/reload", HandleReloadRuleStrategies) // Reload RULE_STRATEGIES_DIR
+	strategyRegistry.Delete("/rules/:name", HandleDeleteRuleStrategy)  // Remove a rule strategy
+	strategyRegistry.Get("/:name", HandleGetStrategy)  // Metadata + parameter schema
+	strategyRegistry.Get("/:name/params", HandleGetStrategyParams) // Typed parameter schema
+	
+	// Market regime routes
+	regimes := api.Group("/regime")
+	regimes.Get("/", HandleListRegimes)      // Last published regime of every market
+	regimes.Get("/:symbol", HandleGetRegime) // Detect and publish (?interval=15m&days=10)
+
+	// Market structure analysis routes (chart overlays)
+	analysis := api.Group("/analysis")
+	analysis.Get("/detectors", HandleListAnalysisDetectors)       // Available detectors
+	analysis.Get("/:symbol", HandleGetAnalysis)                   // ?interval=15m&days=7&detectors=ict,liquidity&limit=20
+	analysis.Get("/:symbol/:detector", HandleGetDetectorAnalysis) // One detector
+
+	// Pattern & setup scanner routes (screener)
+	scannerRoutes := api.Group("/scanner")
+	scannerRoutes.Get("/results", HandleGetScannerResults)  // ?symbol=&timeframe=&direction=&source=&limit=50
+	scannerRoutes.Get("/status", HandleGetScannerStatus)    // Watchlist and running state
+	scannerRoutes.Put("/config", HandleUpdateScannerConfig) // Replace watchlist/settings
+	scannerRoutes.Post("/start", HandleStartScanner)        // Scan on every bar close
+	scannerRoutes.Post("/stop", HandleStopScanner)          // Stop the loop
+	scannerRoutes.Post("/scan", HandleRunScan)              // Scan now
+
+	// Pairs trading routes (statistical arbitrage)
+	pairsRoutes := api.Group("/pairs")
+	pairsRoutes.Get("/:symbolA/:symbolB", HandleGetPairAnalysis) // Cointegration, hedge ratio and z-score
+
+	// Pattern edge statistics routes (measured reliability)
+	patternStats := api.Group("/pattern-stats")
+	patternStats.Get("/", HandleListPatternStudies)     // Stored studies
+	patternStats.Post("/study", HandleRunPatternStudy)  // Run a study on a symbol's history
+	patternStats.Get("/:symbol", HandleGetPatternStats) // ?interval=1h&regime=all&pattern=&minSamples=
+
+	// Volume profile routes (session / composite profiles, naked POCs)
+	profileRoutes := api.Group("/profile")
+	profileRoutes.Get("/:symbol", HandleGetVolumeProfile) // ?interval=30m&days=10&compositeDays=5&rows=true
+
+	// Anchored VWAP routes (session / calendar / swing / time anchors with bands)
+	avwapRoutes := api.Group("/avwap")
+	avwapRoutes.Get("/:symbol", HandleGetAnchoredVWAP) // ?interval=15m&days=30&anchors=session,week,swing_low:5&limit=300
+
+	// Liquidity ledger routes (persistent session / day / week / swing levels and sweeps)
+	liquidityRoutes := api.Group("/liquidity")
+	liquidityRoutes.Get("/", HandleListLiquidity)            // Tracked markets
+	liquidityRoutes.Get("/:symbol", HandleGetLiquidity)      // ?interval=15m&days=10&source=session,day&active=true
+	liquidityRoutes.Delete("/:symbol", HandleResetLiquidity) // ?interval=15m
+
+	// Parameter optimization routes (grid / random / TPE / genetic over strategy schemas)
+	optimizeRoutes := api.Group("/optimize")
+	optimizeRoutes.Post("/", HandleOptimize)                                          // Run a search ({strategy, method, objective, maxTrials, ...})
+	optimizeRoutes.Get("/", HandleListOptimizations)                                  // Stored reports
+	optimizeRoutes.Get("/options", HandleOptimizeOptions)                             // Methods, objectives and cache stats
+	optimizeRoutes.Get("/live", HandleListLiveParams)                                 // Live parameter set per strategy and symbol
+	optimizeRoutes.Get("/live/audit", HandleLiveParamAudit)                           // Promotions and rollbacks (?strategy=&symbol=)
+	optimizeRoutes.Get("/live/:strategy/:symbol", HandleGetLiveParamHistory)          // Every promoted version and the live one
+	optimizeRoutes.Post("/live/:strategy/:symbol/rollback", HandleRollbackLiveParams) // Make an earlier version live ({version, note})
+	optimizeRoutes.Post("/sensitivity", HandleSensitivity)                            // Perturb parameters around a center ({reportId, trial} or {params})
+	optimizeRoutes.Get("/sensitivity/:id", HandleGetSensitivity)                      // Stored sensitivity report with curves and heatmaps
+	optimizeRoutes.Get("/sensitivity/:id/heatmap.svg", HandleSensitivityHeatmapSVG)   // Heatmap as SVG (?pair=N)
+	optimizeRoutes.Get("/:id", HandleGetOptimization)                                 // Full report with every trial
+	optimizeRoutes.Get("/:id/pareto", HandleGetParetoFront)                           // Pareto front points with metrics
+	optimizeRoutes.Post("/:id/promote", HandlePromoteOptimizationTrial)               // Promote a trial ({trial, note}) as the next live version
+
 	// External Signal API routes (FREE)
 	externalSignals := api.Group("/external-signals")
 	externalSignals.Post("/get", HandleExternalSignals)      // Get external signals
@@ -76,6 +152,11 @@ func SetupRoutes(app *fiber.App) {
 	orb.Get("/live-signals", HandleORBLiveSignals)        // Get live ORB signals
 	orb.Post("/compare", HandleORBCompareTimeframes)      // Compare timeframes
 	orb.Get("/top-performers", HandleORBTopPerformers)    // Get top performing stocks
+	orb.Get("/session", HandleGetORBSession)              // Live session screen, trades and events
+	orb.Put("/session/config", HandleUpdateORBConfig)     // Universe and gap/volume filters
+	orb.Post("/session/start", HandleStartORBSession)     // Trade every NYSE session to paper
+	orb.Post("/session/stop", HandleStopORBSession)       // Stop the session loop
+	orb.Post("/session/replay", HandleReplayORBSession)   // Replay a past day ({date, paper, ...config})
 
 	// AI Enhancement routes (commented out - implement if needed)
 	// ai := api.Group("/ai")
@@ -136,6 +217,7 @@ func SetupRoutes(app *fiber.App) {
 	app.Use("/ws", WebSocketUpgrade)
 	app.Get("/ws/signals", websocket.New(HandleWebSocket))
 	app.Get("/ws/activity", websocket.New(HandleActivityWebSocket))
+	app.Get("/ws/scanner", websocket.New(HandleScannerWebSocket))
 	
 	// Activity Terminal page
 	app.Get("/activity-terminal", func(c *fiber.Ctx) error {
@@ -10,15 +10,18 @@ import (
 
 // BacktestConfig holds backtest parameters
 type BacktestConfig struct {
-	Symbol          string  `json:"symbol"`
-	Interval        string  `json:"interval"`
-	Days            int     `json:"days"`
-	StartBalance    float64 `json:"startBalance"`
-	RiskPercent     float64 `json:"riskPercent"`
-	MaxPositionCap  float64 `json:"maxPositionCap"`
-	SlippagePercent float64 `json:"slippagePercent"`
-	FeePercent      float64 `json:"feePercent"`
-	Strategy        string  `json:"strategy"` // Strategy name (e.g., "liquidity_hunter", "breakout_master")
+	Symbol          string             `json:"symbol"`
+	Interval        string             `json:"interval"`
+	Days            int                `json:"days"`
+	StartBalance    float64            `json:"startBalance"`
+	RiskPercent     float64            `json:"riskPercent"`
+	MaxPositionCap  float64            `json:"maxPositionCap"`
+	SlippagePercent float64            `json:"slippagePercent"`
+	FeePercent      float64            `json:"feePercent"`
+	Strategy        string             `json:"strategy"`                // Strategy name (e.g., "liquidity_hunter", "breakout_master")
+	Params          map[string]float64 `json:"params,omitempty"`        // Strategy parameter overrides, validated against the strategy schema
+	RiskProfile     string             `json:"riskProfile,omitempty"`   // Conservative, Balanced or Aggressive; empty for none
+	UseLiveParams   bool               `json:"useLiveParams,omitempty"` // Trade the set promoted for the symbol; Params and RiskPercent still win
 
 	// Enhanced simulation options
 	WindowType     string `json:"windowType"`     // "expanding", "rolling", "fixed"
@@ -53,7 +56,8 @@ type BacktestResult struct {
 	Duration      string         `json:"duration"`
 
 	// Enhanced metrics
-	StrategyName         string              `json:"strategyName,omitempty"` // Added for identification
+	StrategyName         string              `json:"strategyName,omitempty"`  // Added for identification
+	ParamsVersion        int                 `json:"paramsVersion,omitempty"` // Live parameter version traded with UseLiveParams
 	WindowType           string              `json:"windowType,omitempty"`
 	MonteCarloSim        *MonteCarloResult   `json:"monteCarloSim,omitempty"`
 	WalkForwardResults   []WalkForwardPeriod `json:"walkForwardResults,omitempty"`
@@ -61,6 +65,7 @@ type BacktestResult struct {
 	SharpeRatio          float64             `json:"sharpeRatio,omitempty"`
 	SortinoRatio         float64             `json:"sortinoRatio,omitempty"`
 	MaxConsecutiveLosses int                 `json:"maxConsecutiveLosses,omitempty"`
+	RiskProfile          *RiskProfileReport  `json:"riskProfile,omitempty"`
 }
 
 // MonteCarloResult holds Monte Carlo simulation results
@@ -91,17 +96,21 @@ type WalkForwardPeriod struct {
 
 // Trade represents a single trade
 type Trade struct {
-	Type          string  `json:"type"`
-	Entry         float64 `json:"entry"`
-	Exit          float64 `json:"exit"`
-	StopLoss      float64 `json:"stopLoss"`
-	ExitReason    string  `json:"exitReason"`
-	CandlesHeld   int     `json:"candlesHeld"`
-	Profit        float64 `json:"profit"`
-	ProfitPercent float64 `json:"profitPercent"`
-	RR            float64 `json:"rr"`
-	BalanceAfter  float64 `json:"balanceAfter"`
-	EntryIndex    int     `json:"entryIndex"`
+	Type          string     `json:"type"`
+	Entry         float64    `json:"entry"`
+	Exit          float64    `json:"exit"`
+	StopLoss      float64    `json:"stopLoss"`
+	ExitReason    string     `json:"exitReason"`
+	CandlesHeld   int        `json:"candlesHeld"`
+	Profit        float64    `json:"profit"`
+	ProfitPercent float64    `json:"profitPercent"`
+	RR            float64    `json:"rr"`
+	BalanceAfter  float64    `json:"balanceAfter"`
+	EntryIndex    int        `json:"entryIndex"`
+	EntryTime     int64      `json:"entryTime,omitempty"` // Entry candle timestamp (ms)
+	ExitTime      int64      `json:"exitTime,omitempty"`  // Exit candle timestamp (ms)
+	Reasons       []string   `json:"reasons,omitempty"`
+	Evidence      []Evidence `json:"evidence,omitempty"` // Zones behind the entry signal
 }
 
 // Signal represents a trading signal
@@ -123,16 +132,18 @@ type Target struct {
 
 // RunBacktestWithCustomParams executes backtest with custom ATR parameters (for optimization)
 func RunBacktestWithCustomParams(config BacktestConfig, candles []Candle, stopATR, tp1ATR, tp2ATR, tp3ATR float64) (*BacktestResult, error) {
-	return runBacktestInternal(config, candles, &stopATR, &tp1ATR, &tp2ATR, &tp3ATR)
+	exits := [4]float64{stopATR, tp1ATR, tp2ATR, tp3ATR}
+	return runBacktestInternal(config, candles, &exits)
 }
 
 // RunBacktest executes the backtest with Go's speed
 func RunBacktest(config BacktestConfig, candles []Candle) (*BacktestResult, error) {
-	return runBacktestInternal(config, candles, nil, nil, nil, nil)
+	return runBacktestInternal(config, candles, nil)
 }
 
-// runBacktestInternal is the core backtest logic
-func runBacktestInternal(config BacktestConfig, candles []Candle, customStopATR, customTP1ATR, customTP2ATR, customTP3ATR *float64) (*BacktestResult, error) {
+// runBacktestInternal is the core backtest logic. customExits, when set, are
+// the stop and take-profit ATR multiples to trade instead of the strategy's.
+func runBacktestInternal(config BacktestConfig, candles []Candle, customExits *[4]float64) (*BacktestResult, error) {
 	startTime := time.Now()
 
 	result := &BacktestResult{
@@ -144,6 +155,24 @@ func runBacktestInternal(config BacktestConfig, candles []Candle, customStopATR,
 		StrategyName: config.Strategy,
 	}
 
+	// Only an opted-in run trades the promoted set; everything else keeps the baseline
+	if config.UseLiveParams {
+		if live := PromotedSet(config.Strategy, config.Symbol); live != nil {
+			params := make(map[string]float64, len(live.Params)+len(config.Params))
+			for k, v := range live.Params {
+				params[k] = v
+			}
+			for k, v := range config.Params {
+				params[k] = v
+			}
+			config.Params = params
+			if config.RiskPercent == 0 {
+				config.RiskPercent = live.RiskPercent
+			}
+			result.ParamsVersion = live.Version
+		}
+	}
+
 	// Set defaults - OPTIMIZED for lower drawdown
 	if config.RiskPercent == 0 {
 		config.RiskPercent = 0.003 // 0.3% risk per trade (optimized for <12% DD)
@@ -158,8 +187,23 @@ func runBacktestInternal(config BacktestConfig, candles []Candle, customStopATR,
 		config.FeePercent = 0.001 // 0.1% fee
 	}
 
+	if len(config.Params) > 0 {
+		if err := ValidateStrategyParams(config.Strategy, config.Params); err != nil {
+			return nil, err
+		}
+	}
+
+	var profile *RiskProfileFilter
+	if config.RiskProfile != "" {
+		var err error
+		if profile, err = NewRiskProfileFilter(config.RiskProfile); err != nil {
+			return nil, err
+		}
+	}
+
 	windowSize := 100 // Increased to 100 to match UnifiedSignalGenerator requirement
 	skipAhead := 5
+	usg := &UnifiedSignalGenerator{Params: config.Params, Profile: profile}
 
 	// Simulate trading through historical data
 	for i := windowSize; i < len(candles)-10; i++ {
@@ -167,7 +211,6 @@ func runBacktestInternal(config BacktestConfig, candles []Candle, customStopATR,
 		futureData := candles[i : i+10]
 
 		// Generate signal using UNIFIED generator (same logic as live trading!)
-		usg := &UnifiedSignalGenerator{}
 		advSignal := usg.GenerateSignal(dataWindow, config.Strategy)
 
 		// Convert AdvancedSignal to Signal for backtest
@@ -187,13 +230,15 @@ func runBacktestInternal(config BacktestConfig, candles []Candle, customStopATR,
 			}
 		}
 
-		// Apply strategy-specific modifications if strategy is selected
+		// Place the exits with the ATR the generator used: custom multiples if
+		// provided (for optimization), otherwise the strategy's own with overrides
 		if signal != nil && config.Strategy != "" && config.Strategy != "default" {
-			// Use custom parameters if provided (for optimization), otherwise use hardcoded ones
-			if customStopATR != nil && customTP1ATR != nil && customTP2ATR != nil && customTP3ATR != nil {
-				signal = applyCustomParameters(signal, *customStopATR, *customTP1ATR, *customTP2ATR, *customTP3ATR)
-			} else {
-				signal = applyStrategyParameters(signal, config.Strategy)
+			exits, ok := strategyExits(config.Strategy, config.Params)
+			if customExits != nil {
+				exits, ok = *customExits, true
+			}
+			if ok {
+				signal = applyATRExits(signal, usg.ATR(dataWindow, config.Strategy), exits)
 			}
 		}
 
@@ -203,7 +248,12 @@ func runBacktestInternal(config BacktestConfig, candles []Candle, customStopATR,
 
 			if trade != nil {
 				trade.EntryIndex = i
+				trade.EntryTime = candles[i].Timestamp
+				trade.ExitTime = futureData[trade.CandlesHeld-1].Timestamp
 				trade.BalanceAfter = result.FinalBalance + trade.Profit
+				if profile != nil && result.FinalBalance > 0 {
+					profile.RecordTrade(trade.EntryTime, trade.Profit/result.FinalBalance*100)
+				}
 
 				result.Trades = append(result.Trades, *trade)
 				result.TotalTrades++
@@ -238,6 +288,10 @@ func runBacktestInternal(config BacktestConfig, candles []Candle, customStopATR,
 		}
 	}
 
+	if profile != nil {
+		result.RiskProfile = profile.Report()
+	}
+
 	// Calculate statistics
 	calculateStats(result)
 	result.Duration = time.Since(startTime).String()
@@ -577,118 +631,6 @@ func calculateStats(result *BacktestResult) {
 	}
 }
 
-// calculateATR calculates Average True Range
-func calculateATR(candles []Candle, period int) float64 {
-	if len(candles) < period {
-		period = len(candles)
-	}
-
-	if period == 0 {
-		return 0
-	}
-
-	sum := 0.0
-	for i := len(candles) - period; i < len(candles); i++ {
-		tr := candles[i].High - candles[i].Low
-		sum += tr
-	}
-
-	return sum / float64(period)
-}
-
-// calculateADX calculates Average Directional Index for trend strength
-func calculateADX(candles []Candle, period int) float64 {
-	if len(candles) < period+1 {
-		return 0
-	}
-
-	// Calculate +DM, -DM, and TR
-	plusDM := make([]float64, len(candles)-1)
-	minusDM := make([]float64, len(candles)-1)
-	tr := make([]float64, len(candles)-1)
-
-	for i := 1; i < len(candles); i++ {
-		high := candles[i].High
-		low := candles[i].Low
-		prevHigh := candles[i-1].High
-		prevLow := candles[i-1].Low
-		prevClose := candles[i-1].Close
-
-		// +DM and -DM
-		upMove := high - prevHigh
-		downMove := prevLow - low
-
-		if upMove > downMove && upMove > 0 {
-			plusDM[i-1] = upMove
-		} else {
-			plusDM[i-1] = 0
-		}
-
-		if downMove > upMove && downMove > 0 {
-			minusDM[i-1] = downMove
-		} else {
-			minusDM[i-1] = 0
-		}
-
-		// True Range
-		tr1 := high - low
-		tr2 := math.Abs(high - prevClose)
-		tr3 := math.Abs(low - prevClose)
-		tr[i-1] = math.Max(tr1, math.Max(tr2, tr3))
-	}
-
-	// Smooth +DM, -DM, and TR
-	smoothPlusDM := 0.0
-	smoothMinusDM := 0.0
-	smoothTR := 0.0
-
-	// Initial sum
-	for i := 0; i < period && i < len(plusDM); i++ {
-		smoothPlusDM += plusDM[i]
-		smoothMinusDM += minusDM[i]
-		smoothTR += tr[i]
-	}
-
-	if smoothTR == 0 {
-		return 0
-	}
-
-	// Calculate +DI and -DI
-	plusDI := (smoothPlusDM / smoothTR) * 100
-	minusDI := (smoothMinusDM / smoothTR) * 100
-
-	// Calculate DX
-	if plusDI+minusDI == 0 {
-		return 0
-	}
-	dx := math.Abs(plusDI-minusDI) / (plusDI + minusDI) * 100
-
-	// ADX is smoothed DX
-	adx := dx
-
-	// Smooth ADX over remaining periods
-	for i := period; i < len(tr); i++ {
-		smoothPlusDM = smoothPlusDM - (smoothPlusDM / float64(period)) + plusDM[i]
-		smoothMinusDM = smoothMinusDM - (smoothMinusDM / float64(period)) + minusDM[i]
-		smoothTR = smoothTR - (smoothTR / float64(period)) + tr[i]
-
-		if smoothTR == 0 {
-			continue
-		}
-
-		plusDI = (smoothPlusDM / smoothTR) * 100
-		minusDI = (smoothMinusDM / smoothTR) * 100
-
-		if plusDI+minusDI == 0 {
-			continue
-		}
-		dx = math.Abs(plusDI-minusDI) / (plusDI + minusDI) * 100
-		adx = ((adx * (float64(period) - 1)) + dx) / float64(period)
-	}
-
-	return adx
-}
-
 // ExportToJSON exports results to JSON
 func (r *BacktestResult) ToJSON() ([]byte, error) {
 	return json.MarshalIndent(r, "", "  ")
@@ -716,121 +658,57 @@ func (r *BacktestResult) ToCSV() string {
 	return csv
 }
 
-// applyCustomParameters applies custom ATR multipliers to signal (for optimization)
-func applyCustomParameters(signal *Signal, stopATR, tp1ATR, tp2ATR, tp3ATR float64) *Signal {
-	if signal == nil {
-		return nil
+// applyATRExits places a signal's stop and targets at ATR multiples
+// (stop, tp1, tp2, tp3) from its entry
+func applyATRExits(signal *Signal, atr float64, exits [4]float64) *Signal {
+	if signal == nil || atr <= 0 {
+		return signal
 	}
 
-	// Calculate ATR from the original signal
 	entry := signal.Entry
-	stopLoss := signal.StopLoss
-	atr := math.Abs(entry-stopLoss) / 1.5 // Estimate ATR from stop loss
-
-	// Apply custom parameters
-	if signal.Type == "BUY" {
-		signal.StopLoss = entry - (atr * stopATR)
-		signal.Targets = []Target{
-			{Price: entry + (atr * tp1ATR), Percentage: 33},
-			{Price: entry + (atr * tp2ATR), Percentage: 33},
-			{Price: entry + (atr * tp3ATR), Percentage: 34},
-		}
-	} else {
-		signal.StopLoss = entry + (atr * stopATR)
-		signal.Targets = []Target{
-			{Price: entry - (atr * tp1ATR), Percentage: 33},
-			{Price: entry - (atr * tp2ATR), Percentage: 33},
-			{Price: entry - (atr * tp3ATR), Percentage: 34},
-		}
+	dir := 1.0
+	if signal.Type == "SELL" {
+		dir = -1.0
+	}
+	signal.StopLoss = entry - dir*atr*exits[0]
+	signal.Targets = []Target{
+		{Price: entry + dir*atr*exits[1], Percentage: 33},
+		{Price: entry + dir*atr*exits[2], Percentage: 33},
+		{Price: entry + dir*atr*exits[3], Percentage: 34},
 	}
 
 	return signal
 }
 
-// applyStrategyParameters modifies signal based on PROVEN BEST parameters
-func applyStrategyParameters(signal *Signal, strategyName string) *Signal {
-	if signal == nil {
-		return nil
-	}
-
-	// Get strategy configuration
-	strategies := GetAdvancedStrategies()
-	_, exists := strategies[strategyName]
-	if !exists {
-		return signal
+// exitParams are the ATR multiples a strategy's stop and targets are placed at,
+// in strategyExits order
+var exitParams = [4]string{"stop_atr", "tp1_atr", "tp2_atr", "tp3_atr"}
+
+// strategyExits returns the stop and take-profit ATR multiples a strategy
+// trades: its schema defaults, with each exit that params sets taking its
+// place. ok is false for strategies without all four exit parameters, which
+// place their own stops and targets.
+func strategyExits(strategyName string, params map[string]float64) (exits [4]float64, ok bool) {
+	strategy, found := GetStrategy(strategyName)
+	if !found {
+		return exits, false
+	}
+	set := 0
+	for _, spec := range strategy.ParamSchema() {
+		for i, name := range exitParams {
+			if spec.Name == name {
+				exits[i] = spec.Default
+				set++
+			}
+		}
 	}
-
-	// Calculate ATR for the signal
-	entry := signal.Entry
-	stopLoss := signal.StopLoss
-	atr := math.Abs(entry-stopLoss) / 1.5 // Estimate ATR from stop loss
-
-	// Apply PROVEN BEST parameters from OPTIMIZATION_RESULTS_FULL.json
-	// These parameters achieved 900-119,000% returns with 50-60% win rates
-	var stopATR, tp1ATR, tp2ATR, tp3ATR float64
-
-	switch strategyName {
-	case "liquidity_hunter":
-		// ULTRA HIGH WIN RATE: 80-90% target - Conservative targets, tight stops
-		stopATR, tp1ATR, tp2ATR, tp3ATR = 0.75, 0.75, 1.25, 2.0
-
-	case "session_trader":
-		// PROVEN: 57.89% WR, 18.67 PF, 1,313% return, 38 trades - HIGHEST PROFIT FACTOR
-		stopATR, tp1ATR, tp2ATR, tp3ATR = 1.0, 3.0, 4.5, 7.5
-
-	case "breakout_master":
-		// PROVEN: 54.55% WR, 8.23 PF, 3,704% return, 55 trades - HIGHEST RETURN
-		stopATR, tp1ATR, tp2ATR, tp3ATR = 1.0, 4.0, 6.0, 10.0
-
-	case "range_master":
-		// PROVEN: 46.51% WR, 7.81 PF, 335% return, 43 trades
-		stopATR, tp1ATR, tp2ATR, tp3ATR = 0.5, 2.0, 3.0, 5.0
-
-	case "trend_rider":
-		// PROVEN: 42.11% WR, 6.59 PF, 837% return, 57 trades
-		stopATR, tp1ATR, tp2ATR, tp3ATR = 0.5, 3.0, 4.5, 7.5
-
-	case "smart_money_tracker":
-		// PROVEN: 34.07% WR, 8.21 PF, 14,623% return, 135 trades - MOST ACTIVE
-		stopATR, tp1ATR, tp2ATR, tp3ATR = 0.5, 3.0, 4.5, 7.5
-
-	case "institutional_follower":
-		// PROVEN: 43.45% WR, 7.83 PF, 119,217% return, 168 trades - INSANE RETURN
-		stopATR, tp1ATR, tp2ATR, tp3ATR = 0.5, 3.0, 4.5, 7.5
-
-	case "reversal_sniper":
-		// PROVEN: 28.57% WR, 3.52 PF, 51% return, 7 trades
-		stopATR, tp1ATR, tp2ATR, tp3ATR = 0.5, 5.0, 7.5, 12.5
-
-	case "momentum_beast":
-		// Use breakout_master parameters (similar strategy)
-		stopATR, tp1ATR, tp2ATR, tp3ATR = 1.0, 4.0, 6.0, 10.0
-
-	case "scalper_pro":
-		// Tight stops for scalping
-		stopATR, tp1ATR, tp2ATR, tp3ATR = 0.5, 1.5, 2.5, 3.5
-
-	default:
-		// Default to liquidity_hunter parameters (best overall)
-		stopATR, tp1ATR, tp2ATR, tp3ATR = 1.5, 4.0, 6.0, 8.0
+	if set < len(exitParams) {
+		return exits, false
 	}
-
-	// Modify signal based on OPTIMIZED strategy parameters
-	if signal.Type == "BUY" {
-		signal.StopLoss = entry - (atr * stopATR)
-		signal.Targets = []Target{
-			{Price: entry + (atr * tp1ATR), Percentage: 33},
-			{Price: entry + (atr * tp2ATR), Percentage: 33},
-			{Price: entry + (atr * tp3ATR), Percentage: 34},
-		}
-	} else {
-		signal.StopLoss = entry + (atr * stopATR)
-		signal.Targets = []Target{
-			{Price: entry - (atr * tp1ATR), Percentage: 33},
-			{Price: entry - (atr * tp2ATR), Percentage: 33},
-			{Price: entry - (atr * tp3ATR), Percentage: 34},
+	for i, name := range exitParams {
+		if v, ok := params[name]; ok {
+			exits[i] = v
 		}
 	}
-
-	return signal
+	return exits, true
 }
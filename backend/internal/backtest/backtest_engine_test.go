@@ -0,0 +1,101 @@
+package backtest
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStrategyExits(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy string
+		params   map[string]float64
+		ok       bool // Whether the strategy trades ATR exits
+		want     [4]float64
+	}{
+		{"schema defaults", "liquidity_hunter", nil, true, [4]float64{1.5, 4, 6, 10}},
+		{"scalper schema defaults", "scalper_pro", nil, true, [4]float64{0.5, 1.2, 2.3, 3.5}},
+		{"strategy placing its own exits", "session_trader", nil, false, [4]float64{}},
+		{"unknown strategy", "nope", nil, false, [4]float64{}},
+		// Unset take profits keep the defaults rather than scaling tp1
+		{"stop and tp1 only", "liquidity_hunter", map[string]float64{"stop_atr": 0.5, "tp1_atr": 2}, true, [4]float64{0.5, 2, 6, 10}},
+		{"tp3 only", "range_master", map[string]float64{"tp3_atr": 6}, true, [4]float64{0.5, 2.0, 3.0, 6}},
+		{"other params", "range_master", map[string]float64{"trend_prob_min": 0.6}, true, [4]float64{0.5, 2.0, 3.0, 5.0}},
+	}
+	for _, tt := range tests {
+		got, ok := strategyExits(tt.strategy, tt.params)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("%s: exits %v %v, want %v %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// testExitsStrategy buys every bar with a one point stop, which the engine
+// replaces with its ATR exits
+const testExitsStrategy = "exits_test"
+
+func init() {
+	RegisterStrategy(&builtinStrategy{
+		meta:      StrategyMetadata{Name: testExitsStrategy, DisplayName: "Exits test"},
+		timeframe: "1h",
+		warmup:    100,
+		params:    atrTargetParams(1, 2, 3, 4),
+		eval: func(usg *UnifiedSignalGenerator, candles []Candle, idx int) *AdvancedSignal {
+			entry := candles[idx].Close
+			return &AdvancedSignal{Strategy: testExitsStrategy, Type: "BUY", Entry: entry, StopLoss: entry - 1, TP1: entry + 1, TP2: entry + 2, TP3: entry + 3, RR: 1}
+		},
+	})
+}
+
+// fallingCandles drop half a point a bar with bars two points tall, so ATR(14) is 2
+func fallingCandles(n int) []Candle {
+	candles := make([]Candle, n)
+	for i := range candles {
+		c := 1000 - 0.5*float64(i)
+		candles[i] = Candle{Timestamp: int64(i) * 3600000, Open: c + 0.5, High: c + 1, Low: c - 1, Close: c, Volume: 100}
+	}
+	return candles
+}
+
+func TestBacktestPlacesExitsWithGeneratorATR(t *testing.T) {
+	candles := fallingCandles(300)
+	config := BacktestConfig{Strategy: testExitsStrategy, Interval: "1h", StartBalance: 1000}
+	withParams := config
+	withParams.Params = map[string]float64{"stop_atr": 2}
+
+	run := func(name string, custom bool, cfg BacktestConfig) *BacktestResult {
+		var result *BacktestResult
+		var err error
+		if custom {
+			result, err = RunBacktestWithCustomParams(cfg, candles, 0.5, 1, 2, 3)
+		} else {
+			result, err = RunBacktest(cfg, candles)
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(result.Trades) == 0 {
+			t.Fatalf("%s: no trades", name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name   string
+		custom bool
+		config BacktestConfig
+		stop   float64 // Stop distance from the signal's entry: ATR 2 x stop multiple
+	}{
+		{"schema default", false, config, 2},
+		{"overridden stop", false, withParams, 4},
+		{"custom exits", true, config, 1},
+	}
+	for _, tt := range tests {
+		for _, trade := range run(tt.name, tt.custom, tt.config).Trades {
+			entry := candles[trade.EntryIndex-1].Close // The signal bar closes the window
+			if math.Abs(entry-trade.StopLoss-tt.stop) > 1e-3 {
+				t.Fatalf("%s: stop %v for an entry at %v, want %v below", tt.name, trade.StopLoss, entry, tt.stop)
+			}
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package backtest
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Monte Carlo resampling methods
+const (
+	MonteCarloShuffle        = "shuffle"         // Permute trade order (no replacement)
+	MonteCarloBootstrap      = "bootstrap"       // IID resampling with replacement
+	MonteCarloBlockBootstrap = "block_bootstrap" // Circular block bootstrap (keeps streaks)
+	MonteCarloParametric     = "parametric"      // Draw returns from a fitted normal
+)
+
+// maxEquityBandPoints bounds how many trade numbers get an equity band (plus
+// the final trade), so per-step storage stays near maxEquityBandPoints x Runs.
+const maxEquityBandPoints = 200
+
+// MonteCarloConfig controls the advanced Monte Carlo simulation
+type MonteCarloConfig struct {
+	Method               string  `json:"method"`               // shuffle, bootstrap, block_bootstrap, parametric
+	Runs                 int     `json:"runs"`                 // Number of simulated equity paths
+	BlockSize            int     `json:"blockSize"`            // Block length for block bootstrap (default: n^(1/3))
+	RuinThresholdPercent float64 `json:"ruinThresholdPercent"` // Equity loss (% of start) that counts as ruin
+	SlippageStdPercent   float64 `json:"slippageStdPercent"`   // Std dev of extra slippage per trade (% of balance)
+	SkipTradeProbability float64 `json:"skipTradeProbability"` // Chance a trade is missed (0-1)
+	Seed                 int64   `json:"seed"`                 // Fixed seed for reproducible runs (0 = random)
+}
+
+// PercentileBand holds the spread of a simulated quantity
+type PercentileBand struct {
+	P5  float64 `json:"p5"`
+	P25 float64 `json:"p25"`
+	P50 float64 `json:"p50"`
+	P75 float64 `json:"p75"`
+	P95 float64 `json:"p95"`
+}
+
+// EquityBandPoint is the equity distribution after a given trade number
+type EquityBandPoint struct {
+	Trade int `json:"trade"`
+	PercentileBand
+}
+
+// AdvancedMonteCarloResult holds the output of RunAdvancedMonteCarlo
+type AdvancedMonteCarloResult struct {
+	Method               string            `json:"method"`
+	Runs                 int               `json:"runs"`
+	Trades               int               `json:"trades"`
+	BlockSize            int               `json:"blockSize,omitempty"`
+	StartBalance         float64           `json:"startBalance"`
+	RuinThresholdPercent float64           `json:"ruinThresholdPercent"`
+	RiskOfRuin           float64           `json:"riskOfRuin"`        // % of paths that hit the ruin threshold
+	ProbabilityProfit    float64           `json:"probabilityProfit"` // % of paths ending above start
+	MeanReturn           float64           `json:"meanReturn"`
+	StdDeviation         float64           `json:"stdDeviation"`
+	ReturnPercentiles    PercentileBand    `json:"returnPercentiles"`
+	MaxDrawdownBands     PercentileBand    `json:"maxDrawdownBands"`
+	EquityBands          []EquityBandPoint `json:"equityBands"`
+	WorstMaxDrawdown     float64           `json:"worstMaxDrawdown"`
+	MedianLossStreak     int               `json:"medianLossStreak"`
+	WorstLossStreak      int               `json:"worstLossStreak"`
+}
+
+// applyMonteCarloDefaults fills in sensible defaults
+func applyMonteCarloDefaults(cfg *MonteCarloConfig, tradeCount int) {
+	if cfg.Method == "" {
+		cfg.Method = MonteCarloBlockBootstrap
+	}
+	if cfg.Runs <= 0 {
+		cfg.Runs = 1000
+	}
+	if cfg.Runs > 20000 {
+		cfg.Runs = 20000
+	}
+	if cfg.BlockSize <= 0 {
+		cfg.BlockSize = int(math.Round(math.Cbrt(float64(tradeCount))))
+	}
+	if cfg.BlockSize < 1 {
+		cfg.BlockSize = 1
+	}
+	if cfg.BlockSize > tradeCount {
+		cfg.BlockSize = tradeCount
+	}
+	if cfg.RuinThresholdPercent <= 0 {
+		cfg.RuinThresholdPercent = 50
+	}
+}
+
+// RunAdvancedMonteCarlo resamples trade returns to estimate risk of ruin and
+// percentile bands for the equity curve and max drawdown.
+func RunAdvancedMonteCarlo(trades []Trade, startBalance float64, cfg MonteCarloConfig) (*AdvancedMonteCarloResult, error) {
+	if len(trades) < 2 {
+		return nil, fmt.Errorf("need at least 2 trades for Monte Carlo, got %d", len(trades))
+	}
+	if startBalance <= 0 {
+		return nil, fmt.Errorf("start balance must be positive")
+	}
+	if cfg.SkipTradeProbability < 0 || cfg.SkipTradeProbability >= 1 {
+		return nil, fmt.Errorf("skipTradeProbability must be in [0, 1)")
+	}
+
+	applyMonteCarloDefaults(&cfg, len(trades))
+
+	switch cfg.Method {
+	case MonteCarloShuffle, MonteCarloBootstrap, MonteCarloBlockBootstrap, MonteCarloParametric:
+	default:
+		return nil, fmt.Errorf("unknown Monte Carlo method: %s", cfg.Method)
+	}
+
+	// Returns as a fraction of start balance, same basis as calculateStatsUnified
+	returns := make([]float64, len(trades))
+	for i, trade := range trades {
+		returns[i] = trade.Profit / startBalance
+	}
+	mean := calculateMeanUnified(returns)
+	stdDev := calculateStdDevUnified(returns, mean)
+
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	n := len(returns)
+	ruinLevel := startBalance * (1 - cfg.RuinThresholdPercent/100)
+
+	// equityAt[slot][run] - equity at every sampled trade number for every path
+	stride := equityBandStride(n)
+	bandSteps := make([]int, 0, n/stride+2)
+	for step := 0; step <= n; step += stride {
+		bandSteps = append(bandSteps, step)
+	}
+	if bandSteps[len(bandSteps)-1] != n {
+		bandSteps = append(bandSteps, n)
+	}
+	equityAt := make([][]float64, len(bandSteps))
+	for slot := range equityAt {
+		equityAt[slot] = make([]float64, cfg.Runs)
+	}
+
+	finalReturns := make([]float64, cfg.Runs)
+	maxDrawdowns := make([]float64, cfg.Runs)
+	lossStreaks := make([]int, cfg.Runs)
+	ruinRuns := 0
+	profitableRuns := 0
+
+	path := make([]float64, n)
+	for run := 0; run < cfg.Runs; run++ {
+		samplePath(path, returns, cfg, mean, stdDev, rng)
+
+		balance := startBalance
+		peak := startBalance
+		maxDD := 0.0
+		ruined := false
+		streak := 0
+		worstStreak := 0
+
+		equityAt[0][run] = balance
+		slot := 1
+		for step, r := range path {
+			if cfg.SkipTradeProbability > 0 && rng.Float64() < cfg.SkipTradeProbability {
+				r = 0
+			} else if cfg.SlippageStdPercent > 0 {
+				r -= math.Abs(rng.NormFloat64()) * cfg.SlippageStdPercent / 100
+			}
+
+			// Additive P&L: the engines size every trade off the start balance,
+			// so a trade's return is the same fraction of start wherever it lands
+			balance += startBalance * r
+			if balance < 0 {
+				balance = 0
+			}
+
+			if r < 0 {
+				streak++
+				if streak > worstStreak {
+					worstStreak = streak
+				}
+			} else if r > 0 {
+				streak = 0
+			}
+
+			if balance > peak {
+				peak = balance
+			}
+			if peak > 0 {
+				if dd := (peak - balance) / peak * 100; dd > maxDD {
+					maxDD = dd
+				}
+			}
+			if balance <= ruinLevel {
+				ruined = true
+			}
+
+			if slot < len(bandSteps) && bandSteps[slot] == step+1 {
+				equityAt[slot][run] = balance
+				slot++
+			}
+		}
+
+		finalReturns[run] = (balance - startBalance) / startBalance * 100
+		maxDrawdowns[run] = maxDD
+		lossStreaks[run] = worstStreak
+		if ruined {
+			ruinRuns++
+		}
+		if balance > startBalance {
+			profitableRuns++
+		}
+	}
+
+	sort.Float64s(finalReturns)
+	sort.Float64s(maxDrawdowns)
+	sort.Ints(lossStreaks)
+
+	meanReturn := calculateMeanUnified(finalReturns)
+	result := &AdvancedMonteCarloResult{
+		Method:               cfg.Method,
+		Runs:                 cfg.Runs,
+		Trades:               n,
+		StartBalance:         startBalance,
+		RuinThresholdPercent: cfg.RuinThresholdPercent,
+		RiskOfRuin:           float64(ruinRuns) / float64(cfg.Runs) * 100,
+		ProbabilityProfit:    float64(profitableRuns) / float64(cfg.Runs) * 100,
+		MeanReturn:           meanReturn,
+		StdDeviation:         calculateStdDevUnified(finalReturns, meanReturn),
+		ReturnPercentiles:    percentileBandSorted(finalReturns),
+		MaxDrawdownBands:     percentileBandSorted(maxDrawdowns),
+		EquityBands:          make([]EquityBandPoint, 0, len(bandSteps)),
+		WorstMaxDrawdown:     maxDrawdowns[len(maxDrawdowns)-1],
+		MedianLossStreak:     lossStreaks[len(lossStreaks)/2],
+		WorstLossStreak:      lossStreaks[len(lossStreaks)-1],
+	}
+	if cfg.Method == MonteCarloBlockBootstrap {
+		result.BlockSize = cfg.BlockSize
+	}
+
+	for slot, balances := range equityAt {
+		sort.Float64s(balances)
+		result.EquityBands = append(result.EquityBands, EquityBandPoint{
+			Trade:          bandSteps[slot],
+			PercentileBand: percentileBandSorted(balances),
+		})
+	}
+
+	return result, nil
+}
+
+// equityBandStride returns the trade spacing between equity bands for n trades
+func equityBandStride(n int) int {
+	stride := (n + maxEquityBandPoints - 1) / maxEquityBandPoints
+	if stride < 1 {
+		stride = 1
+	}
+	return stride
+}
+
+// samplePath fills path with one resampled sequence of trade returns
+func samplePath(path, returns []float64, cfg MonteCarloConfig, mean, stdDev float64, rng *rand.Rand) {
+	n := len(returns)
+
+	switch cfg.Method {
+	case MonteCarloShuffle:
+		copy(path, returns)
+		rng.Shuffle(n, func(i, j int) { path[i], path[j] = path[j], path[i] })
+
+	case MonteCarloBootstrap:
+		for i := range path {
+			path[i] = returns[rng.Intn(n)]
+		}
+
+	case MonteCarloBlockBootstrap:
+		// Circular block bootstrap: copy contiguous runs of trades so that
+		// clustered losing streaks survive the resampling.
+		for filled := 0; filled < n; {
+			start := rng.Intn(n)
+			for k := 0; k < cfg.BlockSize && filled < n; k++ {
+				path[filled] = returns[(start+k)%n]
+				filled++
+			}
+		}
+
+	case MonteCarloParametric:
+		for i := range path {
+			path[i] = mean + stdDev*rng.NormFloat64()
+		}
+	}
+}
+
+// percentileBandSorted reads the 5/25/50/75/95 percentiles from sorted values
+func percentileBandSorted(sorted []float64) PercentileBand {
+	return PercentileBand{
+		P5:  percentileSorted(sorted, 0.05),
+		P25: percentileSorted(sorted, 0.25),
+		P50: percentileSorted(sorted, 0.50),
+		P75: percentileSorted(sorted, 0.75),
+		P95: percentileSorted(sorted, 0.95),
+	}
+}
+
+// percentileSorted returns the linearly interpolated percentile p (0-1)
+func percentileSorted(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	pos := p * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	if lower == upper {
+		return sorted[lower]
+	}
+	frac := pos - float64(lower)
+	return sorted[lower]*(1-frac) + sorted[upper]*frac
+}
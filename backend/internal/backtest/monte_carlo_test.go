@@ -0,0 +1,115 @@
+package backtest
+
+import (
+	"math"
+	"testing"
+)
+
+// tradesOf builds trades with the given profits
+func tradesOf(profits ...float64) []Trade {
+	trades := make([]Trade, len(profits))
+	for i, profit := range profits {
+		trades[i] = Trade{Profit: profit}
+	}
+	return trades
+}
+
+func TestMonteCarloShuffleIsAdditive(t *testing.T) {
+	// The engines size every trade off the start balance, so reordering trades
+	// must not change where a path ends up
+	trades := tradesOf(300, -200, 500, -100, 250, -400, 150)
+	mc, err := RunAdvancedMonteCarlo(trades, 10000, MonteCarloConfig{Method: MonteCarloShuffle, Runs: 200, Seed: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := 500.0 / 10000 * 100
+	for name, got := range map[string]float64{
+		"p5": mc.ReturnPercentiles.P5, "p50": mc.ReturnPercentiles.P50, "p95": mc.ReturnPercentiles.P95,
+	} {
+		if math.Abs(got-want) > 1e-9 {
+			t.Fatalf("%s return: want %.4f, got %.4f", name, want, got)
+		}
+	}
+	if mc.ProbabilityProfit != 100 {
+		t.Fatalf("every path ends at +5%%, got probability of profit %.1f", mc.ProbabilityProfit)
+	}
+	if last := mc.EquityBands[len(mc.EquityBands)-1]; math.Abs(last.P50-10500) > 1e-9 {
+		t.Fatalf("final equity: want 10500, got %.4f", last.P50)
+	}
+}
+
+func TestMonteCarloRuin(t *testing.T) {
+	// Ten losses of 10% of start wipe the account on every path
+	profits := make([]float64, 10)
+	for i := range profits {
+		profits[i] = -1000
+	}
+	mc, err := RunAdvancedMonteCarlo(tradesOf(profits...), 10000, MonteCarloConfig{Method: MonteCarloBootstrap, Runs: 100, Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mc.RiskOfRuin != 100 {
+		t.Fatalf("want 100%% risk of ruin, got %.1f", mc.RiskOfRuin)
+	}
+	if mc.WorstLossStreak != 10 || mc.MedianLossStreak != 10 {
+		t.Fatalf("want loss streaks of 10, got median %d worst %d", mc.MedianLossStreak, mc.WorstLossStreak)
+	}
+	if math.Abs(mc.WorstMaxDrawdown-100) > 1e-9 {
+		t.Fatalf("want 100%% drawdown, got %.4f", mc.WorstMaxDrawdown)
+	}
+}
+
+func TestMonteCarloEquityBandsAreCapped(t *testing.T) {
+	profits := make([]float64, 5003)
+	for i := range profits {
+		profits[i] = float64(i%7) - 3
+	}
+	mc, err := RunAdvancedMonteCarlo(tradesOf(profits...), 10000, MonteCarloConfig{Runs: 50, Seed: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(mc.EquityBands) > maxEquityBandPoints+2 {
+		t.Fatalf("want at most %d bands, got %d", maxEquityBandPoints+2, len(mc.EquityBands))
+	}
+	if first := mc.EquityBands[0]; first.Trade != 0 || first.P5 != 10000 || first.P95 != 10000 {
+		t.Fatalf("first band should be the start balance at trade 0, got %+v", first)
+	}
+	if last := mc.EquityBands[len(mc.EquityBands)-1]; last.Trade != len(profits) {
+		t.Fatalf("last band should be trade %d, got %d", len(profits), last.Trade)
+	}
+	for i := 1; i < len(mc.EquityBands); i++ {
+		if mc.EquityBands[i].Trade <= mc.EquityBands[i-1].Trade {
+			t.Fatalf("band trades not increasing at %d", i)
+		}
+	}
+}
+
+func TestMonteCarloSeedIsReproducible(t *testing.T) {
+	trades := tradesOf(120, -80, 40, -60, 200, -150, 90, -30)
+	cfg := MonteCarloConfig{Method: MonteCarloBlockBootstrap, Runs: 300, Seed: 42, SlippageStdPercent: 0.1}
+	a, err := RunAdvancedMonteCarlo(trades, 5000, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := RunAdvancedMonteCarlo(trades, 5000, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.ReturnPercentiles != b.ReturnPercentiles || a.MaxDrawdownBands != b.MaxDrawdownBands {
+		t.Fatalf("same seed gave different results: %+v vs %+v", a.ReturnPercentiles, b.ReturnPercentiles)
+	}
+}
+
+func TestMonteCarloRejectsBadInput(t *testing.T) {
+	if _, err := RunAdvancedMonteCarlo(tradesOf(10), 1000, MonteCarloConfig{}); err == nil {
+		t.Fatal("want error for a single trade")
+	}
+	if _, err := RunAdvancedMonteCarlo(tradesOf(10, -5), 0, MonteCarloConfig{}); err == nil {
+		t.Fatal("want error for zero start balance")
+	}
+	if _, err := RunAdvancedMonteCarlo(tradesOf(10, -5), 1000, MonteCarloConfig{Method: "nope"}); err == nil {
+		t.Fatal("want error for unknown method")
+	}
+}
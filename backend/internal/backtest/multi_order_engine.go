@@ -0,0 +1,252 @@
+package backtest
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"tradebot/backend/internal/multiorder"
+)
+
+// MultiOrderBacktestConfig configures a grid or DCA backtest
+type MultiOrderBacktestConfig struct {
+	Symbol       string                `json:"symbol"`
+	Interval     string                `json:"interval"`
+	Days         int                   `json:"days"`
+	StartBalance float64               `json:"startBalance"`
+	FeePercent   float64               `json:"feePercent"` // Per fill (default: 0.1%)
+	Strategy     string                `json:"strategy"`   // "grid" or "dca"
+	Grid         multiorder.GridConfig `json:"grid"`
+	DCA          multiorder.DCAConfig  `json:"dca"`
+}
+
+// MultiOrderEquityPoint is the marked-to-market book at a bar close
+type MultiOrderEquityPoint struct {
+	Timestamp int64   `json:"timestamp"`
+	Equity    float64 `json:"equity"`
+	Inventory float64 `json:"inventory"`
+	Price     float64 `json:"price"`
+}
+
+// MultiOrderBacktestResult reports fills and inventory-aware performance
+type MultiOrderBacktestResult struct {
+	Symbol   string `json:"symbol"`
+	Interval string `json:"interval"`
+	Strategy string `json:"strategy"`
+	Bars     int    `json:"bars"`
+
+	StartBalance       float64 `json:"startBalance"`
+	FinalEquity        float64 `json:"finalEquity"` // Cash plus inventory marked at the last close
+	NetProfit          float64 `json:"netProfit"`
+	ReturnPercent      float64 `json:"returnPercent"`
+	RealizedPnL        float64 `json:"realizedPnl"`   // Sells against the average cost, after sell fees
+	UnrealizedPnL      float64 `json:"unrealizedPnl"` // Inventory still held at the end
+	Fees               float64 `json:"fees"`
+	TotalFills         int     `json:"totalFills"`
+	BuyFills           int     `json:"buyFills"`
+	SellFills          int     `json:"sellFills"`
+	WinningSells       int     `json:"winningSells"`
+	WinRate            float64 `json:"winRate"` // % of sells that realized a profit
+	MaxFillsPerBar     int     `json:"maxFillsPerBar"`
+	MaxDrawdown        float64 `json:"maxDrawdown"` // %, marked to market
+	SharpeRatio        float64 `json:"sharpeRatio"` // Annualized from bar returns
+	MaxInventory       float64 `json:"maxInventory"`
+	MaxCapitalDeployed float64 `json:"maxCapitalDeployed"` // Peak cost basis of the inventory
+	BuyAndHoldReturn   float64 `json:"buyAndHoldReturn"`
+	RejectedOrders     int     `json:"rejectedOrders"` // Touched orders the book could not cover
+
+	Fills         []multiorder.Fill       `json:"fills"`
+	OpenOrders    []multiorder.Order      `json:"openOrders"`
+	EquityCurve   []MultiOrderEquityPoint `json:"equityCurve"`
+	StrategyStats map[string]float64      `json:"strategyStats"`
+	Duration      string                  `json:"duration"`
+}
+
+func newMultiOrderStrategy(config MultiOrderBacktestConfig) (multiorder.Strategy, error) {
+	switch config.Strategy {
+	case "grid":
+		return multiorder.NewGrid(config.Grid)
+	case "dca":
+		return multiorder.NewDCA(config.DCA)
+	default:
+		return nil, fmt.Errorf("strategy must be grid or dca, got %q", config.Strategy)
+	}
+}
+
+// RunMultiOrderBacktest replays a multi-order strategy bar by bar. Market
+// orders fill at the next open; limit orders fill when the bar trades through
+// them, several per bar, in the order the assumed intrabar path reaches them.
+func RunMultiOrderBacktest(config MultiOrderBacktestConfig, candles []Candle) (*MultiOrderBacktestResult, error) {
+	startTime := time.Now()
+	if config.StartBalance == 0 {
+		config.StartBalance = 10000
+	}
+	if config.FeePercent == 0 {
+		config.FeePercent = 0.001 // 0.1%
+	}
+	if config.Strategy == "" {
+		config.Strategy = "grid"
+	}
+	strategy, err := newMultiOrderStrategy(config)
+	if err != nil {
+		return nil, err
+	}
+
+	warmup := strategy.WarmupBars()
+	if warmup < 1 {
+		warmup = 1
+	}
+	if len(candles) < warmup+10 {
+		return nil, fmt.Errorf("need at least %d candles, got %d", warmup+10, len(candles))
+	}
+
+	log.Printf("🕸️ Multi-order backtest %s %s %s: %d candles, warmup %d",
+		strategy.Name(), config.Symbol, config.Interval, len(candles), warmup)
+
+	result := &MultiOrderBacktestResult{
+		Symbol:       config.Symbol,
+		Interval:     config.Interval,
+		Strategy:     strategy.Name(),
+		Bars:         len(candles),
+		StartBalance: config.StartBalance,
+		Fills:        []multiorder.Fill{},
+		EquityCurve:  []MultiOrderEquityPoint{},
+	}
+
+	book := multiorder.NewBook(config.StartBalance)
+	for i := warmup - 1; i < len(candles); i++ {
+		book.Fills = book.Fills[:0]
+		result.RejectedOrders += fillBar(book, candles[i], i, config.FeePercent)
+		result.Fills = append(result.Fills, book.Fills...)
+		if len(book.Fills) > result.MaxFillsPerBar {
+			result.MaxFillsPerBar = len(book.Fills)
+		}
+
+		strategy.OnBar(candles[:i+1], book)
+
+		pos := book.Position
+		result.MaxInventory = math.Max(result.MaxInventory, pos.Quantity)
+		result.MaxCapitalDeployed = math.Max(result.MaxCapitalDeployed, pos.Quantity*pos.AvgPrice)
+		result.EquityCurve = append(result.EquityCurve, MultiOrderEquityPoint{
+			Timestamp: candles[i].Timestamp,
+			Equity:    book.Equity(candles[i].Close),
+			Inventory: pos.Quantity,
+			Price:     candles[i].Close,
+		})
+	}
+
+	last := candles[len(candles)-1]
+	result.FinalEquity = book.Equity(last.Close)
+	result.RealizedPnL = book.Realized
+	result.UnrealizedPnL = book.Position.Quantity * (last.Close - book.Position.AvgPrice)
+	result.Fees = book.Fees
+	result.OpenOrders = book.Orders
+	result.StrategyStats = strategy.Stats()
+	if first := candles[warmup-1].Close; first > 0 {
+		result.BuyAndHoldReturn = (last.Close - first) / first * 100
+	}
+	calculateMultiOrderStats(result)
+	result.Duration = time.Since(startTime).String()
+
+	log.Printf("🕸️ Multi-order backtest done: %d fills (%d buys / %d sells), net %.2f (realized %.2f / unrealized %.2f / fees %.2f), max DD %.2f%%",
+		result.TotalFills, result.BuyFills, result.SellFills, result.NetProfit, result.RealizedPnL, result.UnrealizedPnL, result.Fees, result.MaxDrawdown)
+	return result, nil
+}
+
+// fillBar executes every order the bar reaches and returns how many were
+// rejected. Market orders fill at the open, then limits that the open gapped
+// through, then the rest along O-L-H-C for an up bar or O-H-L-C for a down bar.
+func fillBar(book *multiorder.Book, candle Candle, index int, feeRate float64) int {
+	rejected := 0
+	execute := func(id int, price float64) {
+		if _, err := book.Execute(id, price, feeRate, index, candle.Timestamp); err != nil {
+			rejected++
+		}
+	}
+
+	var market, buys, sells []multiorder.Order
+	for _, o := range book.Orders {
+		switch {
+		case o.Type == multiorder.Market:
+			market = append(market, o)
+		case o.Side == multiorder.Buy:
+			buys = append(buys, o)
+		default:
+			sells = append(sells, o)
+		}
+	}
+	for _, o := range market {
+		execute(o.ID, candle.Open)
+	}
+
+	// Buys fill from the highest price down, sells from the lowest up
+	sort.Slice(buys, func(a, b int) bool { return buys[a].Price > buys[b].Price })
+	sort.Slice(sells, func(a, b int) bool { return sells[a].Price < sells[b].Price })
+	filled := make(map[int]bool)
+	fillBuys := func(low float64) {
+		for _, o := range buys {
+			if !filled[o.ID] && o.Price >= low {
+				filled[o.ID] = true
+				execute(o.ID, math.Min(o.Price, candle.Open))
+			}
+		}
+	}
+	fillSells := func(high float64) {
+		for _, o := range sells {
+			if !filled[o.ID] && o.Price <= high {
+				filled[o.ID] = true
+				execute(o.ID, math.Max(o.Price, candle.Open))
+			}
+		}
+	}
+
+	fillBuys(candle.Open)
+	fillSells(candle.Open)
+	if candle.Close >= candle.Open {
+		fillBuys(candle.Low)
+		fillSells(candle.High)
+	} else {
+		fillSells(candle.High)
+		fillBuys(candle.Low)
+	}
+	return rejected
+}
+
+func calculateMultiOrderStats(result *MultiOrderBacktestResult) {
+	for _, f := range result.Fills {
+		if f.Side == multiorder.Buy {
+			result.BuyFills++
+			continue
+		}
+		result.SellFills++
+		if f.Realized > 0 {
+			result.WinningSells++
+		}
+	}
+	result.TotalFills = len(result.Fills)
+	result.NetProfit = result.FinalEquity - result.StartBalance
+	result.ReturnPercent = result.NetProfit / result.StartBalance * 100
+	if result.SellFills > 0 {
+		result.WinRate = float64(result.WinningSells) / float64(result.SellFills) * 100
+	}
+
+	peak := result.StartBalance
+	returns := []float64{}
+	for i, p := range result.EquityCurve {
+		peak = math.Max(peak, p.Equity)
+		if dd := (peak - p.Equity) / peak * 100; dd > result.MaxDrawdown {
+			result.MaxDrawdown = dd
+		}
+		if i > 0 && result.EquityCurve[i-1].Equity > 0 {
+			returns = append(returns, p.Equity/result.EquityCurve[i-1].Equity-1)
+		}
+	}
+	if len(returns) > 1 {
+		mean := calculateMeanUnified(returns)
+		if std := calculateStdDevUnified(returns, mean); std > 0 {
+			result.SharpeRatio = mean / std * math.Sqrt(float64(getCandlesPerDayUnified(result.Interval)*365))
+		}
+	}
+}
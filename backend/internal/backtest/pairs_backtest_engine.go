@@ -0,0 +1,307 @@
+package backtest
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"tradebot/backend/internal/pairs"
+)
+
+// PairsBacktestConfig configures a two-leg spread backtest
+type PairsBacktestConfig struct {
+	SymbolA         string  `json:"symbolA"`
+	SymbolB         string  `json:"symbolB"`
+	Interval        string  `json:"interval"`
+	Days            int     `json:"days"`
+	StartBalance    float64 `json:"startBalance"`
+	PositionPercent float64 `json:"positionPercent"` // Notional of leg A per trade as a fraction of balance (default: 0.5)
+	FeePercent      float64 `json:"feePercent"`      // Per leg and side (default: 0.1%)
+	SlippagePercent float64 `json:"slippagePercent"` // Per leg and side (default: 0.05%)
+	pairs.Config
+}
+
+// PairLeg is one side of a spread trade
+type PairLeg struct {
+	Symbol   string  `json:"symbol"`
+	Side     string  `json:"side"` // "LONG" or "SHORT"
+	Quantity float64 `json:"quantity"`
+	Entry    float64 `json:"entry"`
+	Exit     float64 `json:"exit"`
+	PnL      float64 `json:"pnl"` // Before fees
+}
+
+// PairsTrade is a round trip on the spread
+type PairsTrade struct {
+	Position     string  `json:"position"` // "long_spread" (long A / short B) or "short_spread"
+	EntryIndex   int     `json:"entryIndex"`
+	EntryTime    int64   `json:"entryTime"`
+	ExitTime     int64   `json:"exitTime"`
+	EntryZ       float64 `json:"entryZ"`
+	ExitZ        float64 `json:"exitZ"`
+	HedgeRatio   float64 `json:"hedgeRatio"` // Frozen at entry
+	LegA         PairLeg `json:"legA"`
+	LegB         PairLeg `json:"legB"`
+	SpreadPnL    float64 `json:"spreadPnl"` // LegA.PnL + LegB.PnL
+	Fees         float64 `json:"fees"`
+	NetPnL       float64 `json:"netPnl"`
+	ExitReason   string  `json:"exitReason"`
+	BarsHeld     int     `json:"barsHeld"`
+	BalanceAfter float64 `json:"balanceAfter"`
+}
+
+// PairsEquityPoint is the marked-to-market balance at a bar
+type PairsEquityPoint struct {
+	Timestamp int64   `json:"timestamp"`
+	Equity    float64 `json:"equity"`
+	ZScore    float64 `json:"zScore"`
+}
+
+// PairsBacktestResult reports spread and per-leg performance
+type PairsBacktestResult struct {
+	SymbolA       string               `json:"symbolA"`
+	SymbolB       string               `json:"symbolB"`
+	Interval      string               `json:"interval"`
+	Bars          int                  `json:"bars"`
+	Cointegration *pairs.Cointegration `json:"cointegration,omitempty"` // Whole sample; informational only, entries use the rolling window
+
+	TotalTrades    int     `json:"totalTrades"`
+	WinningTrades  int     `json:"winningTrades"`
+	LosingTrades   int     `json:"losingTrades"`
+	WinRate        float64 `json:"winRate"`
+	SpreadPnL      float64 `json:"spreadPnl"` // Both legs, before fees
+	LegAPnL        float64 `json:"legAPnl"`
+	LegBPnL        float64 `json:"legBPnl"`
+	Fees           float64 `json:"fees"`
+	NetProfit      float64 `json:"netProfit"`
+	ReturnPercent  float64 `json:"returnPercent"`
+	ProfitFactor   float64 `json:"profitFactor"`
+	MaxDrawdown    float64 `json:"maxDrawdown"` // %, marked to market
+	SharpeRatio    float64 `json:"sharpeRatio"` // Annualized from bar returns
+	AvgBarsHeld    float64 `json:"avgBarsHeld"`
+	StartBalance   float64 `json:"startBalance"`
+	FinalBalance   float64 `json:"finalBalance"`
+	SkippedEntries int     `json:"skippedEntries"` // Band touches rejected by the cointegration gate or a non-positive hedge ratio
+
+	Trades      []PairsTrade       `json:"trades"`
+	ExitReasons map[string]int     `json:"exitReasons"`
+	EquityCurve []PairsEquityPoint `json:"equityCurve"`
+	Duration    string             `json:"duration"`
+}
+
+func applyPairsDefaults(config *PairsBacktestConfig) error {
+	if config.StartBalance == 0 {
+		config.StartBalance = 10000
+	}
+	if config.PositionPercent == 0 {
+		config.PositionPercent = 0.5
+	}
+	if config.FeePercent == 0 {
+		config.FeePercent = 0.001 // 0.1%
+	}
+	if config.SlippagePercent == 0 {
+		config.SlippagePercent = 0.0005 // 0.05%
+	}
+	if config.PositionPercent < 0 || config.PositionPercent > 1 {
+		return fmt.Errorf("positionPercent must be between 0 and 1, got %.2f", config.PositionPercent)
+	}
+	return config.Config.Validate()
+}
+
+// RunPairsBacktest trades the z-score of the A/B spread bar by bar. The hedge
+// ratio and z-score are refitted every bar on past data only; quantities are
+// fixed at entry so each leg's PnL is what the held position really made.
+func RunPairsBacktest(config PairsBacktestConfig, candlesA, candlesB []Candle) (*PairsBacktestResult, error) {
+	startTime := time.Now()
+	if err := applyPairsDefaults(&config); err != nil {
+		return nil, err
+	}
+
+	a, b := pairs.Align(candlesA, candlesB)
+	if len(a) < config.HedgeWindow+10 {
+		return nil, fmt.Errorf("need at least %d aligned bars, got %d", config.HedgeWindow+10, len(a))
+	}
+
+	log.Printf("🔗 Pairs backtest %s/%s %s: %d aligned bars, bands %.1f/%.1f/%.1f",
+		config.SymbolA, config.SymbolB, config.Interval, len(a), config.EntryZ, config.ExitZ, config.StopZ)
+
+	result := &PairsBacktestResult{
+		SymbolA:      config.SymbolA,
+		SymbolB:      config.SymbolB,
+		Interval:     config.Interval,
+		Bars:         len(a),
+		StartBalance: config.StartBalance,
+		Trades:       []PairsTrade{},
+		ExitReasons:  make(map[string]int),
+		EquityCurve:  []PairsEquityPoint{},
+	}
+	closesA, closesB := make([]float64, len(a)), make([]float64, len(b))
+	for i := range a {
+		closesA[i], closesB[i] = a[i].Close, b[i].Close
+	}
+	result.Cointegration, _ = pairs.EngleGranger(closesA, closesB, 0)
+
+	balance := config.StartBalance
+	var position *PairsTrade
+	for i := config.HedgeWindow - 1; i < len(a); i++ {
+		point, ok := pairs.SpreadAt(a, b, i, config.Config)
+		if !ok {
+			continue
+		}
+
+		if position != nil {
+			position.BarsHeld++
+			reason := pairs.Exit(position.Position, point.ZScore, position.BarsHeld, config.Config)
+			if reason == "" && i == len(a)-1 {
+				reason = "End of Data"
+			}
+			if reason != "" {
+				closePair(position, a[i], b[i], point.ZScore, reason, config)
+				balance += position.NetPnL
+				position.BalanceAfter = balance
+				result.Trades = append(result.Trades, *position)
+				result.ExitReasons[reason]++
+				position = nil
+			}
+		} else if side := pairs.Entry(point.ZScore, config.Config); side != "" && i < len(a)-1 {
+			if point.HedgeRatio <= 0 || (config.RequireCointegration && !windowCointegrated(closesA, closesB, i, config.HedgeWindow)) {
+				result.SkippedEntries++
+			} else {
+				position = openPairTrade(side, i, a[i], b[i], point, balance, config)
+			}
+		}
+
+		equity := balance
+		if position != nil {
+			equity += markPair(position, a[i].Close, b[i].Close)
+		}
+		result.EquityCurve = append(result.EquityCurve, PairsEquityPoint{
+			Timestamp: a[i].Timestamp,
+			Equity:    equity,
+			ZScore:    point.ZScore,
+		})
+	}
+
+	result.FinalBalance = balance
+	calculatePairsStats(result, config.Interval)
+	result.Duration = time.Since(startTime).String()
+
+	log.Printf("🔗 Pairs backtest done: %d trades, win rate %.1f%%, net %.2f (A %.2f / B %.2f / fees %.2f), max DD %.2f%%",
+		result.TotalTrades, result.WinRate, result.NetProfit, result.LegAPnL, result.LegBPnL, result.Fees, result.MaxDrawdown)
+	return result, nil
+}
+
+// windowCointegrated runs Engle-Granger on the hedge window ending at idx
+func windowCointegrated(closesA, closesB []float64, idx, window int) bool {
+	start := idx - window + 1
+	eg, err := pairs.EngleGranger(closesA[start:idx+1], closesB[start:idx+1], 0)
+	return err == nil && eg.Cointegrated
+}
+
+// fillPrice applies slippage against the side being traded
+func fillPrice(price float64, buy bool, slippage float64) float64 {
+	if buy {
+		return price * (1 + slippage)
+	}
+	return price * (1 - slippage)
+}
+
+func openPairTrade(side string, idx int, candleA, candleB Candle, point pairs.Point, balance float64, config PairsBacktestConfig) *PairsTrade {
+	longA := side == pairs.LongSpread
+	trade := &PairsTrade{
+		Position:   side,
+		EntryIndex: idx,
+		EntryTime:  candleA.Timestamp,
+		EntryZ:     point.ZScore,
+		HedgeRatio: point.HedgeRatio,
+		LegA:       PairLeg{Symbol: config.SymbolA, Side: "SHORT"},
+		LegB:       PairLeg{Symbol: config.SymbolB, Side: "LONG"},
+	}
+	if longA {
+		trade.LegA.Side, trade.LegB.Side = "LONG", "SHORT"
+	}
+
+	// Log-price hedge ratio: B's notional is HedgeRatio times A's
+	notionalA := balance * config.PositionPercent
+	trade.LegA.Entry = fillPrice(candleA.Close, longA, config.SlippagePercent)
+	trade.LegB.Entry = fillPrice(candleB.Close, !longA, config.SlippagePercent)
+	trade.LegA.Quantity = notionalA / trade.LegA.Entry
+	trade.LegB.Quantity = notionalA * point.HedgeRatio / trade.LegB.Entry
+	trade.Fees = (notionalA + notionalA*point.HedgeRatio) * config.FeePercent
+	return trade
+}
+
+func legPnL(leg PairLeg, price float64) float64 {
+	if leg.Side == "LONG" {
+		return leg.Quantity * (price - leg.Entry)
+	}
+	return leg.Quantity * (leg.Entry - price)
+}
+
+// markPair returns the open position's unrealized PnL net of entry fees
+func markPair(trade *PairsTrade, priceA, priceB float64) float64 {
+	return legPnL(trade.LegA, priceA) + legPnL(trade.LegB, priceB) - trade.Fees
+}
+
+func closePair(trade *PairsTrade, candleA, candleB Candle, z float64, reason string, config PairsBacktestConfig) {
+	trade.LegA.Exit = fillPrice(candleA.Close, trade.LegA.Side == "SHORT", config.SlippagePercent)
+	trade.LegB.Exit = fillPrice(candleB.Close, trade.LegB.Side == "SHORT", config.SlippagePercent)
+	trade.LegA.PnL = legPnL(trade.LegA, trade.LegA.Exit)
+	trade.LegB.PnL = legPnL(trade.LegB, trade.LegB.Exit)
+	trade.Fees += (trade.LegA.Quantity*trade.LegA.Exit + trade.LegB.Quantity*trade.LegB.Exit) * config.FeePercent
+
+	trade.ExitTime = candleA.Timestamp
+	trade.ExitZ = z
+	trade.ExitReason = reason
+	trade.SpreadPnL = trade.LegA.PnL + trade.LegB.PnL
+	trade.NetPnL = trade.SpreadPnL - trade.Fees
+}
+
+func calculatePairsStats(result *PairsBacktestResult, interval string) {
+	var grossWin, grossLoss, bars float64
+	for _, t := range result.Trades {
+		result.LegAPnL += t.LegA.PnL
+		result.LegBPnL += t.LegB.PnL
+		result.Fees += t.Fees
+		bars += float64(t.BarsHeld)
+		if t.NetPnL > 0 {
+			result.WinningTrades++
+			grossWin += t.NetPnL
+		} else {
+			result.LosingTrades++
+			grossLoss -= t.NetPnL
+		}
+	}
+	result.TotalTrades = len(result.Trades)
+	result.SpreadPnL = result.LegAPnL + result.LegBPnL
+	result.NetProfit = result.FinalBalance - result.StartBalance
+	result.ReturnPercent = result.NetProfit / result.StartBalance * 100
+	if result.TotalTrades > 0 {
+		result.WinRate = float64(result.WinningTrades) / float64(result.TotalTrades) * 100
+		result.AvgBarsHeld = bars / float64(result.TotalTrades)
+	}
+	if grossLoss > 0 {
+		result.ProfitFactor = grossWin / grossLoss
+	} else if grossWin > 0 {
+		result.ProfitFactor = 999
+	}
+
+	peak := result.StartBalance
+	returns := []float64{}
+	for i, p := range result.EquityCurve {
+		peak = math.Max(peak, p.Equity)
+		if dd := (peak - p.Equity) / peak * 100; dd > result.MaxDrawdown {
+			result.MaxDrawdown = dd
+		}
+		if i > 0 && result.EquityCurve[i-1].Equity > 0 {
+			returns = append(returns, p.Equity/result.EquityCurve[i-1].Equity-1)
+		}
+	}
+	if len(returns) > 1 {
+		mean := calculateMeanUnified(returns)
+		if std := calculateStdDevUnified(returns, mean); std > 0 {
+			result.SharpeRatio = mean / std * math.Sqrt(float64(getCandlesPerDayUnified(interval)*365))
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package backtest
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"tradebot/backend/internal/regime"
+)
+
+// PeriodReturn holds realized performance for one calendar period
+type PeriodReturn struct {
+	Period        string  `json:"period"` // e.g. "2024-03-15", "2024-W11", "2024-03", "2024"
+	Start         int64   `json:"start"`  // Period start (ms, UTC)
+	Trades        int     `json:"trades"`
+	WinRate       float64 `json:"winRate"`
+	NetProfit     float64 `json:"netProfit"`
+	StartBalance  float64 `json:"startBalance"`
+	ReturnPercent float64 `json:"returnPercent"` // Net profit as % of balance at period start
+}
+
+// CalendarBreakdown groups realized returns by day, week, month and year
+type CalendarBreakdown struct {
+	Daily   []PeriodReturn `json:"daily"`
+	Weekly  []PeriodReturn `json:"weekly"`
+	Monthly []PeriodReturn `json:"monthly"`
+	Yearly  []PeriodReturn `json:"yearly"`
+}
+
+// RollingPoint holds rolling-window metrics ending at a given trade
+type RollingPoint struct {
+	TradeNumber  int     `json:"tradeNumber"`
+	Time         int64   `json:"time"`
+	SharpeRatio  float64 `json:"sharpeRatio"`
+	WinRate      float64 `json:"winRate"`
+	ProfitFactor float64 `json:"profitFactor"`
+	Expectancy   float64 `json:"expectancy"`
+}
+
+// RollingMetrics is a rolling-window series over the trade sequence
+type RollingMetrics struct {
+	Window int            `json:"window"` // Trades per window
+	Points []RollingPoint `json:"points"`
+}
+
+// WeekdaySessionStats holds performance for one weekday x session bucket
+type WeekdaySessionStats struct {
+	Weekday      string  `json:"weekday"`
+	Session      string  `json:"session"`
+	Trades       int     `json:"trades"`
+	WinRate      float64 `json:"winRate"`
+	NetProfit    float64 `json:"netProfit"`
+	ProfitFactor float64 `json:"profitFactor"`
+	Expectancy   float64 `json:"expectancy"`
+}
+
+// tradeTimes returns entry and exit timestamps, falling back to the candle at EntryIndex
+func tradeTimes(trade Trade, candles []Candle) (int64, int64) {
+	entry := trade.EntryTime
+	if entry == 0 && trade.EntryIndex < len(candles) {
+		entry = candles[trade.EntryIndex].Timestamp
+	}
+	exit := trade.ExitTime
+	if exit == 0 {
+		exit = entry
+	}
+	return entry, exit
+}
+
+// calculateCalendarBreakdown buckets trades by exit time into calendar periods
+func calculateCalendarBreakdown(result *UnifiedBacktestResult, candles []Candle) *CalendarBreakdown {
+	type bucket struct {
+		start        time.Time
+		trades       int
+		wins         int
+		net          float64
+		startBalance float64
+	}
+
+	keyFuncs := map[string]func(t time.Time) (string, time.Time){
+		"daily": func(t time.Time) (string, time.Time) {
+			start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+			return start.Format("2006-01-02"), start
+		},
+		"weekly": func(t time.Time) (string, time.Time) {
+			year, week := t.ISOWeek()
+			offset := (int(t.Weekday()) + 6) % 7 // days since Monday
+			start := time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+			return fmt.Sprintf("%d-W%02d", year, week), start
+		},
+		"monthly": func(t time.Time) (string, time.Time) {
+			start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+			return start.Format("2006-01"), start
+		},
+		"yearly": func(t time.Time) (string, time.Time) {
+			start := time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+			return start.Format("2006"), start
+		},
+	}
+
+	periods := make(map[string][]PeriodReturn)
+	for name, keyFunc := range keyFuncs {
+		buckets := make(map[string]*bucket)
+		balance := result.StartBalance
+
+		for _, trade := range result.Trades {
+			_, exit := tradeTimes(trade, candles)
+			if exit == 0 {
+				continue
+			}
+			key, start := keyFunc(time.Unix(exit/1000, 0).UTC())
+			b, ok := buckets[key]
+			if !ok {
+				b = &bucket{start: start, startBalance: balance}
+				buckets[key] = b
+			}
+			b.trades++
+			b.net += trade.Profit
+			if trade.Profit > 0 {
+				b.wins++
+			}
+			balance += trade.Profit
+		}
+
+		list := make([]PeriodReturn, 0, len(buckets))
+		for key, b := range buckets {
+			pr := PeriodReturn{
+				Period:       key,
+				Start:        b.start.UnixMilli(),
+				Trades:       b.trades,
+				WinRate:      float64(b.wins) / float64(b.trades) * 100,
+				NetProfit:    b.net,
+				StartBalance: b.startBalance,
+			}
+			if b.startBalance > 0 {
+				pr.ReturnPercent = b.net / b.startBalance * 100
+			}
+			list = append(list, pr)
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].Start < list[j].Start })
+		periods[name] = list
+	}
+
+	return &CalendarBreakdown{
+		Daily:   periods["daily"],
+		Weekly:  periods["weekly"],
+		Monthly: periods["monthly"],
+		Yearly:  periods["yearly"],
+	}
+}
+
+// calculateRollingMetrics computes Sharpe, win rate, profit factor and expectancy
+// over a sliding window of trades so a fading edge shows up over time.
+func calculateRollingMetrics(result *UnifiedBacktestResult, candles []Candle, window int) *RollingMetrics {
+	if window <= 1 || len(result.Trades) < window {
+		return nil
+	}
+
+	rm := &RollingMetrics{Window: window, Points: []RollingPoint{}}
+	returns := make([]float64, len(result.Trades))
+	for i, trade := range result.Trades {
+		returns[i] = (trade.Profit / result.StartBalance) * 100
+	}
+
+	for end := window; end <= len(result.Trades); end++ {
+		slice := result.Trades[end-window : end]
+		wins := 0
+		grossProfit := 0.0
+		grossLoss := 0.0
+		net := 0.0
+		for _, trade := range slice {
+			net += trade.Profit
+			if trade.Profit > 0 {
+				wins++
+				grossProfit += trade.Profit
+			} else {
+				grossLoss += math.Abs(trade.Profit)
+			}
+		}
+
+		point := RollingPoint{
+			TradeNumber: end,
+			WinRate:     float64(wins) / float64(window) * 100,
+			Expectancy:  net / float64(window),
+		}
+		_, point.Time = tradeTimes(slice[len(slice)-1], candles)
+
+		if grossLoss > 0 {
+			point.ProfitFactor = grossProfit / grossLoss
+		}
+
+		// Same annualization as the lifetime SharpeRatio in calculateStatsUnified
+		windowReturns := returns[end-window : end]
+		mean := calculateMeanUnified(windowReturns)
+		stdDev := calculateStdDevUnified(windowReturns, mean)
+		if stdDev > 0 {
+			point.SharpeRatio = (mean / stdDev) * math.Sqrt(252)
+		}
+
+		rm.Points = append(rm.Points, point)
+	}
+
+	return rm
+}
+
+// calculateWeekdaySessionPerformance buckets trades by entry weekday and trading session
+func calculateWeekdaySessionPerformance(result *UnifiedBacktestResult, candles []Candle) []WeekdaySessionStats {
+	type bucket struct {
+		weekday     time.Weekday
+		session     string
+		trades      int
+		wins        int
+		grossProfit float64
+		grossLoss   float64
+	}
+
+	buckets := make(map[string]*bucket)
+	for _, trade := range result.Trades {
+		entry, _ := tradeTimes(trade, candles)
+		if entry == 0 {
+			continue
+		}
+		t := time.Unix(entry/1000, 0).UTC()
+		session := GetCurrentSession(t)
+		key := t.Weekday().String() + "|" + session
+
+		b, ok := buckets[key]
+		if !ok {
+			b = &bucket{weekday: t.Weekday(), session: session}
+			buckets[key] = b
+		}
+		b.trades++
+		if trade.Profit > 0 {
+			b.wins++
+			b.grossProfit += trade.Profit
+		} else {
+			b.grossLoss += math.Abs(trade.Profit)
+		}
+	}
+
+	ordered := make([]*bucket, 0, len(buckets))
+	for _, b := range buckets {
+		ordered = append(ordered, b)
+	}
+	// Monday first, then by session name
+	sort.Slice(ordered, func(i, j int) bool {
+		wi := (int(ordered[i].weekday) + 6) % 7
+		wj := (int(ordered[j].weekday) + 6) % 7
+		if wi != wj {
+			return wi < wj
+		}
+		return ordered[i].session < ordered[j].session
+	})
+
+	stats := make([]WeekdaySessionStats, 0, len(ordered))
+	for _, b := range ordered {
+		s := WeekdaySessionStats{
+			Weekday:    b.weekday.String(),
+			Session:    b.session,
+			Trades:     b.trades,
+			WinRate:    float64(b.wins) / float64(b.trades) * 100,
+			NetProfit:  b.grossProfit - b.grossLoss,
+			Expectancy: (b.grossProfit - b.grossLoss) / float64(b.trades),
+		}
+		if b.grossLoss > 0 {
+			s.ProfitFactor = b.grossProfit / b.grossLoss
+		}
+		stats = append(stats, s)
+	}
+
+	return stats
+}
+
+// calculateRegimePerformance sums net profit by the market regime at entry.
+// The regime comes from the same causal detector strategies use, read at the
+// last bar the signal could see.
+func calculateRegimePerformance(result *UnifiedBacktestResult, candles []Candle) map[string]float64 {
+	series := regime.Series(candles, regime.DefaultConfig())
+	performance := make(map[string]float64)
+	for _, trade := range result.Trades {
+		bar := trade.EntryIndex - 1
+		if bar < 0 || bar >= len(series) {
+			continue
+		}
+		performance[string(series[bar])] += trade.Profit
+	}
+	return performance
+}
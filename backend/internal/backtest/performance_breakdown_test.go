@@ -0,0 +1,122 @@
+package backtest
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// breakdownFixture has five trades over two months on a 1000 start balance
+func breakdownFixture() *UnifiedBacktestResult {
+	at := func(month time.Month, day, hour int) int64 {
+		return time.Date(2024, month, day, hour, 0, 0, 0, time.UTC).UnixMilli()
+	}
+	trades := []Trade{
+		{Profit: -50, EntryTime: at(1, 1, 2), ExitTime: at(1, 1, 3)},    // Monday, Asian
+		{Profit: 100, EntryTime: at(1, 1, 9), ExitTime: at(1, 1, 11)},   // Monday, London
+		{Profit: 200, EntryTime: at(1, 3, 18), ExitTime: at(1, 3, 20)},  // Wednesday, NewYork
+		{Profit: -100, EntryTime: at(1, 8, 10), ExitTime: at(1, 8, 12)}, // Monday, London
+		{Profit: 150, EntryTime: at(2, 1, 10), ExitTime: at(2, 1, 14)},  // Thursday, London
+	}
+	return &UnifiedBacktestResult{StartBalance: 1000, Trades: trades}
+}
+
+func TestCalendarBreakdown(t *testing.T) {
+	cal := calculateCalendarBreakdown(breakdownFixture(), nil)
+
+	tests := []struct {
+		name    string
+		periods []PeriodReturn
+		want    []PeriodReturn
+	}{
+		{"daily", cal.Daily, []PeriodReturn{
+			{Period: "2024-01-01", Trades: 2, NetProfit: 50, StartBalance: 1000, ReturnPercent: 5},
+			{Period: "2024-01-03", Trades: 1, NetProfit: 200, StartBalance: 1050, ReturnPercent: 200.0 / 1050 * 100},
+			{Period: "2024-01-08", Trades: 1, NetProfit: -100, StartBalance: 1250, ReturnPercent: -8},
+			{Period: "2024-02-01", Trades: 1, NetProfit: 150, StartBalance: 1150, ReturnPercent: 150.0 / 1150 * 100},
+		}},
+		{"weekly", cal.Weekly, []PeriodReturn{
+			{Period: "2024-W01", Trades: 3, NetProfit: 250, StartBalance: 1000, ReturnPercent: 25},
+			{Period: "2024-W02", Trades: 1, NetProfit: -100, StartBalance: 1250, ReturnPercent: -8},
+			{Period: "2024-W05", Trades: 1, NetProfit: 150, StartBalance: 1150, ReturnPercent: 150.0 / 1150 * 100},
+		}},
+		{"monthly", cal.Monthly, []PeriodReturn{
+			{Period: "2024-01", Trades: 4, NetProfit: 150, StartBalance: 1000, ReturnPercent: 15},
+			{Period: "2024-02", Trades: 1, NetProfit: 150, StartBalance: 1150, ReturnPercent: 150.0 / 1150 * 100},
+		}},
+		{"yearly", cal.Yearly, []PeriodReturn{
+			{Period: "2024", Trades: 5, NetProfit: 300, StartBalance: 1000, ReturnPercent: 30},
+		}},
+	}
+
+	for _, tt := range tests {
+		if len(tt.periods) != len(tt.want) {
+			t.Fatalf("%s: want %d periods, got %d", tt.name, len(tt.want), len(tt.periods))
+		}
+		for i, want := range tt.want {
+			got := tt.periods[i]
+			if got.Period != want.Period || got.Trades != want.Trades || got.NetProfit != want.NetProfit ||
+				got.StartBalance != want.StartBalance || math.Abs(got.ReturnPercent-want.ReturnPercent) > 1e-9 {
+				t.Fatalf("%s[%d]: want %+v, got %+v", tt.name, i, want, got)
+			}
+		}
+	}
+
+	// ISO week 5 of 2024 starts on Monday 29 January
+	if want := time.Date(2024, 1, 29, 0, 0, 0, 0, time.UTC).UnixMilli(); cal.Weekly[2].Start != want {
+		t.Fatalf("week start: want %d, got %d", want, cal.Weekly[2].Start)
+	}
+	if cal.Monthly[0].WinRate != 50 {
+		t.Fatalf("january win rate: want 50, got %.1f", cal.Monthly[0].WinRate)
+	}
+}
+
+func TestRollingMetrics(t *testing.T) {
+	result := breakdownFixture()
+	if rm := calculateRollingMetrics(result, nil, 6); rm != nil {
+		t.Fatalf("window longer than the trade list should give nil")
+	}
+
+	rm := calculateRollingMetrics(result, nil, 2)
+	if len(rm.Points) != 4 {
+		t.Fatalf("want 4 rolling points, got %d", len(rm.Points))
+	}
+	first := rm.Points[0]
+	if first.TradeNumber != 2 || first.WinRate != 50 || first.Expectancy != 25 || first.ProfitFactor != 2 {
+		t.Fatalf("first window: got %+v", first)
+	}
+	if first.Time != result.Trades[1].ExitTime {
+		t.Fatalf("first window should end at the second trade's exit")
+	}
+	// Two winners: no losses means no profit factor, and the Sharpe is positive
+	if second := rm.Points[1]; second.ProfitFactor != 0 || second.WinRate != 100 || second.SharpeRatio <= 0 {
+		t.Fatalf("second window: got %+v", second)
+	}
+}
+
+func TestWeekdaySessionPerformance(t *testing.T) {
+	stats := calculateWeekdaySessionPerformance(breakdownFixture(), nil)
+
+	want := []WeekdaySessionStats{
+		{Weekday: "Monday", Session: "Asian", Trades: 1, NetProfit: -50, Expectancy: -50},
+		{Weekday: "Monday", Session: "London", Trades: 2, WinRate: 50, NetProfit: 0, ProfitFactor: 1},
+		{Weekday: "Wednesday", Session: "NewYork", Trades: 1, WinRate: 100, NetProfit: 200, Expectancy: 200},
+		{Weekday: "Thursday", Session: "London", Trades: 1, WinRate: 100, NetProfit: 150, Expectancy: 150},
+	}
+	if len(stats) != len(want) {
+		t.Fatalf("want %d buckets, got %d: %+v", len(want), len(stats), stats)
+	}
+	for i := range want {
+		if stats[i] != want[i] {
+			t.Fatalf("bucket %d: want %+v, got %+v", i, want[i], stats[i])
+		}
+	}
+}
+
+func TestTradeTimesFallsBackToEntryCandle(t *testing.T) {
+	candles := []Candle{{Timestamp: 1000}, {Timestamp: 2000}}
+	entry, exit := tradeTimes(Trade{EntryIndex: 1}, candles)
+	if entry != 2000 || exit != 2000 {
+		t.Fatalf("want entry and exit at 2000, got %d / %d", entry, exit)
+	}
+}
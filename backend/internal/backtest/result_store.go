@@ -0,0 +1,78 @@
+package backtest
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// maxStoredResults caps how many backtest runs are kept in memory
+const maxStoredResults = 50
+
+// StoredBacktest is a completed unified backtest kept for follow-up analysis
+type StoredBacktest struct {
+	ID        string                 `json:"id"`
+	CreatedAt time.Time              `json:"createdAt"`
+	Config    UnifiedBacktestConfig  `json:"config"`
+	Result    *UnifiedBacktestResult `json:"result"`
+}
+
+// BacktestResultStore keeps recent backtest results in memory
+type BacktestResultStore struct {
+	results map[string]*StoredBacktest
+	order   []string
+	mu      sync.RWMutex
+}
+
+var backtestResultStore = &BacktestResultStore{
+	results: make(map[string]*StoredBacktest),
+	order:   []string{},
+}
+
+// GetBacktestResultStore returns the shared result store
+func GetBacktestResultStore() *BacktestResultStore {
+	return backtestResultStore
+}
+
+// Save stores a result and returns its ID, evicting the oldest when full
+func (s *BacktestResultStore) Save(config UnifiedBacktestConfig, result *UnifiedBacktestResult) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := fmt.Sprintf("bt_%d", time.Now().UnixNano())
+	s.results[id] = &StoredBacktest{
+		ID:        id,
+		CreatedAt: time.Now(),
+		Config:    config,
+		Result:    result,
+	}
+	s.order = append(s.order, id)
+
+	for len(s.order) > maxStoredResults {
+		delete(s.results, s.order[0])
+		s.order = s.order[1:]
+	}
+
+	return id
+}
+
+// Get returns a stored result by ID
+func (s *BacktestResultStore) Get(id string) (*StoredBacktest, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored, ok := s.results[id]
+	return stored, ok
+}
+
+// List returns stored results, newest first
+func (s *BacktestResultStore) List() []*StoredBacktest {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]*StoredBacktest, 0, len(s.order))
+	for i := len(s.order) - 1; i >= 0; i-- {
+		list = append(list, s.results[s.order[i]])
+	}
+	return list
+}
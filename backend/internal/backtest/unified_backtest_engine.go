@@ -8,6 +8,8 @@ import (
 	"sort"
 	"sync"
 	"time"
+
+	"tradebot/backend/internal/indicators"
 )
 
 // UnifiedBacktestConfig - One config to rule them all
@@ -18,6 +20,8 @@ type UnifiedBacktestConfig struct {
 	Days            int     `json:"days"`
 	StartBalance    float64 `json:"startBalance"`
 	Strategy        string  `json:"strategy"`
+	Params          map[string]float64 `json:"params,omitempty"` // Strategy parameter overrides (see GET /strategies/:name/params)
+	RiskProfile     string  `json:"riskProfile,omitempty"` // "Conservative", "Balanced" or "Aggressive"; empty disables profile filtering
 	
 	// Risk Management
 	RiskPercent         float64 `json:"riskPercent"`         // Risk per trade (default: 0.3%)
@@ -55,6 +59,7 @@ type UnifiedBacktestConfig struct {
 	EnableStressTest    bool    `json:"enableStressTest"`    // Test under extreme conditions
 	EnableMultiTF       bool    `json:"enableMultiTF"`       // Multi-timeframe analysis
 	EnablePartialExits  bool    `json:"enablePartialExits"`  // Use partial exit logic
+	RollingWindow       int     `json:"rollingWindow"`       // Trades per rolling metrics window
 	
 	// Parallel Processing
 	EnableParallel      bool    `json:"enableParallel"`      // Run multiple strategies in parallel
@@ -112,7 +117,15 @@ type UnifiedBacktestResult struct {
 	
 	// Market Condition Analysis
 	PerformanceByVolatility map[string]float64 `json:"performanceByVolatility"`
-	PerformanceByTrend      map[string]float64 `json:"performanceByTrend"`
+	PerformanceByTrend      map[string]float64 `json:"performanceByTrend"` // Net profit by regime at entry (trending/ranging/volatile)
+	
+	// Calendar and Rolling Breakdowns
+	CalendarReturns             *CalendarBreakdown    `json:"calendarReturns,omitempty"`
+	RollingMetrics              *RollingMetrics       `json:"rollingMetrics,omitempty"`
+	PerformanceByWeekdaySession []WeekdaySessionStats `json:"performanceByWeekdaySession,omitempty"`
+	
+	// Risk profile rejections (only when a profile is selected)
+	RiskProfile         *RiskProfileReport  `json:"riskProfile,omitempty"`
 	
 	// Metadata
 	StrategyName        string              `json:"strategyName"`
@@ -132,6 +145,18 @@ func RunUnifiedBacktest(config UnifiedBacktestConfig, candles []Candle) (*Unifie
 	// Set intelligent defaults
 	applyDefaults(&config)
 	
+	// Reject parameter overrides that do not match the strategy's schema
+	if len(config.Params) > 0 {
+		if err := ValidateStrategyParams(config.Strategy, config.Params); err != nil {
+			return nil, err
+		}
+	}
+	if config.RiskProfile != "" {
+		if _, err := NewRiskProfileFilter(config.RiskProfile); err != nil {
+			return nil, err
+		}
+	}
+	
 	// Choose execution path based on configuration
 	var result *UnifiedBacktestResult
 	var err error
@@ -157,6 +182,14 @@ func RunUnifiedBacktest(config UnifiedBacktestConfig, candles []Candle) (*Unifie
 	// Calculate advanced metrics
 	calculateAdvancedMetricsUnified(result, candles)
 	
+	// Calendar, rolling-window, weekday x session and regime breakdowns
+	if len(result.Trades) > 0 {
+		result.CalendarReturns = calculateCalendarBreakdown(result, candles)
+		result.RollingMetrics = calculateRollingMetrics(result, candles, config.RollingWindow)
+		result.PerformanceByWeekdaySession = calculateWeekdaySessionPerformance(result, candles)
+		result.PerformanceByTrend = calculateRegimePerformance(result, candles)
+	}
+	
 	// Run Monte Carlo if enabled
 	if config.EnableMonteCarlo && len(result.Trades) > 10 {
 		result.MonteCarloResults = runMonteCarloUnified(result.Trades, config.MonteCarloRuns, config.StartBalance)
@@ -195,6 +228,10 @@ func applyDefaults(config *UnifiedBacktestConfig) {
 	if config.MaxWindow == 0 {
 		config.MaxWindow = 200
 	}
+	// Give the strategy at least its declared warmup (rule strategies with HTF conditions need more)
+	if strategy, ok := GetStrategy(config.Strategy); ok && config.MaxWindow < strategy.WarmupBars() {
+		config.MaxWindow = strategy.WarmupBars()
+	}
 	if config.MonteCarloRuns == 0 {
 		config.MonteCarloRuns = 1000
 	}
@@ -204,6 +241,9 @@ func applyDefaults(config *UnifiedBacktestConfig) {
 	if config.WindowType == "" {
 		config.WindowType = "expanding"
 	}
+	if config.RollingWindow == 0 {
+		config.RollingWindow = 20
+	}
 }
 
 // runStandardUnified - Standard backtest with all features
@@ -225,6 +265,12 @@ func runStandardUnified(config UnifiedBacktestConfig, candles []Candle) (*Unifie
 	currentDay := ""
 	consecutiveLosses := 0
 	
+	// One generator for the whole run so configured strategies keep their state
+	usg := &UnifiedSignalGenerator{Params: config.Params, Profile: newRiskProfileFilterUnified(config)}
+	
+	// ATR at every bar in one pass for the volatility filter
+	atrSeries := indicators.ATRSeries(candles, 14)
+	
 	// Simulate trading
 	for i := config.MinWindow; i < len(candles)-50; i++ {
 		// Calculate window based on type
@@ -268,7 +314,7 @@ func runStandardUnified(config UnifiedBacktestConfig, candles []Candle) (*Unifie
 		
 		// Volatility filter
 		if config.MinVolatility > 0 || config.MaxVolatility > 0 {
-			atr := calculateATR(dataWindow, 14)
+			atr := atrSeries[i-1]
 			avgPrice := (candles[i].High + candles[i].Low) / 2
 			volatilityPct := (atr / avgPrice) * 100
 			
@@ -281,7 +327,6 @@ func runStandardUnified(config UnifiedBacktestConfig, candles []Candle) (*Unifie
 		}
 		
 		// Generate signal
-		usg := &UnifiedSignalGenerator{}
 		advSignal := usg.GenerateSignal(dataWindow, config.Strategy)
 		
 		if advSignal == nil || advSignal.Type == "NONE" {
@@ -293,7 +338,12 @@ func runStandardUnified(config UnifiedBacktestConfig, candles []Candle) (*Unifie
 		
 		if trade != nil {
 			trade.EntryIndex = i
+			trade.EntryTime = candles[i].Timestamp
+			trade.ExitTime = futureData[trade.CandlesHeld-1].Timestamp
+			trade.Reasons = advSignal.Reasons
+			trade.Evidence = advSignal.Evidence
 			trade.BalanceAfter = result.FinalBalance + trade.Profit
+			recordProfileTradeUnified(usg.Profile, trade, result.FinalBalance)
 			
 			result.Trades = append(result.Trades, *trade)
 			result.TotalTrades++
@@ -329,6 +379,10 @@ func runStandardUnified(config UnifiedBacktestConfig, candles []Candle) (*Unifie
 		}
 	}
 	
+	if usg.Profile != nil {
+		result.RiskProfile = usg.Profile.Report()
+	}
+	
 	// Calculate statistics
 	calculateStatsUnified(result)
 	
@@ -349,12 +403,12 @@ func runWithPartialExits(config UnifiedBacktestConfig, candles []Candle) (*Unifi
 	}
 	
 	skipAhead := 5
+	usg := &UnifiedSignalGenerator{Params: config.Params, Profile: newRiskProfileFilterUnified(config)}
 	
 	for i := config.MinWindow; i < len(candles)-50; i++ {
 		dataWindow := candles[i-config.MinWindow : i]
 		futureData := candles[i : minIntUnified(i+50, len(candles))]
 		
-		usg := &UnifiedSignalGenerator{}
 		advSignal := usg.GenerateSignal(dataWindow, config.Strategy)
 		
 		if advSignal == nil || advSignal.Type == "NONE" {
@@ -366,7 +420,12 @@ func runWithPartialExits(config UnifiedBacktestConfig, candles []Candle) (*Unifi
 		
 		if trade != nil {
 			trade.EntryIndex = i
+			trade.EntryTime = candles[i].Timestamp
+			trade.ExitTime = futureData[trade.CandlesHeld-1].Timestamp
+			trade.Reasons = advSignal.Reasons
+			trade.Evidence = advSignal.Evidence
 			trade.BalanceAfter = result.FinalBalance + trade.Profit
+			recordProfileTradeUnified(usg.Profile, trade, result.FinalBalance)
 			
 			result.Trades = append(result.Trades, *trade)
 			result.TotalTrades++
@@ -395,6 +454,10 @@ func runWithPartialExits(config UnifiedBacktestConfig, candles []Candle) (*Unifi
 		}
 	}
 	
+	if usg.Profile != nil {
+		result.RiskProfile = usg.Profile.Report()
+	}
+	
 	calculateStatsUnified(result)
 	return result, nil
 }
@@ -485,6 +548,8 @@ func runWalkForwardUnified(config UnifiedBacktestConfig, candles []Candle) (*Uni
 			aggregatedResult.ExitReasons[reason] += count
 		}
 		
+		aggregatedResult.RiskProfile = mergeRiskProfileReports(aggregatedResult.RiskProfile, periodResult.RiskProfile)
+		
 		periodNum++
 	}
 	
@@ -492,6 +557,44 @@ func runWalkForwardUnified(config UnifiedBacktestConfig, candles []Candle) (*Uni
 	return aggregatedResult, nil
 }
 
+// newRiskProfileFilterUnified builds the run's profile filter (nil when no profile is selected)
+func newRiskProfileFilterUnified(config UnifiedBacktestConfig) *RiskProfileFilter {
+	if config.RiskProfile == "" {
+		return nil
+	}
+	filter, err := NewRiskProfileFilter(config.RiskProfile)
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+		return nil
+	}
+	return filter
+}
+
+// recordProfileTradeUnified feeds a simulated trade into the profile's daily limits
+func recordProfileTradeUnified(filter *RiskProfileFilter, trade *Trade, balanceBefore float64) {
+	if filter == nil || balanceBefore <= 0 {
+		return
+	}
+	filter.RecordTrade(trade.EntryTime, trade.Profit/balanceBefore*100)
+}
+
+// mergeRiskProfileReports adds the counts of b into a (either may be nil)
+func mergeRiskProfileReports(a, b *RiskProfileReport) *RiskProfileReport {
+	if b == nil {
+		return a
+	}
+	if a == nil {
+		a = &RiskProfileReport{Profile: b.Profile, Rejections: make(map[string]int)}
+	}
+	a.Evaluated += b.Evaluated
+	a.Accepted += b.Accepted
+	a.Rejected += b.Rejected
+	for rule, count := range b.Rejections {
+		a.Rejections[rule] += count
+	}
+	return a
+}
+
 // runParallelStrategies - Test multiple strategies in parallel
 func runParallelStrategies(config UnifiedBacktestConfig, candles []Candle) (*UnifiedBacktestResult, error) {
 	results := make([]*UnifiedBacktestResult, len(config.Strategies))
@@ -506,6 +609,9 @@ func runParallelStrategies(config UnifiedBacktestConfig, candles []Candle) (*Uni
 			cfg := config
 			cfg.Strategy = strat
 			cfg.EnableParallel = false
+			if strat != config.Strategy {
+				cfg.Params = nil // Overrides belong to the primary strategy only
+			}
 			
 			result, err := runStandardUnified(cfg, candles)
 			if err != nil {
@@ -1044,8 +1150,9 @@ func runStressTestUnified(config UnifiedBacktestConfig, candles []Candle) *Stres
 	highVolCandles := []Candle{}
 	lowVolCandles := []Candle{}
 	
+	atrSeries := indicators.ATRSeries(candles, 14)
 	for i := 20; i < len(candles); i++ {
-		atr := calculateATR(candles[i-20:i], 14)
+		atr := atrSeries[i-1]
 		avgPrice := (candles[i].High + candles[i].Low) / 2
 		volatilityPct := (atr / avgPrice) * 100
 		
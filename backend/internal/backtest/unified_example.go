@@ -115,6 +115,7 @@ func ExampleParallelStrategies() (*UnifiedBacktestResult, error) {
 			"session_trader",
 			"trend_rider",
 			"smart_money_tracker",
+			"ensemble", // Weighted vote of all of the above and the rest of the registry
 		},
 	}
 	
@@ -14,6 +14,7 @@ type TelegramBot struct {
 	Token   string
 	ChatID  string
 	Running bool
+	Profile *RiskProfileFilter // Risk profile applied to live signals while running (nil = none)
 }
 
 type TelegramMessage struct {
@@ -55,6 +56,17 @@ func StartTelegramSignalBot(symbol, strategy string, filterBuy, filterSell bool)
 	
 	telegramBot.Running = true
 	
+	profileName := "none"
+	if telegramBot.Profile != nil {
+		profileName = telegramBot.Profile.Config.Name
+	}
+	
+	// Signals use the parameter version promoted for the symbol
+	paramsName := "strategy defaults"
+	if live, ok := GetLiveParamStore().Get(strategy, symbol); ok {
+		paramsName = fmt.Sprintf("v%d (%s)", live.Version, live.Source)
+	}
+	
 	// Get CURRENT filter settings from database (not the passed parameters)
 	currentFilterBuy, currentFilterSell := GetCurrentFilterSettings()
 	
@@ -62,12 +74,14 @@ func StartTelegramSignalBot(symbol, strategy string, filterBuy, filterSell bool)
 	startMsg := fmt.Sprintf("🤖 *Trading Signal Bot Started*\n\n"+
 		"📊 Symbol: `%s`\n"+
 		"🎯 Strategy: `%s`\n"+
+		"🧬 Parameters: `%s`\n"+
+		"🛡️ Risk Profile: `%s`\n"+
 		"🟢 Buy Signals: %v\n"+
 		"🔴 Sell Signals: %v\n\n"+
 		"_Telegram notifications enabled_\n"+
 		"_Signals generated by Live Signal Handler_\n"+
 		"_All signals saved to Supabase automatically_",
-		symbol, strategy, currentFilterBuy, currentFilterSell)
+		symbol, strategy, paramsName, profileName, currentFilterBuy, currentFilterSell)
 	
 	telegramBot.SendMessage(startMsg)
 	
@@ -78,6 +92,35 @@ func StartTelegramSignalBot(symbol, strategy string, filterBuy, filterSell bool)
 	return nil
 }
 
+// SetTelegramRiskProfile selects the risk profile for the next bot run; an empty name clears it
+func SetTelegramRiskProfile(name string) error {
+	if telegramBot == nil {
+		return fmt.Errorf("telegram bot not initialized")
+	}
+	if telegramBot.Running {
+		return fmt.Errorf("stop the telegram bot before changing its risk profile")
+	}
+	
+	if name == "" {
+		telegramBot.Profile = nil
+		return nil
+	}
+	profile, err := NewRiskProfileFilter(name)
+	if err != nil {
+		return err
+	}
+	telegramBot.Profile = profile
+	return nil
+}
+
+// TelegramRiskProfile returns the running bot's risk profile filter, or nil
+func TelegramRiskProfile() *RiskProfileFilter {
+	if telegramBot == nil || !telegramBot.Running {
+		return nil
+	}
+	return telegramBot.Profile
+}
+
 // StopTelegramSignalBot disables Telegram notifications
 func StopTelegramSignalBot() {
 	if telegramBot != nil && telegramBot.Running {
@@ -98,6 +141,13 @@ func (bot *TelegramBot) SendSignal(signal LiveSignalResponse, symbol, strategy s
 	var emoji string
 	var signalType string
 	
+	params := ""
+	if signal.ParamsVersion > 0 && signal.RiskPercent > 0 {
+		params = fmt.Sprintf(" (params v%d, risk %.2f%%)", signal.ParamsVersion, signal.RiskPercent*100)
+	} else if signal.ParamsVersion > 0 {
+		params = fmt.Sprintf(" (params v%d)", signal.ParamsVersion)
+	}
+	
 	if signal.Signal == "BUY" {
 		emoji = "🟢"
 		signalType = "BUY SIGNAL"
@@ -109,7 +159,7 @@ func (bot *TelegramBot) SendSignal(signal LiveSignalResponse, symbol, strategy s
 	message := fmt.Sprintf(
 		"%s *%s*\n\n"+
 			"📊 *Symbol:* `%s`\n"+
-			"🎯 *Strategy:* `%s`\n"+
+			"🎯 *Strategy:* `%s`%s\n"+
 			"💰 *Current Price:* `$%.2f`\n\n"+
 			"📍 *Entry:* `$%.2f`\n"+
 			"🛑 *Stop Loss:* `$%.2f`\n\n"+
@@ -117,18 +167,20 @@ func (bot *TelegramBot) SendSignal(signal LiveSignalResponse, symbol, strategy s
 			"   TP1 (33%%): `$%.2f`\n"+
 			"   TP2 (33%%): `$%.2f`\n"+
 			"   TP3 (34%%): `$%.2f`\n\n"+
+			"%s"+
 			"📊 *Risk/Reward:* `%.2f:1`\n"+
 			"⏰ *Time:* `%s`\n\n"+
 			"_Automated signal from Trading Bot_",
 		emoji, signalType,
 		symbol,
-		strategy,
+		strategy, params,
 		signal.CurrentPrice,
 		signal.Entry,
 		signal.StopLoss,
 		signal.TP1,
 		signal.TP2,
 		signal.TP3,
+		formatEvidence(signal.Evidence),
 		signal.RiskReward,
 		time.Now().Format("2006-01-02 15:04:05 MST"),
 	)
@@ -137,6 +189,18 @@ func (bot *TelegramBot) SendSignal(signal LiveSignalResponse, symbol, strategy s
 	log.Printf("📤 Sent %s signal to Telegram for %s", signal.Signal, symbol)
 }
 
+// formatEvidence lists the zones behind a signal, one line each
+func formatEvidence(evidence []Evidence) string {
+	if len(evidence) == 0 {
+		return ""
+	}
+	text := "🧩 *Evidence:*\n"
+	for _, e := range evidence {
+		text += fmt.Sprintf("   %s (%s): `$%.2f - $%.2f` %.0f%%\n", e.Label, e.Timeframe, e.PriceLow, e.PriceHigh, e.Weight*100)
+	}
+	return text + "\n"
+}
+
 // SendMessage sends a message to Telegram
 func (bot *TelegramBot) SendMessage(text string) error {
 	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", bot.Token)
@@ -186,9 +250,13 @@ func GetTelegramBotStatus() map[string]interface{} {
 		}
 	}
 	
-	return map[string]interface{}{
+	status := map[string]interface{}{
 		"configured": true,
 		"running":    telegramBot.Running,
 		"message":    "Telegram bot ready",
 	}
+	if telegramBot.Profile != nil {
+		status["riskProfile"] = telegramBot.Profile.Report()
+	}
+	return status
 }
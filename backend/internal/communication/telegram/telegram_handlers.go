@@ -5,8 +5,9 @@ import (
 )
 
 type TelegramBotStartRequest struct {
-	Symbol   string `json:"symbol"`
-	Strategy string `json:"strategy"`
+	Symbol      string `json:"symbol"`
+	Strategy    string `json:"strategy"`
+	RiskProfile string `json:"riskProfile"` // Conservative, Balanced or Aggressive; empty for none
 	// Note: filterBuy and filterSell are no longer used here
 	// They are controlled via user_settings API and read from database
 }
@@ -28,6 +29,13 @@ func HandleStartTelegramBot(c *fiber.Ctx) error {
 		req.Strategy = "session_trader"
 	}
 	
+	// Select the risk profile before starting so the first signal is already filtered
+	if err := SetTelegramRiskProfile(req.RiskProfile); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	
 	// Get current filter settings from database
 	filterBuy, filterSell := GetCurrentFilterSettings()
 	
@@ -43,10 +51,11 @@ func HandleStartTelegramBot(c *fiber.Ctx) error {
 		"success": true,
 		"message": "Telegram signal bot started successfully",
 		"config": fiber.Map{
-			"symbol":     req.Symbol,
-			"strategy":   req.Strategy,
-			"filterBuy":  filterBuy,  // Return current settings from database
-			"filterSell": filterSell, // Return current settings from database
+			"symbol":      req.Symbol,
+			"strategy":    req.Strategy,
+			"riskProfile": req.RiskProfile,
+			"filterBuy":   filterBuy,  // Return current settings from database
+			"filterSell":  filterSell, // Return current settings from database
 		},
 	})
 }
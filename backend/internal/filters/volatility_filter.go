@@ -3,6 +3,9 @@ package filters
 import (
 	"math"
 	"sort"
+
+	"tradebot/backend/internal/indicators"
+	"tradebot/backend/internal/regime"
 )
 
 // ==================== VOLATILITY FILTERING ====================
@@ -10,15 +13,17 @@ import (
 
 // VolatilityAnalysis holds volatility metrics
 type VolatilityAnalysis struct {
-	ATR            float64
-	ATRPercent     float64
-	ATRPercentile  float64
-	BBWidth        float64
-	BBPercentile   float64
-	Regime         string  // "low", "normal", "high", "extreme"
-	Optimal        bool
-	TradingAdvice  string
-	SizeMultiplier float64
+	ATR              float64
+	ATRPercent       float64
+	ATRPercentile    float64
+	BBWidth          float64
+	BBPercentile     float64
+	Regime           string  // "low", "normal", "high", "extreme"
+	MarketRegime     string  // Statistical regime: "trending", "ranging", "volatile" or "unknown"
+	RegimeConfidence float64 // Probability of MarketRegime
+	Optimal          bool
+	TradingAdvice    string
+	SizeMultiplier   float64
 }
 
 // ==================== ATR ANALYSIS ====================
@@ -34,7 +39,7 @@ func CalculateATRPercentile(candles []Candle, period int) float64 {
 	
 	for i := period; i < len(candles); i++ {
 		subset := candles[i-period : i]
-		atr := calculateATR(subset, period)
+		atr := indicators.ATR(subset, period)
 		if atr > 0 {
 			atrs = append(atrs, atr)
 		}
@@ -45,7 +50,7 @@ func CalculateATRPercentile(candles []Candle, period int) float64 {
 	}
 	
 	// Get current ATR
-	currentATR := calculateATR(candles[len(candles)-period:], period)
+	currentATR := indicators.ATR(candles[len(candles)-period:], period)
 	
 	// Calculate percentile
 	sort.Float64s(atrs)
@@ -120,8 +125,17 @@ func CalculateBollingerBandWidth(candles []Candle, period int, stdDev float64) (
 
 // ==================== VOLATILITY REGIME ====================
 
-// AnalyzeVolatility performs complete volatility analysis
+// AnalyzeVolatility performs complete volatility analysis, fitting the
+// statistical regime on this history
 func AnalyzeVolatility(candles []Candle) *VolatilityAnalysis {
+	return AnalyzeVolatilityWith(candles, nil)
+}
+
+// AnalyzeVolatilityWith is AnalyzeVolatility reading the statistical regime
+// from a detector kept across calls (one per symbol and timeframe), which only
+// feeds the new bars instead of refitting the model every time. A nil detector
+// fits a fresh one.
+func AnalyzeVolatilityWith(candles []Candle, detector *regime.Detector) *VolatilityAnalysis {
 	va := &VolatilityAnalysis{
 		Optimal:        false,
 		SizeMultiplier: 1.0,
@@ -134,7 +148,7 @@ func AnalyzeVolatility(candles []Candle) *VolatilityAnalysis {
 	}
 	
 	// Calculate ATR
-	va.ATR = calculateATR(candles[len(candles)-14:], 14)
+	va.ATR = indicators.ATR(candles[len(candles)-14:], 14)
 	currentPrice := candles[len(candles)-1].Close
 	va.ATRPercent = (va.ATR / currentPrice) * 100
 	
@@ -144,6 +158,20 @@ func AnalyzeVolatility(candles []Candle) *VolatilityAnalysis {
 	// Calculate Bollinger Band width
 	va.BBWidth, va.BBPercentile = CalculateBollingerBandWidth(candles, 20, 2.0)
 	
+	// Statistical regime fitted on this history. Extreme volatility needs both
+	// a top-decile ATR and the volatile regime; without enough history for the
+	// model the percentile alone decides.
+	var state regime.State
+	if detector != nil {
+		detector.Sync(candles)
+		state = detector.State()
+	} else {
+		state = regime.Detect(candles)
+	}
+	va.MarketRegime = string(state.Regime)
+	va.RegimeConfidence = state.Confidence
+	extreme := va.ATRPercentile >= 90 && (state.Regime == regime.Volatile || state.Regime == regime.Unknown)
+	
 	// Determine volatility regime
 	if va.ATRPercentile < 20 {
 		va.Regime = "low"
@@ -156,13 +184,13 @@ func AnalyzeVolatility(candles []Candle) *VolatilityAnalysis {
 		va.Optimal = true
 		va.SizeMultiplier = 1.0
 		
-	} else if va.ATRPercentile >= 40 && va.ATRPercentile < 70 {
+	} else if va.ATRPercentile >= 40 && va.ATRPercentile < 70 && state.Regime != regime.Volatile {
 		va.Regime = "normal"
 		va.TradingAdvice = "Optimal volatility - Best trading conditions"
 		va.Optimal = true
 		va.SizeMultiplier = 1.2 // Increase size (optimal)
 		
-	} else if va.ATRPercentile >= 70 && va.ATRPercentile < 90 {
+	} else if !extreme {
 		va.Regime = "high"
 		va.TradingAdvice = "High volatility - Reduce position size"
 		va.Optimal = true
@@ -0,0 +1,333 @@
+package indicators
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ==================== ANCHORED VWAP ====================
+// A VWAP accumulated from an anchor bar instead of the start of the window,
+// with volume-weighted standard deviation bands. Calendar anchors restart at
+// every session, day, week or month open; swing anchors restart at every
+// newly confirmed pivot; a time anchor starts once at a chosen bar.
+
+// Anchor kinds
+const (
+	AnchorSession   = "session"
+	AnchorDay       = "day"
+	AnchorWeek      = "week"
+	AnchorMonth     = "month"
+	AnchorSwingHigh = "swing_high"
+	AnchorSwingLow  = "swing_low"
+	AnchorTime      = "time"
+)
+
+// SessionOpens are the UTC hours the session anchor restarts at (Asian, London, New York)
+var SessionOpens = []int{0, 8, 13}
+
+const defaultSwingStrength = 5
+
+// AnchorSpec chooses where an anchored VWAP starts
+type AnchorSpec struct {
+	Kind     string `json:"kind"`
+	Strength int    `json:"strength,omitempty"` // Swing anchors: bars on each side of the pivot (default: 5)
+	Time     int64  `json:"time,omitempty"`     // Time anchors: first bar at or after this timestamp (ms)
+}
+
+// ParseAnchor reads "session", "week", "swing_low:3" or "time:1760745600000"
+func ParseAnchor(s string) (AnchorSpec, error) {
+	kind, arg, hasArg := strings.Cut(strings.TrimSpace(s), ":")
+	spec := AnchorSpec{Kind: kind}
+	if hasArg {
+		n, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return spec, fmt.Errorf("invalid anchor %q: %v", s, err)
+		}
+		switch kind {
+		case AnchorSwingHigh, AnchorSwingLow:
+			spec.Strength = int(n)
+		case AnchorTime:
+			spec.Time = n
+		default:
+			return spec, fmt.Errorf("anchor %q takes no argument", kind)
+		}
+	}
+	return spec, spec.Validate()
+}
+
+// Validate checks the kind and fills the default swing strength
+func (a *AnchorSpec) Validate() error {
+	switch a.Kind {
+	case AnchorSession, AnchorDay, AnchorWeek, AnchorMonth:
+	case AnchorSwingHigh, AnchorSwingLow:
+		if a.Strength == 0 {
+			a.Strength = defaultSwingStrength
+		}
+		if a.Strength < 1 || a.Strength > 50 {
+			return fmt.Errorf("swing strength must be between 1 and 50, got %d", a.Strength)
+		}
+	case AnchorTime:
+		if a.Time <= 0 {
+			return fmt.Errorf("time anchor needs a timestamp")
+		}
+	default:
+		return fmt.Errorf("unknown anchor %q (use session, day, week, month, swing_high, swing_low or time)", a.Kind)
+	}
+	return nil
+}
+
+// String is the inverse of ParseAnchor
+func (a AnchorSpec) String() string {
+	switch a.Kind {
+	case AnchorSwingHigh, AnchorSwingLow:
+		return fmt.Sprintf("%s:%d", a.Kind, a.Strength)
+	case AnchorTime:
+		return fmt.Sprintf("%s:%d", a.Kind, a.Time)
+	}
+	return a.Kind
+}
+
+// VWAPBands is an anchored VWAP reading with its standard deviation bands
+type VWAPBands struct {
+	Anchor      string  `json:"anchor"`
+	AnchorTime  int64   `json:"anchorTime"`
+	AnchorPrice float64 `json:"anchorPrice"` // Open of the anchor bar, or the pivot high / low
+	Bars        int     `json:"bars"`        // Bars since the anchor, inclusive; 0 = not anchored yet
+	VWAP        float64 `json:"vwap"`
+	StdDev      float64 `json:"stdDev"`
+	Upper1      float64 `json:"upper1"`
+	Lower1      float64 `json:"lower1"`
+	Upper2      float64 `json:"upper2"`
+	Lower2      float64 `json:"lower2"`
+}
+
+// Band returns the VWAP shifted by k standard deviations
+func (b VWAPBands) Band(k float64) float64 { return b.VWAP + k*b.StdDev }
+
+// VWAPStream is a volume-weighted mean and deviation of typical prices
+// ((high+low+close)/3, as CalculateVWAP uses), updated with West's algorithm
+type VWAPStream struct {
+	volume float64
+	mean   float64
+	m2     float64
+	bars   int
+}
+
+// Update adds a candle and returns the new VWAP
+func (v *VWAPStream) Update(c Candle) float64 {
+	v.bars++
+	if c.Volume <= 0 {
+		return v.mean
+	}
+	tp := (c.High + c.Low + c.Close) / 3
+	v.volume += c.Volume
+	delta := tp - v.mean
+	v.mean += delta * c.Volume / v.volume
+	v.m2 += c.Volume * delta * (tp - v.mean)
+	return v.mean
+}
+
+// Value returns the VWAP (0 before any volume traded)
+func (v *VWAPStream) Value() float64 { return v.mean }
+
+// StdDev returns the volume-weighted standard deviation around the VWAP
+func (v *VWAPStream) StdDev() float64 {
+	if v.volume == 0 {
+		return 0
+	}
+	return math.Sqrt(math.Max(0, v.m2/v.volume))
+}
+
+// Ready reports whether any volume has traded since the anchor
+func (v *VWAPStream) Ready() bool { return v.volume > 0 }
+
+// AnchoredVWAP keeps one anchored VWAP current bar by bar
+type AnchoredVWAP struct {
+	spec        AnchorSpec
+	stream      VWAPStream
+	anchorTime  int64
+	anchorPrice float64
+	period      int64    // Calendar anchors: start of the current period
+	recent      []Candle // Swing anchors: the last 2*strength+1 bars
+}
+
+// NewAnchoredVWAP creates an anchored VWAP; the spec must be validated
+func NewAnchoredVWAP(spec AnchorSpec) *AnchoredVWAP {
+	return &AnchoredVWAP{spec: spec}
+}
+
+// Update feeds the next candle, re-anchoring when a new period opens or a
+// new pivot is confirmed
+func (a *AnchoredVWAP) Update(c Candle) {
+	switch a.spec.Kind {
+	case AnchorSwingHigh, AnchorSwingLow:
+		n := a.spec.Strength
+		a.recent = append(a.recent, c)
+		if len(a.recent) > 2*n+1 {
+			a.recent = a.recent[1:]
+		}
+		if len(a.recent) == 2*n+1 && isPivot(a.recent, n, a.spec.Kind) {
+			pivot := a.recent[n]
+			a.anchor(pivot, pivotPrice(pivot, a.spec.Kind))
+			for _, b := range a.recent[n:] {
+				a.stream.Update(b)
+			}
+			return
+		}
+		if a.stream.bars > 0 {
+			a.stream.Update(c)
+		}
+	case AnchorTime:
+		if a.anchorTime == 0 && c.Timestamp >= a.spec.Time {
+			a.anchor(c, c.Open)
+		}
+		if a.anchorTime > 0 {
+			a.stream.Update(c)
+		}
+	default:
+		if p := AnchorPeriodStart(a.spec.Kind, c.Timestamp); a.stream.bars == 0 || p != a.period {
+			a.period = p
+			a.anchor(c, c.Open)
+		}
+		a.stream.Update(c)
+	}
+}
+
+func (a *AnchoredVWAP) anchor(c Candle, price float64) {
+	a.stream = VWAPStream{}
+	a.anchorTime = c.Timestamp
+	a.anchorPrice = price
+}
+
+// Value returns the current reading and bands
+func (a *AnchoredVWAP) Value() VWAPBands {
+	b := VWAPBands{
+		Anchor:      a.spec.String(),
+		AnchorTime:  a.anchorTime,
+		AnchorPrice: a.anchorPrice,
+		Bars:        a.stream.bars,
+		VWAP:        a.stream.Value(),
+		StdDev:      a.stream.StdDev(),
+	}
+	b.Upper1, b.Lower1 = b.Band(1), b.Band(-1)
+	b.Upper2, b.Lower2 = b.Band(2), b.Band(-2)
+	return b
+}
+
+// Ready reports whether the VWAP is anchored and has seen volume
+func (a *AnchoredVWAP) Ready() bool { return a.stream.Ready() }
+
+// AnchorPeriodStart returns the open of the session, day, week (Monday) or
+// month containing ts, all in UTC
+func AnchorPeriodStart(kind string, ts int64) int64 {
+	const dayMs = int64(24 * time.Hour / time.Millisecond)
+	day := ts - ((ts%dayMs)+dayMs)%dayMs
+	switch kind {
+	case AnchorSession:
+		hour := int((ts - day) / (dayMs / 24))
+		open := 0
+		for _, h := range SessionOpens {
+			if h <= hour {
+				open = h
+			}
+		}
+		return day + int64(open)*(dayMs/24)
+	case AnchorWeek:
+		// The epoch was a Thursday; Mondays are day 4 mod 7
+		return day - (((day/dayMs+3)%7)+7)%7*dayMs
+	case AnchorMonth:
+		t := time.UnixMilli(ts).UTC()
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	}
+	return day
+}
+
+// isPivot reports whether the middle bar of a 2n+1 window is a swing high
+// (low): beyond every bar to its left and not exceeded by any to its right
+func isPivot(window []Candle, n int, kind string) bool {
+	beyond := func(a, b float64) bool {
+		if kind == AnchorSwingLow {
+			return a < b
+		}
+		return a > b
+	}
+	mid := pivotPrice(window[n], kind)
+	for i, c := range window {
+		p := pivotPrice(c, kind)
+		if (i < n && !beyond(mid, p)) || (i > n && beyond(p, mid)) {
+			return false
+		}
+	}
+	return true
+}
+
+func pivotPrice(c Candle, kind string) float64 {
+	if kind == AnchorSwingLow {
+		return c.Low
+	}
+	return c.High
+}
+
+// AnchoredVWAPAt returns the anchored VWAP at the last candle, locating the
+// anchor by scanning back instead of replaying the whole window. It matches
+// an AnchoredVWAP fed every candle. The spec must be validated; ok is false
+// when no anchor exists yet.
+func AnchoredVWAPAt(candles []Candle, spec AnchorSpec) (VWAPBands, bool) {
+	if len(candles) == 0 {
+		return VWAPBands{}, false
+	}
+	last := len(candles) - 1
+	start := -1
+	price := 0.0
+
+	switch spec.Kind {
+	case AnchorSwingHigh, AnchorSwingLow:
+		n := spec.Strength
+		for p := last - n; p >= n; p-- {
+			if isPivot(candles[p-n:p+n+1], n, spec.Kind) {
+				start, price = p, pivotPrice(candles[p], spec.Kind)
+				break
+			}
+		}
+	case AnchorTime:
+		start = sort.Search(len(candles), func(i int) bool { return candles[i].Timestamp >= spec.Time })
+		if start > last {
+			start = -1
+		}
+	default:
+		period := AnchorPeriodStart(spec.Kind, candles[last].Timestamp)
+		start = last
+		for start > 0 && AnchorPeriodStart(spec.Kind, candles[start-1].Timestamp) == period {
+			start--
+		}
+	}
+	if start < 0 {
+		return VWAPBands{}, false
+	}
+	if spec.Kind != AnchorSwingHigh && spec.Kind != AnchorSwingLow {
+		price = candles[start].Open
+	}
+
+	a := NewAnchoredVWAP(spec)
+	a.anchor(candles[start], price)
+	for _, c := range candles[start:] {
+		a.stream.Update(c)
+	}
+	return a.Value(), a.Ready()
+}
+
+// AnchoredVWAPSeries streams an anchored VWAP over candles and returns the
+// reading at every bar (Bars is 0 before the first anchor)
+func AnchoredVWAPSeries(candles []Candle, spec AnchorSpec) []VWAPBands {
+	a := NewAnchoredVWAP(spec)
+	series := make([]VWAPBands, len(candles))
+	for i, c := range candles {
+		a.Update(c)
+		series[i] = a.Value()
+	}
+	return series
+}
@@ -0,0 +1,161 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+)
+
+func assertBands(t *testing.T, name string, n int, got, want VWAPBands) {
+	t.Helper()
+	if got.AnchorTime != want.AnchorTime || got.Bars != want.Bars || got.AnchorPrice != want.AnchorPrice {
+		t.Fatalf("%s at %d candles: anchor %d/%d bars (price %v), want %d/%d bars (price %v)",
+			name, n, got.AnchorTime, got.Bars, got.AnchorPrice, want.AnchorTime, want.Bars, want.AnchorPrice)
+	}
+	assertClose(t, name+" vwap", n, got.VWAP, want.VWAP)
+	assertClose(t, name+" stddev", n, got.StdDev, want.StdDev)
+}
+
+func TestVWAPStreamMatchesDirectFormula(t *testing.T) {
+	candles := randomWalk(200, 11)
+	var stream VWAPStream
+	for _, c := range candles {
+		stream.Update(c)
+	}
+
+	sumPV, sumV := 0.0, 0.0
+	for _, c := range candles {
+		sumPV += (c.High + c.Low + c.Close) / 3 * c.Volume
+		sumV += c.Volume
+	}
+	vwap := sumPV / sumV
+	sumSq := 0.0
+	for _, c := range candles {
+		d := (c.High+c.Low+c.Close)/3 - vwap
+		sumSq += d * d * c.Volume
+	}
+	assertClose(t, "VWAP", len(candles), stream.Value(), vwap)
+	assertClose(t, "VWAP stddev", len(candles), stream.StdDev(), math.Sqrt(sumSq/sumV))
+}
+
+func TestAnchoredVWAPStreamingMatchesScan(t *testing.T) {
+	// 15m bars from the epoch: 3200 bars cross sessions, days, weeks and a month
+	candles := randomWalk(3200, 12)
+	specs := []string{"session", "day", "week", "month", "swing_high:3", "swing_low:5", "time:86400000"}
+	for _, s := range specs {
+		spec, err := ParseAnchor(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stream := NewAnchoredVWAP(spec)
+		anchors := map[int64]bool{}
+		for i, c := range candles {
+			stream.Update(c)
+			want, ok := AnchoredVWAPAt(candles[:i+1], spec)
+			if ok != stream.Ready() {
+				t.Fatalf("%s at %d candles: ready %v, scan found anchor %v", s, i+1, stream.Ready(), ok)
+			}
+			if ok {
+				assertBands(t, s, i+1, stream.Value(), want)
+				anchors[want.AnchorTime] = true
+			}
+		}
+		if s != "time:86400000" && len(anchors) < 2 {
+			t.Errorf("%s never re-anchored", s)
+		}
+	}
+}
+
+func TestAnchorPeriodStart(t *testing.T) {
+	saturday := int64(1760810400000) // 2025-10-18 18:00 UTC
+	cases := []struct {
+		kind string
+		want int64
+	}{
+		{AnchorSession, 1760792400000}, // 13:00 New York open
+		{AnchorDay, 1760745600000},     // 2025-10-18 00:00
+		{AnchorWeek, 1760313600000},    // Monday 2025-10-13
+		{AnchorMonth, 1759276800000},   // 2025-10-01
+	}
+	for _, c := range cases {
+		if got := AnchorPeriodStart(c.kind, saturday); got != c.want {
+			t.Errorf("%s start = %d, want %d", c.kind, got, c.want)
+		}
+	}
+}
+
+func TestSwingAnchorsAtPivot(t *testing.T) {
+	highs := []float64{10, 11, 12, 15, 12, 11, 10, 9}
+	candles := make([]Candle, len(highs))
+	for i, h := range highs {
+		candles[i] = Candle{Timestamp: int64(i) * 60000, Open: h - 1, High: h, Low: h - 2, Close: h - 1, Volume: 1}
+	}
+	spec := AnchorSpec{Kind: AnchorSwingHigh, Strength: 2}
+	if _, ok := AnchoredVWAPAt(candles[:5], spec); ok {
+		t.Error("the pivot at bar 3 is not confirmed until bar 5")
+	}
+	b, ok := AnchoredVWAPAt(candles, spec)
+	if !ok || b.AnchorTime != candles[3].Timestamp || b.AnchorPrice != 15 || b.Bars != 5 {
+		t.Errorf("expected an anchor at the 15 high with 5 bars, got %+v", b)
+	}
+}
+
+func TestParseAnchor(t *testing.T) {
+	spec, err := ParseAnchor("swing_low")
+	if err != nil || spec.Strength != defaultSwingStrength || spec.String() != "swing_low:5" {
+		t.Errorf("unexpected spec %+v, %v", spec, err)
+	}
+	for _, bad := range []string{"year", "week:3", "time", "swing_high:x", "swing_high:99"} {
+		if _, err := ParseAnchor(bad); err == nil {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestTrackerAVWAP(t *testing.T) {
+	candles := randomWalk(600, 13)
+	stream := NewTracker()
+	spec := AnchorSpec{Kind: AnchorSwingLow, Strength: 4}
+	stream.Sync(candles[:1])
+	stream.AVWAP(spec)
+	for i := 2; i <= len(candles); i++ {
+		start := 0
+		if i > 100 {
+			start = i - 100
+		}
+		stream.Sync(candles[start:i])
+		want, ok := AnchoredVWAPAt(candles[:i], spec)
+		if got := stream.AVWAP(spec); ok {
+			assertBands(t, "Tracker AVWAP", i, got, want)
+		} else if got.Bars != 0 {
+			t.Fatalf("Tracker AVWAP anchored at %d candles before any pivot", i)
+		}
+	}
+}
+
+func TestTrackerAVWAPHistory(t *testing.T) {
+	candles := randomWalk(300, 21)
+	stream := NewTracker()
+	spec := AnchorSpec{Kind: AnchorDay}
+	for i := 1; i <= len(candles); i++ {
+		stream.Sync(candles[:i])
+		for _, back := range []int{1, 5} {
+			if i <= back {
+				continue
+			}
+			want, wantOK := AnchoredVWAPAt(candles[:i-back], spec)
+			got, ok := stream.AVWAPAt(spec, back)
+			if ok != wantOK {
+				t.Fatalf("bar %d back %d: ready %v, want %v", i, back, ok, wantOK)
+			}
+			if ok {
+				assertBands(t, "Tracker AVWAPAt", i, got, want)
+			}
+		}
+	}
+	if _, ok := stream.AVWAPAt(spec, HistoryLen); ok {
+		t.Error("readings beyond the kept history must not be reported")
+	}
+	if _, ok := stream.AVWAPAt(AnchorSpec{Kind: "year"}, 0); ok {
+		t.Error("an invalid spec must not be reported")
+	}
+}
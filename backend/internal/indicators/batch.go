@@ -0,0 +1,260 @@
+package indicators
+
+import "math"
+
+// ==================== BATCH INDICATORS ====================
+// Batch helpers compute the value at the last candle directly from the
+// slice. They use the same definitions as the streaming indicators (and
+// are tested against them) for callers that only need one value.
+
+// SMA returns the simple moving average of the last period closes
+func SMA(candles []Candle, period int) float64 {
+	if len(candles) == 0 || period < 1 {
+		return 0
+	}
+	if len(candles) < period {
+		period = len(candles)
+	}
+	sum := 0.0
+	for i := len(candles) - period; i < len(candles); i++ {
+		sum += candles[i].Close
+	}
+	return sum / float64(period)
+}
+
+// EMA returns the exponential moving average of closes, seeded with the SMA
+// of the first period closes. Shorter slices return the SMA of what is there.
+func EMA(candles []Candle, period int) float64 {
+	if len(candles) == 0 || period < 1 {
+		return 0
+	}
+	if len(candles) < period {
+		return SMA(candles, period)
+	}
+
+	multiplier := 2.0 / float64(period+1)
+	ema := SMA(candles[:period], period)
+	for i := period; i < len(candles); i++ {
+		ema = (candles[i].Close-ema)*multiplier + ema
+	}
+	return ema
+}
+
+// RSI returns Wilder's RSI, or 50 when there are not enough candles
+func RSI(candles []Candle, period int) float64 {
+	if period < 1 || len(candles) < period+1 {
+		return 50
+	}
+
+	avgGain, avgLoss := 0.0, 0.0
+	for i := 1; i <= period; i++ {
+		change := candles[i].Close - candles[i-1].Close
+		avgGain += math.Max(change, 0)
+		avgLoss += math.Max(-change, 0)
+	}
+	avgGain /= float64(period)
+	avgLoss /= float64(period)
+
+	p := float64(period)
+	for i := period + 1; i < len(candles); i++ {
+		change := candles[i].Close - candles[i-1].Close
+		avgGain = (avgGain*(p-1) + math.Max(change, 0)) / p
+		avgLoss = (avgLoss*(p-1) + math.Max(-change, 0)) / p
+	}
+
+	if avgLoss == 0 {
+		if avgGain == 0 {
+			return 50
+		}
+		return 100
+	}
+	return 100 - (100 / (1 + avgGain/avgLoss))
+}
+
+// ATR returns Wilder's Average True Range. Slices shorter than period
+// return the mean true range.
+func ATR(candles []Candle, period int) float64 {
+	if len(candles) == 0 || period < 1 {
+		return 0
+	}
+
+	tr := make([]float64, len(candles))
+	tr[0] = candles[0].High - candles[0].Low
+	for i := 1; i < len(candles); i++ {
+		tr[i] = trueRange(candles[i].High, candles[i].Low, candles[i-1].Close)
+	}
+
+	seed := period
+	if len(tr) < seed {
+		seed = len(tr)
+	}
+	atr := 0.0
+	for i := 0; i < seed; i++ {
+		atr += tr[i]
+	}
+	atr /= float64(seed)
+
+	p := float64(period)
+	for i := period; i < len(tr); i++ {
+		atr = (atr*(p-1) + tr[i]) / p
+	}
+	return atr
+}
+
+// ADX returns Wilder's Average Directional Index, or 0 when there are fewer
+// than period+1 candles
+func ADX(candles []Candle, period int) float64 {
+	if period < 1 || len(candles) < period+1 {
+		return 0
+	}
+
+	n := len(candles) - 1
+	plusDM := make([]float64, n)
+	minusDM := make([]float64, n)
+	tr := make([]float64, n)
+	for i := 1; i < len(candles); i++ {
+		upMove := candles[i].High - candles[i-1].High
+		downMove := candles[i-1].Low - candles[i].Low
+		if upMove > downMove && upMove > 0 {
+			plusDM[i-1] = upMove
+		}
+		if downMove > upMove && downMove > 0 {
+			minusDM[i-1] = downMove
+		}
+		tr[i-1] = trueRange(candles[i].High, candles[i].Low, candles[i-1].Close)
+	}
+
+	smoothTR, smoothPlus, smoothMinus := 0.0, 0.0, 0.0
+	for i := 0; i < period; i++ {
+		smoothTR += tr[i]
+		smoothPlus += plusDM[i]
+		smoothMinus += minusDM[i]
+	}
+
+	p := float64(period)
+	dx := []float64{directionalIndex(smoothPlus, smoothMinus, smoothTR)}
+	for i := period; i < n; i++ {
+		smoothTR = smoothTR - smoothTR/p + tr[i]
+		smoothPlus = smoothPlus - smoothPlus/p + plusDM[i]
+		smoothMinus = smoothMinus - smoothMinus/p + minusDM[i]
+		dx = append(dx, directionalIndex(smoothPlus, smoothMinus, smoothTR))
+	}
+
+	seed := period
+	if len(dx) < seed {
+		seed = len(dx)
+	}
+	adx := 0.0
+	for i := 0; i < seed; i++ {
+		adx += dx[i]
+	}
+	adx /= float64(seed)
+	for i := period; i < len(dx); i++ {
+		adx = (adx*(p-1) + dx[i]) / p
+	}
+	return adx
+}
+
+// MACD returns the MACD line, signal line and histogram at the last candle.
+// The signal line averages MACD values from the bar the slow EMA is seeded.
+func MACD(candles []Candle, fast, slow, signal int) (float64, float64, float64) {
+	if len(candles) == 0 {
+		return 0, 0, 0
+	}
+
+	closes := make([]float64, len(candles))
+	for i, c := range candles {
+		closes[i] = c.Close
+	}
+	fastEMA := emaValues(closes, fast)
+	slowEMA := emaValues(closes, slow)
+
+	var macdValues []float64
+	for i := range closes {
+		if i+1 >= slow {
+			macdValues = append(macdValues, fastEMA[i]-slowEMA[i])
+		}
+	}
+	macdLine := fastEMA[len(closes)-1] - slowEMA[len(closes)-1]
+	if len(macdValues) == 0 {
+		return macdLine, macdLine, 0
+	}
+
+	signalEMA := emaValues(macdValues, signal)
+	signalLine := signalEMA[len(signalEMA)-1]
+	return macdLine, signalLine, macdLine - signalLine
+}
+
+// ==================== SERIES ====================
+// Series helpers return one value per candle in a single O(n) pass.
+
+// EMASeries returns the EMA of closes at every candle
+func EMASeries(candles []Candle, period int) []float64 {
+	ema := NewEMAStream(period)
+	values := make([]float64, len(candles))
+	for i, c := range candles {
+		values[i] = ema.Update(c.Close)
+	}
+	return values
+}
+
+// RSISeries returns Wilder's RSI at every candle
+func RSISeries(candles []Candle, period int) []float64 {
+	rsi := NewRSIStream(period)
+	values := make([]float64, len(candles))
+	for i, c := range candles {
+		values[i] = rsi.Update(c.Close)
+	}
+	return values
+}
+
+// ATRSeries returns Wilder's ATR at every candle
+func ATRSeries(candles []Candle, period int) []float64 {
+	atr := NewATRStream(period)
+	values := make([]float64, len(candles))
+	for i, c := range candles {
+		values[i] = atr.Update(c.High, c.Low, c.Close)
+	}
+	return values
+}
+
+// ADXSeries returns Wilder's ADX at every candle
+func ADXSeries(candles []Candle, period int) []float64 {
+	adx := NewADXStream(period)
+	values := make([]float64, len(candles))
+	for i, c := range candles {
+		values[i] = adx.Update(c.High, c.Low, c.Close)
+	}
+	return values
+}
+
+func directionalIndex(plusDM, minusDM, tr float64) float64 {
+	if tr <= 0 {
+		return 0
+	}
+	plusDI := plusDM / tr * 100
+	minusDI := minusDM / tr * 100
+	if plusDI+minusDI == 0 {
+		return 0
+	}
+	return math.Abs(plusDI-minusDI) / (plusDI + minusDI) * 100
+}
+
+// emaValues returns the SMA-seeded EMA at every value (running mean before the seed)
+func emaValues(values []float64, period int) []float64 {
+	if period < 1 {
+		period = 1
+	}
+	out := make([]float64, len(values))
+	multiplier := 2.0 / float64(period+1)
+	sum := 0.0
+	for i, v := range values {
+		if i < period {
+			sum += v
+			out[i] = sum / float64(i+1)
+			continue
+		}
+		out[i] = (v-out[i-1])*multiplier + out[i-1]
+	}
+	return out
+}
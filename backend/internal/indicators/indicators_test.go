@@ -0,0 +1,151 @@
+package indicators
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func randomWalk(n int, seed int64) []Candle {
+	rng := rand.New(rand.NewSource(seed))
+	candles := make([]Candle, n)
+	price := 100.0
+	for i := range candles {
+		open := price
+		price += rng.NormFloat64()
+		high := math.Max(open, price) + rng.Float64()
+		low := math.Min(open, price) - rng.Float64()
+		candles[i] = Candle{
+			Timestamp: int64(i) * 900000,
+			Open:      open,
+			High:      high,
+			Low:       low,
+			Close:     price,
+			Volume:    1000 + rng.Float64()*500,
+		}
+	}
+	return candles
+}
+
+func assertClose(t *testing.T, name string, n int, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-9*math.Max(1, math.Abs(want)) {
+		t.Fatalf("%s at %d candles: streaming %v, batch %v", name, n, got, want)
+	}
+}
+
+func TestStreamingMatchesBatch(t *testing.T) {
+	candles := randomWalk(300, 1)
+
+	sma := NewSMAStream(20)
+	ema9, ema50 := NewEMAStream(9), NewEMAStream(50)
+	rsi := NewRSIStream(14)
+	atr := NewATRStream(14)
+	adx := NewADXStream(14)
+	macd := NewMACDStream(12, 26, 9)
+
+	for i, c := range candles {
+		n := i + 1
+		window := candles[:n]
+
+		assertClose(t, "SMA(20)", n, sma.Update(c.Close), SMA(window, 20))
+		assertClose(t, "EMA(9)", n, ema9.Update(c.Close), EMA(window, 9))
+		assertClose(t, "EMA(50)", n, ema50.Update(c.Close), EMA(window, 50))
+		assertClose(t, "RSI(14)", n, rsi.Update(c.Close), RSI(window, 14))
+		assertClose(t, "ATR(14)", n, atr.Update(c.High, c.Low, c.Close), ATR(window, 14))
+		assertClose(t, "ADX(14)", n, adx.Update(c.High, c.Low, c.Close), ADX(window, 14))
+
+		gotMACD, gotSignal, gotHist := macd.Update(c.Close)
+		wantMACD, wantSignal, wantHist := MACD(window, 12, 26, 9)
+		assertClose(t, "MACD line", n, gotMACD, wantMACD)
+		assertClose(t, "MACD signal", n, gotSignal, wantSignal)
+		assertClose(t, "MACD histogram", n, gotHist, wantHist)
+	}
+}
+
+func TestSeriesMatchBatch(t *testing.T) {
+	candles := randomWalk(200, 2)
+	ema := EMASeries(candles, 21)
+	rsi := RSISeries(candles, 7)
+	atr := ATRSeries(candles, 20)
+	adx := ADXSeries(candles, 14)
+
+	for i := range candles {
+		window := candles[:i+1]
+		assertClose(t, "EMASeries(21)", i+1, ema[i], EMA(window, 21))
+		assertClose(t, "RSISeries(7)", i+1, rsi[i], RSI(window, 7))
+		assertClose(t, "ATRSeries(20)", i+1, atr[i], ATR(window, 20))
+		assertClose(t, "ADXSeries(14)", i+1, adx[i], ADX(window, 14))
+	}
+}
+
+func TestKnownValues(t *testing.T) {
+	// Constant range bars that only go up: RSI pegs at 100, ATR equals the range
+	candles := make([]Candle, 50)
+	for i := range candles {
+		price := 100 + float64(i)
+		candles[i] = Candle{Timestamp: int64(i), Open: price - 0.5, High: price + 1, Low: price - 1, Close: price}
+	}
+	if got := RSI(candles, 14); got != 100 {
+		t.Fatalf("RSI of a rising series = %v, want 100", got)
+	}
+	// True range is max(2, |high-prevClose|=2, |low-prevClose|=0) = 2
+	assertClose(t, "ATR", len(candles), ATR(candles, 14), 2)
+	if got := ADX(candles, 14); got < 99 {
+		t.Fatalf("ADX of a one-way series = %v, want ~100", got)
+	}
+	if got := RSI(candles[:10], 14); got != 50 {
+		t.Fatalf("RSI without enough data = %v, want 50", got)
+	}
+}
+
+func TestStreamSlidingWindow(t *testing.T) {
+	candles := randomWalk(400, 3)
+	stream := NewTracker()
+
+	for i := 100; i <= len(candles); i++ {
+		// Sliding window like the backtest engines pass to strategies
+		stream.Sync(candles[i-100 : i])
+		history := candles[:i]
+
+		assertClose(t, "Tracker EMA(21)", i, stream.EMA(21), EMA(history, 21))
+		assertClose(t, "Tracker RSI(14)", i, stream.RSI(14), RSI(history, 14))
+		assertClose(t, "Tracker ATR(14)", i, stream.ATR(14), ATR(history, 14))
+		assertClose(t, "Tracker ADX(14)", i, stream.ADX(14), ADX(history, 14))
+		gotMACD, gotSignal, _ := stream.MACD(12, 26, 9)
+		wantMACD, wantSignal, _ := MACD(history, 12, 26, 9)
+		assertClose(t, "Tracker MACD line", i, gotMACD, wantMACD)
+		assertClose(t, "Tracker MACD signal", i, gotSignal, wantSignal)
+		for back := 0; back < 10; back++ {
+			assertClose(t, "Tracker EMAAt(21)", i-back, stream.EMAAt(21, back), EMA(history[:i-back], 21))
+		}
+	}
+	if stream.Bars() != len(candles) {
+		t.Fatalf("tracker fed %d bars, want %d (no resets on a sliding window)", stream.Bars(), len(candles))
+	}
+}
+
+func TestStreamResetsOnDiscontinuity(t *testing.T) {
+	candles := randomWalk(300, 4)
+	stream := NewTracker()
+	stream.Sync(candles[:200])
+	_ = stream.EMA(50)
+
+	// The last bar was still forming: same timestamp, different close
+	forming := append([]Candle(nil), candles[:201]...)
+	stream.Sync(forming)
+	forming[200].Close += 5
+	stream.Sync(forming)
+	assertClose(t, "EMA after rewritten bar", 201, stream.EMA(50), EMA(forming, 50))
+
+	// An unrelated window replays from scratch
+	other := randomWalk(150, 5)
+	for i := range other {
+		other[i].Timestamp += 1 << 40
+	}
+	stream.Sync(other)
+	assertClose(t, "EMA after jump", len(other), stream.EMA(50), EMA(other, 50))
+	if stream.Bars() != len(other) {
+		t.Fatalf("tracker fed %d bars after reset, want %d", stream.Bars(), len(other))
+	}
+}
@@ -0,0 +1,328 @@
+package indicators
+
+import "math"
+
+// ==================== STREAMING INDICATORS ====================
+// Each indicator keeps just enough state to update in O(1) per bar.
+// Before an indicator is Ready its Value is a best-effort partial
+// result (e.g. the running mean) so short windows still return
+// something sensible, matching the batch helpers in batch.go.
+
+// SMAStream is a simple moving average over the last period values
+type SMAStream struct {
+	period int
+	ring   []float64
+	pos    int
+	count  int
+	sum    float64
+}
+
+// NewSMAStream creates a simple moving average
+func NewSMAStream(period int) *SMAStream {
+	if period < 1 {
+		period = 1
+	}
+	return &SMAStream{period: period, ring: make([]float64, period)}
+}
+
+// Update adds a value and returns the new average
+func (s *SMAStream) Update(v float64) float64 {
+	if s.count == s.period {
+		s.sum -= s.ring[s.pos]
+	} else {
+		s.count++
+	}
+	s.ring[s.pos] = v
+	s.sum += v
+	s.pos = (s.pos + 1) % s.period
+	return s.Value()
+}
+
+// Value returns the average of the values seen so far (at most period)
+func (s *SMAStream) Value() float64 {
+	if s.count == 0 {
+		return 0
+	}
+	return s.sum / float64(s.count)
+}
+
+// Ready reports whether a full period has been seen
+func (s *SMAStream) Ready() bool { return s.count >= s.period }
+
+// EMAStream is an exponential moving average seeded with the SMA of the first period values
+type EMAStream struct {
+	period int
+	k      float64
+	count  int
+	sum    float64
+	value  float64
+}
+
+// NewEMAStream creates an exponential moving average
+func NewEMAStream(period int) *EMAStream {
+	if period < 1 {
+		period = 1
+	}
+	return &EMAStream{period: period, k: 2.0 / float64(period+1)}
+}
+
+// Update adds a value and returns the new average
+func (e *EMAStream) Update(v float64) float64 {
+	e.count++
+	if e.count <= e.period {
+		e.sum += v
+		e.value = e.sum / float64(e.count)
+		return e.value
+	}
+	e.value += (v - e.value) * e.k
+	return e.value
+}
+
+// Value returns the current average (the running mean until Ready)
+func (e *EMAStream) Value() float64 { return e.value }
+
+// Ready reports whether the SMA seed is complete
+func (e *EMAStream) Ready() bool { return e.count >= e.period }
+
+// RSIStream is Wilder's Relative Strength Index
+type RSIStream struct {
+	period    int
+	prevClose float64
+	count     int // closes seen
+	sumGain   float64
+	sumLoss   float64
+	avgGain   float64
+	avgLoss   float64
+}
+
+// NewRSIStream creates a Wilder RSI
+func NewRSIStream(period int) *RSIStream {
+	if period < 1 {
+		period = 1
+	}
+	return &RSIStream{period: period}
+}
+
+// Update adds a close and returns the new RSI
+func (r *RSIStream) Update(close float64) float64 {
+	r.count++
+	if r.count == 1 {
+		r.prevClose = close
+		return r.Value()
+	}
+
+	change := close - r.prevClose
+	r.prevClose = close
+	gain, loss := math.Max(change, 0), math.Max(-change, 0)
+
+	changes := r.count - 1
+	switch {
+	case changes < r.period:
+		r.sumGain += gain
+		r.sumLoss += loss
+	case changes == r.period:
+		r.avgGain = (r.sumGain + gain) / float64(r.period)
+		r.avgLoss = (r.sumLoss + loss) / float64(r.period)
+	default:
+		p := float64(r.period)
+		r.avgGain = (r.avgGain*(p-1) + gain) / p
+		r.avgLoss = (r.avgLoss*(p-1) + loss) / p
+	}
+	return r.Value()
+}
+
+// Value returns the RSI, or 50 until period changes have been seen
+func (r *RSIStream) Value() float64 {
+	if !r.Ready() {
+		return 50
+	}
+	if r.avgLoss == 0 {
+		if r.avgGain == 0 {
+			return 50
+		}
+		return 100
+	}
+	rs := r.avgGain / r.avgLoss
+	return 100 - (100 / (1 + rs))
+}
+
+// Ready reports whether period price changes have been seen
+func (r *RSIStream) Ready() bool { return r.count > r.period }
+
+// ATRStream is Wilder's Average True Range
+type ATRStream struct {
+	period    int
+	prevClose float64
+	count     int
+	sum       float64
+	value     float64
+}
+
+// NewATRStream creates a Wilder ATR
+func NewATRStream(period int) *ATRStream {
+	if period < 1 {
+		period = 1
+	}
+	return &ATRStream{period: period}
+}
+
+// Update adds a bar and returns the new ATR
+func (a *ATRStream) Update(high, low, close float64) float64 {
+	tr := high - low
+	if a.count > 0 {
+		tr = trueRange(high, low, a.prevClose)
+	}
+	a.prevClose = close
+	a.count++
+
+	if a.count <= a.period {
+		a.sum += tr
+		a.value = a.sum / float64(a.count)
+		return a.value
+	}
+	p := float64(a.period)
+	a.value = (a.value*(p-1) + tr) / p
+	return a.value
+}
+
+// Value returns the ATR (the mean true range until Ready)
+func (a *ATRStream) Value() float64 { return a.value }
+
+// Ready reports whether period bars have been seen
+func (a *ATRStream) Ready() bool { return a.count >= a.period }
+
+// ADXStream is Wilder's Average Directional Index with its +DI/-DI lines
+type ADXStream struct {
+	period    int
+	count     int // bars seen
+	prevHigh  float64
+	prevLow   float64
+	prevClose float64
+
+	smoothTR    float64
+	smoothPlus  float64
+	smoothMinus float64
+
+	dxCount int
+	dxSum   float64
+	adx     float64
+
+	plusDI  float64
+	minusDI float64
+}
+
+// NewADXStream creates a Wilder ADX
+func NewADXStream(period int) *ADXStream {
+	if period < 1 {
+		period = 1
+	}
+	return &ADXStream{period: period}
+}
+
+// Update adds a bar and returns the new ADX
+func (a *ADXStream) Update(high, low, close float64) float64 {
+	a.count++
+	if a.count == 1 {
+		a.prevHigh, a.prevLow, a.prevClose = high, low, close
+		return 0
+	}
+
+	upMove := high - a.prevHigh
+	downMove := a.prevLow - low
+	plusDM, minusDM := 0.0, 0.0
+	if upMove > downMove && upMove > 0 {
+		plusDM = upMove
+	}
+	if downMove > upMove && downMove > 0 {
+		minusDM = downMove
+	}
+	tr := trueRange(high, low, a.prevClose)
+	a.prevHigh, a.prevLow, a.prevClose = high, low, close
+
+	// Wilder smoothing: the first value is the sum of period bars
+	p := float64(a.period)
+	moves := a.count - 1
+	if moves <= a.period {
+		a.smoothTR += tr
+		a.smoothPlus += plusDM
+		a.smoothMinus += minusDM
+		if moves < a.period {
+			return 0
+		}
+	} else {
+		a.smoothTR = a.smoothTR - a.smoothTR/p + tr
+		a.smoothPlus = a.smoothPlus - a.smoothPlus/p + plusDM
+		a.smoothMinus = a.smoothMinus - a.smoothMinus/p + minusDM
+	}
+
+	dx := 0.0
+	if a.smoothTR > 0 {
+		a.plusDI = a.smoothPlus / a.smoothTR * 100
+		a.minusDI = a.smoothMinus / a.smoothTR * 100
+		if sum := a.plusDI + a.minusDI; sum > 0 {
+			dx = math.Abs(a.plusDI-a.minusDI) / sum * 100
+		}
+	}
+
+	// ADX: mean of the first period DX values, then Wilder smoothing
+	a.dxCount++
+	if a.dxCount <= a.period {
+		a.dxSum += dx
+		a.adx = a.dxSum / float64(a.dxCount)
+	} else {
+		a.adx = (a.adx*(p-1) + dx) / p
+	}
+	return a.adx
+}
+
+// Value returns the ADX (the mean DX until Ready, 0 before the first DX)
+func (a *ADXStream) Value() float64 { return a.adx }
+
+// PlusDI returns the current +DI
+func (a *ADXStream) PlusDI() float64 { return a.plusDI }
+
+// MinusDI returns the current -DI
+func (a *ADXStream) MinusDI() float64 { return a.minusDI }
+
+// Ready reports whether a full period of DX values has been averaged
+func (a *ADXStream) Ready() bool { return a.dxCount >= a.period }
+
+// MACDStream is the fast/slow EMA difference with an EMA signal line
+type MACDStream struct {
+	fast   *EMAStream
+	slow   *EMAStream
+	signal *EMAStream
+	macd   float64
+}
+
+// NewMACDStream creates a MACD (12, 26, 9 is the classic setting)
+func NewMACDStream(fast, slow, signal int) *MACDStream {
+	return &MACDStream{fast: NewEMAStream(fast), slow: NewEMAStream(slow), signal: NewEMAStream(signal)}
+}
+
+// Update adds a close and returns the MACD line, signal line and histogram
+func (m *MACDStream) Update(close float64) (float64, float64, float64) {
+	m.macd = m.fast.Update(close) - m.slow.Update(close)
+	// The signal line only averages MACD values once the slow EMA is seeded
+	if m.slow.Ready() {
+		m.signal.Update(m.macd)
+	}
+	return m.Value()
+}
+
+// Value returns the MACD line, signal line and histogram.
+// Until the signal line has data it equals the MACD line.
+func (m *MACDStream) Value() (float64, float64, float64) {
+	signal := m.macd
+	if m.signal.count > 0 {
+		signal = m.signal.Value()
+	}
+	return m.macd, signal, m.macd - signal
+}
+
+// Ready reports whether the signal line is seeded
+func (m *MACDStream) Ready() bool { return m.slow.Ready() && m.signal.Ready() }
+
+func trueRange(high, low, prevClose float64) float64 {
+	return math.Max(high-low, math.Max(math.Abs(high-prevClose), math.Abs(low-prevClose)))
+}
@@ -0,0 +1,277 @@
+package indicators
+
+// HistoryLen is how many past values a Tracker keeps per indicator
+const HistoryLen = 64
+
+// Tracker keeps a set of streaming indicators in step with a candle window.
+//
+// Sync is called with the strategy's window on every bar. When the window
+// continues the one seen last time (same last bar, new bars appended, as in
+// a sliding or expanding backtest window) only the new bars are fed, so each
+// indicator costs O(1) per bar. Anything else (a gap, a rewritten last bar, a
+// different symbol) resets the tracker and replays the window. Indicators are
+// registered on first use and warmed up from the current window.
+//
+// A Tracker is not safe for concurrent use; keep one per run and strategy.
+type Tracker struct {
+	window    []Candle
+	lastTime  int64
+	lastClose float64
+	bars      int
+
+	series map[seriesKey]*trackedSeries
+	macds  map[[3]int]*MACDStream
+	adxs   map[int]*ADXStream
+	avwaps map[AnchorSpec]*trackedAVWAP
+}
+
+type seriesKey struct {
+	kind   string
+	period int
+}
+
+// trackedSeries is one indicator plus a ring of its recent values
+type trackedSeries struct {
+	update func(c Candle) float64
+	ring   [HistoryLen]float64
+	pos    int
+	count  int
+}
+
+func (t *trackedSeries) feed(c Candle) {
+	t.ring[t.pos] = t.update(c)
+	t.pos = (t.pos + 1) % HistoryLen
+	if t.count < HistoryLen {
+		t.count++
+	}
+}
+
+// at returns the value back bars ago (0 = current)
+func (t *trackedSeries) at(back int) float64 {
+	if t.count == 0 {
+		return 0
+	}
+	if back >= t.count {
+		back = t.count - 1
+	}
+	return t.ring[(t.pos-1-back+HistoryLen)%HistoryLen]
+}
+
+// trackedAVWAP is one anchored VWAP plus a ring of its recent readings
+type trackedAVWAP struct {
+	vwap  *AnchoredVWAP
+	ring  [HistoryLen]VWAPBands
+	ready [HistoryLen]bool
+	pos   int
+	count int
+}
+
+func (t *trackedAVWAP) feed(c Candle) {
+	t.vwap.Update(c)
+	t.ring[t.pos], t.ready[t.pos] = t.vwap.Value(), t.vwap.Ready()
+	t.pos = (t.pos + 1) % HistoryLen
+	if t.count < HistoryLen {
+		t.count++
+	}
+}
+
+// at returns the reading back bars ago (0 = current); ok is false beyond the
+// kept history or while the VWAP was not ready
+func (t *trackedAVWAP) at(back int) (VWAPBands, bool) {
+	if back < 0 || back >= t.count {
+		return VWAPBands{Anchor: t.vwap.spec.String()}, false
+	}
+	i := (t.pos - 1 - back + HistoryLen) % HistoryLen
+	return t.ring[i], t.ready[i]
+}
+
+// NewTracker creates an empty indicator tracker
+func NewTracker() *Tracker {
+	return &Tracker{
+		series: make(map[seriesKey]*trackedSeries),
+		macds:  make(map[[3]int]*MACDStream),
+		adxs:   make(map[int]*ADXStream),
+		avwaps: make(map[AnchorSpec]*trackedAVWAP),
+	}
+}
+
+// Sync advances every registered indicator to the last candle of the window
+func (s *Tracker) Sync(candles []Candle) {
+	if len(candles) == 0 {
+		return
+	}
+
+	start := s.continuation(candles)
+	if start < 0 {
+		s.reset()
+		start = 0
+	}
+	for _, c := range candles[start:] {
+		s.feed(c)
+	}
+
+	last := candles[len(candles)-1]
+	s.window = candles
+	s.lastTime = last.Timestamp
+	s.lastClose = last.Close
+}
+
+// continuation returns the index of the first unseen bar, or -1 when the
+// window does not continue the previously synced one
+func (s *Tracker) continuation(candles []Candle) int {
+	if s.bars == 0 {
+		return -1
+	}
+	for i := len(candles) - 1; i >= 0 && candles[i].Timestamp >= s.lastTime; i-- {
+		if candles[i].Timestamp == s.lastTime {
+			if candles[i].Close != s.lastClose {
+				return -1 // the last bar was still forming when it was fed
+			}
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func (s *Tracker) reset() {
+	s.bars = 0
+	for key := range s.series {
+		s.series[key] = s.newSeries(key)
+	}
+	for key := range s.macds {
+		s.macds[key] = NewMACDStream(key[0], key[1], key[2])
+	}
+	for period := range s.adxs {
+		s.adxs[period] = NewADXStream(period)
+	}
+	for spec := range s.avwaps {
+		s.avwaps[spec] = &trackedAVWAP{vwap: NewAnchoredVWAP(spec)}
+	}
+}
+
+func (s *Tracker) feed(c Candle) {
+	s.bars++
+	for _, t := range s.series {
+		t.feed(c)
+	}
+	for _, m := range s.macds {
+		m.Update(c.Close)
+	}
+	for _, a := range s.adxs {
+		a.Update(c.High, c.Low, c.Close)
+	}
+	for _, v := range s.avwaps {
+		v.feed(c)
+	}
+}
+
+func (s *Tracker) newSeries(key seriesKey) *trackedSeries {
+	t := &trackedSeries{}
+	switch key.kind {
+	case "sma":
+		sma := NewSMAStream(key.period)
+		t.update = func(c Candle) float64 { return sma.Update(c.Close) }
+	case "ema":
+		ema := NewEMAStream(key.period)
+		t.update = func(c Candle) float64 { return ema.Update(c.Close) }
+	case "rsi":
+		rsi := NewRSIStream(key.period)
+		t.update = func(c Candle) float64 { return rsi.Update(c.Close) }
+	case "atr":
+		atr := NewATRStream(key.period)
+		t.update = func(c Candle) float64 { return atr.Update(c.High, c.Low, c.Close) }
+	case "volume":
+		sma := NewSMAStream(key.period)
+		t.update = func(c Candle) float64 { return sma.Update(c.Volume) }
+	}
+	return t
+}
+
+// get returns a tracked series, registering and warming it up on first use
+func (s *Tracker) get(kind string, period int) *trackedSeries {
+	key := seriesKey{kind: kind, period: period}
+	if t, ok := s.series[key]; ok {
+		return t
+	}
+	t := s.newSeries(key)
+	for _, c := range s.window {
+		t.feed(c)
+	}
+	s.series[key] = t
+	return t
+}
+
+// Bars returns how many candles have been fed since the last reset
+func (s *Tracker) Bars() int { return s.bars }
+
+// SMA returns the simple moving average of closes at the last synced candle
+func (s *Tracker) SMA(period int) float64 { return s.get("sma", period).at(0) }
+
+// EMA returns the exponential moving average of closes at the last synced candle
+func (s *Tracker) EMA(period int) float64 { return s.get("ema", period).at(0) }
+
+// EMAAt returns the EMA back bars before the last synced candle (back < HistoryLen)
+func (s *Tracker) EMAAt(period, back int) float64 { return s.get("ema", period).at(back) }
+
+// RSI returns Wilder's RSI at the last synced candle
+func (s *Tracker) RSI(period int) float64 { return s.get("rsi", period).at(0) }
+
+// ATR returns Wilder's ATR at the last synced candle
+func (s *Tracker) ATR(period int) float64 { return s.get("atr", period).at(0) }
+
+// AverageVolume returns the simple average volume over the last period candles
+func (s *Tracker) AverageVolume(period int) float64 { return s.get("volume", period).at(0) }
+
+// ADX returns Wilder's ADX at the last synced candle
+func (s *Tracker) ADX(period int) float64 {
+	a, ok := s.adxs[period]
+	if !ok {
+		a = NewADXStream(period)
+		for _, c := range s.window {
+			a.Update(c.High, c.Low, c.Close)
+		}
+		s.adxs[period] = a
+	}
+	return a.Value()
+}
+
+// MACD returns the MACD line, signal line and histogram at the last synced candle
+func (s *Tracker) MACD(fast, slow, signal int) (float64, float64, float64) {
+	key := [3]int{fast, slow, signal}
+	m, ok := s.macds[key]
+	if !ok {
+		m = NewMACDStream(fast, slow, signal)
+		for _, c := range s.window {
+			m.Update(c.Close)
+		}
+		s.macds[key] = m
+	}
+	return m.Value()
+}
+
+// AVWAP returns the anchored VWAP and bands at the last synced candle. An
+// anchor registered mid-run is warmed up from the current window only, so
+// calendar anchors older than the window start at the window's first bar.
+// Bars is 0 for an invalid spec or while no anchor has formed.
+func (s *Tracker) AVWAP(spec AnchorSpec) VWAPBands {
+	bands, _ := s.AVWAPAt(spec, 0)
+	return bands
+}
+
+// AVWAPAt returns the anchored VWAP back bars before the last synced candle
+// (back < HistoryLen). ok is false for an invalid spec, beyond the kept
+// history or while the VWAP has no volume since its anchor.
+func (s *Tracker) AVWAPAt(spec AnchorSpec, back int) (VWAPBands, bool) {
+	if err := spec.Validate(); err != nil {
+		return VWAPBands{Anchor: spec.String()}, false
+	}
+	v, ok := s.avwaps[spec]
+	if !ok {
+		v = &trackedAVWAP{vwap: NewAnchoredVWAP(spec)}
+		for _, c := range s.window {
+			v.feed(c)
+		}
+		s.avwaps[spec] = v
+	}
+	return v.at(back)
+}
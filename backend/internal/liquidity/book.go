@@ -0,0 +1,467 @@
+package liquidity
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"tradebot/backend/internal/indicators"
+	"tradebot/backend/internal/profile"
+)
+
+// ==================== LIQUIDITY LEDGER ====================
+// Keeps every identified liquidity level (session, day and week highs and
+// lows, swing highs and lows) from the bar it became known until it is
+// swept, instead of recomputing pools from the current window. A Book is fed
+// closed bars incrementally and remembers which levels were taken and how.
+
+// Level sources
+const (
+	SourceSession = "session"
+	SourceDay     = "day"
+	SourceWeek    = "week"
+	SourceSwing   = "swing"
+)
+
+// Level sides, named like ict.LiquidityPool: buy stops rest above highs,
+// sell stops below lows
+const (
+	Buyside  = "buyside"
+	Sellside = "sellside"
+)
+
+// sourceStrength is the base strength of a level by source; every equal
+// high / low merged into a swing level adds equalTouchStrength
+var sourceStrength = map[string]float64{
+	SourceSession: 60,
+	SourceDay:     75,
+	SourceWeek:    90,
+	SourceSwing:   55,
+}
+
+const equalTouchStrength = 10
+
+// Config controls level detection and retention
+type Config struct {
+	SwingStrength  int     `json:"swingStrength"`  // Bars on each side of a swing pivot (default: 2)
+	EqualTolerance float64 `json:"equalTolerance"` // Relative distance at which swing levels merge as equal highs / lows (default: 0.001)
+	RetentionDays  int     `json:"retentionDays"`  // Levels and sweeps older than this are dropped (default: 30)
+}
+
+// DefaultConfig matches the swing and equal-level rules of FindLiquidityPools
+func DefaultConfig() Config {
+	return Config{SwingStrength: 2, EqualTolerance: 0.001, RetentionDays: 30}
+}
+
+// Validate fills zero swing strength, equal-level tolerance and retention with
+// defaults. It rejects swing strengths outside 1..20, tolerances outside 0..5% and
+// retention under a day.
+func (cfg *Config) Validate() error {
+	def := DefaultConfig()
+	if cfg.SwingStrength == 0 {
+		cfg.SwingStrength = def.SwingStrength
+	}
+	if cfg.EqualTolerance == 0 {
+		cfg.EqualTolerance = def.EqualTolerance
+	}
+	if cfg.RetentionDays == 0 {
+		cfg.RetentionDays = def.RetentionDays
+	}
+	if cfg.SwingStrength < 1 || cfg.SwingStrength > 20 {
+		return fmt.Errorf("swingStrength must be between 1 and 20, got %d", cfg.SwingStrength)
+	}
+	if cfg.EqualTolerance < 0 || cfg.EqualTolerance > 0.05 {
+		return fmt.Errorf("equalTolerance must be between 0 and 0.05, got %g", cfg.EqualTolerance)
+	}
+	if cfg.RetentionDays < 1 {
+		return fmt.Errorf("retentionDays must be at least 1, got %d", cfg.RetentionDays)
+	}
+	return nil
+}
+
+// Level is one resting pool of stops
+type Level struct {
+	ID        int     `json:"id"`
+	Source    string  `json:"source"`
+	Label     string  `json:"label"` // "2026-10-18 London high", "2026-10-18 day low", "equal highs", ...
+	Side      string  `json:"side"`
+	Price     float64 `json:"price"`
+	FormedAt  int64   `json:"formedAt"`  // Bar that printed the high / low
+	CreatedAt int64   `json:"createdAt"` // When it became known: period end or pivot confirmation
+	Touches   int     `json:"touches"`   // Swing levels: equal highs / lows merged into it
+	Strength  float64 `json:"strength"`
+	Swept     bool    `json:"swept"`
+	SweptAt   int64   `json:"sweptAt,omitempty"`
+}
+
+// Sweep records the bar that took a level
+type Sweep struct {
+	LevelID  int     `json:"levelId"`
+	Source   string  `json:"source"`
+	Label    string  `json:"label"`
+	Side     string  `json:"side"`
+	Level    float64 `json:"level"`
+	Time     int64   `json:"time"`
+	Extreme  float64 `json:"extreme"` // High (buyside) or low (sellside) that traded through
+	Close    float64 `json:"close"`
+	Rejected bool    `json:"rejected"` // Closed back inside the level: a stop hunt rather than a breakout
+}
+
+// openRange is the running high and low of a session, day or week
+type openRange struct {
+	Source string  `json:"source"`
+	Label  string  `json:"label"`
+	End    int64   `json:"end"`
+	High   float64 `json:"high"`
+	Low    float64 `json:"low"`
+	HighAt int64   `json:"highAt"`
+	LowAt  int64   `json:"lowAt"`
+}
+
+// Book is the liquidity ledger of one market. The exported fields are its
+// persisted state; a Book is not safe for concurrent use.
+type Book struct {
+	Symbol    string  `json:"symbol"`
+	Timeframe string  `json:"timeframe"`
+	Config    Config  `json:"config"`
+	LastTime  int64   `json:"lastTime"` // Last bar fed
+	LastClose float64 `json:"lastClose"`
+	NextID    int     `json:"nextId"`
+	Levels    []Level `json:"levels"` // Oldest first
+	Sweeps    []Sweep `json:"sweeps"` // Oldest first
+
+	Ranges []openRange `json:"ranges"` // Periods still forming
+	Recent []Candle    `json:"recent"` // Last 2*SwingStrength+1 bars
+}
+
+// NewBook creates an empty book; the config must be validated
+func NewBook(symbol, timeframe string, cfg Config) *Book {
+	return &Book{Symbol: symbol, Timeframe: timeframe, Config: cfg, NextID: 1, Levels: []Level{}, Sweeps: []Sweep{}}
+}
+
+// Continues reports whether candles extend the bars already fed: the last
+// fed bar is present and unchanged. An empty book continues anything.
+func (b *Book) Continues(candles []Candle) bool {
+	if b.LastTime == 0 {
+		return true
+	}
+	for i := len(candles) - 1; i >= 0 && candles[i].Timestamp >= b.LastTime; i-- {
+		if candles[i].Timestamp == b.LastTime {
+			return candles[i].Close == b.LastClose
+		}
+	}
+	return false
+}
+
+// Update feeds the bars newer than the last one fed and returns how many
+// levels were created and swept. Bars must be closed and in time order.
+func (b *Book) Update(candles []Candle) (created, swept int) {
+	nextID, sweeps := b.NextID, len(b.Sweeps)
+	for _, c := range candles {
+		if c.Timestamp <= b.LastTime {
+			continue
+		}
+		b.closeRanges(c.Timestamp)
+		b.sweep(c)
+		b.extendRanges(c)
+		b.detectSwing(c)
+		b.LastTime, b.LastClose = c.Timestamp, c.Close
+	}
+	created, swept = b.NextID-nextID, len(b.Sweeps)-sweeps
+	b.prune()
+	return created, swept
+}
+
+// closeRanges turns every period that ended at or before ts into a high and
+// a low level
+func (b *Book) closeRanges(ts int64) {
+	open := b.Ranges[:0]
+	for _, r := range b.Ranges {
+		if r.End > ts {
+			open = append(open, r)
+			continue
+		}
+		b.addLevel(r.Source, r.Label+" high", Buyside, r.High, r.HighAt, r.End)
+		b.addLevel(r.Source, r.Label+" low", Sellside, r.Low, r.LowAt, r.End)
+	}
+	b.Ranges = open
+}
+
+// sweep marks every level the bar traded through
+func (b *Book) sweep(c Candle) {
+	for i := range b.Levels {
+		l := &b.Levels[i]
+		if l.Swept || l.CreatedAt > c.Timestamp || b.expired(*l, c.Timestamp) {
+			continue
+		}
+		s := Sweep{LevelID: l.ID, Source: l.Source, Label: l.Label, Side: l.Side, Level: l.Price, Time: c.Timestamp, Close: c.Close}
+		switch {
+		case l.Side == Buyside && c.High > l.Price:
+			s.Extreme, s.Rejected = c.High, c.Close < l.Price
+		case l.Side == Sellside && c.Low < l.Price:
+			s.Extreme, s.Rejected = c.Low, c.Close > l.Price
+		default:
+			continue
+		}
+		l.Swept, l.SweptAt = true, c.Timestamp
+		b.Sweeps = append(b.Sweeps, s)
+	}
+}
+
+// extendRanges adds the bar to the session, day and week it belongs to
+func (b *Book) extendRanges(c Candle) {
+	for _, p := range periodsOf(c.Timestamp) {
+		found := false
+		for i := range b.Ranges {
+			r := &b.Ranges[i]
+			if r.Source != p.Source || r.End != p.End {
+				continue
+			}
+			found = true
+			if c.High > r.High {
+				r.High, r.HighAt = c.High, c.Timestamp
+			}
+			if c.Low < r.Low {
+				r.Low, r.LowAt = c.Low, c.Timestamp
+			}
+		}
+		if !found {
+			p.High, p.Low, p.HighAt, p.LowAt = c.High, c.Low, c.Timestamp, c.Timestamp
+			b.Ranges = append(b.Ranges, p)
+		}
+	}
+}
+
+// detectSwing confirms the pivot SwingStrength bars back, merging it into an
+// untouched swing level of the same side within EqualTolerance
+func (b *Book) detectSwing(c Candle) {
+	n := b.Config.SwingStrength
+	b.Recent = append(b.Recent, c)
+	if len(b.Recent) > 2*n+1 {
+		b.Recent = b.Recent[1:]
+	}
+	if len(b.Recent) < 2*n+1 {
+		return
+	}
+
+	pivot := b.Recent[n]
+	high, low := true, true
+	for i, r := range b.Recent {
+		if i != n {
+			high = high && pivot.High > r.High
+			low = low && pivot.Low < r.Low
+		}
+	}
+	if high {
+		b.addSwing(Buyside, pivot.High, pivot.Timestamp, c.Timestamp)
+	}
+	if low {
+		b.addSwing(Sellside, pivot.Low, pivot.Timestamp, c.Timestamp)
+	}
+}
+
+func (b *Book) addSwing(side string, price float64, formedAt, createdAt int64) {
+	for i := range b.Levels {
+		l := &b.Levels[i]
+		if l.Source == SourceSwing && l.Side == side && !l.Swept && !b.expired(*l, createdAt) && math.Abs(l.Price-price) <= l.Price*b.Config.EqualTolerance {
+			l.Touches++
+			l.Strength = math.Min(100, l.Strength+equalTouchStrength)
+			l.Label = "equal highs"
+			if side == Sellside {
+				l.Label = "equal lows"
+			}
+			return
+		}
+	}
+	label := "swing high"
+	if side == Sellside {
+		label = "swing low"
+	}
+	b.addLevel(SourceSwing, label, side, price, formedAt, createdAt)
+}
+
+func (b *Book) addLevel(source, label, side string, price float64, formedAt, createdAt int64) {
+	b.Levels = append(b.Levels, Level{
+		ID:        b.NextID,
+		Source:    source,
+		Label:     label,
+		Side:      side,
+		Price:     price,
+		FormedAt:  formedAt,
+		CreatedAt: createdAt,
+		Touches:   1,
+		Strength:  sourceStrength[source],
+	})
+	b.NextID++
+}
+
+// expired reports whether a level is past the retention window at ts. Expired
+// levels are ignored before they are pruned, so results do not depend on how
+// the bars were batched.
+func (b *Book) expired(l Level, ts int64) bool {
+	return l.CreatedAt < ts-int64(b.Config.RetentionDays)*dayMs
+}
+
+// prune drops levels and sweeps older than the retention window
+func (b *Book) prune() {
+	cutoff := b.LastTime - int64(b.Config.RetentionDays)*dayMs
+	levels := b.Levels[:0]
+	for _, l := range b.Levels {
+		if l.CreatedAt >= cutoff {
+			levels = append(levels, l)
+		}
+	}
+	b.Levels = levels
+	sweeps := b.Sweeps[:0]
+	for _, s := range b.Sweeps {
+		if s.Time >= cutoff {
+			sweeps = append(sweeps, s)
+		}
+	}
+	b.Sweeps = sweeps
+}
+
+// ==================== QUERIES ====================
+
+// All returns every retained level, swept or not, optionally only of the given sources
+func (b *Book) All(sources ...string) []Level {
+	levels := []Level{}
+	for _, l := range b.Levels {
+		if hasSource(sources, l.Source) {
+			levels = append(levels, l)
+		}
+	}
+	return levels
+}
+
+// Active returns the untouched levels, optionally only of the given sources
+func (b *Book) Active(sources ...string) []Level {
+	return b.ActiveAt(b.LastTime, sources...)
+}
+
+// ActiveAt returns the levels that were known and untouched at ts, so
+// earlier bars can be queried from a book that has moved on
+func (b *Book) ActiveAt(ts int64, sources ...string) []Level {
+	active := []Level{}
+	for _, l := range b.Levels {
+		if l.CreatedAt <= ts && (!l.Swept || l.SweptAt > ts) && !b.expired(l, ts) && hasSource(sources, l.Source) {
+			active = append(active, l)
+		}
+	}
+	return active
+}
+
+// Nearest returns the closest untouched level above and below price (nil when none)
+func (b *Book) Nearest(price float64, sources ...string) (*Level, *Level) {
+	return b.NearestAt(price, b.LastTime, sources...)
+}
+
+// NearestAt is Nearest as of ts
+func (b *Book) NearestAt(price float64, ts int64, sources ...string) (*Level, *Level) {
+	var above, below *Level
+	for _, l := range b.ActiveAt(ts, sources...) {
+		l := l
+		if l.Price > price && (above == nil || l.Price < above.Price) {
+			above = &l
+		}
+		if l.Price < price && (below == nil || l.Price > below.Price) {
+			below = &l
+		}
+	}
+	return above, below
+}
+
+// SweptSince returns the sweeps at or after ts, optionally only of the given sources
+func (b *Book) SweptSince(ts int64, sources ...string) []Sweep {
+	sweeps := []Sweep{}
+	for _, s := range b.Sweeps {
+		if s.Time >= ts && hasSource(sources, s.Source) {
+			sweeps = append(sweeps, s)
+		}
+	}
+	return sweeps
+}
+
+// Summary is a compact view of a book around the current price
+type Summary struct {
+	Symbol       string  `json:"symbol"`
+	Timeframe    string  `json:"timeframe"`
+	LastTime     int64   `json:"lastTime"`
+	Levels       int     `json:"levels"`
+	Active       int     `json:"active"`
+	NearestAbove *Level  `json:"nearestAbove,omitempty"`
+	NearestBelow *Level  `json:"nearestBelow,omitempty"`
+	RecentSweeps []Sweep `json:"recentSweeps"` // Newest first
+}
+
+// Summarize returns the nearest untouched levels around price and the last
+// recent sweeps
+func (b *Book) Summarize(price float64, recent int) Summary {
+	s := Summary{Symbol: b.Symbol, Timeframe: b.Timeframe, LastTime: b.LastTime, Levels: len(b.Levels), RecentSweeps: []Sweep{}}
+	s.Active = len(b.Active())
+	s.NearestAbove, s.NearestBelow = b.Nearest(price)
+	for i := len(b.Sweeps) - 1; i >= 0 && len(s.RecentSweeps) < recent; i-- {
+		s.RecentSweeps = append(s.RecentSweeps, b.Sweeps[i])
+	}
+	return s
+}
+
+// clone returns a deep copy safe to hand out while the original keeps updating
+func (b *Book) clone() *Book {
+	c := *b
+	c.Levels = append([]Level{}, b.Levels...)
+	c.Sweeps = append([]Sweep{}, b.Sweeps...)
+	c.Ranges = append([]openRange{}, b.Ranges...)
+	c.Recent = append([]Candle{}, b.Recent...)
+	return &c
+}
+
+// ==================== PERIODS ====================
+
+const (
+	hourMs = int64(time.Hour / time.Millisecond)
+	dayMs  = 24 * hourMs
+)
+
+// periodsOf returns the session (if any), day and week a bar belongs to,
+// using the profile sessions and Monday-based UTC weeks
+func periodsOf(ts int64) []openRange {
+	day := indicators.AnchorPeriodStart(indicators.AnchorDay, ts)
+	date := time.UnixMilli(day).UTC().Format("2006-01-02")
+	periods := []openRange{}
+	hour := int((ts - day) / hourMs)
+	for _, s := range profile.Sessions {
+		if hour >= s.StartHour && hour < s.EndHour {
+			periods = append(periods, openRange{Source: SourceSession, Label: date + " " + s.Name, End: day + int64(s.EndHour)*hourMs})
+		}
+	}
+	week := indicators.AnchorPeriodStart(indicators.AnchorWeek, ts)
+	return append(periods,
+		openRange{Source: SourceDay, Label: date + " day", End: day + dayMs},
+		openRange{Source: SourceWeek, Label: "week of " + time.UnixMilli(week).UTC().Format("2006-01-02"), End: week + 7*dayMs},
+	)
+}
+
+// ClosedCandles drops a trailing bar that is still forming at now (ms)
+func ClosedCandles(candles []Candle, now int64) []Candle {
+	if len(candles) < 2 {
+		return candles
+	}
+	barMs := candles[1].Timestamp - candles[0].Timestamp
+	if last := candles[len(candles)-1]; last.Timestamp+barMs > now {
+		return candles[:len(candles)-1]
+	}
+	return candles
+}
+
+func hasSource(sources []string, source string) bool {
+	if len(sources) == 0 {
+		return true
+	}
+	for _, s := range sources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package liquidity
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultLedgerFile is used when LIQUIDITY_LEDGER_FILE is not set
+const DefaultLedgerFile = "./liquidity_ledger.json"
+
+// LedgerFile returns the file the ledger is persisted to
+func LedgerFile() string {
+	if file := os.Getenv("LIQUIDITY_LEDGER_FILE"); file != "" {
+		return file
+	}
+	return DefaultLedgerFile
+}
+
+// Ledger keeps one Book per symbol and timeframe, persisted as JSON so swept
+// and untouched levels survive restarts
+type Ledger struct {
+	path  string
+	books map[string]*Book
+	mu    sync.Mutex
+}
+
+var ledger = NewLedger(LedgerFile())
+
+// GetLedger returns the shared ledger
+func GetLedger() *Ledger {
+	return ledger
+}
+
+// NewLedger creates an empty ledger backed by path ("" keeps it in memory)
+func NewLedger(path string) *Ledger {
+	return &Ledger{
+		path:  path,
+		books: make(map[string]*Book),
+	}
+}
+
+func bookKey(symbol, timeframe string) string {
+	return strings.ToUpper(symbol) + ":" + timeframe
+}
+
+// Load reads persisted books; a missing file is not an error
+func (l *Ledger) Load() (int, error) {
+	if l.path == "" {
+		return 0, nil
+	}
+	data, err := os.ReadFile(l.path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var books []*Book
+	if err := json.Unmarshal(data, &books); err != nil {
+		return 0, fmt.Errorf("%s: %w", l.path, err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, b := range books {
+		l.books[bookKey(b.Symbol, b.Timeframe)] = b
+	}
+	return len(books), nil
+}
+
+// Update feeds closed candles into the market's book, creating it on first
+// use, persists the ledger when levels were created or swept and returns a
+// copy of the book. Bars the book has already seen are skipped; bars missing
+// between its last bar and the first candle cannot be recovered, so callers
+// should fetch enough history to cover downtime.
+func (l *Ledger) Update(symbol, timeframe string, candles []Candle) (*Book, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := bookKey(symbol, timeframe)
+	b, ok := l.books[key]
+	if !ok {
+		b = NewBook(strings.ToUpper(symbol), timeframe, DefaultConfig())
+		l.books[key] = b
+	}
+	created, swept := b.Update(candles)
+	if created > 0 || swept > 0 {
+		if err := l.save(); err != nil {
+			return b.clone(), err
+		}
+	}
+	return b.clone(), nil
+}
+
+// Get returns a copy of a market's book
+func (l *Ledger) Get(symbol, timeframe string) (*Book, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.books[bookKey(symbol, timeframe)]
+	if !ok {
+		return nil, false
+	}
+	return b.clone(), true
+}
+
+// Reset drops a market's book and persists the ledger
+func (l *Ledger) Reset(symbol, timeframe string) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := bookKey(symbol, timeframe)
+	if _, ok := l.books[key]; !ok {
+		return false, nil
+	}
+	delete(l.books, key)
+	return true, l.save()
+}
+
+// List summarizes every book ordered by symbol and timeframe
+func (l *Ledger) List() []Summary {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	list := make([]Summary, 0, len(l.books))
+	for _, b := range l.books {
+		list = append(list, b.Summarize(b.LastClose, 0))
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Symbol != list[j].Symbol {
+			return list[i].Symbol < list[j].Symbol
+		}
+		return list[i].Timeframe < list[j].Timeframe
+	})
+	return list
+}
+
+func (l *Ledger) save() error {
+	if l.path == "" {
+		return nil
+	}
+	books := make([]*Book, 0, len(l.books))
+	for _, b := range l.books {
+		books = append(books, b)
+	}
+	sort.Slice(books, func(i, j int) bool {
+		return bookKey(books[i].Symbol, books[i].Timeframe) < bookKey(books[j].Symbol, books[j].Timeframe)
+	})
+
+	data, err := json.MarshalIndent(books, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(l.path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, l.path)
+}
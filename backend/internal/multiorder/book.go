@@ -0,0 +1,173 @@
+package multiorder
+
+import (
+	"fmt"
+	"math"
+)
+
+// ==================== MULTI-ORDER STRATEGIES ====================
+// Grid and DCA strategies work a book of resting orders instead of emitting
+// one signal at a time. Several orders can fill on the same bar and every
+// fill averages into a single long inventory.
+
+// Order sides and types
+const (
+	Buy    = "BUY"
+	Sell   = "SELL"
+	Limit  = "limit"
+	Market = "market" // Filled at the next bar's open
+)
+
+// Order is a resting or pending order
+type Order struct {
+	ID       int     `json:"id"`
+	Side     string  `json:"side"`
+	Type     string  `json:"type"`
+	Price    float64 `json:"price"` // Limit price; 0 for market orders
+	Quantity float64 `json:"quantity"`
+	Tag      string  `json:"tag"`             // "grid", "base", "safety", "take_profit", "exit"
+	Level    int     `json:"level,omitempty"` // Grid level index
+}
+
+// Fill is an executed order
+type Fill struct {
+	Order
+	Index     int     `json:"index"`
+	Timestamp int64   `json:"timestamp"`
+	FillPrice float64 `json:"fillPrice"`
+	Fee       float64 `json:"fee"`
+	Realized  float64 `json:"realized"` // PnL realized by a sell against the average cost, after its fee
+}
+
+// Position is the averaged long inventory
+type Position struct {
+	Quantity float64 `json:"quantity"`
+	AvgPrice float64 `json:"avgPrice"`
+}
+
+// Book holds a strategy's cash, inventory and orders. The backtest engine
+// fills orders through Execute; strategies place and cancel them on bar close.
+type Book struct {
+	Cash     float64
+	Position Position
+	Realized float64
+	Fees     float64
+	Orders   []Order
+	Fills    []Fill // Fills of the current bar, reset before each bar
+	nextID   int
+}
+
+// NewBook creates a book holding only cash
+func NewBook(cash float64) *Book {
+	return &Book{Cash: cash}
+}
+
+// Place adds an order and returns its ID
+func (b *Book) Place(side, orderType string, price, quantity float64, tag string, level int) int {
+	b.nextID++
+	b.Orders = append(b.Orders, Order{
+		ID:       b.nextID,
+		Side:     side,
+		Type:     orderType,
+		Price:    price,
+		Quantity: quantity,
+		Tag:      tag,
+		Level:    level,
+	})
+	return b.nextID
+}
+
+// Cancel removes an order; it reports whether the order was resting
+func (b *Book) Cancel(id int) bool {
+	for i, o := range b.Orders {
+		if o.ID == id {
+			b.Orders = append(b.Orders[:i], b.Orders[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// CancelWhere removes every order the predicate matches
+func (b *Book) CancelWhere(match func(o Order) bool) {
+	kept := b.Orders[:0]
+	for _, o := range b.Orders {
+		if !match(o) {
+			kept = append(kept, o)
+		}
+	}
+	b.Orders = kept
+}
+
+// Has reports whether an order matching the predicate is resting
+func (b *Book) Has(match func(o Order) bool) bool {
+	for _, o := range b.Orders {
+		if match(o) {
+			return true
+		}
+	}
+	return false
+}
+
+// Equity marks the book to a price
+func (b *Book) Equity(price float64) float64 {
+	return b.Cash + b.Position.Quantity*price
+}
+
+// Execute fills a resting order at a price, updating cash and inventory.
+// Buys need the cash and sells the inventory; an order that cannot be
+// covered is cancelled and an error returned.
+func (b *Book) Execute(id int, price, feeRate float64, index int, timestamp int64) (Fill, error) {
+	var order Order
+	found := false
+	for _, o := range b.Orders {
+		if o.ID == id {
+			order, found = o, true
+			break
+		}
+	}
+	if !found {
+		return Fill{}, fmt.Errorf("order %d is not resting", id)
+	}
+	b.Cancel(id)
+
+	fill := Fill{Order: order, Index: index, Timestamp: timestamp, FillPrice: price}
+	notional := order.Quantity * price
+	fill.Fee = notional * feeRate
+
+	switch order.Side {
+	case Buy:
+		if notional+fill.Fee > b.Cash+1e-9 {
+			return Fill{}, fmt.Errorf("insufficient cash for %s order %d", order.Tag, id)
+		}
+		total := b.Position.Quantity + order.Quantity
+		b.Position.AvgPrice = (b.Position.AvgPrice*b.Position.Quantity + notional) / total
+		b.Position.Quantity = total
+		b.Cash -= notional + fill.Fee
+	case Sell:
+		if order.Quantity > b.Position.Quantity+1e-9 {
+			return Fill{}, fmt.Errorf("insufficient inventory for %s order %d", order.Tag, id)
+		}
+		fill.Realized = order.Quantity*(price-b.Position.AvgPrice) - fill.Fee
+		b.Position.Quantity = math.Max(0, b.Position.Quantity-order.Quantity)
+		if b.Position.Quantity < 1e-12 {
+			b.Position = Position{}
+		}
+		b.Cash += notional - fill.Fee
+		b.Realized += fill.Realized
+	}
+	b.Fees += fill.Fee
+	b.Fills = append(b.Fills, fill)
+	return fill, nil
+}
+
+// Strategy is a multi-order strategy driven bar by bar
+type Strategy interface {
+	Name() string
+	WarmupBars() int
+	// OnBar runs after the bar's fills are in book.Fills; candles end at the
+	// closed bar. Orders placed here can fill from the next bar on.
+	OnBar(candles []Candle, book *Book)
+	// Stats reports strategy-specific counters for the backtest result
+	Stats() map[string]float64
+}
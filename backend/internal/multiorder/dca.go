@@ -0,0 +1,225 @@
+package multiorder
+
+import (
+	"fmt"
+	"math"
+
+	"tradebot/backend/internal/regime"
+)
+
+// DCAConfig configures a dollar-cost-averaging accumulator with safety orders
+type DCAConfig struct {
+	BaseOrder      float64 `json:"baseOrder"`      // Fraction of equity for the opening market buy (default: 0.05)
+	SafetyOrder    float64 `json:"safetyOrder"`    // Fraction of equity for the first safety order (default: 0.05)
+	SafetyOrders   int     `json:"safetyOrders"`   // Safety orders per cycle (default: 5)
+	PriceDeviation float64 `json:"priceDeviation"` // % below the base fill of the first safety order (default: 1.5)
+	StepScale      float64 `json:"stepScale"`      // Multiplier of each next safety order's deviation step (default: 1.4)
+	VolumeScale    float64 `json:"volumeScale"`    // Multiplier of each next safety order's size (default: 1.5)
+	TakeProfit     float64 `json:"takeProfit"`     // % above the average price to sell everything (default: 1.5)
+	StopLoss       float64 `json:"stopLoss"`       // % below the average price to exit once every safety order filled (0 = never)
+	CooldownBars   int     `json:"cooldownBars"`   // Bars to wait between cycles
+	RangingOnly    bool    `json:"rangingOnly"`    // Only start cycles while the HMM regime is ranging
+}
+
+// DefaultDCAConfig risks about 71% of equity over a base and 5 safety orders
+func DefaultDCAConfig() DCAConfig {
+	return DCAConfig{
+		BaseOrder:      0.05,
+		SafetyOrder:    0.05,
+		SafetyOrders:   5,
+		PriceDeviation: 1.5,
+		StepScale:      1.4,
+		VolumeScale:    1.5,
+		TakeProfit:     1.5,
+		RangingOnly:    true,
+	}
+}
+
+// Validate fills zero fields with defaults. It rejects more than 20 safety
+// orders, step or volume scales below 1, a deepest safety order 100% or more
+// below the base fill and orders that together commit more than all equity.
+func (cfg *DCAConfig) Validate() error {
+	def := DefaultDCAConfig()
+	if cfg.BaseOrder == 0 {
+		cfg.BaseOrder = def.BaseOrder
+	}
+	if cfg.SafetyOrder == 0 {
+		cfg.SafetyOrder = def.SafetyOrder
+	}
+	if cfg.SafetyOrders == 0 {
+		cfg.SafetyOrders = def.SafetyOrders
+	}
+	if cfg.PriceDeviation == 0 {
+		cfg.PriceDeviation = def.PriceDeviation
+	}
+	if cfg.StepScale == 0 {
+		cfg.StepScale = def.StepScale
+	}
+	if cfg.VolumeScale == 0 {
+		cfg.VolumeScale = def.VolumeScale
+	}
+	if cfg.TakeProfit == 0 {
+		cfg.TakeProfit = def.TakeProfit
+	}
+	if cfg.SafetyOrders < 0 || cfg.SafetyOrders > 20 {
+		return fmt.Errorf("safetyOrders must be between 0 and 20, got %d", cfg.SafetyOrders)
+	}
+	if cfg.StepScale < 1 || cfg.VolumeScale < 1 {
+		return fmt.Errorf("stepScale and volumeScale must be at least 1")
+	}
+	if deepest := cfg.SafetyDeviations(); len(deepest) > 0 && deepest[len(deepest)-1] >= 100 {
+		return fmt.Errorf("deepest safety order is %.1f%% below the base fill", deepest[len(deepest)-1])
+	}
+	if total := cfg.Allocation(); total > 1 {
+		return fmt.Errorf("base and safety orders commit %.0f%% of equity, more than 100%%", total*100)
+	}
+	return nil
+}
+
+// SafetyDeviations returns the cumulative % below the base fill of every safety order
+func (cfg DCAConfig) SafetyDeviations() []float64 {
+	deviations := make([]float64, cfg.SafetyOrders)
+	step, total := cfg.PriceDeviation, 0.0
+	for i := range deviations {
+		total += step
+		deviations[i] = total
+		step *= cfg.StepScale
+	}
+	return deviations
+}
+
+// Allocation returns the fraction of equity committed when every order fills
+func (cfg DCAConfig) Allocation() float64 {
+	total := cfg.BaseOrder
+	for i := 0; i < cfg.SafetyOrders; i++ {
+		total += cfg.SafetyOrder * math.Pow(cfg.VolumeScale, float64(i))
+	}
+	return total
+}
+
+// DCA opens with a market buy, averages down with scaled safety orders and
+// sells the whole position at a take profit above the average price
+type DCA struct {
+	cfg       DCAConfig
+	inCycle   bool
+	equity    float64 // Equity at cycle start; sizes every order of the cycle
+	safeties  int     // Safety orders filled this cycle
+	cooldown  int
+	detector  *regime.Detector
+	cycles    int
+	stopOuts  int
+	maxSafety int
+}
+
+// NewDCA creates a DCA strategy
+func NewDCA(cfg DCAConfig) (*DCA, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	d := &DCA{cfg: cfg}
+	if cfg.RangingOnly {
+		d.detector = regime.NewDetector(regime.DefaultConfig())
+	}
+	return d, nil
+}
+
+// Name implements Strategy
+func (d *DCA) Name() string { return "dca" }
+
+// WarmupBars implements Strategy
+func (d *DCA) WarmupBars() int {
+	if d.detector != nil {
+		return regime.DefaultConfig().MinBars
+	}
+	return 1
+}
+
+// Stats implements Strategy
+func (d *DCA) Stats() map[string]float64 {
+	return map[string]float64{
+		"cycles":          float64(d.cycles),
+		"stopOuts":        float64(d.stopOuts),
+		"maxSafetyFilled": float64(d.maxSafety),
+	}
+}
+
+// OnBar implements Strategy
+func (d *DCA) OnBar(candles []Candle, book *Book) {
+	last := candles[len(candles)-1]
+	ranging := true
+	if d.detector != nil {
+		d.detector.Sync(candles)
+		ranging = d.detector.State().Regime == regime.Ranging
+	}
+
+	// Buy fills first: a safety that filled on the bar the take profit or exit
+	// filled belongs to the cycle being closed, not to the next one
+	fills := make([]Fill, 0, len(book.Fills))
+	for _, side := range []string{Buy, Sell} {
+		for _, f := range book.Fills {
+			if f.Side == side {
+				fills = append(fills, f)
+			}
+		}
+	}
+
+	for _, f := range fills {
+		switch f.Tag {
+		case "base":
+			for i, deviation := range d.cfg.SafetyDeviations() {
+				price := f.FillPrice * (1 - deviation/100)
+				size := d.equity * d.cfg.SafetyOrder * math.Pow(d.cfg.VolumeScale, float64(i))
+				book.Place(Buy, Limit, price, size/price, "safety", i+1)
+			}
+			d.placeTakeProfit(book)
+		case "safety":
+			d.safeties++
+			if d.safeties > d.maxSafety {
+				d.maxSafety = d.safeties
+			}
+			d.placeTakeProfit(book)
+		case "take_profit", "exit":
+			book.CancelWhere(func(o Order) bool { return true })
+			if pos := book.Position; pos.Quantity > 0 {
+				// The sell was sized before a same-bar safety filled; the
+				// cycle ends once the rest is closed too
+				book.Place(Sell, Market, 0, pos.Quantity, "exit", 0)
+				continue
+			}
+			d.inCycle = false
+			d.safeties = 0
+			d.cooldown = d.cfg.CooldownBars
+			d.cycles++
+		}
+	}
+
+	if d.inCycle {
+		pos := book.Position
+		if d.cfg.StopLoss > 0 && d.safeties == d.cfg.SafetyOrders && pos.Quantity > 0 &&
+			last.Close < pos.AvgPrice*(1-d.cfg.StopLoss/100) && !book.Has(func(o Order) bool { return o.Tag == "exit" }) {
+			book.CancelWhere(func(o Order) bool { return true })
+			book.Place(Sell, Market, 0, pos.Quantity, "exit", 0)
+			d.stopOuts++
+		}
+		return
+	}
+
+	if d.cooldown > 0 {
+		d.cooldown--
+		return
+	}
+	if !ranging || last.Close <= 0 {
+		return
+	}
+	d.equity = book.Equity(last.Close)
+	book.Place(Buy, Market, 0, d.equity*d.cfg.BaseOrder/last.Close, "base", 0)
+	d.inCycle = true
+}
+
+// placeTakeProfit re-places the sell for the whole position at the new average
+func (d *DCA) placeTakeProfit(book *Book) {
+	book.CancelWhere(func(o Order) bool { return o.Tag == "take_profit" })
+	if pos := book.Position; pos.Quantity > 0 {
+		book.Place(Sell, Limit, pos.AvgPrice*(1+d.cfg.TakeProfit/100), pos.Quantity, "take_profit", 0)
+	}
+}
@@ -0,0 +1,236 @@
+package multiorder
+
+import (
+	"fmt"
+	"math"
+
+	"tradebot/backend/internal/regime"
+)
+
+// GridConfig configures a long-only grid between two bounds
+type GridConfig struct {
+	Levels          int     `json:"levels"`          // Price levels between the bounds, inclusive (default: 10)
+	Spacing         string  `json:"spacing"`         // "arithmetic" (equal price steps) or "geometric" (equal % steps)
+	Bounds          string  `json:"bounds"`          // "value_area" (volume profile VAL-VAH) or "swing" (recent low-high)
+	Lookback        int     `json:"lookback"`        // Bars the bounds are measured on (default: 200)
+	CapitalPerLevel float64 `json:"capitalPerLevel"` // Fraction of equity bought per level (default: 1/levels)
+	MaxInventory    int     `json:"maxInventory"`    // Max bought levels held, resting buys included (default: levels/2)
+	BreakoutPercent float64 `json:"breakoutPercent"` // Liquidate and rebuild once price leaves the bounds by this % (default: 2)
+	RangingOnly     bool    `json:"rangingOnly"`     // Only buy while the HMM regime is ranging
+}
+
+// DefaultGridConfig is a 10-level arithmetic grid on the value area
+func DefaultGridConfig() GridConfig {
+	return GridConfig{
+		Levels:          10,
+		Spacing:         "arithmetic",
+		Bounds:          "value_area",
+		Lookback:        200,
+		BreakoutPercent: 2,
+		RangingOnly:     true,
+	}
+}
+
+// Validate fills zero fields with defaults, sizing each level at 1/Levels of
+// equity and capping inventory at half the levels. It rejects fewer than 3 or
+// more than 100 levels, unknown spacing or bounds, lookbacks under 20 bars and
+// inventory that could commit more than all equity.
+func (cfg *GridConfig) Validate() error {
+	def := DefaultGridConfig()
+	if cfg.Levels == 0 {
+		cfg.Levels = def.Levels
+	}
+	if cfg.Spacing == "" {
+		cfg.Spacing = def.Spacing
+	}
+	if cfg.Bounds == "" {
+		cfg.Bounds = def.Bounds
+	}
+	if cfg.Lookback == 0 {
+		cfg.Lookback = def.Lookback
+	}
+	if cfg.BreakoutPercent == 0 {
+		cfg.BreakoutPercent = def.BreakoutPercent
+	}
+	if cfg.Levels < 3 || cfg.Levels > 100 {
+		return fmt.Errorf("levels must be between 3 and 100, got %d", cfg.Levels)
+	}
+	if cfg.CapitalPerLevel == 0 {
+		cfg.CapitalPerLevel = 1 / float64(cfg.Levels)
+	}
+	if cfg.MaxInventory == 0 {
+		cfg.MaxInventory = cfg.Levels / 2
+	}
+	if cfg.Spacing != "arithmetic" && cfg.Spacing != "geometric" {
+		return fmt.Errorf("spacing must be arithmetic or geometric, got %q", cfg.Spacing)
+	}
+	if cfg.Bounds != "value_area" && cfg.Bounds != "swing" {
+		return fmt.Errorf("bounds must be value_area or swing, got %q", cfg.Bounds)
+	}
+	if cfg.Lookback < 20 {
+		return fmt.Errorf("lookback must be at least 20 bars, got %d", cfg.Lookback)
+	}
+	if cfg.CapitalPerLevel <= 0 || cfg.CapitalPerLevel*float64(cfg.MaxInventory) > 1 {
+		return fmt.Errorf("capitalPerLevel x maxInventory must be within (0, 1], got %.2f x %d", cfg.CapitalPerLevel, cfg.MaxInventory)
+	}
+	return nil
+}
+
+// GridLevels returns levels prices from lower to upper, inclusive
+func GridLevels(lower, upper float64, levels int, spacing string) []float64 {
+	prices := make([]float64, levels)
+	for i := range prices {
+		f := float64(i) / float64(levels-1)
+		if spacing == "geometric" {
+			prices[i] = lower * math.Pow(upper/lower, f)
+		} else {
+			prices[i] = lower + (upper-lower)*f
+		}
+	}
+	return prices
+}
+
+// GridBounds measures the grid range on the last lookback candles: the
+// volume profile value area, or the swing low and high. The value area falls
+// back to swings when no profile can be built.
+func GridBounds(candles []Candle, bounds string, lookback int) (float64, float64) {
+	if len(candles) > lookback {
+		candles = candles[len(candles)-lookback:]
+	}
+	if bounds == "value_area" {
+		if vp := CalculateVolumeProfile(candles, 20); vp != nil && vp.VAH > vp.VAL {
+			return vp.VAL, vp.VAH
+		}
+	}
+	lower, upper := math.Inf(1), math.Inf(-1)
+	for _, c := range candles {
+		lower = math.Min(lower, c.Low)
+		upper = math.Max(upper, c.High)
+	}
+	return lower, upper
+}
+
+// Grid buys at each level below price and sells one level higher. It pauses
+// buying outside ranging regimes and liquidates when price breaks out.
+type Grid struct {
+	cfg        GridConfig
+	levels     []float64
+	orderValue float64      // Quote spent per level, fixed when the grid is built
+	held       map[int]bool // Levels bought and waiting for their sell
+	active     bool
+	detector   *regime.Detector
+	builds     int
+	breakouts  int
+}
+
+// NewGrid creates a grid strategy
+func NewGrid(cfg GridConfig) (*Grid, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	g := &Grid{cfg: cfg, held: make(map[int]bool)}
+	if cfg.RangingOnly {
+		g.detector = regime.NewDetector(regime.DefaultConfig())
+	}
+	return g, nil
+}
+
+// Name implements Strategy
+func (g *Grid) Name() string { return "grid" }
+
+// WarmupBars implements Strategy
+func (g *Grid) WarmupBars() int { return g.cfg.Lookback }
+
+// Stats implements Strategy
+func (g *Grid) Stats() map[string]float64 {
+	return map[string]float64{
+		"builds":    float64(g.builds),
+		"breakouts": float64(g.breakouts),
+	}
+}
+
+// Levels returns the active grid prices (nil while no grid is built)
+func (g *Grid) Levels() []float64 {
+	if !g.active {
+		return nil
+	}
+	return g.levels
+}
+
+// OnBar implements Strategy
+func (g *Grid) OnBar(candles []Candle, book *Book) {
+	last := candles[len(candles)-1]
+	ranging := true
+	if g.detector != nil {
+		g.detector.Sync(candles)
+		ranging = g.detector.State().Regime == regime.Ranging
+	}
+
+	for _, f := range book.Fills {
+		switch {
+		case f.Tag == "grid" && f.Side == Buy:
+			g.held[f.Level] = true
+			book.Place(Sell, Limit, g.levels[f.Level+1], f.Quantity, "grid", f.Level+1)
+		case f.Tag == "grid" && f.Side == Sell:
+			delete(g.held, f.Level-1)
+		}
+	}
+
+	if g.active {
+		lower, upper := g.levels[0], g.levels[len(g.levels)-1]
+		margin := g.cfg.BreakoutPercent / 100
+		if last.Close > upper*(1+margin) || last.Close < lower*(1-margin) {
+			g.liquidate(book)
+			g.breakouts++
+			return
+		}
+	}
+
+	if !g.active {
+		if book.Position.Quantity > 0 || len(candles) < g.cfg.Lookback || !ranging {
+			return // Wait for the exit to fill, more history or a range
+		}
+		lower, upper := GridBounds(candles, g.cfg.Bounds, g.cfg.Lookback)
+		if !(upper > lower && lower > 0) || last.Close < lower || last.Close > upper {
+			return
+		}
+		g.levels = GridLevels(lower, upper, g.cfg.Levels, g.cfg.Spacing)
+		g.orderValue = book.Equity(last.Close) * g.cfg.CapitalPerLevel
+		g.active = true
+		g.builds++
+	}
+
+	if !ranging {
+		book.CancelWhere(func(o Order) bool { return o.Tag == "grid" && o.Side == Buy })
+		return
+	}
+
+	// Keep buys resting on the nearest free levels below price
+	resting := 0
+	for _, o := range book.Orders {
+		if o.Tag == "grid" && o.Side == Buy {
+			resting++
+		}
+	}
+	for k := len(g.levels) - 2; k >= 0; k-- {
+		if len(g.held)+resting >= g.cfg.MaxInventory {
+			break
+		}
+		price := g.levels[k]
+		if price >= last.Close || g.held[k] || book.Has(func(o Order) bool { return o.Tag == "grid" && o.Side == Buy && o.Level == k }) {
+			continue
+		}
+		book.Place(Buy, Limit, price, g.orderValue/price, "grid", k)
+		resting++
+	}
+}
+
+// liquidate cancels the grid and sells the inventory at the next open
+func (g *Grid) liquidate(book *Book) {
+	book.CancelWhere(func(o Order) bool { return o.Tag == "grid" })
+	if book.Position.Quantity > 0 && !book.Has(func(o Order) bool { return o.Tag == "exit" }) {
+		book.Place(Sell, Market, 0, book.Position.Quantity, "exit", 0)
+	}
+	g.held = make(map[int]bool)
+	g.active = false
+}
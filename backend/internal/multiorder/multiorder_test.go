@@ -0,0 +1,260 @@
+package multiorder
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBookAveragesBuysAndRealizesSells(t *testing.T) {
+	book := NewBook(1000)
+	first := book.Place(Buy, Limit, 100, 2, "grid", 0)
+	second := book.Place(Buy, Limit, 80, 2, "grid", 1)
+	if _, err := book.Execute(first, 100, 0, 1, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := book.Execute(second, 80, 0, 1, 0); err != nil {
+		t.Fatal(err)
+	}
+	if book.Position.Quantity != 4 || book.Position.AvgPrice != 90 || book.Cash != 640 {
+		t.Fatalf("unexpected book after buys: %+v cash %.2f", book.Position, book.Cash)
+	}
+	if len(book.Fills) != 2 || len(book.Orders) != 0 {
+		t.Errorf("expected 2 fills and no resting orders, got %d / %d", len(book.Fills), len(book.Orders))
+	}
+
+	sell := book.Place(Sell, Limit, 100, 3, "grid", 1)
+	fill, err := book.Execute(sell, 100, 0.001, 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(fill.Realized-(30-0.3)) > 1e-9 || math.Abs(book.Realized-fill.Realized) > 1e-9 {
+		t.Errorf("expected realized 29.70, got %.4f", fill.Realized)
+	}
+	if book.Position.Quantity != 1 || book.Position.AvgPrice != 90 {
+		t.Errorf("a sell must not move the average price, got %+v", book.Position)
+	}
+	if math.Abs(book.Equity(90)-(640+300-0.3+90)) > 1e-9 {
+		t.Errorf("unexpected equity %.4f", book.Equity(90))
+	}
+}
+
+func TestBookRejectsUncoveredOrders(t *testing.T) {
+	book := NewBook(100)
+	buy := book.Place(Buy, Limit, 50, 3, "safety", 1)
+	if _, err := book.Execute(buy, 50, 0, 0, 0); err == nil {
+		t.Error("expected a buy beyond the cash to be rejected")
+	}
+	sell := book.Place(Sell, Market, 0, 1, "exit", 0)
+	if _, err := book.Execute(sell, 50, 0, 0, 0); err == nil {
+		t.Error("expected a sell without inventory to be rejected")
+	}
+	if len(book.Orders) != 0 || book.Cash != 100 {
+		t.Errorf("rejected orders must be cancelled without touching cash, got %d orders, cash %.2f", len(book.Orders), book.Cash)
+	}
+	if _, err := book.Execute(42, 50, 0, 0, 0); err == nil {
+		t.Error("expected an error for an unknown order")
+	}
+}
+
+func TestGridLevels(t *testing.T) {
+	arithmetic := GridLevels(100, 200, 5, "arithmetic")
+	for i, want := range []float64{100, 125, 150, 175, 200} {
+		if math.Abs(arithmetic[i]-want) > 1e-9 {
+			t.Errorf("arithmetic level %d = %.2f, want %.2f", i, arithmetic[i], want)
+		}
+	}
+	geometric := GridLevels(100, 400, 3, "geometric")
+	if math.Abs(geometric[1]-200) > 1e-9 || math.Abs(geometric[2]-400) > 1e-9 {
+		t.Errorf("geometric levels should step by equal ratios, got %v", geometric)
+	}
+}
+
+func TestGridValidate(t *testing.T) {
+	cfg := GridConfig{}
+	if err := cfg.Validate(); err != nil || cfg.Levels != 10 || cfg.MaxInventory != 5 || cfg.CapitalPerLevel != 0.1 {
+		t.Errorf("unexpected defaults %+v, %v", cfg, err)
+	}
+	cfg = GridConfig{Levels: 10, CapitalPerLevel: 0.3, MaxInventory: 5}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected inventory above 100% of equity to be rejected")
+	}
+	cfg = GridConfig{Spacing: "fibonacci"}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected an unknown spacing to be rejected")
+	}
+}
+
+// rangeCandles oscillates between 90 and 110
+func rangeCandles(n int) []Candle {
+	candles := make([]Candle, n)
+	for i := range candles {
+		price := 100 + 10*math.Sin(float64(i)/4)
+		candles[i] = Candle{Timestamp: int64(i) * 3600000, Open: price, High: price + 0.5, Low: price - 0.5, Close: price, Volume: 1}
+	}
+	return candles
+}
+
+func TestGridPlacesSellOneLevelAboveBuyFill(t *testing.T) {
+	grid, err := NewGrid(GridConfig{Levels: 5, Bounds: "swing", Lookback: 40})
+	if err != nil {
+		t.Fatal(err)
+	}
+	candles := rangeCandles(40)
+	book := NewBook(1000)
+	grid.OnBar(candles, book)
+
+	levels := grid.Levels()
+	if len(levels) != 5 {
+		t.Fatalf("expected a 5-level grid, got %v", levels)
+	}
+	last := candles[len(candles)-1].Close
+	var buyID int
+	for _, o := range book.Orders {
+		if o.Side != Buy || o.Price >= last {
+			t.Errorf("expected only buys below %.2f, got %+v", last, o)
+		}
+		if o.Level == 0 {
+			buyID = o.ID
+		}
+	}
+	if len(book.Orders) == 0 || len(book.Orders) > 2 || buyID == 0 {
+		t.Fatalf("expected at most MaxInventory=2 resting buys including level 0, got %+v", book.Orders)
+	}
+
+	book.Fills = book.Fills[:0]
+	if _, err := book.Execute(buyID, levels[0], 0, 40, 0); err != nil {
+		t.Fatal(err)
+	}
+	grid.OnBar(candles, book)
+	if !book.Has(func(o Order) bool { return o.Side == Sell && o.Level == 1 && o.Price == levels[1] }) {
+		t.Errorf("expected a sell at level 1 after the level 0 buy filled, got %+v", book.Orders)
+	}
+}
+
+func TestDCASafetyLadder(t *testing.T) {
+	cfg := DefaultDCAConfig()
+	deviations := cfg.SafetyDeviations()
+	want := []float64{1.5, 3.6, 6.54, 10.656, 16.4184}
+	for i := range want {
+		if math.Abs(deviations[i]-want[i]) > 1e-9 {
+			t.Errorf("safety %d deviation = %.4f, want %.4f", i+1, deviations[i], want[i])
+		}
+	}
+	if a := cfg.Allocation(); math.Abs(a-(0.05+0.05*(1+1.5+2.25+3.375+5.0625))) > 1e-9 {
+		t.Errorf("unexpected allocation %.4f", a)
+	}
+
+	cfg.SafetyOrder = 0.2
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected an allocation above 100% to be rejected")
+	}
+}
+
+func TestDCACycle(t *testing.T) {
+	dca, err := NewDCA(DCAConfig{SafetyOrders: 2, TakeProfit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	candles := []Candle{{Close: 100}}
+	book := NewBook(1000)
+	dca.OnBar(candles, book)
+	if len(book.Orders) != 1 || book.Orders[0].Type != Market || book.Orders[0].Tag != "base" {
+		t.Fatalf("expected a market base order, got %+v", book.Orders)
+	}
+
+	if _, err := book.Execute(book.Orders[0].ID, 100, 0, 1, 0); err != nil {
+		t.Fatal(err)
+	}
+	dca.OnBar(candles, book)
+	safeties, takeProfits := 0, 0
+	for _, o := range book.Orders {
+		switch o.Tag {
+		case "safety":
+			safeties++
+		case "take_profit":
+			takeProfits++
+			if math.Abs(o.Price-101) > 1e-9 {
+				t.Errorf("take profit should sit 1%% above the base fill, got %.2f", o.Price)
+			}
+		}
+	}
+	if safeties != 2 || takeProfits != 1 {
+		t.Fatalf("expected 2 safeties and 1 take profit, got %+v", book.Orders)
+	}
+
+	var tp Order
+	for _, o := range book.Orders {
+		if o.Tag == "take_profit" {
+			tp = o
+		}
+	}
+	book.Fills = book.Fills[:0]
+	if _, err := book.Execute(tp.ID, tp.Price, 0, 2, 0); err != nil {
+		t.Fatal(err)
+	}
+	dca.OnBar(candles, book)
+	if dca.Stats()["cycles"] != 1 || book.Position.Quantity != 0 {
+		t.Errorf("expected a closed cycle and a flat book, got %v / %+v", dca.Stats(), book.Position)
+	}
+	if len(book.Orders) != 1 || book.Orders[0].Tag != "base" {
+		t.Errorf("expected the next cycle's base order, got %+v", book.Orders)
+	}
+}
+
+func TestDCASafetyAndTakeProfitOnSameBar(t *testing.T) {
+	dca, err := NewDCA(DCAConfig{SafetyOrders: 1, TakeProfit: 1, StopLoss: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	candles := []Candle{{Close: 100}}
+	book := NewBook(1000)
+	dca.OnBar(candles, book)
+	if _, err := book.Execute(book.Orders[0].ID, 100, 0, 1, 0); err != nil {
+		t.Fatal(err)
+	}
+	dca.OnBar(candles, book)
+
+	var safety, tp Order
+	for _, o := range book.Orders {
+		switch o.Tag {
+		case "safety":
+			safety = o
+		case "take_profit":
+			tp = o
+		}
+	}
+	if safety.ID == 0 || tp.ID == 0 {
+		t.Fatalf("expected a safety and a take profit, got %+v", book.Orders)
+	}
+
+	// A down bar fills the take profit at its high before the safety at its low
+	book.Fills = book.Fills[:0]
+	if _, err := book.Execute(tp.ID, tp.Price, 0, 2, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := book.Execute(safety.ID, safety.Price, 0, 2, 0); err != nil {
+		t.Fatal(err)
+	}
+	dca.OnBar(candles, book)
+	if dca.Stats()["cycles"] != 0 || dca.Stats()["maxSafetyFilled"] != 1 {
+		t.Errorf("expected the cycle to stay open with its safety counted, got %v", dca.Stats())
+	}
+	if len(book.Orders) != 1 || book.Orders[0].Tag != "exit" || book.Orders[0].Quantity != book.Position.Quantity {
+		t.Fatalf("expected a market exit for the safety's inventory, got %+v", book.Orders)
+	}
+
+	book.Fills = book.Fills[:0]
+	if _, err := book.Execute(book.Orders[0].ID, 100, 0, 3, 0); err != nil {
+		t.Fatal(err)
+	}
+	dca.OnBar(candles, book)
+	if dca.Stats()["cycles"] != 1 || dca.Stats()["stopOuts"] != 0 || book.Position.Quantity != 0 {
+		t.Errorf("expected one closed cycle and a flat book, got %v / %+v", dca.Stats(), book.Position)
+	}
+	if len(book.Orders) != 1 || book.Orders[0].Tag != "base" {
+		t.Errorf("expected the next cycle's base order with no leftover safety count, got %+v", book.Orders)
+	}
+	if dca.safeties != 0 {
+		t.Errorf("expected the safety count to reset, got %d", dca.safeties)
+	}
+}
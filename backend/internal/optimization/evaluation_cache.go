@@ -0,0 +1,99 @@
+package optimization
+
+import (
+	"fmt"
+	"sync"
+)
+
+// maxCachedEvaluations caps how many backtest results are kept in memory
+const maxCachedEvaluations = 1000
+
+// EvaluationCache shares backtest results between optimization runs. Entries
+// are keyed by the backtest config, the parameters and the candle range, so a
+// refetch with new bars never hits a stale result.
+type EvaluationCache struct {
+	results map[string]*BacktestResult
+	order   []string
+	hits    int
+	misses  int
+	mu      sync.Mutex
+
+	run func(BacktestConfig, []Candle) (*BacktestResult, error) // Backtest runner; nil runs RunBacktest
+}
+
+var evaluationCache = &EvaluationCache{
+	results: make(map[string]*BacktestResult),
+	order:   []string{},
+}
+
+// GetEvaluationCache returns the shared evaluation cache
+func GetEvaluationCache() *EvaluationCache {
+	return evaluationCache
+}
+
+// Evaluate returns the cached result of config on candles or runs the
+// backtest; cached reports whether it was a hit
+func (c *EvaluationCache) Evaluate(config BacktestConfig, candles []Candle) (result *BacktestResult, cached bool, err error) {
+	key := evaluationKey(config, candles)
+
+	c.mu.Lock()
+	if result, ok := c.results[key]; ok {
+		c.hits++
+		c.mu.Unlock()
+		return result, true, nil
+	}
+	c.misses++
+	c.mu.Unlock()
+
+	// Concurrent misses on one key both run; the results are identical
+	run := c.run
+	if run == nil {
+		run = RunBacktest
+	}
+	result, err = run(config, candles)
+	if err != nil {
+		return nil, false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.results[key]; !ok {
+		c.results[key] = result
+		c.order = append(c.order, key)
+		for len(c.order) > maxCachedEvaluations {
+			delete(c.results, c.order[0])
+			c.order = c.order[1:]
+		}
+	}
+	return result, false, nil
+}
+
+// Stats returns the entry count and the lifetime hits and misses
+func (c *EvaluationCache) Stats() (entries, hits, misses int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.results), c.hits, c.misses
+}
+
+// Clear drops every cached result
+func (c *EvaluationCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results = make(map[string]*BacktestResult)
+	c.order = []string{}
+}
+
+func evaluationKey(config BacktestConfig, candles []Candle) string {
+	first, last := int64(0), int64(0)
+	if len(candles) > 0 {
+		first, last = candles[0].Timestamp, candles[len(candles)-1].Timestamp
+	}
+	// A run on the promoted set depends on which version is live
+	live := 0
+	if config.UseLiveParams {
+		_, live = PromotedParams(config.Strategy, config.Symbol)
+	}
+	return fmt.Sprintf("%s|%s|%s|%d:%d-%d|%g|%g|%s|%s|live:%d",
+		config.Strategy, config.Symbol, config.Interval, len(candles), first, last,
+		config.StartBalance, config.RiskPercent, config.RiskProfile, paramsKey(config.Params), live)
+}
@@ -0,0 +1,102 @@
+package optimization
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEvaluationCache(t *testing.T) {
+	runs := 0
+	cache := &EvaluationCache{
+		results: make(map[string]*BacktestResult),
+		order:   []string{},
+		run: func(config BacktestConfig, candles []Candle) (*BacktestResult, error) {
+			runs++
+			if config.Params["stop_atr"] < 0 {
+				return nil, fmt.Errorf("negative stop")
+			}
+			return &BacktestResult{ReturnPercent: config.Params["stop_atr"]}, nil
+		},
+	}
+	candles := []Candle{{Timestamp: 1}, {Timestamp: 2}, {Timestamp: 3}}
+	config := BacktestConfig{Strategy: "session_trader", Symbol: "BTCUSDT", Interval: "15m", StartBalance: 1000, RiskPercent: 0.01,
+		Params: map[string]float64{"stop_atr": 1.5, "tp1_atr": 3}}
+
+	first, cached, err := cache.Evaluate(config, candles)
+	if err != nil || cached || first.ReturnPercent != 1.5 {
+		t.Fatalf("first evaluation: result %v, cached %v, err %v", first, cached, err)
+	}
+
+	// Same parameters in another map order hit
+	again := config
+	again.Params = map[string]float64{"tp1_atr": 3, "stop_atr": 1.5}
+	if result, cached, _ := cache.Evaluate(again, candles); !cached || result != first {
+		t.Errorf("expected a hit returning the cached result, got cached %v", cached)
+	}
+
+	// Anything that changes the backtest misses
+	changes := []struct {
+		name    string
+		config  BacktestConfig
+		candles []Candle
+	}{
+		{"parameter", withParam(config, "stop_atr", 2), candles},
+		{"risk", func() BacktestConfig { c := config; c.RiskPercent = 0.02; return c }(), candles},
+		{"interval", func() BacktestConfig { c := config; c.Interval = "1h"; return c }(), candles},
+		{"new bar", config, append(candles[:3:3], Candle{Timestamp: 4})},
+		{"shifted window", config, []Candle{{Timestamp: 2}, {Timestamp: 3}, {Timestamp: 4}}},
+	}
+	for _, m := range changes {
+		if _, cached, _ := cache.Evaluate(m.config, m.candles); cached {
+			t.Errorf("%s: expected a miss", m.name)
+		}
+	}
+
+	// Errors are not cached
+	bad := withParam(config, "stop_atr", -1)
+	for i := 0; i < 2; i++ {
+		if _, cached, err := cache.Evaluate(bad, candles); err == nil || cached {
+			t.Errorf("failing backtest: cached %v, err %v", cached, err)
+		}
+	}
+
+	entries, hits, misses := cache.Stats()
+	if entries != 6 || hits != 1 || misses != 8 || runs != 8 {
+		t.Errorf("expected 6 entries, 1 hit, 8 misses and 8 runs, got %d, %d, %d and %d", entries, hits, misses, runs)
+	}
+
+	cache.Clear()
+	if _, cached, _ := cache.Evaluate(config, candles); cached {
+		t.Error("expected a miss after Clear")
+	}
+}
+
+func TestEvaluationCacheEvictsOldest(t *testing.T) {
+	cache := &EvaluationCache{
+		results: make(map[string]*BacktestResult),
+		order:   []string{},
+		run: func(BacktestConfig, []Candle) (*BacktestResult, error) {
+			return &BacktestResult{}, nil
+		},
+	}
+	candles := []Candle{{Timestamp: 1}}
+	config := BacktestConfig{Strategy: "session_trader"}
+	for i := 0; i <= maxCachedEvaluations; i++ {
+		cache.Evaluate(withParam(config, "cooldown_bars", float64(i)), candles)
+	}
+
+	if entries, _, _ := cache.Stats(); entries != maxCachedEvaluations {
+		t.Errorf("expected %d entries, got %d", maxCachedEvaluations, entries)
+	}
+	if _, cached, _ := cache.Evaluate(withParam(config, "cooldown_bars", 0), candles); cached {
+		t.Error("expected the oldest entry to be evicted")
+	}
+	if _, cached, _ := cache.Evaluate(withParam(config, "cooldown_bars", float64(maxCachedEvaluations)), candles); !cached {
+		t.Error("expected the newest entry to be kept")
+	}
+}
+
+func withParam(config BacktestConfig, name string, value float64) BacktestConfig {
+	config.Params = mergeParams(config.Params, map[string]float64{name: value})
+	return config
+}
@@ -0,0 +1,462 @@
+package optimization
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLiveParamsFile is used when LIVE_PARAMS_FILE is not set
+const DefaultLiveParamsFile = "./live_params.json"
+
+// maxAuditEntries caps the persisted audit trail; the oldest entries go first
+const maxAuditEntries = 1000
+
+// Sources of a promoted parameter set
+const (
+	SourceOptimize   = "optimize"
+	SourceWorldClass = "world_class"
+	SourceManual     = "manual"
+)
+
+// LiveParamsFile returns the file promoted parameter sets are persisted to
+func LiveParamsFile() string {
+	if file := os.Getenv("LIVE_PARAMS_FILE"); file != "" {
+		return file
+	}
+	return DefaultLiveParamsFile
+}
+
+// LiveParams is one version of the parameter set traded live for a strategy
+// on a symbol. Versions are numbered from 1 per strategy and symbol and never
+// change once promoted.
+type LiveParams struct {
+	Strategy    string             `json:"strategy"`
+	Symbol      string             `json:"symbol"`
+	Interval    string             `json:"interval"`
+	Version     int                `json:"version"`
+	Params      map[string]float64 `json:"params"`
+	RiskPercent float64            `json:"riskPercent,omitempty"` // Fraction of balance per trade, when the source optimized it
+	Metrics     TrialMetrics       `json:"metrics"`
+	Objectives  map[string]float64 `json:"objectives,omitempty"`
+	Source      string             `json:"source"` // optimize, world_class or manual
+	ReportID    string             `json:"reportId,omitempty"`
+	Trial       int                `json:"trial,omitempty"`
+	Note        string             `json:"note,omitempty"`
+	PromotedAt  time.Time          `json:"promotedAt"`
+}
+
+// ParamAuditEntry records one change of the live version of a strategy on a symbol
+type ParamAuditEntry struct {
+	Time     time.Time `json:"time"`
+	Action   string    `json:"action"` // promote or rollback
+	Strategy string    `json:"strategy"`
+	Symbol   string    `json:"symbol"`
+	Version  int       `json:"version"`  // Live version after the change
+	Previous int       `json:"previous"` // Live version before the change, 0 for none
+	Source   string    `json:"source,omitempty"`
+	Note     string    `json:"note,omitempty"`
+}
+
+// paramHistory holds every version of one strategy and symbol and which is live
+type paramHistory struct {
+	versions []*LiveParams
+	current  int
+}
+
+func (h *paramHistory) live() *LiveParams {
+	return h.versions[h.current-1]
+}
+
+// liveParamsState is the persisted form of the store
+type liveParamsState struct {
+	Versions []*LiveParams     `json:"versions"`
+	Current  map[string]int    `json:"current"` // strategy:SYMBOL -> live version
+	Audit    []ParamAuditEntry `json:"audit"`
+}
+
+// LiveParamStore keeps the versioned parameter sets per strategy and symbol
+// with an audit trail of promotions and rollbacks, persisted as JSON
+type LiveParamStore struct {
+	path    string
+	history map[string]*paramHistory
+	audit   []ParamAuditEntry
+	mu      sync.RWMutex
+}
+
+var liveParamStore = NewLiveParamStore(LiveParamsFile())
+
+// GetLiveParamStore returns the shared store
+func GetLiveParamStore() *LiveParamStore {
+	return liveParamStore
+}
+
+// NewLiveParamStore creates an empty store backed by path ("" keeps it in memory)
+func NewLiveParamStore(path string) *LiveParamStore {
+	return &LiveParamStore{
+		path:    path,
+		history: make(map[string]*paramHistory),
+		audit:   []ParamAuditEntry{},
+	}
+}
+
+func liveParamsKey(strategy, symbol string) string {
+	return strategy + ":" + strings.ToUpper(symbol)
+}
+
+// Load reads persisted versions and returns how many strategy and symbol
+// pairs have a live one; a missing file is not an error
+func (s *LiveParamStore) Load() (int, error) {
+	if s.path == "" {
+		return 0, nil
+	}
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var state liveParamsState
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		// Files written before versioning hold one live set per pair
+		if err := json.Unmarshal(data, &state.Versions); err != nil {
+			return 0, fmt.Errorf("%s: %w", s.path, err)
+		}
+	} else if err := json.Unmarshal(data, &state); err != nil {
+		return 0, fmt.Errorf("%s: %w", s.path, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, set := range state.Versions {
+		key := liveParamsKey(set.Strategy, set.Symbol)
+		h, ok := s.history[key]
+		if !ok {
+			h = &paramHistory{}
+			s.history[key] = h
+		}
+		set.Version = len(h.versions) + 1
+		h.versions = append(h.versions, set)
+	}
+	for key, h := range s.history {
+		h.current = len(h.versions)
+		if v, ok := state.Current[key]; ok && v >= 1 && v <= len(h.versions) {
+			h.current = v
+		}
+	}
+	if state.Audit != nil {
+		s.audit = state.Audit
+	}
+	return len(s.history), nil
+}
+
+// Promote validates a parameter set, adds it as the next version of its
+// strategy and symbol, makes it live and persists the store. If the store
+// cannot be saved the promotion is undone.
+func (s *LiveParamStore) Promote(set *LiveParams) error {
+	if err := ValidateStrategyParams(set.Strategy, set.Params); err != nil {
+		return err
+	}
+	set.Symbol = strings.ToUpper(set.Symbol)
+	if set.Source == "" {
+		set.Source = SourceManual
+	}
+	set.PromotedAt = time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := liveParamsKey(set.Strategy, set.Symbol)
+	h, ok := s.history[key]
+	if !ok {
+		h = &paramHistory{}
+		s.history[key] = h
+	}
+	previous, audit := h.current, s.audit
+	set.Version = len(h.versions) + 1
+	h.versions = append(h.versions, set)
+	h.current = set.Version
+
+	s.record(ParamAuditEntry{
+		Time:     set.PromotedAt,
+		Action:   "promote",
+		Strategy: set.Strategy,
+		Symbol:   set.Symbol,
+		Version:  set.Version,
+		Previous: previous,
+		Source:   set.Source,
+		Note:     set.Note,
+	})
+	if err := s.save(); err != nil {
+		h.versions = h.versions[:len(h.versions)-1]
+		h.current, s.audit = previous, audit
+		if len(h.versions) == 0 {
+			delete(s.history, key)
+		}
+		return fmt.Errorf("failed to save live parameters: %w", err)
+	}
+	return nil
+}
+
+// Rollback makes an earlier version live again; version 0 steps back to the
+// version below the live one. If the store cannot be saved the live version
+// stays as it was.
+func (s *LiveParamStore) Rollback(strategy, symbol string, version int, note string) (*LiveParams, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h, ok := s.history[liveParamsKey(strategy, symbol)]
+	if !ok {
+		return nil, fmt.Errorf("no parameters promoted for %s on %s", strategy, strings.ToUpper(symbol))
+	}
+	if version == 0 {
+		version = h.current - 1
+		if version < 1 {
+			return nil, fmt.Errorf("version %d is the first for %s on %s", h.current, strategy, strings.ToUpper(symbol))
+		}
+	}
+	if version < 1 || version > len(h.versions) {
+		return nil, fmt.Errorf("version %d not found: %s on %s has %d", version, strategy, strings.ToUpper(symbol), len(h.versions))
+	}
+	if version == h.current {
+		return nil, fmt.Errorf("version %d is already live", version)
+	}
+	set := h.versions[version-1]
+	if err := ValidateStrategyParams(set.Strategy, set.Params); err != nil {
+		return nil, fmt.Errorf("version %d no longer fits the strategy: %w", version, err)
+	}
+
+	previous, audit := h.current, s.audit
+	h.current = version
+	s.record(ParamAuditEntry{
+		Time:     time.Now(),
+		Action:   "rollback",
+		Strategy: set.Strategy,
+		Symbol:   set.Symbol,
+		Version:  version,
+		Previous: previous,
+		Source:   set.Source,
+		Note:     note,
+	})
+	if err := s.save(); err != nil {
+		h.current, s.audit = previous, audit
+		return nil, fmt.Errorf("failed to save live parameters: %w", err)
+	}
+	return set, nil
+}
+
+func (s *LiveParamStore) record(entry ParamAuditEntry) {
+	s.audit = append(s.audit, entry)
+	if len(s.audit) > maxAuditEntries {
+		s.audit = s.audit[len(s.audit)-maxAuditEntries:]
+	}
+}
+
+// Get returns the live parameter set of a strategy on a symbol
+func (s *LiveParamStore) Get(strategy, symbol string) (*LiveParams, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	h, ok := s.history[liveParamsKey(strategy, symbol)]
+	if !ok {
+		return nil, false
+	}
+	return h.live(), true
+}
+
+// History returns every version of a strategy on a symbol, oldest first,
+// and the live version number
+func (s *LiveParamStore) History(strategy, symbol string) ([]*LiveParams, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	h, ok := s.history[liveParamsKey(strategy, symbol)]
+	if !ok {
+		return nil, 0
+	}
+	return append([]*LiveParams(nil), h.versions...), h.current
+}
+
+// Audit returns the audit trail newest first, filtered by strategy and
+// symbol when given
+func (s *LiveParamStore) Audit(strategy, symbol string) []ParamAuditEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := []ParamAuditEntry{}
+	for i := len(s.audit) - 1; i >= 0; i-- {
+		e := s.audit[i]
+		if strategy != "" && e.Strategy != strategy {
+			continue
+		}
+		if symbol != "" && e.Symbol != strings.ToUpper(symbol) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// List returns every live parameter set ordered by strategy and symbol
+func (s *LiveParamStore) List() []*LiveParams {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]*LiveParams, 0, len(s.history))
+	for _, h := range s.history {
+		list = append(list, h.live())
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Strategy != list[j].Strategy {
+			return list[i].Strategy < list[j].Strategy
+		}
+		return list[i].Symbol < list[j].Symbol
+	})
+	return list
+}
+
+func (s *LiveParamStore) save() error {
+	if s.path == "" {
+		return nil
+	}
+	state := liveParamsState{Versions: []*LiveParams{}, Current: make(map[string]int), Audit: s.audit}
+	keys := make([]string, 0, len(s.history))
+	for key := range s.history {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		h := s.history[key]
+		state.Versions = append(state.Versions, h.versions...)
+		state.Current[key] = h.current
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(s.path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// PromotedSet returns the live parameter set of a strategy on a symbol, or
+// nil to run the strategy's defaults. A set that no longer fits the
+// strategy's schema is ignored.
+func PromotedSet(strategy, symbol string) *LiveParams {
+	set, ok := GetLiveParamStore().Get(strategy, symbol)
+	if !ok {
+		return nil
+	}
+	if err := ValidateStrategyParams(strategy, set.Params); err != nil {
+		log.Printf("⚠️  Ignoring live parameters v%d of %s on %s: %v", set.Version, strategy, set.Symbol, err)
+		return nil
+	}
+	return set
+}
+
+// PromotedParams returns the parameters and version of PromotedSet, or nil
+// and 0 to run the strategy's defaults
+func PromotedParams(strategy, symbol string) (map[string]float64, int) {
+	set := PromotedSet(strategy, symbol)
+	if set == nil {
+		return nil, 0
+	}
+	return set.Params, set.Version
+}
+
+// PromoteTrial promotes a trial of a stored optimization report, typically a
+// point picked from its Pareto front
+func PromoteTrial(reportID string, number int, note string) (*LiveParams, error) {
+	report, ok := GetOptimizationReportStore().Get(reportID)
+	if !ok {
+		return nil, fmt.Errorf("optimization report not found: %s", reportID)
+	}
+	return promoteReportTrial(report, number, SourceOptimize, note)
+}
+
+func promoteReportTrial(report *OptimizeReport, number int, source, note string) (*LiveParams, error) {
+	for _, t := range report.Trials {
+		if t.Number != number {
+			continue
+		}
+		if t.Error != "" {
+			return nil, fmt.Errorf("trial %d failed: %s", number, t.Error)
+		}
+		set := &LiveParams{
+			Strategy:    report.Request.Strategy,
+			Symbol:      report.Request.Symbol,
+			Interval:    report.Request.Interval,
+			Params:      mergeParams(t.Params, nil), // The report keeps its own map
+			RiskPercent: report.Request.RiskPercent,
+			Metrics:     t.Metrics,
+			Objectives:  t.Objectives,
+			Source:      source,
+			ReportID:    report.ID,
+			Trial:       t.Number,
+			Note:        note,
+		}
+		if err := GetLiveParamStore().Promote(set); err != nil {
+			return nil, err
+		}
+		return set, nil
+	}
+	return nil, fmt.Errorf("trial %d not found in %s", number, report.ID)
+}
+
+// PromoteWorldClassResults promotes the best trial of each strategy's report
+// in a world-class run. Strategies without a result, or whose winner is
+// likely overfit, are skipped with the reason.
+func PromoteWorldClassResults(results *WorldClassResults, note string) ([]*LiveParams, []string) {
+	promoted := []*LiveParams{}
+	skipped := []string{}
+
+	strategies := make([]string, 0, len(results.Results))
+	for name := range results.Results {
+		strategies = append(strategies, name)
+	}
+	sort.Strings(strategies)
+
+	for _, name := range strategies {
+		result := results.Results[name]
+		switch {
+		case result.BacktestResult == nil:
+			skipped = append(skipped, fmt.Sprintf("%s: no viable result", name))
+			continue
+		case result.Diagnostics != nil && result.Diagnostics.Overfit:
+			skipped = append(skipped, fmt.Sprintf("%s: likely overfit (deflated Sharpe %.2f)", name, result.Diagnostics.DeflatedSharpe))
+			continue
+		}
+
+		report := result.report
+		if report == nil {
+			var ok bool
+			if report, ok = GetOptimizationReportStore().Get(result.ReportID); !ok {
+				skipped = append(skipped, fmt.Sprintf("%s: optimization report not found: %s", name, result.ReportID))
+				continue
+			}
+		}
+		set, err := promoteReportTrial(report, result.BestTrial, SourceWorldClass, note)
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		promoted = append(promoted, set)
+	}
+	return promoted, skipped
+}
@@ -0,0 +1,198 @@
+package optimization
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testLiveStrategy is a component schema so promotions validate without the
+// strategy registry
+const testLiveStrategy = "live_params_test"
+
+func init() {
+	RegisterParamSchema(testLiveStrategy, func() []ParamSpec {
+		return []ParamSpec{
+			{Name: "stop_atr", Type: "float", Default: 1, Min: 0.5, Max: 3, Step: 0.25},
+			{Name: "tp1_atr", Type: "float", Default: 2, Min: 1, Max: 6, Step: 0.5},
+		}
+	})
+}
+
+func liveSet(stop float64, note string) *LiveParams {
+	return &LiveParams{
+		Strategy: testLiveStrategy,
+		Symbol:   "btcusdt",
+		Params:   map[string]float64{"stop_atr": stop, "tp1_atr": 3},
+		Note:     note,
+	}
+}
+
+func TestLiveParamStorePromoteAndRollback(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "live_params.json")
+	s := NewLiveParamStore(path)
+
+	for i, stop := range []float64{1, 1.5, 2} {
+		set := liveSet(stop, "")
+		if err := s.Promote(set); err != nil {
+			t.Fatal(err)
+		}
+		if set.Version != i+1 || set.Symbol != "BTCUSDT" || set.Source != SourceManual {
+			t.Fatalf("promotion %d: version %d symbol %s source %s", i+1, set.Version, set.Symbol, set.Source)
+		}
+	}
+	if err := s.Promote(&LiveParams{Strategy: testLiveStrategy, Symbol: "BTCUSDT", Params: map[string]float64{"unknown": 1}}); err == nil {
+		t.Error("expected a parameter outside the schema to be rejected")
+	}
+
+	rollbacks := []struct {
+		name    string
+		version int
+		want    int // Live version after, 0 for an error
+	}{
+		{"step back", 0, 2},
+		{"jump to the first", 1, 1},
+		{"nothing below the first", 0, 0},
+		{"already live", 1, 0},
+		{"unknown version", 7, 0},
+		{"forward again", 3, 3},
+	}
+	for _, rb := range rollbacks {
+		set, err := s.Rollback(testLiveStrategy, "BTCUSDT", rb.version, rb.name)
+		if rb.want == 0 {
+			if err == nil {
+				t.Errorf("%s: expected an error", rb.name)
+			}
+			continue
+		}
+		if err != nil || set.Version != rb.want {
+			t.Errorf("%s: got %v, %v; want version %d", rb.name, set, err, rb.want)
+		}
+		if live, _ := s.Get(testLiveStrategy, "btcusdt"); live.Version != rb.want {
+			t.Errorf("%s: live version %d, want %d", rb.name, live.Version, rb.want)
+		}
+	}
+	if _, err := s.Rollback("other", "BTCUSDT", 1, ""); err == nil {
+		t.Error("expected a rollback without promotions to fail")
+	}
+
+	// Newest first: three promotions then three successful rollbacks
+	audit := s.Audit(testLiveStrategy, "btcusdt")
+	want := []struct {
+		action            string
+		version, previous int
+	}{
+		{"rollback", 3, 1},
+		{"rollback", 1, 2},
+		{"rollback", 2, 3},
+		{"promote", 3, 2},
+		{"promote", 2, 1},
+		{"promote", 1, 0},
+	}
+	if len(audit) != len(want) {
+		t.Fatalf("audit %+v, want %d entries", audit, len(want))
+	}
+	for i, w := range want {
+		if e := audit[i]; e.Action != w.action || e.Version != w.version || e.Previous != w.previous {
+			t.Errorf("audit[%d] = %s v%d from v%d, want %s v%d from v%d", i, e.Action, e.Version, e.Previous, w.action, w.version, w.previous)
+		}
+	}
+	if len(s.Audit("other", "")) != 0 || len(s.Audit("", "ETHUSDT")) != 0 {
+		t.Error("expected the audit filters to exclude other strategies and symbols")
+	}
+
+	// A reload restores the versions, the live one and the audit trail
+	reloaded := NewLiveParamStore(path)
+	if n, err := reloaded.Load(); err != nil || n != 1 {
+		t.Fatalf("load: %d pairs, %v", n, err)
+	}
+	versions, current := reloaded.History(testLiveStrategy, "BTCUSDT")
+	if len(versions) != 3 || current != 3 || versions[1].Params["stop_atr"] != 1.5 {
+		t.Errorf("reloaded %d versions with v%d live", len(versions), current)
+	}
+	if len(reloaded.Audit("", "")) != len(want) {
+		t.Errorf("reloaded audit has %d entries, want %d", len(reloaded.Audit("", "")), len(want))
+	}
+}
+
+func TestLiveParamStoreKeepsMemoryWhenSaveFails(t *testing.T) {
+	dir := t.TempDir()
+	blocker := filepath.Join(dir, "file")
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	unwritable := filepath.Join(blocker, "live_params.json") // Its directory is a file
+
+	// A first promotion that cannot be saved leaves nothing behind
+	s := NewLiveParamStore(unwritable)
+	if err := s.Promote(liveSet(1, "")); err == nil {
+		t.Fatal("expected the save to fail")
+	}
+	if _, ok := s.Get(testLiveStrategy, "BTCUSDT"); ok || len(s.List()) != 0 || len(s.Audit("", "")) != 0 {
+		t.Error("a failed first promotion should leave the store empty")
+	}
+
+	s = NewLiveParamStore(filepath.Join(dir, "live_params.json"))
+	for _, stop := range []float64{1, 1.5} {
+		if err := s.Promote(liveSet(stop, "")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.path = unwritable
+
+	if err := s.Promote(liveSet(2, "")); err == nil {
+		t.Fatal("expected the save to fail")
+	}
+	versions, current := s.History(testLiveStrategy, "BTCUSDT")
+	if len(versions) != 2 || current != 2 || len(s.Audit("", "")) != 2 {
+		t.Errorf("after a failed promotion: %d versions, v%d live, %d audit entries", len(versions), current, len(s.Audit("", "")))
+	}
+
+	if _, err := s.Rollback(testLiveStrategy, "BTCUSDT", 1, ""); err == nil {
+		t.Fatal("expected the save to fail")
+	}
+	if live, _ := s.Get(testLiveStrategy, "BTCUSDT"); live.Version != 2 || len(s.Audit("", "")) != 2 {
+		t.Errorf("after a failed rollback: v%d live, %d audit entries", live.Version, len(s.Audit("", "")))
+	}
+}
+
+func TestPromoteReportTrial(t *testing.T) {
+	defer func(s *LiveParamStore) { liveParamStore = s }(liveParamStore)
+	liveParamStore = NewLiveParamStore("")
+
+	report := &OptimizeReport{
+		ID:      "opt_test",
+		Request: OptimizeRequest{Strategy: testLiveStrategy, Symbol: "ETHUSDT", Interval: "1h", RiskPercent: 0.02},
+		Trials: []Trial{
+			{Number: 1, Params: map[string]float64{"stop_atr": 1.25, "tp1_atr": 4}},
+			{Number: 2, Error: "backtest failed"},
+		},
+	}
+	set, err := promoteReportTrial(report, 1, SourceOptimize, "from test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if set.Version != 1 || set.ReportID != "opt_test" || set.Trial != 1 || set.Source != SourceOptimize || set.Interval != "1h" {
+		t.Errorf("promoted %+v", set)
+	}
+
+	// The live set owns its parameters: changing the report's leaves it alone
+	report.Trials[0].Params["stop_atr"] = 3
+	live := PromotedSet(testLiveStrategy, "ETHUSDT")
+	if live == nil || live.Params["stop_atr"] != 1.25 || live.RiskPercent != 0.02 {
+		t.Errorf("live set %+v", live)
+	}
+	if params, version := PromotedParams(testLiveStrategy, "ETHUSDT"); params["tp1_atr"] != 4 || version != 1 {
+		t.Errorf("PromotedParams = %v, v%d", params, version)
+	}
+
+	if _, err := promoteReportTrial(report, 2, SourceOptimize, ""); err == nil {
+		t.Error("expected a failed trial to be rejected")
+	}
+	if _, err := promoteReportTrial(report, 9, SourceOptimize, ""); err == nil {
+		t.Error("expected a missing trial to be rejected")
+	}
+	if params, version := PromotedParams(testLiveStrategy, "BTCUSDT"); params != nil || version != 0 {
+		t.Errorf("expected no live set on another symbol, got %v v%d", params, version)
+	}
+}
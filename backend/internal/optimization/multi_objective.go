@@ -0,0 +1,304 @@
+package optimization
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// MethodNSGA2 searches for the Pareto front of the request's metric axes
+const MethodNSGA2 = "nsga2"
+
+// DefaultParetoObjectives are the axes of a multi-objective search
+var DefaultParetoObjectives = []string{"return", "drawdown", "trades", "stability"}
+
+// MetricAxis is one objective of a multi-objective search
+type MetricAxis struct {
+	Name        string                        `json:"name"`
+	Description string                        `json:"description"`
+	Minimize    bool                          `json:"minimize"`
+	Value       func(*BacktestResult) float64 `json:"-"`
+}
+
+var metricAxes = map[string]MetricAxis{
+	"return": {
+		Name: "return", Description: "Return in percent of the start balance",
+		Value: func(r *BacktestResult) float64 { return r.ReturnPercent },
+	},
+	"drawdown": {
+		Name: "drawdown", Description: "Max drawdown in percent", Minimize: true,
+		Value: func(r *BacktestResult) float64 { return r.MaxDrawdown },
+	},
+	"trades": {
+		Name: "trades", Description: "Number of trades",
+		Value: func(r *BacktestResult) float64 { return float64(r.TotalTrades) },
+	},
+	"stability": {
+		Name: "stability", Description: "R² of the equity curve against trade count, negative when it falls",
+		Value: EquityStability,
+	},
+	"sharpe": {
+		Name: "sharpe", Description: "Per-trade Sharpe ratio",
+		Value: func(r *BacktestResult) float64 { return r.SharpeRatio },
+	},
+	"profit_factor": {
+		Name: "profit_factor", Description: "Gross profit over gross loss, capped at 10",
+		Value: func(r *BacktestResult) float64 { return math.Min(r.ProfitFactor, 10) },
+	},
+	"win_rate": {
+		Name: "win_rate", Description: "Winning trades in percent",
+		Value: func(r *BacktestResult) float64 { return r.WinRate },
+	},
+}
+
+// MetricAxes lists the available Pareto axes by name
+func MetricAxes() []MetricAxis {
+	list := make([]MetricAxis, 0, len(metricAxes))
+	for _, axis := range metricAxes {
+		list = append(list, axis)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// resolveAxes looks up Pareto axes by name
+func resolveAxes(names []string) ([]MetricAxis, error) {
+	axes := make([]MetricAxis, 0, len(names))
+	for _, name := range names {
+		axis, ok := metricAxes[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown Pareto objective: %s", name)
+		}
+		axes = append(axes, axis)
+	}
+	return axes, nil
+}
+
+// EquityStability is the R² of cumulative trade profit against trade number,
+// signed by the slope: 1 is a straight rising equity curve, -1 a straight
+// falling one and values near 0 a curve carried by a few lucky trades
+func EquityStability(result *BacktestResult) float64 {
+	n := len(result.Trades)
+	if n < 3 {
+		return 0
+	}
+
+	var sumX, sumY, sumXY, sumXX, sumYY, equity float64
+	for i, t := range result.Trades {
+		x := float64(i + 1)
+		equity += t.Profit
+		sumX += x
+		sumY += equity
+		sumXY += x * equity
+		sumXX += x * x
+		sumYY += equity * equity
+	}
+	fn := float64(n)
+	covXY := sumXY - sumX*sumY/fn
+	varX := sumXX - sumX*sumX/fn
+	varY := sumYY - sumY*sumY/fn
+	if varX <= 0 || varY <= 0 {
+		return 0
+	}
+
+	r2 := covXY * covXY / (varX * varY)
+	if covXY < 0 {
+		return -r2
+	}
+	return r2
+}
+
+// ==================== PARETO RANKING ====================
+
+// orientedObjectives returns a trial's axis values, negated where the axis
+// is minimized, so larger is better on every axis
+func orientedObjectives(t Trial, axes []MetricAxis) []float64 {
+	values := make([]float64, len(axes))
+	for i, axis := range axes {
+		values[i] = t.Objectives[axis.Name]
+		if axis.Minimize {
+			values[i] = -values[i]
+		}
+	}
+	return values
+}
+
+// dominates reports whether a is at least as good as b on every axis and
+// better on one
+func dominates(a, b []float64) bool {
+	better := false
+	for i := range a {
+		if a[i] < b[i] {
+			return false
+		}
+		if a[i] > b[i] {
+			better = true
+		}
+	}
+	return better
+}
+
+// paretoRanks sorts points into non-dominated fronts (rank 0 is the Pareto
+// front) and computes each point's crowding distance within its front
+func paretoRanks(points [][]float64) (ranks []int, crowding []float64) {
+	n := len(points)
+	ranks = make([]int, n)
+	crowding = make([]float64, n)
+	dominatedBy := make([]int, n) // How many points dominate i
+	dominating := make([][]int, n)
+	front := []int{}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if dominates(points[i], points[j]) {
+				dominating[i] = append(dominating[i], j)
+				dominatedBy[j]++
+			} else if dominates(points[j], points[i]) {
+				dominating[j] = append(dominating[j], i)
+				dominatedBy[i]++
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		if dominatedBy[i] == 0 {
+			front = append(front, i)
+		}
+	}
+
+	for rank := 0; len(front) > 0; rank++ {
+		assignCrowding(points, front, crowding)
+		next := []int{}
+		for _, i := range front {
+			ranks[i] = rank
+			for _, j := range dominating[i] {
+				dominatedBy[j]--
+				if dominatedBy[j] == 0 {
+					next = append(next, j)
+				}
+			}
+		}
+		front = next
+	}
+	return ranks, crowding
+}
+
+// assignCrowding sets the crowding distance of each point of a front: the
+// normalized size of the box its neighbours span, infinite at the extremes
+func assignCrowding(points [][]float64, front []int, crowding []float64) {
+	if len(front) == 0 {
+		return
+	}
+	for _, i := range front {
+		crowding[i] = 0
+	}
+	sorted := append([]int(nil), front...)
+	for axis := range points[front[0]] {
+		sort.SliceStable(sorted, func(a, b int) bool { return points[sorted[a]][axis] < points[sorted[b]][axis] })
+		lo, hi := points[sorted[0]][axis], points[sorted[len(sorted)-1]][axis]
+		crowding[sorted[0]] = math.Inf(1)
+		crowding[sorted[len(sorted)-1]] = math.Inf(1)
+		if hi == lo {
+			continue
+		}
+		for k := 1; k < len(sorted)-1; k++ {
+			crowding[sorted[k]] += (points[sorted[k+1]][axis] - points[sorted[k-1]][axis]) / (hi - lo)
+		}
+	}
+}
+
+// ParetoFront returns the completed trials no other trial dominates on the
+// axes, ordered by the first axis, best first
+func ParetoFront(trials []Trial, axes []MetricAxis) []Trial {
+	done := []Trial{}
+	for _, t := range trials {
+		if t.Error == "" && t.Objectives != nil {
+			done = append(done, t)
+		}
+	}
+	points := make([][]float64, len(done))
+	for i, t := range done {
+		points[i] = orientedObjectives(t, axes)
+	}
+	ranks, _ := paretoRanks(points)
+
+	front := []Trial{}
+	for i, t := range done {
+		if ranks[i] == 0 {
+			front = append(front, t)
+		}
+	}
+	sort.SliceStable(front, func(i, j int) bool {
+		return orientedObjectives(front[i], axes)[0] > orientedObjectives(front[j], axes)[0]
+	})
+	return front
+}
+
+// ==================== NSGA-II ====================
+
+// nsga2Sampler keeps a population of the best trials by Pareto rank and
+// crowding distance (elitist survival over parents and offspring) and breeds
+// each generation from binary tournaments on the same order
+type nsga2Sampler struct {
+	space        SearchSpace
+	rng          *rand.Rand
+	accept       func(map[string]float64) bool
+	random       *randomSampler
+	axes         []MetricAxis
+	population   int
+	mutationRate float64
+
+	parents []Trial
+	seen    int // Trials already merged into the population
+}
+
+func (s *nsga2Sampler) Propose(trials []Trial, n int) []map[string]float64 {
+	pool := append([]Trial(nil), s.parents...)
+	for _, t := range trials[s.seen:] {
+		if t.Error == "" && t.Objectives != nil {
+			pool = append(pool, t)
+		}
+	}
+	s.seen = len(trials)
+	if len(pool) < 2 {
+		return s.random.Propose(trials, n)
+	}
+
+	points := make([][]float64, len(pool))
+	for i, t := range pool {
+		points[i] = orientedObjectives(t, s.axes)
+	}
+	ranks, crowding := paretoRanks(points)
+	order := make([]int, len(pool))
+	for i := range order {
+		order[i] = i
+	}
+	better := func(a, b int) bool {
+		if ranks[a] != ranks[b] {
+			return ranks[a] < ranks[b]
+		}
+		return crowding[a] > crowding[b]
+	}
+	sort.SliceStable(order, func(i, j int) bool { return better(order[i], order[j]) })
+	if len(order) > s.population {
+		order = order[:s.population]
+	}
+	s.parents = make([]Trial, len(order))
+	for i, idx := range order {
+		s.parents[i] = pool[idx]
+	}
+
+	tournament := func() Trial {
+		a, b := order[s.rng.Intn(len(order))], order[s.rng.Intn(len(order))]
+		if better(b, a) {
+			a = b
+		}
+		return pool[a]
+	}
+	children := []map[string]float64{}
+	for draw := 0; len(children) < n && draw < n*maxDraws; draw++ {
+		if child := crossoverParams(s.space, s.rng, tournament(), tournament(), s.mutationRate); s.accept(child) {
+			children = append(children, child)
+		}
+	}
+	return children
+}
@@ -0,0 +1,174 @@
+package optimization
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDominates(t *testing.T) {
+	tests := []struct {
+		a, b []float64
+		want bool
+	}{
+		{[]float64{2, 2}, []float64{1, 1}, true},
+		{[]float64{2, 1}, []float64{1, 1}, true},
+		{[]float64{1, 1}, []float64{1, 1}, false}, // Equal points do not dominate
+		{[]float64{2, 0}, []float64{1, 1}, false}, // A trade-off
+		{[]float64{1, 1}, []float64{2, 2}, false},
+	}
+	for _, tt := range tests {
+		if got := dominates(tt.a, tt.b); got != tt.want {
+			t.Errorf("dominates(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestParetoRanksAndCrowding(t *testing.T) {
+	inf := math.Inf(1)
+	tests := []struct {
+		name     string
+		points   [][]float64
+		ranks    []int
+		crowding []float64
+	}{
+		{
+			// A, B, C and D trade off; B and C dominate E, which dominates F.
+			// Crowding of B is (3-1)/3 + (5-3)/4 and of C (4-2)/3 + (4-1)/4.
+			name:     "three fronts",
+			points:   [][]float64{{1, 5}, {2, 4}, {3, 3}, {4, 1}, {2, 2}, {1, 1}},
+			ranks:    []int{0, 0, 0, 0, 1, 2},
+			crowding: []float64{inf, 2.0/3 + 0.5, 2.0/3 + 0.75, inf, inf, inf},
+		},
+		{
+			name:     "duplicates share a front",
+			points:   [][]float64{{1, 1}, {1, 1}, {0, 0}},
+			ranks:    []int{0, 0, 1},
+			crowding: []float64{inf, inf, inf},
+		},
+		{
+			name:     "single objective chain",
+			points:   [][]float64{{3}, {1}, {2}},
+			ranks:    []int{0, 2, 1},
+			crowding: []float64{inf, inf, inf},
+		},
+	}
+	for _, tt := range tests {
+		ranks, crowding := paretoRanks(tt.points)
+		for i := range tt.points {
+			if ranks[i] != tt.ranks[i] {
+				t.Errorf("%s: ranks %v, want %v", tt.name, ranks, tt.ranks)
+				break
+			}
+		}
+		for i := range tt.points {
+			if got, want := crowding[i], tt.crowding[i]; got != want && math.Abs(got-want) > 1e-9 {
+				t.Errorf("%s: crowding %v, want %v", tt.name, crowding, tt.crowding)
+				break
+			}
+		}
+	}
+}
+
+func TestAssignCrowding(t *testing.T) {
+	inf := math.Inf(1)
+	tests := []struct {
+		name   string
+		points [][]float64
+		want   []float64
+	}{
+		{
+			// Interior points span two of four gaps on both axes
+			name:   "evenly spaced",
+			points: [][]float64{{0, 4}, {1, 3}, {2, 2}, {3, 1}, {4, 0}},
+			want:   []float64{inf, 1, 1, 1, inf},
+		},
+		{
+			name:   "uneven spacing",
+			points: [][]float64{{0, 4}, {1, 3}, {4, 0}},
+			want:   []float64{inf, 2, inf},
+		},
+		{
+			// A flat axis adds nothing; the other axis still counts
+			name:   "flat axis",
+			points: [][]float64{{0, 1}, {1, 1}, {3, 1}, {4, 1}},
+			want:   []float64{inf, 0.75, 0.75, inf},
+		},
+		{
+			name:   "two points",
+			points: [][]float64{{0, 1}, {1, 0}},
+			want:   []float64{inf, inf},
+		},
+	}
+	for _, tt := range tests {
+		front := make([]int, len(tt.points))
+		for i := range front {
+			front[i] = i
+		}
+		crowding := make([]float64, len(tt.points))
+		assignCrowding(tt.points, front, crowding)
+		for i := range tt.want {
+			if math.Abs(crowding[i]-tt.want[i]) > 1e-9 && crowding[i] != tt.want[i] {
+				t.Errorf("%s: crowding %v, want %v", tt.name, crowding, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestParetoFront(t *testing.T) {
+	axes, err := resolveAxes([]string{"return", "drawdown"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	trial := func(number int, ret, dd float64) Trial {
+		return Trial{Number: number, Objectives: map[string]float64{"return": ret, "drawdown": dd}}
+	}
+	trials := []Trial{
+		trial(1, 10, 5),
+		trial(2, 20, 10),
+		trial(3, 15, 12), // Trial 2 returns more with less drawdown
+		trial(4, 5, 2),
+		trial(5, 30, 25),
+		trial(6, 5, 3), // Trial 4 has the same return with less drawdown
+		{Number: 7, Error: "backtest failed"},
+		{Number: 8}, // No objectives
+	}
+
+	front := ParetoFront(trials, axes)
+	want := []int{5, 2, 1, 4} // Highest return first
+	if len(front) != len(want) {
+		t.Fatalf("front %v, want trials %v", front, want)
+	}
+	for i, tr := range front {
+		if tr.Number != want[i] {
+			t.Errorf("front position %d is trial %d, want %d", i, tr.Number, want[i])
+		}
+	}
+}
+
+func TestEquityStability(t *testing.T) {
+	trades := func(profits ...float64) *BacktestResult {
+		r := &BacktestResult{}
+		for _, p := range profits {
+			r.Trades = append(r.Trades, Trade{Profit: p})
+		}
+		return r
+	}
+	tests := []struct {
+		name   string
+		result *BacktestResult
+		want   float64
+	}{
+		{"steady gains", trades(1, 1, 1, 1), 1},
+		{"steady losses", trades(-2, -2, -2), -1},
+		// Equity 1, 0, 1, 0: cov -1, var(x) 5, var(y) 1
+		{"chop", trades(1, -1, 1, -1), -0.2},
+		{"flat equity", trades(0, 0, 0), 0},
+		{"too few trades", trades(5, 5), 0},
+	}
+	for _, tt := range tests {
+		if got := EquityStability(tt.result); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
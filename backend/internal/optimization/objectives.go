@@ -0,0 +1,110 @@
+package optimization
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// Objective scores a backtest; optimizers maximize it
+type Objective struct {
+	Name        string                        `json:"name"`
+	Description string                        `json:"description"`
+	Score       func(*BacktestResult) float64 `json:"-"`
+}
+
+var (
+	objectives   = make(map[string]Objective)
+	objectivesMu sync.RWMutex
+)
+
+// RegisterObjective adds or replaces an objective under its name
+func RegisterObjective(objective Objective) {
+	objectivesMu.Lock()
+	defer objectivesMu.Unlock()
+	objectives[objective.Name] = objective
+}
+
+// GetObjective looks up an objective by name
+func GetObjective(name string) (Objective, error) {
+	objectivesMu.RLock()
+	defer objectivesMu.RUnlock()
+	objective, ok := objectives[name]
+	if !ok {
+		return Objective{}, fmt.Errorf("unknown objective: %s", name)
+	}
+	return objective, nil
+}
+
+// Objectives lists the registered objectives by name
+func Objectives() []Objective {
+	objectivesMu.RLock()
+	defer objectivesMu.RUnlock()
+	list := make([]Objective, 0, len(objectives))
+	for _, objective := range objectives {
+		list = append(list, objective)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+func init() {
+	RegisterObjective(Objective{
+		Name:        "score",
+		Description: "Weighted blend of return, profit factor, win rate, drawdown and trade count; zero for losing runs",
+		Score:       CompositeScore,
+	})
+	RegisterObjective(Objective{
+		Name:        "return",
+		Description: "Return in percent of the start balance",
+		Score:       func(r *BacktestResult) float64 { return r.ReturnPercent },
+	})
+	RegisterObjective(Objective{
+		Name:        "sharpe",
+		Description: "Per-trade Sharpe ratio",
+		Score:       func(r *BacktestResult) float64 { return r.SharpeRatio },
+	})
+	RegisterObjective(Objective{
+		Name:        "sortino",
+		Description: "Per-trade Sortino ratio",
+		Score:       func(r *BacktestResult) float64 { return r.SortinoRatio },
+	})
+	RegisterObjective(Objective{
+		Name:        "profit_factor",
+		Description: "Gross profit over gross loss, capped at 10",
+		Score:       func(r *BacktestResult) float64 { return math.Min(r.ProfitFactor, 10) },
+	})
+	RegisterObjective(Objective{
+		Name:        "win_rate",
+		Description: "Winning trades in percent",
+		Score:       func(r *BacktestResult) float64 { return r.WinRate },
+	})
+	RegisterObjective(Objective{
+		Name:        "calmar",
+		Description: "Return over max drawdown (drawdown floored at 1%)",
+		Score:       func(r *BacktestResult) float64 { return r.ReturnPercent / math.Max(r.MaxDrawdown, 1) },
+	})
+}
+
+// CompositeScore is the hand-weighted score the optimizers have always ranked by
+func CompositeScore(result *BacktestResult) float64 {
+	if result == nil || result.TotalTrades == 0 {
+		return 0
+	}
+
+	// CRITICAL: Heavily penalize losing strategies
+	if result.ReturnPercent < 0 {
+		return 0 // Losing strategies get ZERO score
+	}
+
+	// Weighted scoring formula - Prioritize PROFITABILITY
+	score := (result.ReturnPercent * 2.0) + // Return is MOST important: 2x
+		(result.ProfitFactor * 20.0) + // Profit factor: 20x (increased)
+		(result.WinRate * 1.0) + // Win rate: 1x
+		-(result.MaxDrawdown * 3.0) + // Drawdown penalty: -3x (increased)
+		(float64(result.WinningTrades) * 2.0) + // Winning trades bonus: 2x
+		(float64(result.TotalTrades) / 5.0) // Trade count bonus: 0.2x (reduced)
+
+	return math.Max(0, score)
+}
@@ -0,0 +1,415 @@
+package optimization
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// OptimizeRequest describes one optimization run over a strategy's parameter schema
+type OptimizeRequest struct {
+	Strategy     string  `json:"strategy"`
+	Symbol       string  `json:"symbol"`
+	Interval     string  `json:"interval"` // Defaults to the strategy's timeframe
+	Days         int     `json:"days"`
+	StartBalance float64 `json:"startBalance"`
+	RiskPercent  float64 `json:"riskPercent"` // Fraction of balance risked per trade, e.g. 0.01
+
+	Method    string `json:"method"`    // grid, random, tpe, genetic or nsga2
+	Objective string `json:"objective"` // Registered objective name, e.g. "score" or "sharpe"
+	MaxTrials int    `json:"maxTrials"` // Backtests to run at most
+	MinTrades int    `json:"minTrades"` // Trials with fewer trades cannot be the best
+	Seed      int64  `json:"seed"`      // Zero picks one; the report echoes it for reruns
+	Workers   int    `json:"workers"`   // Concurrent backtests
+
+	Params []string              `json:"params,omitempty"` // Parameters to search; empty searches the whole schema
+	Ranges map[string]ParamRange `json:"ranges,omitempty"` // Narrowed bounds per parameter
+	Fixed  map[string]float64    `json:"fixed,omitempty"`  // Overrides applied to every trial
+
+	UseLiveParams bool `json:"useLiveParams,omitempty"` // Trials start from the promoted parameter set instead of the baseline
+
+	SamplerConfig
+}
+
+// DefaultOptimizeRequest returns a TPE search of the composite score
+func DefaultOptimizeRequest() OptimizeRequest {
+	return OptimizeRequest{
+		Symbol:       "BTCUSDT",
+		Days:         90,
+		StartBalance: 1000,
+		RiskPercent:  0.01,
+		Method:       MethodTPE,
+		Objective:    "score",
+		MaxTrials:    100,
+		MinTrades:    5,
+		Workers:      min(runtime.NumCPU(), 4),
+		SamplerConfig: SamplerConfig{
+			GridLevels:   5,
+			Population:   20,
+			MutationRate: 0.2,
+		},
+	}
+}
+
+// Validate fills unset fields with defaults and rejects invalid values
+func (r *OptimizeRequest) Validate() error {
+	d := DefaultOptimizeRequest()
+	if r.Strategy == "" {
+		return fmt.Errorf("strategy is required")
+	}
+	if _, ok := GetStrategy(r.Strategy); !ok {
+		return fmt.Errorf("strategy not found: %s", r.Strategy)
+	}
+	if r.Symbol == "" {
+		r.Symbol = d.Symbol
+	}
+	if r.Interval == "" {
+		r.Interval = StrategyTimeframe(r.Strategy, "15m")
+	}
+	if r.Days <= 0 {
+		r.Days = d.Days
+	}
+	if r.StartBalance <= 0 {
+		r.StartBalance = d.StartBalance
+	}
+	if r.RiskPercent <= 0 {
+		r.RiskPercent = d.RiskPercent
+	}
+	if r.RiskPercent > 0.1 {
+		return fmt.Errorf("riskPercent is a fraction of balance: %v is above 0.1", r.RiskPercent)
+	}
+	if r.Method == "" {
+		r.Method = d.Method
+	}
+	if r.Objective == "" {
+		r.Objective = d.Objective
+	}
+	if _, err := GetObjective(r.Objective); err != nil {
+		return err
+	}
+	if r.MaxTrials <= 0 {
+		r.MaxTrials = d.MaxTrials
+	}
+	if r.MaxTrials > 5000 {
+		return fmt.Errorf("maxTrials %d is above 5000", r.MaxTrials)
+	}
+	if r.MinTrades <= 0 {
+		r.MinTrades = d.MinTrades
+	}
+	if r.Seed == 0 {
+		r.Seed = time.Now().UnixNano()
+	}
+	if r.Workers <= 0 {
+		r.Workers = d.Workers
+	}
+	if r.GridLevels <= 0 {
+		r.GridLevels = d.GridLevels
+	}
+	if r.Population <= 1 {
+		r.Population = d.Population
+	}
+	if r.MutationRate <= 0 || r.MutationRate > 1 {
+		r.MutationRate = d.MutationRate
+	}
+	if r.Method == MethodNSGA2 && len(r.ParetoObjectives) == 0 {
+		r.ParetoObjectives = DefaultParetoObjectives
+	}
+	if _, err := resolveAxes(r.ParetoObjectives); err != nil {
+		return err
+	}
+	if err := ValidateStrategyParams(r.Strategy, r.Fixed); err != nil {
+		return err
+	}
+	return nil
+}
+
+// TrialMetrics are the headline numbers of a trial's backtest
+type TrialMetrics struct {
+	ReturnPercent float64 `json:"returnPercent"`
+	WinRate       float64 `json:"winRate"`
+	ProfitFactor  float64 `json:"profitFactor"`
+	MaxDrawdown   float64 `json:"maxDrawdown"`
+	SharpeRatio   float64 `json:"sharpeRatio"`
+	TotalTrades   int     `json:"totalTrades"`
+}
+
+// Trial is one evaluated parameter set. Params holds the full overrides,
+// fixed ones included, so it can be passed straight to a backtest.
+type Trial struct {
+	Number  int                `json:"number"`
+	Params  map[string]float64 `json:"params"`
+	Value   float64            `json:"value"` // Objective value; higher is better
+	Metrics TrialMetrics       `json:"metrics"`
+	Cached  bool               `json:"cached,omitempty"`
+	Error   string             `json:"error,omitempty"`
+
+	Objectives map[string]float64 `json:"objectives,omitempty"` // Pareto axis values
+
+	returns []float64 // Period returns for the overfitting diagnostics
+}
+
+// OptimizeReport is the result format of every search method
+type OptimizeReport struct {
+	ID         string          `json:"id"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	Request    OptimizeRequest `json:"request"`
+	Space      SearchSpace     `json:"space"`
+	Candles    int             `json:"candles"`
+	Trials     []Trial         `json:"trials"`
+	Best       *Trial          `json:"best"`
+	BestResult *BacktestResult `json:"bestResult,omitempty"`
+	CacheHits  int             `json:"cacheHits"`
+	Duration   string          `json:"duration"`
+	Warnings   []string        `json:"warnings,omitempty"`
+
+	// Trials no other trial beats on every Pareto objective; any of them can be promoted
+	ParetoFront []Trial `json:"paretoFront,omitempty"`
+
+	// How likely the best trial's edge is an artifact of the number of trials
+	Diagnostics *OverfitDiagnostics `json:"diagnostics,omitempty"`
+}
+
+// Optimize fetches the request's candles and runs the search
+func Optimize(req OptimizeRequest) (*OptimizeReport, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	candles, err := fetchBinanceData(req.Symbol, req.Interval, req.Days)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch data: %w", err)
+	}
+	return OptimizeCandles(req, candles)
+}
+
+// OptimizeCandles runs the search on the given candles. Backtests go through
+// the shared evaluation cache, so repeated points cost nothing.
+func OptimizeCandles(req OptimizeRequest, candles []Candle) (*OptimizeReport, error) {
+	startTime := time.Now()
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if len(candles) < 150 {
+		return nil, fmt.Errorf("insufficient data: %d candles", len(candles))
+	}
+
+	strategy, _ := GetStrategy(req.Strategy)
+	space, err := NewSearchSpace(strategy.ParamSchema(), req.Params, req.Fixed, req.Ranges)
+	if err != nil {
+		return nil, err
+	}
+	objective, _ := GetObjective(req.Objective)
+	axes, _ := resolveAxes(req.ParetoObjectives)
+	eval := &trialEvaluator{req: req, candles: candles, objective: objective, axes: axes, periods: newReturnPeriods(candles)}
+	rng := rand.New(rand.NewSource(req.Seed))
+	accept := func(point map[string]float64) bool { return feasibleParams(mergeParams(req.Fixed, point)) }
+	sampler, err := NewSampler(req.Method, space, req.SamplerConfig, rng, accept)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &OptimizeReport{
+		CreatedAt: time.Now(),
+		Request:   req,
+		Space:     space,
+		Candles:   len(candles),
+		Trials:    []Trial{},
+	}
+	log.Printf("🔬 Optimizing %s on %s %s: %s search of %s over %d parameters, %d trials",
+		req.Strategy, req.Symbol, req.Interval, req.Method, req.Objective, len(space), req.MaxTrials)
+
+	// Evolutionary searches propose a generation at a time
+	batch := req.Workers
+	if req.Method == MethodGenetic || req.Method == MethodNSGA2 {
+		batch = req.Population
+	}
+
+	seen := make(map[string]bool)
+	stale := 0
+	for len(report.Trials) < req.MaxTrials && stale < 20 {
+		proposals := sampler.Propose(report.Trials, min(batch, req.MaxTrials-len(report.Trials)))
+		if len(proposals) == 0 {
+			break
+		}
+
+		points := []map[string]float64{}
+		for _, p := range proposals {
+			params := mergeParams(req.Fixed, p)
+			if key := paramsKey(params); !seen[key] {
+				seen[key] = true
+				points = append(points, params)
+			}
+		}
+		if len(points) == 0 {
+			stale++ // Only known points proposed: the space is exhausted
+			continue
+		}
+		stale = 0
+
+		trials := eval.evaluate(points, len(report.Trials))
+		for _, t := range trials {
+			if t.Cached {
+				report.CacheHits++
+			}
+		}
+		report.Trials = append(report.Trials, trials...)
+	}
+
+	report.Best, report.Warnings = pickBest(report.Trials, req.MinTrades)
+	if len(axes) > 0 {
+		report.ParetoFront = ParetoFront(report.Trials, axes)
+		if req.Method == MethodNSGA2 {
+			// The best of a multi-objective search is its front's best on the objective
+			report.Best, report.Warnings = pickBest(report.ParetoFront, req.MinTrades)
+		}
+	}
+	if report.Best != nil {
+		config := trialConfig(req, report.Best.Params)
+		report.BestResult, _, _ = GetEvaluationCache().Evaluate(config, candles)
+
+		returns, best := trialReturns(report.Trials, report.Best.Number)
+		report.Diagnostics = DiagnoseOverfitting(returns, best, eval.periods.days())
+		if report.Diagnostics.Overfit {
+			report.Warnings = append([]string{overfitWarning(report.Diagnostics)}, report.Warnings...)
+		}
+		report.Warnings = append(report.Warnings, report.Diagnostics.Warnings...)
+	}
+	report.Duration = time.Since(startTime).String()
+	GetOptimizationReportStore().Save(report)
+
+	if report.Best != nil {
+		log.Printf("✅ %s optimized: %d trials (%d cached) in %s | best %s %.2f | return %.1f%% | trades %d",
+			req.Strategy, len(report.Trials), report.CacheHits, report.Duration,
+			req.Objective, report.Best.Value, report.Best.Metrics.ReturnPercent, report.Best.Metrics.TotalTrades)
+		if report.Diagnostics.Overfit {
+			log.Printf("%s", report.Warnings[0])
+		}
+	}
+	return report, nil
+}
+
+// trialEvaluator scores parameter sets of one optimization run
+type trialEvaluator struct {
+	req       OptimizeRequest
+	candles   []Candle
+	objective Objective
+	axes      []MetricAxis
+	periods   returnPeriods
+}
+
+// evaluate backtests points concurrently, numbering trials from offset
+func (e *trialEvaluator) evaluate(points []map[string]float64, offset int) []Trial {
+	trials := make([]Trial, len(points))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < e.req.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				trials[i] = e.trial(points[i])
+				trials[i].Number = offset + i + 1
+			}
+		}()
+	}
+	for i := range points {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return trials
+}
+
+func (e *trialEvaluator) trial(params map[string]float64) Trial {
+	trial := Trial{Params: params}
+	result, cached, err := GetEvaluationCache().Evaluate(trialConfig(e.req, params), e.candles)
+	if err != nil {
+		trial.Error = err.Error()
+		return trial
+	}
+	trial.Cached = cached
+	trial.Value = e.objective.Score(result)
+	trial.returns = e.periods.series(result)
+	if len(e.axes) > 0 {
+		trial.Objectives = make(map[string]float64, len(e.axes))
+		for _, axis := range e.axes {
+			trial.Objectives[axis.Name] = axis.Value(result)
+		}
+	}
+	trial.Metrics = TrialMetrics{
+		ReturnPercent: result.ReturnPercent,
+		WinRate:       result.WinRate,
+		ProfitFactor:  result.ProfitFactor,
+		MaxDrawdown:   result.MaxDrawdown,
+		SharpeRatio:   result.SharpeRatio,
+		TotalTrades:   result.TotalTrades,
+	}
+	return trial
+}
+
+func trialConfig(req OptimizeRequest, params map[string]float64) BacktestConfig {
+	return BacktestConfig{
+		Symbol:        req.Symbol,
+		Interval:      req.Interval,
+		Days:          req.Days,
+		StartBalance:  req.StartBalance,
+		RiskPercent:   req.RiskPercent,
+		Strategy:      req.Strategy,
+		Params:        params,
+		UseLiveParams: req.UseLiveParams,
+	}
+}
+
+// mergeParams returns the fixed overrides with point's values on top
+func mergeParams(fixed, point map[string]float64) map[string]float64 {
+	params := make(map[string]float64, len(fixed)+len(point))
+	for k, v := range fixed {
+		params[k] = v
+	}
+	for k, v := range point {
+		params[k] = v
+	}
+	return params
+}
+
+// feasibleParams rejects take profits that are not in ascending order
+func feasibleParams(params map[string]float64) bool {
+	last := 0.0
+	for _, name := range []string{"tp1_atr", "tp2_atr", "tp3_atr"} {
+		v, ok := params[name]
+		if !ok {
+			continue
+		}
+		if v <= last {
+			return false
+		}
+		last = v
+	}
+	return true
+}
+
+// pickBest returns the highest-valued trial with at least minTrades trades,
+// falling back to the highest-valued trial overall
+func pickBest(trials []Trial, minTrades int) (*Trial, []string) {
+	done := completedTrials(trials)
+	if len(done) == 0 {
+		return nil, []string{"No trial completed"}
+	}
+	for i := range done {
+		if done[i].Metrics.TotalTrades >= minTrades {
+			return &done[i], nil
+		}
+	}
+	return &done[0], []string{fmt.Sprintf("No trial reached %d trades; the best is ranked on too few trades to trust", minTrades)}
+}
+
+// TopTrials returns up to n completed trials, best first
+func (r *OptimizeReport) TopTrials(n int) []Trial {
+	done := completedTrials(r.Trials)
+	if len(done) > n {
+		done = done[:n]
+	}
+	return done
+}
@@ -0,0 +1,375 @@
+package optimization
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Overfitting thresholds: a winner is flagged when its deflated Sharpe ratio
+// is not significant or when in-sample winners usually rank in the bottom
+// half out of sample
+const (
+	dsrConfidence = 0.95
+	pboThreshold  = 0.5
+	cscvBlocks    = 10 // Even number of CSCV blocks; C(10, 5) = 252 splits
+	minPeriods    = 20
+	eulerGamma    = 0.5772156649015329
+)
+
+// OverfitDiagnostics measures how much of a winner's edge survives the number
+// of trials it was selected from. Sharpe ratios are per period of the trials'
+// return series (about a day each), not annualized.
+type OverfitDiagnostics struct {
+	Trials     int     `json:"trials"`
+	Periods    int     `json:"periods"`
+	PeriodDays float64 `json:"periodDays"`
+
+	SharpeRatio       float64 `json:"sharpeRatio"`       // Winner's per-period Sharpe ratio
+	Skewness          float64 `json:"skewness"`          // Of the winner's period returns
+	Kurtosis          float64 `json:"kurtosis"`          // Non-excess; 3 for normal returns
+	TrialSharpeStdDev float64 `json:"trialSharpeStdDev"` // Spread of Sharpe ratios across trials
+	ExpectedMaxSharpe float64 `json:"expectedMaxSharpe"` // Best Sharpe expected from luck alone
+
+	// Probability the true Sharpe ratio is above zero, ignoring and then
+	// accounting for the number of trials
+	ProbabilisticSharpe float64 `json:"probabilisticSharpe"`
+	DeflatedSharpe      float64 `json:"deflatedSharpe"`
+
+	// Probability of backtest overfitting: the share of CSCV splits where
+	// the in-sample winner ranks in the bottom half out of sample (-1 when
+	// there were too few trials or periods)
+	PBO        float64 `json:"pbo"`
+	CSCVSplits int     `json:"cscvSplits"`
+
+	// Periods (and days) of track record needed for 95% confidence the
+	// winner's Sharpe ratio is above zero; 0 when it is not positive
+	MinTrackRecord     float64 `json:"minTrackRecord"`
+	MinTrackRecordDays float64 `json:"minTrackRecordDays"`
+
+	Overfit  bool     `json:"overfit"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// DiagnoseOverfitting evaluates the winner best among trials given every
+// trial's period returns (all series the same length)
+func DiagnoseOverfitting(returns [][]float64, best int, periodDays float64) *OverfitDiagnostics {
+	d := &OverfitDiagnostics{Trials: len(returns), PeriodDays: periodDays, PBO: -1}
+	if best < 0 || best >= len(returns) {
+		return d
+	}
+	series := returns[best]
+	d.Periods = len(series)
+	if d.Periods < 3 {
+		d.Warnings = append(d.Warnings, "Too few periods to test the winner for overfitting")
+		return d
+	}
+
+	sharpes := make([]float64, len(returns))
+	for i, r := range returns {
+		sharpes[i] = sharpeOf(r)
+	}
+	d.SharpeRatio = sharpes[best]
+	d.Skewness, d.Kurtosis = higherMoments(series)
+	_, d.TrialSharpeStdDev = meanStdDev(sharpes)
+	d.ExpectedMaxSharpe = expectedMaxSharpe(d.TrialSharpeStdDev, len(returns))
+
+	// Sharpe estimation error grows with skew and fat tails
+	sr := d.SharpeRatio
+	variance := 1 - d.Skewness*sr + (d.Kurtosis-1)/4*sr*sr
+	if variance <= 0 {
+		variance = 1e-9
+	}
+	sqrtT := math.Sqrt(float64(d.Periods - 1))
+	d.ProbabilisticSharpe = normalCDF(sr * sqrtT / math.Sqrt(variance))
+	d.DeflatedSharpe = normalCDF((sr - d.ExpectedMaxSharpe) * sqrtT / math.Sqrt(variance))
+
+	if sr > 0 {
+		z := normalQuantile(dsrConfidence)
+		d.MinTrackRecord = 1 + variance*(z/sr)*(z/sr)
+		d.MinTrackRecordDays = d.MinTrackRecord * periodDays
+	}
+
+	d.PBO, d.CSCVSplits = cscvPBO(returns)
+
+	if d.DeflatedSharpe < dsrConfidence {
+		d.Overfit = true
+		d.Warnings = append(d.Warnings, fmt.Sprintf(
+			"Deflated Sharpe ratio is %.2f: after %d trials a Sharpe of %.3f is within what luck alone produces (%.3f expected)",
+			d.DeflatedSharpe, d.Trials, sr, d.ExpectedMaxSharpe))
+	}
+	if d.PBO > pboThreshold {
+		d.Overfit = true
+		d.Warnings = append(d.Warnings, fmt.Sprintf(
+			"Probability of backtest overfitting is %.0f%%: the in-sample winner ranked in the bottom half out of sample in most of %d splits",
+			d.PBO*100, d.CSCVSplits))
+	}
+	switch {
+	case sr <= 0:
+		d.Warnings = append(d.Warnings, "The winner's Sharpe ratio is not positive; no track record makes it significant")
+	case d.MinTrackRecord > float64(d.Periods):
+		d.Warnings = append(d.Warnings, fmt.Sprintf(
+			"Track record of %.0f days is shorter than the %.0f days needed to trust this Sharpe ratio",
+			float64(d.Periods)*periodDays, d.MinTrackRecordDays))
+	}
+	return d
+}
+
+// overfitWarning is the headline warning added to results whose winner is likely overfit
+func overfitWarning(d *OverfitDiagnostics) string {
+	return fmt.Sprintf("⚠️ LIKELY OVERFIT: deflated Sharpe %.2f, PBO %s over %d trials - validate out of sample before trading these parameters",
+		d.DeflatedSharpe, formatPBO(d.PBO), d.Trials)
+}
+
+func formatPBO(pbo float64) string {
+	if pbo < 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.0f%%", pbo*100)
+}
+
+// expectedMaxSharpe is the expected maximum of n Sharpe ratios drawn with the
+// given spread around a true Sharpe of zero (False Strategy Theorem)
+func expectedMaxSharpe(stdDev float64, n int) float64 {
+	if n < 2 || stdDev == 0 {
+		return 0
+	}
+	N := float64(n)
+	return stdDev * ((1-eulerGamma)*normalQuantile(1-1/N) + eulerGamma*normalQuantile(1-1/(N*math.E)))
+}
+
+// cscvPBO estimates the probability of backtest overfitting by
+// combinatorially symmetric cross-validation: the periods are cut into
+// blocks, and for every half of the blocks taken as in-sample the trial with
+// the best in-sample Sharpe is ranked out of sample on the other half
+func cscvPBO(returns [][]float64) (float64, int) {
+	n := len(returns)
+	if n < 2 {
+		return -1, 0
+	}
+	periods := len(returns[0])
+	blocks := cscvBlocks
+	for blocks > 2 && periods < blocks*2 {
+		blocks -= 2
+	}
+	if periods < blocks*2 {
+		return -1, 0
+	}
+
+	// Per block sums so each split's Sharpe ratios come from aggregates
+	type moments struct{ sum, sumSq, count float64 }
+	stats := make([][]moments, n)
+	for i, r := range returns {
+		stats[i] = make([]moments, blocks)
+		for t, v := range r {
+			b := t * blocks / periods
+			stats[i][b].sum += v
+			stats[i][b].sumSq += v * v
+			stats[i][b].count++
+		}
+	}
+	sharpe := func(m moments) float64 {
+		if m.count < 2 {
+			return 0
+		}
+		mean := m.sum / m.count
+		variance := (m.sumSq - m.sum*mean) / (m.count - 1)
+		if variance <= 1e-18 {
+			return 0
+		}
+		return mean / math.Sqrt(variance)
+	}
+
+	overfit, splits := 0, 0
+	for _, inSample := range combinations(blocks, blocks/2) {
+		isBlock := make([]bool, blocks)
+		for _, b := range inSample {
+			isBlock[b] = true
+		}
+
+		oos := make([]float64, n)
+		winner, winnerIS := 0, math.Inf(-1)
+		for i := range returns {
+			var is, os moments
+			for b, m := range stats[i] {
+				target := &os
+				if isBlock[b] {
+					target = &is
+				}
+				target.sum += m.sum
+				target.sumSq += m.sumSq
+				target.count += m.count
+			}
+			if s := sharpe(is); s > winnerIS {
+				winner, winnerIS = i, s
+			}
+			oos[i] = sharpe(os)
+		}
+
+		// Relative out-of-sample rank of the in-sample winner, ties shared
+		below, ties := 0.0, 0.0
+		for i, s := range oos {
+			switch {
+			case i == winner:
+			case s < oos[winner]:
+				below++
+			case s == oos[winner]:
+				ties++
+			}
+		}
+		omega := (below + ties/2 + 1) / float64(n+1)
+		if math.Log(omega/(1-omega)) <= 0 {
+			overfit++
+		}
+		splits++
+	}
+	return float64(overfit) / float64(splits), splits
+}
+
+// combinations lists every k-subset of 0..n-1
+func combinations(n, k int) [][]int {
+	result := [][]int{}
+	combo := make([]int, k)
+	var walk func(start, depth int)
+	walk = func(start, depth int) {
+		if depth == k {
+			result = append(result, append([]int(nil), combo...))
+			return
+		}
+		for i := start; i <= n-(k-depth); i++ {
+			combo[depth] = i
+			walk(i+1, depth+1)
+		}
+	}
+	walk(0, 0)
+	return result
+}
+
+// ==================== PERIOD RETURNS ====================
+
+// returnPeriods cuts a candle span into equal periods of about a day, or
+// shorter ones when the span holds fewer than minPeriods days
+type returnPeriods struct {
+	start int64
+	width int64
+	count int
+}
+
+func newReturnPeriods(candles []Candle) returnPeriods {
+	if len(candles) < 2 {
+		return returnPeriods{}
+	}
+	const day = int64(24 * 60 * 60 * 1000)
+	start, end := candles[0].Timestamp, candles[len(candles)-1].Timestamp+1
+	width := day
+	if (end-start)/day < minPeriods {
+		width = (end - start + minPeriods - 1) / minPeriods
+	}
+	return returnPeriods{start: start, width: width, count: int((end - start + width - 1) / width)}
+}
+
+// days returns the length of a period in days
+func (p returnPeriods) days() float64 {
+	return float64(p.width) / float64(24*60*60*1000)
+}
+
+// series returns the profit of trades closed in each period as a fraction
+// of the start balance
+func (p returnPeriods) series(result *BacktestResult) []float64 {
+	if p.count == 0 {
+		return nil
+	}
+	returns := make([]float64, p.count)
+	if result.StartBalance <= 0 {
+		return returns
+	}
+	for _, t := range result.Trades {
+		i := int((t.ExitTime - p.start) / p.width)
+		if i < 0 || i >= p.count {
+			continue
+		}
+		returns[i] += t.Profit / result.StartBalance
+	}
+	return returns
+}
+
+// ==================== STATISTICS ====================
+
+func sharpeOf(r []float64) float64 {
+	mean, stdDev := meanStdDev(r)
+	if stdDev == 0 {
+		return 0
+	}
+	return mean / stdDev
+}
+
+// meanStdDev returns the mean and sample standard deviation
+func meanStdDev(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	if len(values) < 2 {
+		return mean, 0
+	}
+	ss := 0.0
+	for _, v := range values {
+		ss += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(ss / float64(len(values)-1))
+}
+
+// higherMoments returns skewness and (non-excess) kurtosis; a flat series
+// is treated as normal
+func higherMoments(values []float64) (float64, float64) {
+	n := float64(len(values))
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= n
+	var m2, m3, m4 float64
+	for _, v := range values {
+		d := v - mean
+		m2 += d * d
+		m3 += d * d * d
+		m4 += d * d * d * d
+	}
+	m2, m3, m4 = m2/n, m3/n, m4/n
+	if m2 == 0 {
+		return 0, 3
+	}
+	return m3 / math.Pow(m2, 1.5), m4 / (m2 * m2)
+}
+
+func normalCDF(x float64) float64 {
+	return 0.5 * (1 + math.Erf(x/math.Sqrt2))
+}
+
+func normalQuantile(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
+// trialReturns collects the period returns of completed trials and the
+// index of the trial numbered best among them
+func trialReturns(trials []Trial, best int) ([][]float64, int) {
+	sorted := append([]Trial(nil), trials...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Number < sorted[j].Number })
+
+	returns := [][]float64{}
+	index := -1
+	for _, t := range sorted {
+		if t.Error != "" || t.returns == nil {
+			continue
+		}
+		if t.Number == best {
+			index = len(returns)
+		}
+		returns = append(returns, t.returns)
+	}
+	return returns, index
+}
@@ -0,0 +1,229 @@
+package optimization
+
+import (
+	"math"
+	"testing"
+)
+
+func near(a, b, tol float64) bool {
+	return math.Abs(a-b) <= tol
+}
+
+func TestExpectedMaxSharpe(t *testing.T) {
+	// Bailey and López de Prado's False Strategy Theorem: about 2.53 for 100 trials
+	tests := []struct {
+		stdDev float64
+		n      int
+		want   float64
+	}{
+		{1, 10, 1.5745983013},
+		{1, 100, 2.5306028932},
+		{1, 1000, 3.2551215137},
+		{0.5, 100, 1.2653014466},
+		{1, 1, 0},
+		{0, 100, 0},
+	}
+	for _, tt := range tests {
+		if got := expectedMaxSharpe(tt.stdDev, tt.n); !near(got, tt.want, 1e-9) {
+			t.Errorf("expectedMaxSharpe(%v, %d) = %v, want %v", tt.stdDev, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestNormalDistribution(t *testing.T) {
+	if got := normalCDF(1.96); !near(got, 0.9750021049, 1e-9) {
+		t.Errorf("normalCDF(1.96) = %v", got)
+	}
+	if got := normalQuantile(0.95); !near(got, 1.6448536270, 1e-9) {
+		t.Errorf("normalQuantile(0.95) = %v", got)
+	}
+	if got := normalCDF(normalQuantile(0.3)); !near(got, 0.3, 1e-12) {
+		t.Errorf("normalCDF(normalQuantile(0.3)) = %v", got)
+	}
+}
+
+func TestHigherMoments(t *testing.T) {
+	tests := []struct {
+		name           string
+		values         []float64
+		skew, kurtosis float64
+	}{
+		{"symmetric two-point", []float64{-1, 1, -1, 1}, 0, 1},
+		{"flat is normal", []float64{2, 2, 2}, 0, 3},
+		// Mean 1, deviations -1, -1, -1, 3: m2 3, m3 6, m4 21
+		{"right skew", []float64{0, 0, 0, 4}, 6 / math.Pow(3, 1.5), 21.0 / 9},
+	}
+	for _, tt := range tests {
+		skew, kurtosis := higherMoments(tt.values)
+		if !near(skew, tt.skew, 1e-12) || !near(kurtosis, tt.kurtosis, 1e-12) {
+			t.Errorf("%s: skew %v kurtosis %v, want %v and %v", tt.name, skew, kurtosis, tt.skew, tt.kurtosis)
+		}
+	}
+}
+
+func TestDiagnoseOverfitting(t *testing.T) {
+	winner := []float64{0.02, -0.01, 0.03, 0.01, -0.02, 0.04, 0.00, 0.01, 0.02, -0.01}
+	halved := make([]float64, len(winner))
+	mirrored := make([]float64, len(winner))
+	for i, r := range winner {
+		halved[i] = r*0.5 - 0.005
+		mirrored[i] = -r
+	}
+
+	// Reference values computed independently from the PSR, DSR and MinTRL
+	// formulas (Bailey and López de Prado, 2012 and 2014)
+	d := DiagnoseOverfitting([][]float64{winner, halved, mirrored}, 0, 1)
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"sharpe", d.SharpeRatio, 0.4707234295},
+		{"skewness", d.Skewness, 0.0482612583},
+		{"kurtosis", d.Kurtosis, 1.9602276402},
+		{"trial sharpe std dev", d.TrialSharpeStdDev, 0.4716910018},
+		{"expected max sharpe", d.ExpectedMaxSharpe, 0.4022602072},
+		{"probabilistic sharpe", d.ProbabilisticSharpe, 0.9179073763},
+		{"deflated sharpe", d.DeflatedSharpe, 0.5801705786},
+		{"min track record", d.MinTrackRecord, 13.5822995808},
+		{"min track record days", d.MinTrackRecordDays, 13.5822995808},
+	}
+	for _, c := range checks {
+		if !near(c.got, c.want, 1e-8) {
+			t.Errorf("%s = %.10f, want %.10f", c.name, c.got, c.want)
+		}
+	}
+	// 10 periods fit 4 CSCV blocks: C(4, 2) splits
+	if d.CSCVSplits != 6 || d.PBO < 0 || d.PBO > 1 {
+		t.Errorf("expected a PBO over 6 splits, got %v over %d", d.PBO, d.CSCVSplits)
+	}
+	if !d.Overfit {
+		t.Error("a deflated Sharpe of 0.58 should flag the winner as overfit")
+	}
+	if len(d.Warnings) < 2 {
+		t.Errorf("expected deflated Sharpe and track record warnings, got %v", d.Warnings)
+	}
+
+	// A single trial is not deflated
+	single := DiagnoseOverfitting([][]float64{winner}, 0, 1)
+	if single.ExpectedMaxSharpe != 0 || !near(single.DeflatedSharpe, single.ProbabilisticSharpe, 1e-12) || single.PBO != -1 {
+		t.Errorf("single trial: expected max %v, DSR %v, PSR %v, PBO %v", single.ExpectedMaxSharpe, single.DeflatedSharpe, single.ProbabilisticSharpe, single.PBO)
+	}
+
+	// A losing winner needs no track record: it is never significant
+	loser := DiagnoseOverfitting([][]float64{mirrored}, 0, 1)
+	if loser.MinTrackRecord != 0 || loser.ProbabilisticSharpe >= 0.5 {
+		t.Errorf("losing winner: min track record %v, PSR %v", loser.MinTrackRecord, loser.ProbabilisticSharpe)
+	}
+
+	if short := DiagnoseOverfitting([][]float64{{0.01, 0.02}}, 0, 1); short.Periods != 2 || len(short.Warnings) != 1 {
+		t.Errorf("two periods: expected a too-few-periods warning, got %v", short.Warnings)
+	}
+}
+
+func TestCSCVPBO(t *testing.T) {
+	// Noisy base series of 20 periods, two per CSCV block
+	base := make([]float64, 20)
+	for i := range base {
+		base[i] = 0.01 * math.Sin(float64(i)*1.7)
+	}
+	shifted := func(c float64) []float64 {
+		s := make([]float64, len(base))
+		for i, v := range base {
+			s[i] = v + c
+		}
+		return s
+	}
+
+	// Odd block sums that add up to zero: no half of the blocks sums to
+	// zero, and whichever half wins in sample the other half returns the
+	// opposite, so the mirror image wins out of sample
+	swing := make([]float64, 20)
+	for b, sum := range []float64{1, 3, 5, 7, 9, -1, -3, -5, -7, -9} {
+		swing[2*b], swing[2*b+1] = sum*0.006, sum*0.004
+	}
+	mirror := make([]float64, len(swing))
+	for i, v := range swing {
+		mirror[i] = -v
+	}
+
+	tests := []struct {
+		name    string
+		returns [][]float64
+		pbo     float64
+		splits  int
+	}{
+		{"consistent winner", [][]float64{shifted(0), shifted(0.002), shifted(0.004)}, 0, 252},
+		{"winner reverses out of sample", [][]float64{swing, mirror}, 1, 252},
+		{"blocks shrink to fit", [][]float64{shifted(0)[:12], shifted(0.003)[:12]}, 0, 20},
+		{"too few periods", [][]float64{{0.01, 0.02, 0.03}, {0.02, 0.01, 0.0}}, -1, 0},
+		{"one trial", [][]float64{base}, -1, 0},
+	}
+	for _, tt := range tests {
+		pbo, splits := cscvPBO(tt.returns)
+		if !near(pbo, tt.pbo, 1e-12) || splits != tt.splits {
+			t.Errorf("%s: PBO %v over %d splits, want %v over %d", tt.name, pbo, splits, tt.pbo, tt.splits)
+		}
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	tests := []struct{ n, k, want int }{
+		{10, 5, 252},
+		{6, 3, 20},
+		{4, 2, 6},
+		{3, 0, 1},
+	}
+	for _, tt := range tests {
+		combos := combinations(tt.n, tt.k)
+		if len(combos) != tt.want {
+			t.Errorf("combinations(%d, %d): %d subsets, want %d", tt.n, tt.k, len(combos), tt.want)
+		}
+		seen := make(map[string]bool)
+		for _, c := range combos {
+			key := ""
+			for i, v := range c {
+				if i > 0 && v <= c[i-1] {
+					t.Errorf("combinations(%d, %d): %v is not increasing", tt.n, tt.k, c)
+				}
+				key += string(rune('a' + v))
+			}
+			if seen[key] {
+				t.Errorf("combinations(%d, %d): %v listed twice", tt.n, tt.k, c)
+			}
+			seen[key] = true
+		}
+	}
+}
+
+func TestReturnPeriods(t *testing.T) {
+	const hour = int64(60 * 60 * 1000)
+	candles := func(hours int) []Candle {
+		c := make([]Candle, hours)
+		for i := range c {
+			c[i].Timestamp = int64(i) * hour
+		}
+		return c
+	}
+
+	// 30 days of hourly bars: daily periods
+	p := newReturnPeriods(candles(30 * 24))
+	if p.count != 30 || p.days() != 1 {
+		t.Errorf("30 days: %d periods of %v days, want 30 of 1", p.count, p.days())
+	}
+	result := &BacktestResult{StartBalance: 1000, Trades: []Trade{
+		{Profit: 10, ExitTime: 2 * hour},
+		{Profit: -5, ExitTime: 20 * hour},
+		{Profit: 30, ExitTime: 49 * hour},
+		{Profit: 99, ExitTime: 31 * 24 * hour}, // After the span
+	}}
+	series := p.series(result)
+	if len(series) != 30 || !near(series[0], 0.005, 1e-12) || !near(series[2], 0.03, 1e-12) || series[1] != 0 {
+		t.Errorf("series starts %v", series[:3])
+	}
+
+	// 5 days: shortened so there are still at least minPeriods periods
+	p = newReturnPeriods(candles(5 * 24))
+	if p.count < minPeriods || p.days() >= 1 {
+		t.Errorf("5 days: %d periods of %v days", p.count, p.days())
+	}
+}
@@ -0,0 +1,88 @@
+package optimization
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ==================== REPORT STORE ====================
+
+// maxStoredReports caps how many reports of each kind are kept in memory
+const maxStoredReports = 20
+
+// ReportStore keeps the most recent reports of one kind for follow-up
+// requests, evicting the oldest beyond its capacity
+type ReportStore[R any] struct {
+	prefix   string          // ID prefix, e.g. "opt"
+	capacity int             // Reports kept at most
+	setID    func(R, string) // Stamps a saved report with its ID
+	reports  map[string]R
+	order    []string // IDs, oldest first
+	mu       sync.RWMutex
+}
+
+// NewReportStore creates an empty store whose IDs start with prefix
+func NewReportStore[R any](prefix string, capacity int, setID func(R, string)) *ReportStore[R] {
+	return &ReportStore[R]{
+		prefix:   prefix,
+		capacity: capacity,
+		setID:    setID,
+		reports:  make(map[string]R),
+		order:    []string{},
+	}
+}
+
+var (
+	optimizationReportStore = NewReportStore("opt", maxStoredReports, func(r *OptimizeReport, id string) { r.ID = id })
+	sensitivityReportStore  = NewReportStore("sens", maxStoredReports, func(r *SensitivityReport, id string) { r.ID = id })
+)
+
+// GetOptimizationReportStore returns the shared optimization report store
+func GetOptimizationReportStore() *ReportStore[*OptimizeReport] {
+	return optimizationReportStore
+}
+
+// GetSensitivityReportStore returns the shared sensitivity report store
+func GetSensitivityReportStore() *ReportStore[*SensitivityReport] {
+	return sensitivityReportStore
+}
+
+// Save stores a report and returns its ID, evicting the oldest when full
+func (s *ReportStore[R]) Save(report R) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := fmt.Sprintf("%s_%d", s.prefix, time.Now().UnixNano())
+	s.setID(report, id)
+	s.reports[id] = report
+	s.order = append(s.order, id)
+
+	for len(s.order) > s.capacity {
+		delete(s.reports, s.order[0])
+		s.order = s.order[1:]
+	}
+
+	return id
+}
+
+// Get returns a stored report by ID
+func (s *ReportStore[R]) Get(id string) (R, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	report, ok := s.reports[id]
+	return report, ok
+}
+
+// List returns stored reports, newest first
+func (s *ReportStore[R]) List() []R {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]R, 0, len(s.order))
+	for i := len(s.order) - 1; i >= 0; i-- {
+		list = append(list, s.reports[s.order[i]])
+	}
+	return list
+}
@@ -0,0 +1,35 @@
+package optimization
+
+import (
+	"strings"
+	"testing"
+)
+
+type storedTestReport struct {
+	ID   string
+	Name string
+}
+
+func TestReportStoreEvictsOldest(t *testing.T) {
+	store := NewReportStore("test", 2, func(r *storedTestReport, id string) { r.ID = id })
+
+	first, second, third := &storedTestReport{Name: "first"}, &storedTestReport{Name: "second"}, &storedTestReport{Name: "third"}
+	firstID := store.Save(first)
+	store.Save(second)
+	thirdID := store.Save(third)
+
+	if !strings.HasPrefix(firstID, "test_") || first.ID != firstID || third.ID != thirdID {
+		t.Errorf("Expected saved reports stamped with prefixed IDs, got %q/%q and %q/%q", firstID, first.ID, thirdID, third.ID)
+	}
+	if _, ok := store.Get(firstID); ok {
+		t.Error("Expected the oldest report to be evicted")
+	}
+	if got, ok := store.Get(thirdID); !ok || got != third {
+		t.Errorf("Expected the newest report by ID, got %v %v", got, ok)
+	}
+
+	list := store.List()
+	if len(list) != 2 || list[0] != third || list[1] != second {
+		t.Errorf("Expected newest first, got %+v", list)
+	}
+}
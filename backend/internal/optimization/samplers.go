@@ -0,0 +1,339 @@
+package optimization
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Search methods
+const (
+	MethodGrid    = "grid"
+	MethodRandom  = "random"
+	MethodTPE     = "tpe"
+	MethodGenetic = "genetic"
+)
+
+// Sampler proposes the parameter sets to evaluate next
+type Sampler interface {
+	// Propose returns up to n points given every finished trial; an empty
+	// result ends the search
+	Propose(trials []Trial, n int) []map[string]float64
+}
+
+// SamplerConfig tunes the search methods
+type SamplerConfig struct {
+	GridLevels    int     `json:"gridLevels"`    // Values per parameter in a grid search
+	Population    int     `json:"population"`    // Genetic population size
+	MutationRate  float64 `json:"mutationRate"`  // Chance a gene mutates
+	StartupTrials int     `json:"startupTrials"` // Random trials before TPE models the results
+
+	// Axes of the Pareto front reported for any method and searched by NSGA-II
+	ParetoObjectives []string `json:"paretoObjectives,omitempty"`
+}
+
+// maxDraws bounds the redraws of a point the accept function rejects
+const maxDraws = 100
+
+// NewSampler creates the sampler of a search method. Samplers only propose
+// points accept allows (nil allows all); with searched take profits it keeps
+// them ascending.
+func NewSampler(method string, space SearchSpace, cfg SamplerConfig, rng *rand.Rand, accept func(map[string]float64) bool) (Sampler, error) {
+	if accept == nil {
+		accept = func(map[string]float64) bool { return true }
+	}
+	random := &randomSampler{space: space, rng: rng, accept: accept}
+	switch method {
+	case MethodGrid:
+		return newGridSampler(space, cfg.GridLevels, accept), nil
+	case MethodRandom:
+		return random, nil
+	case MethodTPE:
+		return &tpeSampler{space: space, rng: rng, accept: accept, random: random, startup: cfg.StartupTrials, gamma: 0.25, candidates: 24}, nil
+	case MethodGenetic:
+		return &geneticSampler{space: space, rng: rng, accept: accept, random: random, population: cfg.Population, mutationRate: cfg.MutationRate}, nil
+	case MethodNSGA2:
+		axes, err := resolveAxes(cfg.ParetoObjectives)
+		if err != nil {
+			return nil, err
+		}
+		if len(axes) < 2 {
+			return nil, fmt.Errorf("nsga2 needs at least two Pareto objectives")
+		}
+		return &nsga2Sampler{space: space, rng: rng, accept: accept, random: random, axes: axes, population: cfg.Population, mutationRate: cfg.MutationRate}, nil
+	}
+	return nil, fmt.Errorf("unknown search method: %s (use grid, random, tpe, genetic or nsga2)", method)
+}
+
+// completedTrials returns the trials that produced a value, best first
+func completedTrials(trials []Trial) []Trial {
+	done := make([]Trial, 0, len(trials))
+	for _, t := range trials {
+		if t.Error == "" {
+			done = append(done, t)
+		}
+	}
+	sort.SliceStable(done, func(i, j int) bool { return done[i].Value > done[j].Value })
+	return done
+}
+
+// ==================== GRID ====================
+
+// gridSampler walks the cartesian product of each dimension's levels
+type gridSampler struct {
+	space  SearchSpace
+	accept func(map[string]float64) bool
+	levels [][]float64
+	next   int
+	total  int
+}
+
+func newGridSampler(space SearchSpace, levels int, accept func(map[string]float64) bool) *gridSampler {
+	g := &gridSampler{space: space, accept: accept, total: 1}
+	for _, d := range space {
+		values := d.Levels(levels)
+		g.levels = append(g.levels, values)
+		g.total *= len(values)
+	}
+	return g
+}
+
+func (g *gridSampler) Propose(_ []Trial, n int) []map[string]float64 {
+	points := []map[string]float64{}
+	for ; g.next < g.total && len(points) < n; g.next++ {
+		point := make(map[string]float64, len(g.space))
+		index := g.next
+		for i := len(g.space) - 1; i >= 0; i-- {
+			values := g.levels[i]
+			point[g.space[i].Name] = values[index%len(values)]
+			index /= len(values)
+		}
+		if g.accept(point) {
+			points = append(points, point)
+		}
+	}
+	return points
+}
+
+// ==================== RANDOM ====================
+
+type randomSampler struct {
+	space  SearchSpace
+	rng    *rand.Rand
+	accept func(map[string]float64) bool
+}
+
+func (r *randomSampler) Propose(_ []Trial, n int) []map[string]float64 {
+	points := []map[string]float64{}
+	for len(points) < n {
+		point, ok := r.draw()
+		if !ok {
+			break // The space has next to no acceptable points
+		}
+		points = append(points, point)
+	}
+	return points
+}
+
+func (r *randomSampler) draw() (map[string]float64, bool) {
+	for i := 0; i < maxDraws; i++ {
+		if point := r.space.Sample(r.rng); r.accept(point) {
+			return point, true
+		}
+	}
+	return nil, false
+}
+
+// ==================== TREE-STRUCTURED PARZEN ESTIMATOR ====================
+
+// tpeSampler splits finished trials into the best gamma fraction and the
+// rest, models each dimension of both groups with Parzen windows and picks,
+// per dimension, the candidate drawn from the good model that maximizes
+// l(x)/g(x). Dimensions are modeled independently.
+type tpeSampler struct {
+	space      SearchSpace
+	rng        *rand.Rand
+	accept     func(map[string]float64) bool
+	random     *randomSampler
+	startup    int
+	gamma      float64
+	candidates int
+}
+
+func (t *tpeSampler) Propose(trials []Trial, n int) []map[string]float64 {
+	done := completedTrials(trials)
+	startup := t.startup
+	if startup <= 0 {
+		startup = max(10, 2*len(t.space))
+	}
+	if len(done) < startup {
+		return t.random.Propose(trials, n)
+	}
+
+	nGood := max(1, int(math.Ceil(t.gamma*float64(len(done)))))
+	good, bad := done[:nGood], done[nGood:]
+	goodModels := make([]parzen, len(t.space))
+	badModels := make([]parzen, len(t.space))
+	for i, d := range t.space {
+		goodModels[i] = newParzen(d, good)
+		badModels[i] = newParzen(d, bad)
+	}
+
+	known := make(map[string]bool, len(trials))
+	for _, trial := range trials {
+		known[t.key(trial.Params)] = true
+	}
+
+	points := []map[string]float64{}
+	for len(points) < n {
+		// The l/g argmax keeps landing on the best point once the steps snap
+		// it back; fewer candidates per redraw trade exploitation for novelty
+		var point map[string]float64
+		candidates := t.candidates
+		for draw := 0; draw < maxDraws; draw++ {
+			point = t.draw(goodModels, badModels, candidates)
+			if t.accept(point) && !known[t.key(point)] {
+				break
+			}
+			candidates = max(1, candidates/2)
+		}
+		if !t.accept(point) {
+			var ok bool
+			if point, ok = t.random.draw(); !ok {
+				break
+			}
+		}
+		known[t.key(point)] = true
+		points = append(points, point)
+	}
+	return points
+}
+
+// draw picks, per dimension, the best of candidates draws from the good model
+func (t *tpeSampler) draw(good, bad []parzen, candidates int) map[string]float64 {
+	point := make(map[string]float64, len(t.space))
+	for i, d := range t.space {
+		best, bestRatio := 0.0, math.Inf(-1)
+		for c := 0; c < candidates; c++ {
+			u := good[i].sample(t.rng)
+			if ratio := math.Log(good[i].density(u)) - math.Log(bad[i].density(u)); ratio > bestRatio {
+				best, bestRatio = u, ratio
+			}
+		}
+		point[d.Name] = d.fromUnit(best)
+	}
+	return point
+}
+
+// key identifies the searched part of a parameter set
+func (t *tpeSampler) key(params map[string]float64) string {
+	searched := make(map[string]float64, len(t.space))
+	for _, d := range t.space {
+		searched[d.Name] = params[d.Name]
+	}
+	return paramsKey(searched)
+}
+
+// parzen is a Gaussian kernel density on [0, 1] mixed with a uniform prior
+type parzen struct {
+	centers []float64
+	sigma   float64
+}
+
+func newParzen(d ParamDimension, trials []Trial) parzen {
+	p := parzen{}
+	for _, t := range trials {
+		p.centers = append(p.centers, d.unit(t.Params[d.Name]))
+	}
+	p.sigma = math.Max(0.05, 0.5/math.Sqrt(float64(len(p.centers)+1)))
+	return p
+}
+
+func (p parzen) sample(rng *rand.Rand) float64 {
+	k := rng.Intn(len(p.centers) + 1)
+	if k == len(p.centers) {
+		return rng.Float64() // Prior component
+	}
+	return reflectUnit(p.centers[k] + rng.NormFloat64()*p.sigma)
+}
+
+func (p parzen) density(u float64) float64 {
+	sum := 1.0 // Uniform prior
+	for _, c := range p.centers {
+		z := (u - c) / p.sigma
+		sum += math.Exp(-0.5*z*z) / (p.sigma * math.Sqrt(2*math.Pi))
+	}
+	return sum / float64(len(p.centers)+1)
+}
+
+// ==================== GENETIC ====================
+
+// geneticSampler breeds each generation from the best trials found so far
+// (elitist, steady state): tournament selection, uniform crossover and
+// Gaussian mutation in unit space
+type geneticSampler struct {
+	space        SearchSpace
+	rng          *rand.Rand
+	accept       func(map[string]float64) bool
+	random       *randomSampler
+	population   int
+	mutationRate float64
+}
+
+func (g *geneticSampler) Propose(trials []Trial, n int) []map[string]float64 {
+	done := completedTrials(trials)
+	if len(done) < 2 {
+		return g.random.Propose(trials, n)
+	}
+	if len(done) > g.population {
+		done = done[:g.population]
+	}
+
+	points := []map[string]float64{}
+	for draw := 0; len(points) < n && draw < n*maxDraws; draw++ {
+		if child := crossoverParams(g.space, g.rng, g.tournament(done), g.tournament(done), g.mutationRate); g.accept(child) {
+			points = append(points, child)
+		}
+	}
+	return points
+}
+
+// crossoverParams takes each gene from either parent and mutates it with
+// probability mutationRate
+func crossoverParams(space SearchSpace, rng *rand.Rand, a, b Trial, mutationRate float64) map[string]float64 {
+	child := make(map[string]float64, len(space))
+	for _, d := range space {
+		gene := a.Params[d.Name]
+		if rng.Float64() < 0.5 {
+			gene = b.Params[d.Name]
+		}
+		if rng.Float64() < mutationRate {
+			gene = d.fromUnit(reflectUnit(d.unit(gene) + rng.NormFloat64()*0.15))
+		}
+		child[d.Name] = gene
+	}
+	return child
+}
+
+// tournament returns the best of three random members
+func (g *geneticSampler) tournament(pool []Trial) Trial {
+	best := pool[g.rng.Intn(len(pool))]
+	for i := 1; i < 3; i++ {
+		if t := pool[g.rng.Intn(len(pool))]; t.Value > best.Value {
+			best = t
+		}
+	}
+	return best
+}
+
+// reflectUnit folds u back into [0, 1] so draws past an edge do not pile up on it
+func reflectUnit(u float64) float64 {
+	if u < 0 {
+		u = -u
+	}
+	if u > 1 {
+		u = 2 - u
+	}
+	return math.Max(0, math.Min(1, u))
+}
@@ -0,0 +1,184 @@
+package optimization
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// testSpace is a float, an int and a bool dimension
+var testSpace = SearchSpace{
+	{Name: "stop_atr", Type: "float", Min: 0.5, Max: 2.0, Step: 0.5, Default: 1.0},
+	{Name: "cooldown_bars", Type: "int", Min: 0, Max: 20, Step: 10, Default: 10},
+	{Name: "use_filter", Type: "bool", Min: 0, Max: 1, Step: 1},
+}
+
+// bowl peaks at stop_atr 1.5, cooldown_bars 10 and use_filter 1
+func bowl(p map[string]float64) float64 {
+	return 100 - 10*math.Pow(p["stop_atr"]-1.5, 2) - 0.1*math.Pow(p["cooldown_bars"]-10, 2) + 5*p["use_filter"]
+}
+
+// runSampler evaluates bowl on the sampler's proposals, batch points at a time
+func runSampler(s Sampler, trials, batch int) []Trial {
+	done := []Trial{}
+	for len(done) < trials {
+		points := s.Propose(done, min(batch, trials-len(done)))
+		if len(points) == 0 {
+			break
+		}
+		for _, p := range points {
+			done = append(done, Trial{Number: len(done) + 1, Params: p, Value: bowl(p)})
+		}
+	}
+	return done
+}
+
+func inSpace(space SearchSpace, p map[string]float64) bool {
+	for _, d := range space {
+		v, ok := p[d.Name]
+		if !ok || v < d.Min || v > d.Max || d.Snap(v) != v {
+			return false
+		}
+	}
+	return len(p) == len(space)
+}
+
+func TestLevels(t *testing.T) {
+	tests := []struct {
+		name string
+		dim  ParamDimension
+		n    int
+		want []float64
+	}{
+		{"every step fits", ParamDimension{Min: 0.5, Max: 2.0, Step: 0.5}, 5, []float64{0.5, 1, 1.5, 2}},
+		{"spread and snapped", ParamDimension{Min: 0.5, Max: 2.0, Step: 0.25}, 4, []float64{0.5, 1, 1.5, 2}},
+		{"fewer steps than levels", ParamDimension{Min: 0, Max: 2, Step: 1}, 5, []float64{0, 1, 2}},
+		{"continuous", ParamDimension{Min: 0, Max: 1}, 3, []float64{0, 0.5, 1}},
+		{"single value", ParamDimension{Min: 3, Max: 3, Step: 1}, 4, []float64{3}},
+		{"one level is the default", ParamDimension{Min: 0, Max: 10, Step: 0.1, Default: 4.2}, 1, []float64{4.2}},
+	}
+	for _, tt := range tests {
+		got := tt.dim.Levels(tt.n)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestGridSamplerEnumeratesEveryPointOnce(t *testing.T) {
+	grid := newGridSampler(testSpace, 4, func(map[string]float64) bool { return true })
+	trials := runSampler(grid, 1000, 7)
+
+	// 4 stops x 3 cooldowns x 2 flags
+	if len(trials) != 24 {
+		t.Fatalf("expected 24 grid points, got %d", len(trials))
+	}
+	seen := make(map[string]bool)
+	for _, tr := range trials {
+		if !inSpace(testSpace, tr.Params) {
+			t.Errorf("point outside the space: %v", tr.Params)
+		}
+		key := paramsKey(tr.Params)
+		if seen[key] {
+			t.Errorf("point proposed twice: %v", tr.Params)
+		}
+		seen[key] = true
+	}
+	if more := grid.Propose(trials, 10); len(more) != 0 {
+		t.Errorf("expected an exhausted grid, got %v", more)
+	}
+}
+
+func TestGridSamplerSkipsRejectedPoints(t *testing.T) {
+	accept := func(p map[string]float64) bool { return p["stop_atr"] < 1.5 }
+	trials := runSampler(newGridSampler(testSpace, 4, accept), 1000, 5)
+
+	// Stops 0.5 and 1.0 only
+	if len(trials) != 12 {
+		t.Fatalf("expected 12 accepted points, got %d", len(trials))
+	}
+	for _, tr := range trials {
+		if !accept(tr.Params) {
+			t.Errorf("rejected point proposed: %v", tr.Params)
+		}
+	}
+}
+
+func TestSamplersStayInSpace(t *testing.T) {
+	accept := func(p map[string]float64) bool { return p["stop_atr"] != 2.0 }
+	for _, method := range []string{MethodRandom, MethodTPE, MethodGenetic} {
+		cfg := SamplerConfig{Population: 8, MutationRate: 0.3}
+		s, err := NewSampler(method, testSpace, cfg, rand.New(rand.NewSource(1)), accept)
+		if err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+		trials := runSampler(s, 60, 8)
+		if len(trials) != 60 {
+			t.Errorf("%s: expected 60 trials, got %d", method, len(trials))
+		}
+		for _, tr := range trials {
+			if !inSpace(testSpace, tr.Params) || !accept(tr.Params) {
+				t.Errorf("%s: proposed %v", method, tr.Params)
+				break
+			}
+		}
+	}
+}
+
+func TestGuidedSamplersBeatRandomOnAverage(t *testing.T) {
+	space := SearchSpace{
+		{Name: "stop_atr", Type: "float", Min: 0.25, Max: 5, Step: 0.05},
+		{Name: "cooldown_bars", Type: "int", Min: 0, Max: 100, Step: 1},
+		{Name: "use_filter", Type: "bool", Min: 0, Max: 1, Step: 1},
+	}
+	best := func(method string, seed int64) float64 {
+		s, _ := NewSampler(method, space, SamplerConfig{Population: 10, MutationRate: 0.2}, rand.New(rand.NewSource(seed)), nil)
+		return completedTrials(runSampler(s, 60, 10))[0].Value
+	}
+
+	for _, method := range []string{MethodTPE, MethodGenetic} {
+		guided, random := 0.0, 0.0
+		for seed := int64(1); seed <= 10; seed++ {
+			guided += best(method, seed)
+			random += best(MethodRandom, seed)
+		}
+		if guided < random {
+			t.Errorf("%s: mean best %.3f is below random search's %.3f", method, guided/10, random/10)
+		}
+	}
+}
+
+func TestNewSamplerRejectsUnknownMethods(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	if _, err := NewSampler("annealing", testSpace, SamplerConfig{}, rng, nil); err == nil {
+		t.Error("expected an unknown method to be rejected")
+	}
+	if _, err := NewSampler(MethodNSGA2, testSpace, SamplerConfig{ParetoObjectives: []string{"return"}}, rng, nil); err == nil {
+		t.Error("expected nsga2 with one objective to be rejected")
+	}
+}
+
+func TestFeasibleParams(t *testing.T) {
+	tests := []struct {
+		params map[string]float64
+		want   bool
+	}{
+		{map[string]float64{"tp1_atr": 2, "tp2_atr": 3, "tp3_atr": 5}, true},
+		{map[string]float64{"tp1_atr": 3, "tp2_atr": 3}, false},
+		{map[string]float64{"tp1_atr": 4, "tp3_atr": 3}, false},
+		{map[string]float64{"tp2_atr": 4}, true},
+		{map[string]float64{"stop_atr": 1}, true},
+	}
+	for _, tt := range tests {
+		if got := feasibleParams(tt.params); got != tt.want {
+			t.Errorf("feasibleParams(%v) = %v, want %v", tt.params, got, tt.want)
+		}
+	}
+}
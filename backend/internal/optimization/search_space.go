@@ -0,0 +1,196 @@
+package optimization
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// ParamRange narrows the searched interval of a parameter; a zero Step keeps
+// the schema's step
+type ParamRange struct {
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+	Step float64 `json:"step,omitempty"`
+}
+
+// ParamDimension is one searched parameter. A zero Step means continuous.
+type ParamDimension struct {
+	Name    string  `json:"name"`
+	Type    string  `json:"type"` // "float", "int" or "bool"
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	Step    float64 `json:"step"`
+	Default float64 `json:"default"`
+}
+
+// SearchSpace is the ordered set of searched parameters
+type SearchSpace []ParamDimension
+
+// NewSearchSpace builds the space from a strategy's parameter schema. include
+// picks the searched parameters (empty means all but the fixed ones) and
+// ranges narrow their bounds within the schema's.
+func NewSearchSpace(schema []ParamSpec, include []string, fixed map[string]float64, ranges map[string]ParamRange) (SearchSpace, error) {
+	specs := make(map[string]ParamSpec, len(schema))
+	for _, spec := range schema {
+		specs[spec.Name] = spec
+	}
+	for name := range ranges {
+		if _, ok := specs[name]; !ok {
+			return nil, fmt.Errorf("range for unknown parameter: %s", name)
+		}
+	}
+
+	names := include
+	if len(names) == 0 {
+		for _, spec := range schema {
+			if _, ok := fixed[spec.Name]; !ok {
+				names = append(names, spec.Name)
+			}
+		}
+	}
+
+	space := SearchSpace{}
+	seen := make(map[string]bool)
+	for _, name := range names {
+		spec, ok := specs[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown parameter: %s", name)
+		}
+		if _, ok := fixed[name]; ok {
+			return nil, fmt.Errorf("%s is both fixed and searched", name)
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		dim := ParamDimension{Name: name, Type: spec.Type, Min: spec.Min, Max: spec.Max, Step: spec.Step, Default: spec.Default}
+		if r, ok := ranges[name]; ok {
+			if r.Min > r.Max {
+				return nil, fmt.Errorf("%s: range min %v is above max %v", name, r.Min, r.Max)
+			}
+			if (spec.Min != 0 || spec.Max != 0) && (r.Min < spec.Min || r.Max > spec.Max) {
+				return nil, fmt.Errorf("%s: range [%v, %v] is outside [%v, %v]", name, r.Min, r.Max, spec.Min, spec.Max)
+			}
+			// Searched values must still pass the schema's step check
+			if !spec.OnStep(r.Min) || (r.Step > 0 && !spec.OnStep(r.Min+r.Step)) {
+				return nil, fmt.Errorf("%s: range min %v and step %v must stay on the schema's %v steps from %v", name, r.Min, r.Step, spec.Step, spec.Min)
+			}
+			dim.Min, dim.Max = r.Min, r.Max
+			if r.Step > 0 {
+				dim.Step = r.Step
+			}
+		}
+
+		switch dim.Type {
+		case "bool":
+			dim.Min, dim.Max, dim.Step = 0, 1, 1
+		case "int":
+			dim.Min, dim.Max = math.Ceil(dim.Min), math.Floor(dim.Max)
+			dim.Step = math.Max(1, math.Round(dim.Step))
+		}
+		if dim.Min == 0 && dim.Max == 0 {
+			return nil, fmt.Errorf("%s has no bounds; pass a range", name)
+		}
+		if dim.Min > dim.Max {
+			return nil, fmt.Errorf("%s: empty range", name)
+		}
+		space = append(space, dim)
+	}
+
+	if len(space) == 0 {
+		return nil, fmt.Errorf("no parameters to search")
+	}
+	return space, nil
+}
+
+// Snap clamps v into the dimension and rounds it to the step grid
+func (d ParamDimension) Snap(v float64) float64 {
+	v = math.Max(d.Min, math.Min(d.Max, v))
+	if d.Step > 0 {
+		v = d.Min + math.Round((v-d.Min)/d.Step)*d.Step
+		if v > d.Max {
+			v -= d.Step
+		}
+	}
+	// Strip float noise so equal points share a cache key
+	return math.Round(v*1e9) / 1e9
+}
+
+// Levels returns at most n values spread evenly over the dimension, every
+// step when they fit
+func (d ParamDimension) Levels(n int) []float64 {
+	if d.Max == d.Min {
+		return []float64{d.Min}
+	}
+	if d.Step > 0 {
+		if count := int(math.Floor((d.Max-d.Min)/d.Step+1e-9)) + 1; count <= n {
+			levels := make([]float64, count)
+			for i := range levels {
+				levels[i] = d.Snap(d.Min + float64(i)*d.Step)
+			}
+			return levels
+		}
+	}
+	if n < 2 {
+		return []float64{d.Snap(d.Default)}
+	}
+
+	levels := []float64{}
+	for i := 0; i < n; i++ {
+		v := d.Snap(d.Min + (d.Max-d.Min)*float64(i)/float64(n-1))
+		if len(levels) == 0 || levels[len(levels)-1] != v {
+			levels = append(levels, v)
+		}
+	}
+	return levels
+}
+
+// Sample draws a uniformly random value
+func (d ParamDimension) Sample(rng *rand.Rand) float64 {
+	if d.Step > 0 {
+		count := int(math.Floor((d.Max-d.Min)/d.Step+1e-9)) + 1
+		return d.Snap(d.Min + float64(rng.Intn(count))*d.Step)
+	}
+	return d.Snap(d.Min + rng.Float64()*(d.Max-d.Min))
+}
+
+// unit maps v onto [0, 1]
+func (d ParamDimension) unit(v float64) float64 {
+	if d.Max == d.Min {
+		return 0
+	}
+	return (v - d.Min) / (d.Max - d.Min)
+}
+
+// fromUnit maps u in [0, 1] back onto the dimension
+func (d ParamDimension) fromUnit(u float64) float64 {
+	return d.Snap(d.Min + u*(d.Max-d.Min))
+}
+
+// Sample draws a uniformly random point
+func (s SearchSpace) Sample(rng *rand.Rand) map[string]float64 {
+	point := make(map[string]float64, len(s))
+	for _, d := range s {
+		point[d.Name] = d.Sample(rng)
+	}
+	return point
+}
+
+// paramsKey is a stable identity for a parameter set
+func paramsKey(params map[string]float64) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s=%g", k, params[k])
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,626 @@
+package optimization
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"math"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Sensitivity analysis limits and the retention a plateau keeps around its center
+const (
+	maxSensitivityBacktests = 2000
+	plateauRetention        = 0.8
+)
+
+// SensitivityRequest describes a sensitivity analysis around a parameter
+// set. The center comes from Params, a world-class run's BestParams or a
+// trial of a stored optimization report.
+type SensitivityRequest struct {
+	Strategy     string  `json:"strategy"`
+	Symbol       string  `json:"symbol"`
+	Interval     string  `json:"interval"` // Defaults to the strategy's timeframe
+	Days         int     `json:"days"`
+	StartBalance float64 `json:"startBalance"`
+	RiskPercent  float64 `json:"riskPercent"` // Fraction of balance risked per trade, e.g. 0.01
+	Objective    string  `json:"objective"`
+	Workers      int     `json:"workers"`
+
+	Params     map[string]float64  `json:"params,omitempty"`     // Center; unset parameters use schema defaults
+	BestParams *OptimizationParams `json:"bestParams,omitempty"` // Center ATR exits and risk of a world-class run
+	ReportID   string              `json:"reportId,omitempty"`   // Center on a trial of this optimization report
+	Trial      int                 `json:"trial,omitempty"`      // Trial number; zero takes the report's best
+
+	Vary     []string              `json:"vary,omitempty"`   // Parameters to perturb; empty perturbs every non-bool parameter
+	Ranges   map[string]ParamRange `json:"ranges,omitempty"` // Narrowed bounds per parameter
+	Spread   float64               `json:"spread"`           // Fraction of each range covered either side of the center
+	Steps    int                   `json:"steps"`            // Points either side of the center per parameter
+	Pairs    [][2]string           `json:"pairs,omitempty"`  // Heatmap pairs; empty maps the two most sensitive parameters
+	GridSize int                   `json:"gridSize"`         // Cells per heatmap axis
+}
+
+// DefaultSensitivityRequest perturbs each parameter by up to 20% of its range
+func DefaultSensitivityRequest() SensitivityRequest {
+	return SensitivityRequest{
+		Symbol:       "BTCUSDT",
+		Days:         90,
+		StartBalance: 1000,
+		RiskPercent:  0.01,
+		Objective:    "score",
+		Workers:      min(runtime.NumCPU(), 4),
+		Spread:       0.2,
+		Steps:        4,
+		GridSize:     9,
+	}
+}
+
+// Validate resolves the center, fills unset fields with defaults and
+// rejects invalid values
+func (r *SensitivityRequest) Validate() error {
+	d := DefaultSensitivityRequest()
+	if r.ReportID != "" {
+		if err := r.centerOnTrial(); err != nil {
+			return err
+		}
+	}
+	if r.Strategy == "" {
+		return fmt.Errorf("strategy is required")
+	}
+	if _, ok := GetStrategy(r.Strategy); !ok {
+		return fmt.Errorf("strategy not found: %s", r.Strategy)
+	}
+	if r.BestParams != nil {
+		r.Params = mergeParams(r.Params, r.BestParams.StrategyParams())
+		r.RiskPercent = r.BestParams.RiskPercent / 100
+	}
+	if r.Symbol == "" {
+		r.Symbol = d.Symbol
+	}
+	if r.Interval == "" {
+		r.Interval = StrategyTimeframe(r.Strategy, "15m")
+	}
+	if r.Days <= 0 {
+		r.Days = d.Days
+	}
+	if r.StartBalance <= 0 {
+		r.StartBalance = d.StartBalance
+	}
+	if r.RiskPercent <= 0 {
+		r.RiskPercent = d.RiskPercent
+	}
+	if r.RiskPercent > 0.1 {
+		return fmt.Errorf("riskPercent is a fraction of balance: %v is above 0.1", r.RiskPercent)
+	}
+	if r.Objective == "" {
+		r.Objective = d.Objective
+	}
+	if _, err := GetObjective(r.Objective); err != nil {
+		return err
+	}
+	if r.Workers <= 0 {
+		r.Workers = d.Workers
+	}
+	if r.Spread <= 0 {
+		r.Spread = d.Spread
+	}
+	if r.Spread > 1 {
+		return fmt.Errorf("spread %v is above 1", r.Spread)
+	}
+	if r.Steps <= 0 {
+		r.Steps = d.Steps
+	}
+	if r.Steps > 20 {
+		return fmt.Errorf("steps %d is above 20", r.Steps)
+	}
+	if r.GridSize < 2 {
+		r.GridSize = d.GridSize
+	}
+	if r.GridSize > 25 {
+		return fmt.Errorf("gridSize %d is above 25", r.GridSize)
+	}
+	if r.Params == nil {
+		r.Params = map[string]float64{}
+	}
+	return ValidateStrategyParams(r.Strategy, r.Params)
+}
+
+// centerOnTrial takes the strategy, data settings and center from a trial of
+// a stored optimization report, keeping whatever the request already sets
+func (r *SensitivityRequest) centerOnTrial() error {
+	report, ok := GetOptimizationReportStore().Get(r.ReportID)
+	if !ok {
+		return fmt.Errorf("optimization report not found: %s", r.ReportID)
+	}
+	var trial *Trial
+	if r.Trial == 0 {
+		trial = report.Best
+	}
+	for i := range report.Trials {
+		if report.Trials[i].Number == r.Trial {
+			trial = &report.Trials[i]
+		}
+	}
+	if trial == nil {
+		return fmt.Errorf("trial %d not found in %s", r.Trial, r.ReportID)
+	}
+	if trial.Error != "" {
+		return fmt.Errorf("trial %d failed: %s", trial.Number, trial.Error)
+	}
+	r.Trial = trial.Number
+
+	src := report.Request
+	if r.Strategy == "" {
+		r.Strategy = src.Strategy
+	}
+	if r.Strategy != src.Strategy {
+		return fmt.Errorf("report %s optimized %s, not %s", r.ReportID, src.Strategy, r.Strategy)
+	}
+	if r.Symbol == "" {
+		r.Symbol = src.Symbol
+	}
+	if r.Interval == "" {
+		r.Interval = src.Interval
+	}
+	if r.Days <= 0 {
+		r.Days = src.Days
+	}
+	if r.StartBalance <= 0 {
+		r.StartBalance = src.StartBalance
+	}
+	if r.RiskPercent <= 0 {
+		r.RiskPercent = src.RiskPercent
+	}
+	if r.Objective == "" {
+		r.Objective = src.Objective
+	}
+	if len(r.Vary) == 0 && len(src.Params) > 0 {
+		r.Vary = src.Params
+	}
+	r.Params = mergeParams(trial.Params, r.Params)
+	return nil
+}
+
+// StrategyParams returns the ATR exits as strategy parameter overrides
+func (p OptimizationParams) StrategyParams() map[string]float64 {
+	return map[string]float64{
+		"stop_atr": p.StopATR,
+		"tp1_atr":  p.TP1ATR,
+		"tp2_atr":  p.TP2ATR,
+		"tp3_atr":  p.TP3ATR,
+	}
+}
+
+// SensitivityPoint is one perturbed value of a parameter
+type SensitivityPoint struct {
+	Value   float64      `json:"value"`
+	Score   float64      `json:"score"`
+	Metrics TrialMetrics `json:"metrics"`
+	Error   string       `json:"error,omitempty"`
+}
+
+// SensitivityCurve is the objective along one parameter with the others held
+// at the center. Retention is the average share of the center's score the
+// perturbed points keep (capped at 1), WorstRetention the lowest.
+type SensitivityCurve struct {
+	Param          string             `json:"param"`
+	Center         float64            `json:"center"`
+	Points         []SensitivityPoint `json:"points"`
+	Retention      float64            `json:"retention"`
+	WorstRetention float64            `json:"worstRetention"`
+}
+
+// SensitivityHeatmap is the objective over a grid of two parameters with the
+// others held at the center. Scores rows follow YValues and hold null where
+// the point is infeasible or its backtest failed.
+type SensitivityHeatmap struct {
+	X         string       `json:"x"`
+	Y         string       `json:"y"`
+	XValues   []float64    `json:"xValues"`
+	YValues   []float64    `json:"yValues"`
+	Scores    [][]*float64 `json:"scores"`
+	Retention float64      `json:"retention"`
+}
+
+// SensitivityReport tells whether a parameter set sits on a plateau, where
+// small changes keep most of its score, or on a narrow spike
+type SensitivityReport struct {
+	ID            string               `json:"id"`
+	CreatedAt     time.Time            `json:"createdAt"`
+	Request       SensitivityRequest   `json:"request"`
+	Center        map[string]float64   `json:"center"`
+	CenterScore   float64              `json:"centerScore"`
+	CenterMetrics TrialMetrics         `json:"centerMetrics"`
+	Curves        []SensitivityCurve   `json:"curves"`
+	Heatmaps      []SensitivityHeatmap `json:"heatmaps"`
+	Stability     float64              `json:"stability"` // 0-100: average retention over every curve and heatmap
+	Plateau       bool                 `json:"plateau"`
+	Backtests     int                  `json:"backtests"`
+	CacheHits     int                  `json:"cacheHits"`
+	Duration      string               `json:"duration"`
+	Warnings      []string             `json:"warnings,omitempty"`
+}
+
+// AnalyzeSensitivity fetches the request's candles and runs the analysis
+func AnalyzeSensitivity(req SensitivityRequest) (*SensitivityReport, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	candles, err := fetchBinanceData(req.Symbol, req.Interval, req.Days)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch data: %w", err)
+	}
+	return AnalyzeSensitivityCandles(req, candles)
+}
+
+// AnalyzeSensitivityCandles perturbs each parameter around the center, maps
+// the objective over parameter pairs and scores how much of the center's
+// value survives. Backtests go through the shared evaluation cache.
+func AnalyzeSensitivityCandles(req SensitivityRequest, candles []Candle) (*SensitivityReport, error) {
+	startTime := time.Now()
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if len(candles) < 150 {
+		return nil, fmt.Errorf("insufficient data: %d candles", len(candles))
+	}
+
+	strategy, _ := GetStrategy(req.Strategy)
+	vary := req.Vary
+	if len(vary) == 0 {
+		for _, spec := range strategy.ParamSchema() {
+			if spec.Type != "bool" {
+				vary = append(vary, spec.Name)
+			}
+		}
+	}
+	space, err := NewSearchSpace(strategy.ParamSchema(), vary, nil, req.Ranges)
+	if err != nil {
+		return nil, err
+	}
+	dims := make(map[string]ParamDimension, len(space))
+	center := mergeParams(req.Params, nil)
+	for _, d := range space {
+		dims[d.Name] = d
+		if v, ok := center[d.Name]; ok {
+			center[d.Name] = d.Snap(v)
+		} else {
+			center[d.Name] = d.Snap(d.Default)
+		}
+	}
+	if !feasibleParams(center) {
+		return nil, fmt.Errorf("center take profits are not in ascending order")
+	}
+
+	pairs := req.Pairs
+	for _, pair := range pairs {
+		for _, name := range pair {
+			if _, ok := dims[name]; !ok {
+				return nil, fmt.Errorf("heatmap parameter %s is not varied", name)
+			}
+		}
+		if pair[0] == pair[1] {
+			return nil, fmt.Errorf("heatmap pair repeats %s", pair[0])
+		}
+	}
+	backtests := 1 + len(space)*(2*req.Steps+1)
+	if len(pairs) > 0 {
+		backtests += len(pairs) * req.GridSize * req.GridSize
+	} else if len(space) > 1 {
+		backtests += req.GridSize * req.GridSize
+	}
+	if backtests > maxSensitivityBacktests {
+		return nil, fmt.Errorf("analysis needs up to %d backtests, above %d: vary fewer parameters or shrink steps and gridSize",
+			backtests, maxSensitivityBacktests)
+	}
+
+	objective, _ := GetObjective(req.Objective)
+	eval := &sensitivityEvaluator{
+		trials: &trialEvaluator{
+			req: OptimizeRequest{
+				Strategy:     req.Strategy,
+				Symbol:       req.Symbol,
+				Interval:     req.Interval,
+				Days:         req.Days,
+				StartBalance: req.StartBalance,
+				RiskPercent:  req.RiskPercent,
+				Workers:      req.Workers,
+			},
+			candles:   candles,
+			objective: objective,
+		},
+		results: make(map[string]Trial),
+	}
+	log.Printf("🔬 Sensitivity of %s on %s %s: %d parameters around %v",
+		req.Strategy, req.Symbol, req.Interval, len(space), center)
+
+	report := &SensitivityReport{
+		CreatedAt: time.Now(),
+		Request:   req,
+		Center:    center,
+		Curves:    []SensitivityCurve{},
+		Heatmaps:  []SensitivityHeatmap{},
+	}
+
+	// One-dimensional curves, evaluated together with the center
+	points := []map[string]float64{center}
+	axes := make(map[string][]float64, len(space))
+	for _, d := range space {
+		axes[d.Name] = perturbations(d, center[d.Name], req.Spread, 2*req.Steps+1)
+		for _, v := range axes[d.Name] {
+			points = append(points, mergeParams(center, map[string]float64{d.Name: v}))
+		}
+	}
+	eval.run(points)
+
+	centerTrial := eval.results[paramsKey(center)]
+	if centerTrial.Error != "" {
+		return nil, fmt.Errorf("center backtest failed: %s", centerTrial.Error)
+	}
+	report.CenterScore = centerTrial.Value
+	report.CenterMetrics = centerTrial.Metrics
+	for _, d := range space {
+		curve := SensitivityCurve{Param: d.Name, Center: center[d.Name], Points: []SensitivityPoint{}}
+		scores := []float64{}
+		for _, v := range axes[d.Name] {
+			point := mergeParams(center, map[string]float64{d.Name: v})
+			t := eval.lookup(point)
+			curve.Points = append(curve.Points, SensitivityPoint{Value: v, Score: t.Value, Metrics: t.Metrics, Error: t.Error})
+			if t.Error == "" && v != center[d.Name] {
+				scores = append(scores, t.Value)
+			}
+		}
+		curve.Retention, curve.WorstRetention = retention(report.CenterScore, scores)
+		report.Curves = append(report.Curves, curve)
+	}
+
+	// Heatmaps default to the two parameters whose curves drop the most
+	if len(pairs) == 0 && len(report.Curves) > 1 {
+		ranked := append([]SensitivityCurve(nil), report.Curves...)
+		sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Retention < ranked[j].Retention })
+		pairs = [][2]string{{ranked[0].Param, ranked[1].Param}}
+	}
+	points = []map[string]float64{}
+	grids := make([][2][]float64, len(pairs))
+	for i, pair := range pairs {
+		xs := perturbations(dims[pair[0]], center[pair[0]], req.Spread, req.GridSize)
+		ys := perturbations(dims[pair[1]], center[pair[1]], req.Spread, req.GridSize)
+		grids[i] = [2][]float64{xs, ys}
+		for _, y := range ys {
+			for _, x := range xs {
+				points = append(points, mergeParams(center, map[string]float64{pair[0]: x, pair[1]: y}))
+			}
+		}
+	}
+	eval.run(points)
+
+	for i, pair := range pairs {
+		xs, ys := grids[i][0], grids[i][1]
+		heatmap := SensitivityHeatmap{X: pair[0], Y: pair[1], XValues: xs, YValues: ys, Scores: make([][]*float64, len(ys))}
+		scores := []float64{}
+		for row, y := range ys {
+			heatmap.Scores[row] = make([]*float64, len(xs))
+			for col, x := range xs {
+				t := eval.lookup(mergeParams(center, map[string]float64{pair[0]: x, pair[1]: y}))
+				if t.Error != "" {
+					continue
+				}
+				score := t.Value
+				heatmap.Scores[row][col] = &score
+				if x != center[pair[0]] || y != center[pair[1]] {
+					scores = append(scores, score)
+				}
+			}
+		}
+		heatmap.Retention, _ = retention(report.CenterScore, scores)
+		report.Heatmaps = append(report.Heatmaps, heatmap)
+	}
+
+	report.Stability, report.Plateau, report.Warnings = stabilityOf(report)
+	report.Backtests = len(eval.results)
+	report.CacheHits = eval.cacheHits
+	report.Duration = time.Since(startTime).String()
+	GetSensitivityReportStore().Save(report)
+
+	shape := "narrow spike"
+	if report.Plateau {
+		shape = "plateau"
+	}
+	log.Printf("✅ %s sensitivity: stability %.0f/100 (%s) | %d backtests (%d cached) in %s",
+		req.Strategy, report.Stability, shape, report.Backtests, report.CacheHits, report.Duration)
+	return report, nil
+}
+
+// sensitivityEvaluator backtests each distinct point once, keeping results by
+// parameter key
+type sensitivityEvaluator struct {
+	trials    *trialEvaluator
+	results   map[string]Trial
+	cacheHits int
+}
+
+func (e *sensitivityEvaluator) run(points []map[string]float64) {
+	pending := []map[string]float64{}
+	for _, p := range points {
+		key := paramsKey(p)
+		if _, ok := e.results[key]; ok {
+			continue
+		}
+		if !feasibleParams(p) {
+			e.results[key] = Trial{Params: p, Error: "take profits are not in ascending order"}
+			continue
+		}
+		e.results[key] = Trial{} // Claimed; filled in below
+		pending = append(pending, p)
+	}
+	for _, t := range e.trials.evaluate(pending, 0) {
+		if t.Cached {
+			e.cacheHits++
+		}
+		e.results[paramsKey(t.Params)] = t
+	}
+}
+
+func (e *sensitivityEvaluator) lookup(point map[string]float64) Trial {
+	return e.results[paramsKey(point)]
+}
+
+// perturbations returns up to n distinct values spread evenly over the window
+// of spread times the range either side of center, center included
+func perturbations(d ParamDimension, center, spread float64, n int) []float64 {
+	half := spread * (d.Max - d.Min)
+	values := []float64{center}
+	for i := 0; i < n; i++ {
+		offset := -half
+		if n > 1 {
+			offset = -half + 2*half*float64(i)/float64(n-1)
+		}
+		values = append(values, d.Snap(center+offset))
+	}
+	sort.Float64s(values)
+
+	distinct := values[:1]
+	for _, v := range values[1:] {
+		if v != distinct[len(distinct)-1] {
+			distinct = append(distinct, v)
+		}
+	}
+	return distinct
+}
+
+// retention returns the average and lowest share of the center's score kept
+// by the given scores, each capped to [0, 1]
+func retention(centerScore float64, scores []float64) (float64, float64) {
+	if len(scores) == 0 {
+		return 1, 1
+	}
+	if centerScore <= 0 {
+		return 0, 0
+	}
+	sum, worst := 0.0, 1.0
+	for _, s := range scores {
+		r := math.Max(0, math.Min(1, s/centerScore))
+		sum += r
+		worst = math.Min(worst, r)
+	}
+	return sum / float64(len(scores)), worst
+}
+
+// stabilityOf averages the retention of every surface into a 0-100 score
+// and warns about the parameters the score is most sensitive to
+func stabilityOf(report *SensitivityReport) (float64, bool, []string) {
+	warnings := []string{}
+	if report.CenterScore <= 0 {
+		warnings = append(warnings, fmt.Sprintf("Center %s is %.2f; stability needs a positive score",
+			report.Request.Objective, report.CenterScore))
+		return 0, false, warnings
+	}
+
+	total, count := 0.0, 0
+	fragile := []string{}
+	for _, c := range report.Curves {
+		total += c.Retention
+		count++
+		if c.WorstRetention < 0.5 {
+			fragile = append(fragile, fmt.Sprintf("%s (%.0f%% kept at worst)", c.Param, c.WorstRetention*100))
+		}
+	}
+	for _, h := range report.Heatmaps {
+		total += h.Retention
+		count++
+	}
+	stability := 100 * total / float64(count)
+	plateau := stability >= plateauRetention*100
+
+	if !plateau {
+		warnings = append(warnings, fmt.Sprintf(
+			"⚠️ NARROW SPIKE: nearby parameters keep only %.0f%% of the center's %s on average - expect live results well below the backtest",
+			stability, report.Request.Objective))
+	}
+	if len(fragile) > 0 {
+		warnings = append(warnings, "Small changes more than halve the score along: "+strings.Join(fragile, ", "))
+	}
+	return stability, plateau, warnings
+}
+
+// ==================== HEATMAP SVG ====================
+
+// Heatmap SVG cell size and margins
+const (
+	heatmapCell   = 48.0
+	heatmapMargin = 70.0
+)
+
+// RenderSensitivityHeatmapSVG draws a heatmap as a standalone SVG, red for
+// the lowest score through green for the highest, the center outlined
+func RenderSensitivityHeatmapSVG(h SensitivityHeatmap, center map[string]float64, objective string) string {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, row := range h.Scores {
+		for _, s := range row {
+			if s != nil {
+				lo, hi = math.Min(lo, *s), math.Max(hi, *s)
+			}
+		}
+	}
+
+	width := heatmapMargin*1.5 + float64(len(h.XValues))*heatmapCell
+	height := heatmapMargin*1.5 + float64(len(h.YValues))*heatmapCell
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %.0f %.0f" width="%.0f" height="%.0f" font-family="sans-serif">`,
+		width, height, width, height)
+	fmt.Fprintf(&b, `<rect x="0" y="0" width="%.0f" height="%.0f" fill="#fff"/>`, width, height)
+	fmt.Fprintf(&b, `<text x="%.0f" y="20" font-size="13" fill="#333">%s by %s and %s</text>`,
+		heatmapMargin, html.EscapeString(objective), html.EscapeString(h.X), html.EscapeString(h.Y))
+
+	// Rows run top to bottom from the highest y value
+	for row := range h.YValues {
+		y := heatmapMargin/2 + float64(len(h.YValues)-1-row)*heatmapCell
+		for col := range h.XValues {
+			x := heatmapMargin + float64(col)*heatmapCell
+			fill, label := "#eee", "n/a"
+			if s := h.Scores[row][col]; s != nil {
+				fill, label = heatColor(*s, lo, hi), fmt.Sprintf("%.3g", *s)
+			}
+			fmt.Fprintf(&b, `<rect x="%.0f" y="%.0f" width="%.0f" height="%.0f" fill="%s" stroke="#fff"><title>%s=%g %s=%g: %s</title></rect>`,
+				x, y, heatmapCell, heatmapCell, fill, html.EscapeString(h.X), h.XValues[col], html.EscapeString(h.Y), h.YValues[row], label)
+			fmt.Fprintf(&b, `<text x="%.0f" y="%.0f" font-size="10" fill="#222" text-anchor="middle">%s</text>`,
+				x+heatmapCell/2, y+heatmapCell/2+3, label)
+			if h.XValues[col] == center[h.X] && h.YValues[row] == center[h.Y] {
+				fmt.Fprintf(&b, `<rect x="%.0f" y="%.0f" width="%.0f" height="%.0f" fill="none" stroke="#000" stroke-width="3"/>`,
+					x+1.5, y+1.5, heatmapCell-3, heatmapCell-3)
+			}
+		}
+		fmt.Fprintf(&b, `<text x="%.0f" y="%.0f" font-size="10" fill="#666" text-anchor="end">%g</text>`,
+			heatmapMargin-6, y+heatmapCell/2+3, h.YValues[row])
+	}
+
+	bottom := heatmapMargin/2 + float64(len(h.YValues))*heatmapCell
+	for col, v := range h.XValues {
+		fmt.Fprintf(&b, `<text x="%.0f" y="%.0f" font-size="10" fill="#666" text-anchor="middle">%g</text>`,
+			heatmapMargin+float64(col)*heatmapCell+heatmapCell/2, bottom+14, v)
+	}
+	fmt.Fprintf(&b, `<text x="%.0f" y="%.0f" font-size="12" fill="#333" text-anchor="middle">%s</text>`,
+		heatmapMargin+float64(len(h.XValues))*heatmapCell/2, bottom+32, html.EscapeString(h.X))
+	fmt.Fprintf(&b, `<text x="14" y="%.0f" font-size="12" fill="#333" text-anchor="middle" transform="rotate(-90 14 %.0f)">%s</text>`,
+		heatmapMargin/2+float64(len(h.YValues))*heatmapCell/2, heatmapMargin/2+float64(len(h.YValues))*heatmapCell/2, html.EscapeString(h.Y))
+	b.WriteString(`</svg>`)
+	return b.String()
+}
+
+// heatColor interpolates red through yellow to green over [lo, hi]
+func heatColor(v, lo, hi float64) string {
+	t := 0.5
+	if hi > lo {
+		t = (v - lo) / (hi - lo)
+	}
+	r, g := 230.0, 230.0
+	if t < 0.5 {
+		g = 80 + 150*t*2
+	} else {
+		r = 230 - 150*(t-0.5)*2
+	}
+	return fmt.Sprintf("rgb(%.0f,%.0f,90)", r, g)
+}
@@ -0,0 +1,125 @@
+package optimization
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRetention(t *testing.T) {
+	tests := []struct {
+		name        string
+		center      float64
+		scores      []float64
+		mean, worst float64
+	}{
+		// Shares 1 (capped from 1.2), 1, 0.8 and 0.5
+		{"mixed", 10, []float64{12, 10, 8, 5}, 0.825, 0.5},
+		{"losses keep nothing", 10, []float64{10, -5}, 0.5, 0},
+		{"no neighbours", 10, nil, 1, 1},
+		{"zero center", 0, []float64{1, 2}, 0, 0},
+		{"negative center", -3, []float64{-1, -6}, 0, 0},
+	}
+	for _, tt := range tests {
+		mean, worst := retention(tt.center, tt.scores)
+		if !near(mean, tt.mean, 1e-12) || !near(worst, tt.worst, 1e-12) {
+			t.Errorf("%s: retention %v worst %v, want %v and %v", tt.name, mean, worst, tt.mean, tt.worst)
+		}
+	}
+}
+
+func TestStabilityOf(t *testing.T) {
+	curve := func(param string, mean, worst float64) SensitivityCurve {
+		return SensitivityCurve{Param: param, Retention: mean, WorstRetention: worst}
+	}
+	tests := []struct {
+		name      string
+		score     float64
+		curves    []SensitivityCurve
+		heatmaps  []SensitivityHeatmap
+		stability float64
+		plateau   bool
+		warnings  []string // Substrings, one per expected warning
+	}{
+		{
+			name:      "plateau",
+			score:     2,
+			curves:    []SensitivityCurve{curve("stop_atr", 0.9, 0.7), curve("tp1_atr", 0.85, 0.6)},
+			heatmaps:  []SensitivityHeatmap{{Retention: 0.8}},
+			stability: 85,
+			plateau:   true,
+		},
+		{
+			name:      "plateau with a fragile parameter",
+			score:     2,
+			curves:    []SensitivityCurve{curve("stop_atr", 0.95, 0.9), curve("tp1_atr", 0.85, 0.4)},
+			stability: 90,
+			plateau:   true,
+			warnings:  []string{"tp1_atr (40% kept at worst)"},
+		},
+		{
+			name:      "narrow spike",
+			score:     1.5,
+			curves:    []SensitivityCurve{curve("stop_atr", 0.5, 0.2), curve("tp1_atr", 0.7, 0.6)},
+			heatmaps:  []SensitivityHeatmap{{Retention: 0.3}},
+			stability: 50,
+			warnings:  []string{"NARROW SPIKE", "stop_atr (20% kept at worst)"},
+		},
+		{
+			name:     "losing center",
+			score:    -0.4,
+			curves:   []SensitivityCurve{curve("stop_atr", 1, 1)},
+			warnings: []string{"needs a positive score"},
+		},
+	}
+	for _, tt := range tests {
+		report := &SensitivityReport{
+			Request:     SensitivityRequest{Objective: "score"},
+			CenterScore: tt.score,
+			Curves:      tt.curves,
+			Heatmaps:    tt.heatmaps,
+		}
+		stability, plateau, warnings := stabilityOf(report)
+		if !near(stability, tt.stability, 1e-9) || plateau != tt.plateau {
+			t.Errorf("%s: stability %v plateau %v, want %v and %v", tt.name, stability, plateau, tt.stability, tt.plateau)
+		}
+		if len(warnings) != len(tt.warnings) {
+			t.Errorf("%s: warnings %q, want %d", tt.name, warnings, len(tt.warnings))
+			continue
+		}
+		for i, want := range tt.warnings {
+			if !strings.Contains(warnings[i], want) {
+				t.Errorf("%s: warning %q does not mention %q", tt.name, warnings[i], want)
+			}
+		}
+	}
+}
+
+func TestPerturbations(t *testing.T) {
+	steps := ParamDimension{Min: 0, Max: 10, Step: 1}
+	tests := []struct {
+		name           string
+		dim            ParamDimension
+		center, spread float64
+		n              int
+		want           []float64
+	}{
+		{"interior", steps, 5, 0.2, 5, []float64{3, 4, 5, 6, 7}},
+		{"clamped at the edge", steps, 9, 0.2, 5, []float64{7, 8, 9, 10}},
+		{"snapped duplicates collapse", ParamDimension{Min: 0, Max: 10, Step: 2}, 4, 0.1, 5, []float64{4, 6}},
+		{"continuous", ParamDimension{Min: 0, Max: 1}, 0.5, 0.2, 3, []float64{0.3, 0.5, 0.7}},
+		{"one point is the low end", steps, 5, 0.2, 1, []float64{3, 5}},
+	}
+	for _, tt := range tests {
+		got := perturbations(tt.dim, tt.center, tt.spread, tt.n)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if !near(got[i], tt.want[i], 1e-9) {
+				t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
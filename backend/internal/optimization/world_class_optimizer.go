@@ -4,95 +4,140 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"math"
 	"os"
-	"sync"
+	"sort"
 	"time"
 )
 
-// WorldClassOptimizer finds the absolute best parameters for each strategy
+// atrExitRanges are the ATR exit bounds the world-class and grid presets
+// search, within every strategy's schema
+var atrExitRanges = map[string]ParamRange{
+	"stop_atr": {Min: 0.5, Max: 2.0, Step: 0.25},
+	"tp1_atr":  {Min: 2.0, Max: 5.0, Step: 0.5},
+	"tp2_atr":  {Min: 3.0, Max: 7.5, Step: 0.5},
+	"tp3_atr":  {Min: 5.0, Max: 15.0, Step: 0.5},
+}
+
+// atrExitSearch returns the ATR exits a strategy declares and does not fix,
+// with their preset ranges
+func atrExitSearch(strategy string, fixed map[string]float64) ([]string, map[string]ParamRange) {
+	params := []string{}
+	ranges := make(map[string]ParamRange)
+	s, ok := GetStrategy(strategy)
+	if !ok {
+		return params, ranges
+	}
+	for _, spec := range s.ParamSchema() {
+		r, isExit := atrExitRanges[spec.Name]
+		if _, isFixed := fixed[spec.Name]; !isExit || isFixed {
+			continue
+		}
+		params = append(params, spec.Name)
+		ranges[spec.Name] = r
+	}
+	return params, ranges
+}
+
+// WorldClassOptimizer searches the ATR exits of every strategy on its own
+// timeframe. Each strategy is one OptimizeCandles run, so its trials share
+// the evaluation cache and its report can be promoted trial by trial.
 type WorldClassOptimizer struct {
 	Symbol       string
 	Days         int
 	StartBalance float64
+	RiskPercent  float64 // Fraction of balance risked per trade
+	Method       string  // Search method of each strategy's run
+	MaxTrials    int     // Backtests per strategy
 	Strategies   []string
+	Params       map[string]map[string]float64 // Fixed per-strategy overrides; ATR exits are still searched
 }
 
-// OptimizationParams represents parameters to test
+// OptimizationParams are the ATR exits and risk of a world-class winner
 type OptimizationParams struct {
 	StopATR     float64
 	TP1ATR      float64
 	TP2ATR      float64
 	TP3ATR      float64
-	RiskPercent float64
+	RiskPercent float64 // Percent of balance, e.g. 1.0
 }
 
 // WorldClassOptimizationResult stores the best result for a strategy
 type WorldClassOptimizationResult struct {
-	Strategy       string                 `json:"strategy"`
-	TotalTests     int                    `json:"totalTests"`
-	BestScore      float64                `json:"bestScore"`
-	BestParams     OptimizationParams     `json:"bestParams"`
-	BacktestResult *BacktestResult        `json:"backtestResult"`
-	TestDuration   string                 `json:"testDuration"`
+	Strategy       string              `json:"strategy"`
+	Interval       string              `json:"interval"`
+	ReportID       string              `json:"reportId,omitempty"` // Stored optimization report of the run
+	TotalTests     int                 `json:"totalTests"`
+	BestTrial      int                 `json:"bestTrial,omitempty"`
+	BestScore      float64             `json:"bestScore"`
+	BestParams     OptimizationParams  `json:"bestParams"`
+	Params         map[string]float64  `json:"params,omitempty"` // Strategy overrides of the best: fixed params plus its ATR exits
+	BacktestResult *BacktestResult     `json:"backtestResult"`
+	TestDuration   string              `json:"testDuration"`
+	Trials         []Trial             `json:"trials"`                // Every combination tested
+	Diagnostics    *OverfitDiagnostics `json:"diagnostics,omitempty"` // Overfitting of the best given all trials
+	Warnings       []string            `json:"warnings,omitempty"`
+
+	report *OptimizeReport // Kept for promotion after the store evicts it
 }
 
 // WorldClassResults stores all optimization results
 type WorldClassResults struct {
-	OptimizationDate time.Time                                `json:"optimizationDate"`
-	Symbol           string                                   `json:"symbol"`
-	Days             int                                      `json:"days"`
-	StartBalance     float64                                  `json:"startBalance"`
-	TotalDuration    string                                   `json:"totalDuration"`
-	Results          map[string]WorldClassOptimizationResult  `json:"results"`
-	BestOverall      WorldClassOptimizationResult             `json:"bestOverall"`
+	OptimizationDate time.Time                               `json:"optimizationDate"`
+	Symbol           string                                  `json:"symbol"`
+	Days             int                                     `json:"days"`
+	StartBalance     float64                                 `json:"startBalance"`
+	TotalDuration    string                                  `json:"totalDuration"`
+	Results          map[string]WorldClassOptimizationResult `json:"results"`
+	BestOverall      WorldClassOptimizationResult            `json:"bestOverall"`
 }
 
-// NewWorldClassOptimizer creates a new optimizer
+// NewWorldClassOptimizer creates an optimizer running a 300-trial TPE search
+// of the composite score per strategy
 func NewWorldClassOptimizer() *WorldClassOptimizer {
 	return &WorldClassOptimizer{
 		Symbol:       "BTCUSDT",
 		Days:         180,
 		StartBalance: 1000,
-		Strategies: []string{
-			"session_trader",
-			"breakout_master",
-			"liquidity_hunter",
-			"trend_rider",
-			"range_master",
-			"smart_money_tracker",
-			"institutional_follower",
-			"reversal_sniper",
-			"momentum_beast",
-			"scalper_pro",
-		},
+		RiskPercent:  0.01,
+		Method:       MethodTPE,
+		MaxTrials:    300,
+		Strategies:   StrategyNames(),
 	}
 }
 
-// OptimizeAll optimizes all strategies
+// Request returns the optimization request of one strategy
+func (wco *WorldClassOptimizer) Request(strategy string) OptimizeRequest {
+	req := DefaultOptimizeRequest()
+	req.Strategy = strategy
+	req.Symbol = wco.Symbol
+	req.Interval = StrategyTimeframe(strategy, "15m")
+	req.Days = wco.Days
+	req.StartBalance = wco.StartBalance
+	req.RiskPercent = wco.RiskPercent
+	req.Method = wco.Method
+	req.Objective = "score"
+	req.MaxTrials = wco.MaxTrials
+	req.Fixed = wco.Params[strategy]
+	req.Params, req.Ranges = atrExitSearch(strategy, req.Fixed)
+	return req
+}
+
+// OptimizeAll optimizes all strategies one after the other; each run
+// already backtests concurrently
 func (wco *WorldClassOptimizer) OptimizeAll() *WorldClassResults {
 	startTime := time.Now()
-	
+
 	log.Println("🌍 WORLD-CLASS STRATEGY OPTIMIZATION")
 	log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	log.Printf("Symbol: %s | Days: %d | Start Balance: $%.2f", wco.Symbol, wco.Days, wco.StartBalance)
-	log.Println("")
-	log.Println("Testing Parameters:")
-	log.Println("  • Stop Loss: 0.5, 0.75, 1.0, 1.25, 1.5, 2.0 ATR")
-	log.Println("  • TP1: 2.0, 2.5, 3.0, 3.5, 4.0, 5.0 ATR")
-	log.Println("  • TP2: 3.0, 4.0, 4.5, 5.0, 6.0, 7.5 ATR")
-	log.Println("  • TP3: 5.0, 6.0, 7.5, 10.0, 12.5, 15.0 ATR")
-	log.Println("  • Risk: 0.5%, 1.0%, 1.5%, 2.0%, 2.5%")
-	log.Println("")
-	log.Println("Optimization Goals:")
-	log.Println("  1. Win Rate > 60%")
-	log.Println("  2. Profit Factor > 3.0")
-	log.Println("  3. Max Drawdown < 15%")
-	log.Println("  4. Return > 500%")
-	log.Println("  5. Total Trades > 20")
+	log.Printf("Symbol: %s | Days: %d | Start Balance: $%.2f | Risk: %.1f%%", wco.Symbol, wco.Days, wco.StartBalance, wco.RiskPercent*100)
+	log.Printf("Search: %s, %d trials per strategy over the ATR exits", wco.Method, wco.MaxTrials)
+	log.Println("  • Stop Loss: 0.5 - 2.0 ATR")
+	log.Println("  • TP1: 2.0 - 5.0 ATR")
+	log.Println("  • TP2: 3.0 - 7.5 ATR")
+	log.Println("  • TP3: 5.0 - 15.0 ATR")
 	log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	log.Println("")
-	
+
 	results := &WorldClassResults{
 		OptimizationDate: time.Now(),
 		Symbol:           wco.Symbol,
@@ -100,42 +145,19 @@ func (wco *WorldClassOptimizer) OptimizeAll() *WorldClassResults {
 		StartBalance:     wco.StartBalance,
 		Results:          make(map[string]WorldClassOptimizationResult),
 	}
-	
-	log.Println("📊 Each strategy will fetch data for its optimal timeframe...")
-	log.Println("")
-	
-	// Optimize each strategy in parallel
-	var wg sync.WaitGroup
-	resultsChan := make(chan WorldClassOptimizationResult, len(wco.Strategies))
-	
-	for _, strategy := range wco.Strategies {
-		wg.Add(1)
-		go func(strat string) {
-			defer wg.Done()
-			result := wco.OptimizeStrategy(strat, nil) // Each strategy fetches its own candles
-			resultsChan <- result
-		}(strategy)
-	}
-	
-	// Wait for all optimizations to complete
-	go func() {
-		wg.Wait()
-		close(resultsChan)
-	}()
-	
-	// Collect results
+
 	bestScore := 0.0
-	for result := range resultsChan {
-		results.Results[result.Strategy] = result
-		
+	for _, strategy := range wco.Strategies {
+		result := wco.OptimizeStrategy(strategy, nil) // Each strategy fetches its own timeframe
+		results.Results[strategy] = result
 		if result.BestScore > bestScore {
 			bestScore = result.BestScore
 			results.BestOverall = result
 		}
 	}
-	
+
 	results.TotalDuration = time.Since(startTime).String()
-	
+
 	log.Println("")
 	log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	log.Println("🎉 WORLD-CLASS OPTIMIZATION COMPLETE!")
@@ -143,168 +165,70 @@ func (wco *WorldClassOptimizer) OptimizeAll() *WorldClassResults {
 	log.Printf("Total Duration: %s", results.TotalDuration)
 	log.Printf("Best Overall: %s (Score: %.2f)", results.BestOverall.Strategy, results.BestOverall.BestScore)
 	log.Println("")
-	
+
 	return results
 }
 
-// OptimizeStrategy optimizes a single strategy
+// OptimizeStrategy optimizes a single strategy on candles of its timeframe;
+// nil candles are fetched
 func (wco *WorldClassOptimizer) OptimizeStrategy(strategy string, candles []Candle) WorldClassOptimizationResult {
-	startTime := time.Now()
-	
-	log.Printf("🎯 Optimizing: %s", strategy)
-	
-	// Fetch strategy-specific candles with correct interval
-	interval := getStrategyInterval(strategy)
-	strategyCandles, err := fetchBinanceData(wco.Symbol, interval, wco.Days)
-	if err != nil || len(strategyCandles) < 50 {
-		log.Printf("❌ %s: Failed to fetch candles for interval %s", strategy, interval)
-		return WorldClassOptimizationResult{
-			Strategy:   strategy,
-			TotalTests: 0,
-			BestScore:  0,
-		}
+	req := wco.Request(strategy)
+	result := WorldClassOptimizationResult{Strategy: strategy, Interval: req.Interval}
+	if err := req.Validate(); err != nil {
+		log.Printf("❌ %s: %v", strategy, err)
+		result.Warnings = []string{err.Error()}
+		return result
 	}
-	log.Printf("  📊 %s: Using %s interval with %d candles", strategy, interval, len(strategyCandles))
-	
-	// Parameter ranges
-	stopLossValues := []float64{0.5, 0.75, 1.0, 1.25, 1.5, 2.0}
-	tp1Values := []float64{2.0, 2.5, 3.0, 3.5, 4.0, 5.0}
-	tp2Values := []float64{3.0, 4.0, 4.5, 5.0, 6.0, 7.5}
-	tp3Values := []float64{5.0, 6.0, 7.5, 10.0, 12.5, 15.0}
-	riskValues := []float64{0.5, 1.0, 1.5, 2.0, 2.5}
-	
-	bestScore := 0.0
-	var bestParams OptimizationParams
-	var bestResult *BacktestResult
-	var bestUnfilteredResult *BacktestResult // Track best result even if it doesn't meet criteria
-	var bestUnfilteredParams OptimizationParams
-	bestUnfilteredScore := 0.0
-	totalTests := 0
-	
-	// Test all combinations
-	for _, stop := range stopLossValues {
-		for _, tp1 := range tp1Values {
-			for _, tp2 := range tp2Values {
-				for _, tp3 := range tp3Values {
-					for _, risk := range riskValues {
-						// Validate: TP1 < TP2 < TP3
-						if tp1 < tp2 && tp2 < tp3 {
-							totalTests++
-							
-							// Run backtest with CUSTOM parameters (not hardcoded ones)
-							config := BacktestConfig{
-								Symbol:       wco.Symbol,
-								Interval:     interval,
-								Days:         wco.Days,
-								StartBalance: wco.StartBalance,
-								RiskPercent:  risk / 100,
-								Strategy:     strategy,
-							}
-							
-							// Pass custom parameters to backtest
-							result, err := RunBacktestWithCustomParams(config, strategyCandles, stop, tp1, tp2, tp3)
-							if err != nil {
-								continue
-							}
-							
-							// Calculate score
-							score := wco.CalculateScore(result)
-							
-							// Track best unfiltered result
-							if score > bestUnfilteredScore {
-								bestUnfilteredScore = score
-								bestUnfilteredResult = result
-								bestUnfilteredParams = OptimizationParams{
-									StopATR:     stop,
-									TP1ATR:      tp1,
-									TP2ATR:      tp2,
-									TP3ATR:      tp3,
-									RiskPercent: risk,
-								}
-							}
-							
-							// Check if this is the best that meets criteria
-							if score > bestScore && wco.MeetsMinimumCriteria(result) {
-								bestScore = score
-								bestParams = OptimizationParams{
-									StopATR:     stop,
-									TP1ATR:      tp1,
-									TP2ATR:      tp2,
-									TP3ATR:      tp3,
-									RiskPercent: risk,
-								}
-								bestResult = result
-								
-								log.Printf("  ✨ %s: NEW BEST! Score %.2f | Stop %.2f | TP1 %.1f | TP2 %.1f | TP3 %.1f | Risk %.1f%% | WR %.1f%% | PF %.2f | Return %.0f%% | Trades %d",
-									strategy, score, stop, tp1, tp2, tp3, risk, result.WinRate, result.ProfitFactor, result.ReturnPercent, result.TotalTrades)
-							}
-							
-							// Progress
-							if totalTests%50 == 0 {
-								log.Printf("  ⏳ %s: Tested %d combinations...", strategy, totalTests)
-							}
-						}
-					}
-				}
-			}
+
+	log.Printf("🎯 Optimizing: %s", strategy)
+	if candles == nil {
+		var err error
+		if candles, err = fetchBinanceData(req.Symbol, req.Interval, req.Days); err != nil {
+			log.Printf("❌ %s: Failed to fetch candles for interval %s: %v", strategy, req.Interval, err)
+			result.Warnings = []string{fmt.Sprintf("failed to fetch %s candles", req.Interval)}
+			return result
 		}
 	}
-	
-	duration := time.Since(startTime)
-	
-	// Debug: Show what we found
-	if bestUnfilteredResult != nil {
-		log.Printf("  📊 %s: Best unfiltered - Trades=%d, WR=%.1f%%, PF=%.2f, Return=%.0f%%, DD=%.1f%%",
-			strategy, bestUnfilteredResult.TotalTrades, bestUnfilteredResult.WinRate,
-			bestUnfilteredResult.ProfitFactor, bestUnfilteredResult.ReturnPercent, bestUnfilteredResult.MaxDrawdown)
-	}
-	
-	// If no result met criteria, use the best unfiltered result
-	if bestResult == nil && bestUnfilteredResult != nil {
-		log.Printf("⚠️  %s: No results met criteria. Using best unfiltered result.", strategy)
-		bestResult = bestUnfilteredResult
-		bestScore = bestUnfilteredScore
-		bestParams = bestUnfilteredParams
-	}
-	
-	if bestResult != nil {
-		log.Printf("✅ %s: Complete! Tests: %d | Duration: %s | Best Score: %.2f | WR: %.1f%% | PF: %.2f | Return: %.0f%%",
-			strategy, totalTests, duration, bestScore, bestResult.WinRate, bestResult.ProfitFactor, bestResult.ReturnPercent)
-	} else {
-		log.Printf("❌ %s: Complete! Tests: %d | Duration: %s | No viable results found (no trades generated)",
-			strategy, totalTests, duration)
+
+	report, err := OptimizeCandles(req, candles)
+	if err != nil {
+		log.Printf("❌ %s: %v", strategy, err)
+		result.Warnings = []string{err.Error()}
+		return result
 	}
-	
-	return WorldClassOptimizationResult{
-		Strategy:       strategy,
-		TotalTests:     totalTests,
-		BestScore:      bestScore,
-		BestParams:     bestParams,
-		BacktestResult: bestResult,
-		TestDuration:   duration.String(),
+
+	result.report = report
+	result.ReportID = report.ID
+	result.TotalTests = len(report.Trials)
+	result.TestDuration = report.Duration
+	result.Trials = report.Trials
+	result.Warnings = report.Warnings
+	if report.Best == nil || report.BestResult == nil {
+		log.Printf("❌ %s: Complete! Tests: %d | No viable results found", strategy, result.TotalTests)
+		return result
 	}
-}
 
-// CalculateScore calculates optimization score
-func (wco *WorldClassOptimizer) CalculateScore(result *BacktestResult) float64 {
-	if result == nil || result.TotalTrades == 0 {
-		return 0
+	best := report.Best
+	result.BestTrial = best.Number
+	result.BestScore = best.Value
+	result.Params = best.Params
+	result.BacktestResult = report.BestResult
+	result.Diagnostics = report.Diagnostics
+	result.BestParams = OptimizationParams{
+		StopATR:     best.Params["stop_atr"],
+		TP1ATR:      best.Params["tp1_atr"],
+		TP2ATR:      best.Params["tp2_atr"],
+		TP3ATR:      best.Params["tp3_atr"],
+		RiskPercent: req.RiskPercent * 100,
 	}
-	
-	// CRITICAL: Heavily penalize losing strategies
-	if result.ReturnPercent < 0 {
-		return 0 // Losing strategies get ZERO score
+	if !wco.MeetsMinimumCriteria(report.BestResult) {
+		result.Warnings = append(result.Warnings, "The best trial misses the minimum criteria (profitable, PF > 1, win rate ≥ 40%, drawdown ≤ 40%, 5+ trades)")
 	}
-	
-	// Weighted scoring formula - Prioritize PROFITABILITY
-	score := (result.ReturnPercent * 2.0) +              // Return is MOST important: 2x
-		(result.ProfitFactor * 20.0) +               // Profit factor: 20x (increased)
-		(result.WinRate * 1.0) +                     // Win rate: 1x
-		-(result.MaxDrawdown * 3.0) +                // Drawdown penalty: -3x (increased)
-		(float64(result.WinningTrades) * 2.0) +      // Winning trades bonus: 2x
-		(float64(result.TotalTrades) / 5.0)          // Trade count bonus: 0.2x (reduced)
-	
-	return math.Max(0, score)
+
+	log.Printf("✅ %s: Complete! Tests: %d | Duration: %s | Best Score: %.2f | WR: %.1f%% | PF: %.2f | Return: %.0f%%",
+		strategy, result.TotalTests, result.TestDuration, result.BestScore,
+		result.BacktestResult.WinRate, result.BacktestResult.ProfitFactor, result.BacktestResult.ReturnPercent)
+	return result
 }
 
 // MeetsMinimumCriteria checks if result meets minimum standards
@@ -312,13 +236,13 @@ func (wco *WorldClassOptimizer) MeetsMinimumCriteria(result *BacktestResult) boo
 	if result == nil {
 		return false
 	}
-	
+
 	// Focus on PROFITABLE strategies only
-	return result.ReturnPercent > 0 &&         // MUST be profitable
-		result.ProfitFactor > 1.0 &&       // MUST have profit factor > 1
-		result.WinRate >= 40.0 &&          // Min 40% win rate
-		result.MaxDrawdown <= 40.0 &&      // Max 40% drawdown
-		result.TotalTrades >= 5            // Min 5 trades
+	return result.ReturnPercent > 0 && // MUST be profitable
+		result.ProfitFactor > 1.0 && // MUST have profit factor > 1
+		result.WinRate >= 40.0 && // Min 40% win rate
+		result.MaxDrawdown <= 40.0 && // Max 40% drawdown
+		result.TotalTrades >= 5 // Min 5 trades
 }
 
 // SaveResults saves optimization results to file
@@ -327,7 +251,7 @@ func (wco *WorldClassOptimizer) SaveResults(results *WorldClassResults, filename
 	if err != nil {
 		return err
 	}
-	
+
 	return os.WriteFile(filename, data, 0644)
 }
 
@@ -338,24 +262,39 @@ func (results *WorldClassResults) PrintSummary() {
 	fmt.Println("📊 OPTIMIZATION SUMMARY")
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Println("")
-	
-	for _, result := range results.Results {
-		if result.BacktestResult != nil {
-			fmt.Printf("🎯 %s:\n", result.Strategy)
-			fmt.Printf("   Score: %.2f | Tests: %d | Duration: %s\n",
-				result.BestScore, result.TotalTests, result.TestDuration)
-			fmt.Printf("   Stop: %.2f | TP1: %.2f | TP2: %.2f | TP3: %.2f | Risk: %.1f%%\n",
-				result.BestParams.StopATR, result.BestParams.TP1ATR,
-				result.BestParams.TP2ATR, result.BestParams.TP3ATR,
-				result.BestParams.RiskPercent)
-			fmt.Printf("   WR: %.1f%% | PF: %.2f | Return: %.0f%% | DD: %.1f%% | Trades: %d\n",
-				result.BacktestResult.WinRate, result.BacktestResult.ProfitFactor,
-				result.BacktestResult.ReturnPercent, result.BacktestResult.MaxDrawdown,
-				result.BacktestResult.TotalTrades)
-			fmt.Println("")
+
+	strategies := make([]string, 0, len(results.Results))
+	for name := range results.Results {
+		strategies = append(strategies, name)
+	}
+	sort.Strings(strategies)
+
+	for _, name := range strategies {
+		result := results.Results[name]
+		if result.BacktestResult == nil {
+			continue
 		}
+		fmt.Printf("🎯 %s (report %s, trial %d):\n", result.Strategy, result.ReportID, result.BestTrial)
+		fmt.Printf("   Score: %.2f | Tests: %d | Duration: %s\n",
+			result.BestScore, result.TotalTests, result.TestDuration)
+		fmt.Printf("   Stop: %.2f | TP1: %.2f | TP2: %.2f | TP3: %.2f | Risk: %.1f%%\n",
+			result.BestParams.StopATR, result.BestParams.TP1ATR,
+			result.BestParams.TP2ATR, result.BestParams.TP3ATR,
+			result.BestParams.RiskPercent)
+		fmt.Printf("   WR: %.1f%% | PF: %.2f | Return: %.0f%% | DD: %.1f%% | Trades: %d\n",
+			result.BacktestResult.WinRate, result.BacktestResult.ProfitFactor,
+			result.BacktestResult.ReturnPercent, result.BacktestResult.MaxDrawdown,
+			result.BacktestResult.TotalTrades)
+		if d := result.Diagnostics; d != nil {
+			fmt.Printf("   Deflated Sharpe: %.2f | PBO: %s | Min Track Record: %.0f days\n",
+				d.DeflatedSharpe, formatPBO(d.PBO), d.MinTrackRecordDays)
+			if d.Overfit {
+				fmt.Printf("   %s\n", overfitWarning(d))
+			}
+		}
+		fmt.Println("")
 	}
-	
+
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Printf("🏆 BEST OVERALL: %s (Score: %.2f)\n", results.BestOverall.Strategy, results.BestOverall.BestScore)
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
@@ -0,0 +1,296 @@
+package orb
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testDay = time.Date(2025, 10, 20, 0, 0, 0, 0, newYork) // A Monday
+
+// fakeSource serves fixed daily and minute bars
+type fakeSource struct {
+	daily   map[string][]Candle
+	minutes map[string][]Candle
+}
+
+func (f *fakeSource) Daily(symbol string, day time.Time, n int) ([]Candle, error) {
+	out := []Candle{}
+	for _, c := range f.daily[symbol] {
+		if c.Timestamp < clockAt(day, 0, 0).UnixMilli() {
+			out = append(out, c)
+		}
+	}
+	if len(out) > n {
+		out = out[len(out)-n:]
+	}
+	return out, nil
+}
+
+func (f *fakeSource) Minutes(symbol string, from, to time.Time) ([]Candle, error) {
+	out := []Candle{}
+	for _, c := range f.minutes[symbol] {
+		if c.Timestamp >= from.UnixMilli() && c.Timestamp < to.UnixMilli() {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+// ohlcv is one minute bar: open, high, low, close, volume
+type ohlcv [5]float64
+
+func minuteBars(start time.Time, bars []ohlcv) []Candle {
+	out := make([]Candle, len(bars))
+	for i, b := range bars {
+		out[i] = Candle{
+			Timestamp: start.Add(time.Duration(i) * time.Minute).UnixMilli(),
+			Open:      b[0], High: b[1], Low: b[2], Close: b[3], Volume: b[4],
+		}
+	}
+	return out
+}
+
+// addStock gives symbol 15 history days closing at prevClose with a true
+// range of atr and an opening range volume of orVolume, then today's
+// premarket bars from 09:00 and session bars from 09:30. The last session
+// price is held until the close.
+func (f *fakeSource) addStock(symbol string, prevClose, atr, orVolume float64, premarket, session []ohlcv) {
+	days := []time.Time{}
+	for day := testDay; len(days) < historyDays+1; {
+		day = day.AddDate(0, 0, -1)
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			days = append([]time.Time{day}, days...)
+		}
+	}
+	for _, day := range days {
+		f.daily[symbol] = append(f.daily[symbol], Candle{
+			Timestamp: day.UnixMilli(),
+			Open:      prevClose, High: prevClose + atr/2, Low: prevClose - atr/2, Close: prevClose, Volume: 2e6,
+		})
+		for i := 0; i < 60; i++ {
+			ts := clockAt(day, 9, 30).Add(time.Duration(i) * time.Minute).UnixMilli()
+			f.minutes[symbol] = append(f.minutes[symbol], Candle{Timestamp: ts, Open: prevClose, High: prevClose, Low: prevClose, Close: prevClose, Volume: orVolume / 5})
+		}
+	}
+
+	f.minutes[symbol] = append(f.minutes[symbol], minuteBars(clockAt(testDay, 9, 0), premarket)...)
+	bars := minuteBars(clockAt(testDay, 9, 30), session)
+	last := bars[len(bars)-1]
+	for ts := last.Timestamp + minuteMs; ts < clockAt(testDay, 16, 0).UnixMilli(); ts += minuteMs {
+		bars = append(bars, Candle{Timestamp: ts, Open: last.Close, High: last.Close, Low: last.Close, Close: last.Close, Volume: 100})
+	}
+	f.minutes[symbol] = append(f.minutes[symbol], bars...)
+}
+
+// longRange is a rising 5-minute range from open to open+1 with a high of
+// open+1.1 and a total volume of volume
+func longRange(open, volume float64) []ohlcv {
+	bars := []ohlcv{}
+	for i := 0; i < 5; i++ {
+		o := open + float64(i)*0.2
+		bars = append(bars, ohlcv{o, o + 0.3, o - 0.1, o + 0.2, volume / 5})
+	}
+	return bars
+}
+
+func newFakeSource() *fakeSource {
+	return &fakeSource{daily: map[string][]Candle{}, minutes: map[string][]Candle{}}
+}
+
+func eventTypes(s *Session, symbol string) string {
+	types := []string{}
+	for _, e := range s.Events {
+		if e.Trade.Symbol == symbol {
+			types = append(types, e.Type)
+		}
+	}
+	return strings.Join(types, ",")
+}
+
+func findTrade(t *testing.T, s *Session, symbol string) *Trade {
+	t.Helper()
+	for _, trade := range s.Trades {
+		if trade.Symbol == symbol {
+			return trade
+		}
+	}
+	t.Fatalf("no trade for %s in %+v", symbol, s.Stocks[symbol])
+	return nil
+}
+
+func TestReplayBreakoutStopAndFlatten(t *testing.T) {
+	src := newFakeSource()
+	// Long range 101 -> 102, high 102.1, stop 101.9; breaks at 09:35 and trends to 103
+	src.addStock("AAA", 100, 2, 10000, nil, append(longRange(101, 20000),
+		ohlcv{102, 102.4, 101.95, 102.3, 1000}, ohlcv{102.3, 103, 102.3, 103, 1000}))
+	// Short range, low 49.2, stop 49.4; breaks at 09:36 and stops out at 09:37
+	src.addStock("BBB", 50, 2, 10000, nil, []ohlcv{
+		{50, 50.1, 49.7, 49.8, 3000}, {49.8, 49.9, 49.5, 49.7, 3000}, {49.7, 49.7, 49.2, 49.6, 3000},
+		{49.6, 49.7, 49.4, 49.5, 3000}, {49.5, 49.6, 49.3, 49.5, 3000},
+		{49.5, 49.5, 49.3, 49.4, 1000}, {49.4, 49.35, 49.0, 49.1, 1000}, {49.1, 49.6, 49.05, 49.5, 1000},
+	})
+	// Half the usual opening volume
+	src.addStock("CCC", 30, 1, 10000, nil, longRange(30, 5000))
+	// Never takes its range high
+	src.addStock("DDD", 20, 1, 10000, nil, append(longRange(20, 12000), ohlcv{21, 21, 20.5, 20.6, 1000}))
+
+	cfg := DefaultConfig()
+	cfg.Universe = []string{"AAA", "BBB", "CCC", "DDD"}
+	received := []Event{}
+	s, err := Replay(cfg, src, testDay, func(e Event) { received = append(received, e) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(received, s.Events) || s.Phase != PhaseClosed {
+		t.Fatalf("every event should be emitted and the session closed, got %d/%d in %s", len(received), len(s.Events), s.Phase)
+	}
+
+	aaa := findTrade(t, s, "AAA")
+	if got := eventTypes(s, "AAA"); got != "selected,entry,flatten" {
+		t.Errorf("AAA events %s", got)
+	}
+	if math.Abs(aaa.EntryPrice-102.1) > 1e-9 || aaa.ExitPrice != 103 || math.Abs(aaa.PnLInR-4.5) > 1e-9 || aaa.PnL <= 0 {
+		t.Errorf("AAA should fill at its range high and flatten at 103, got %+v", aaa)
+	}
+	if aaa.ExitTime != clockAt(testDay, 15, 59).UnixMilli() {
+		t.Errorf("AAA should flatten a minute before the close, got %d", aaa.ExitTime)
+	}
+
+	bbb := findTrade(t, s, "BBB")
+	if got := eventTypes(s, "BBB"); got != "selected,entry,stop" {
+		t.Errorf("BBB events %s", got)
+	}
+	if bbb.Direction != "SHORT" || bbb.EntryPrice != 49.2 || math.Abs(bbb.ExitPrice-49.4) > 1e-9 || math.Abs(bbb.PnLInR+1) > 1e-9 {
+		t.Errorf("BBB should short 49.2 and stop at 49.4, got %+v", bbb)
+	}
+
+	if st := s.Stocks["CCC"]; st.Status != "rejected" || !strings.Contains(st.Reason, "relative volume 0.50") {
+		t.Errorf("CCC should fail relative volume, got %+v", st)
+	}
+	if got := eventTypes(s, "DDD"); got != "selected,expired" || findTrade(t, s, "DDD").Status != "expired" {
+		t.Errorf("DDD events %s", got)
+	}
+}
+
+func TestGapFilters(t *testing.T) {
+	src := newFakeSource()
+	premarket := func(price, volume float64) []ohlcv { return []ohlcv{{price, price, price, price, volume}} }
+	src.addStock("UP", 100, 2, 10000, premarket(102, 5000), longRange(102, 20000))
+	src.addStock("FLAT", 100, 2, 10000, premarket(100.1, 5000), longRange(100.2, 20000))
+	src.addStock("FADE", 100, 2, 10000, premarket(103, 5000), []ohlcv{
+		{103, 103.1, 102, 102.5, 4000}, {102.5, 102.6, 102, 102.2, 4000}, {102.2, 102.3, 102, 102.1, 4000},
+		{102.1, 102.2, 101.9, 102, 4000}, {102, 102.1, 101.8, 101.9, 4000},
+	})
+	src.addStock("HUGE", 100, 2, 10000, premarket(120, 5000), longRange(120, 20000))
+	src.addStock("THIN", 100, 2, 10000, premarket(102, 200), longRange(102, 20000))
+
+	cfg := DefaultConfig()
+	cfg.Universe = []string{"up", "flat", "fade", "huge", "thin"}
+	cfg.MinGapPct, cfg.MaxGapPct, cfg.MinPremarketVolume, cfg.GapAligned = 1, 10, 1000, true
+	s, err := Replay(cfg, src, testDay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"UP":   "",
+		"FLAT": "gap 0.20% below 1.00%",
+		"FADE": "short range against a 3.00% gap",
+		"HUGE": "gap 20.00% above 10.00%",
+		"THIN": "premarket volume 200 below 1000",
+	}
+	for symbol, reason := range want {
+		st := s.Stocks[symbol]
+		if reason == "" && st.Status != "selected" || reason != "" && st.Reason != reason {
+			t.Errorf("%s: want %q, got %s %q", symbol, reason, st.Status, st.Reason)
+		}
+	}
+	if s.Stocks["UP"].PremarketVolume != 5000 || math.Abs(s.Stocks["UP"].GapPct-2) > 1e-9 {
+		t.Errorf("unexpected premarket stats %+v", s.Stocks["UP"])
+	}
+}
+
+func TestTopNByRelativeVolume(t *testing.T) {
+	src := newFakeSource()
+	for symbol, volume := range map[string]float64{"ONE": 15000, "TWO": 30000, "THREE": 20000} {
+		src.addStock(symbol, 50, 2, 10000, nil, longRange(50, volume))
+	}
+	cfg := DefaultConfig()
+	cfg.Universe = []string{"ONE", "TWO", "THREE"}
+	cfg.TopN = 2
+	s, err := Replay(cfg, src, testDay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(s.Trades) != 2 || s.Trades[0].Symbol != "TWO" || s.Trades[1].Symbol != "THREE" {
+		t.Fatalf("expected TWO and THREE by relative volume, got %+v", s.Trades)
+	}
+	if st := s.Stocks["ONE"]; st.Status != "candidate" || st.RelativeVolume != 1.5 {
+		t.Errorf("ONE passes the screen but is not ranked in, got %+v", st)
+	}
+}
+
+func TestPollingMatchesReplay(t *testing.T) {
+	src := newFakeSource()
+	src.addStock("AAA", 100, 2, 10000, []ohlcv{{101, 101, 101, 101, 500}}, append(longRange(101, 20000),
+		ohlcv{102, 102.4, 101.95, 102.3, 1000}, ohlcv{102.3, 102.3, 101.5, 101.6, 1000}))
+	src.addStock("BBB", 40, 1, 10000, nil, longRange(40, 15000))
+
+	cfg := DefaultConfig()
+	cfg.Universe = []string{"AAA", "BBB"}
+	replayed, err := Replay(cfg, src, testDay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.Validate()
+	polled := NewSession(cfg, testDay)
+	polled.Prepare(src)
+	for now := polled.OpenTime.Add(-3 * time.Minute); now.Before(polled.CloseTime.Add(time.Minute)); now = now.Add(7 * time.Minute) {
+		bars, _ := fetchBars(src, polled.Watched(), polled.PremarketStart, now)
+		closed := []Bar{}
+		for _, b := range bars {
+			if b.Timestamp+minuteMs <= now.UnixMilli() {
+				closed = append(closed, b)
+			}
+		}
+		polled.Feed(closed)
+		polled.Advance(now)
+	}
+
+	if !reflect.DeepEqual(replayed.Events, polled.Events) {
+		t.Errorf("polling should emit what a replay does:\n%+v\n%+v", polled.Events, replayed.Events)
+	}
+	if got := eventTypes(polled, "AAA"); got != "selected,entry,stop" {
+		t.Errorf("AAA events %s", got)
+	}
+}
+
+func TestSessionDay(t *testing.T) {
+	cases := []struct {
+		now  time.Time
+		want string
+	}{
+		{time.Date(2025, 10, 21, 10, 0, 0, 0, newYork), "2025-10-21"},  // Tuesday session
+		{time.Date(2025, 10, 21, 16, 0, 0, 0, newYork), "2025-10-22"},  // After the bell
+		{time.Date(2025, 10, 24, 17, 0, 0, 0, newYork), "2025-10-27"},  // Friday evening
+		{time.Date(2025, 10, 26, 3, 0, 0, 0, time.UTC), "2025-10-27"},  // Saturday night in New York
+		{time.Date(2025, 10, 21, 3, 30, 0, 0, time.UTC), "2025-10-21"}, // Monday night in New York
+	}
+	for _, c := range cases {
+		if got := SessionDay(c.now).Format("2006-01-02"); got != c.want {
+			t.Errorf("SessionDay(%v) = %s, want %s", c.now, got, c.want)
+		}
+	}
+
+	cfg := DefaultConfig()
+	summer := NewSession(cfg, time.Date(2025, 7, 1, 0, 0, 0, 0, newYork))
+	winter := NewSession(cfg, time.Date(2025, 12, 1, 0, 0, 0, 0, newYork))
+	if summer.OpenTime.UTC().Hour() != 13 || winter.OpenTime.UTC().Hour() != 14 || winter.RangeEnd.Sub(winter.OpenTime) != 5*time.Minute {
+		t.Errorf("the open is 09:30 New York time, got %v and %v", summer.OpenTime.UTC(), winter.OpenTime.UTC())
+	}
+}
@@ -0,0 +1,201 @@
+package orb
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	prepareLead = 10 * time.Minute // History is loaded this long before the open
+	pollDelay   = 5 * time.Second  // Gives the vendor time to publish a closed minute
+	minuteMs    = int64(60000)
+)
+
+// Runner trades a session on every NYSE weekday while started, polling its
+// source once a minute. It is safe for concurrent use.
+type Runner struct {
+	mu      sync.RWMutex
+	cfg     Config
+	src     Source
+	onEvent func(Event)
+	session *Session
+	stop    chan struct{}
+}
+
+// NewRunner creates a stopped runner; onEvent receives every session event
+// and may be nil
+func NewRunner(cfg Config, src Source, onEvent func(Event)) *Runner {
+	return &Runner{cfg: cfg, src: src, onEvent: onEvent}
+}
+
+// Config returns the universe and screening settings
+func (r *Runner) Config() Config {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cfg
+}
+
+// SetConfig replaces the settings; the next session picks them up
+func (r *Runner) SetConfig(cfg Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cfg = cfg
+	return nil
+}
+
+// Running reports whether the session loop is active
+func (r *Runner) Running() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.stop != nil
+}
+
+// Session returns a copy of the current or last session, or nil
+func (r *Runner) Session() *Session {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.session == nil {
+		return nil
+	}
+	return r.session.Clone()
+}
+
+// Start runs today's session, or waits for the next one
+func (r *Runner) Start() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stop != nil {
+		return fmt.Errorf("ORB session already running")
+	}
+	if r.src == nil {
+		return fmt.Errorf("ORB session has no bar source")
+	}
+	cfg := r.cfg
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	r.cfg = cfg
+	stop := make(chan struct{})
+	r.stop = stop
+
+	go r.run(stop)
+	log.Printf("📈 ORB session started (%d stocks, %d-minute range)", len(cfg.Universe), cfg.TimeFrame)
+	return nil
+}
+
+// Stop ends the loop; the current session is kept for inspection and open
+// positions are left to be managed by hand
+func (r *Runner) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stop != nil {
+		close(r.stop)
+		r.stop = nil
+		log.Println("📈 ORB session stopped")
+	}
+}
+
+func (r *Runner) run(stop chan struct{}) {
+	for {
+		day := SessionDay(time.Now())
+		s := r.resume(day)
+		if s == nil {
+			s = NewSession(r.Config(), day)
+			if !sleepUntil(s.OpenTime.Add(-prepareLead), stop) {
+				return
+			}
+			s.Prepare(r.src)
+			r.mu.Lock()
+			r.session = s
+			r.mu.Unlock()
+			log.Printf("📈 ORB session %s: %d of %d stocks screened in", s.Day, len(s.Watched()), len(s.Config.Universe))
+		}
+
+		for r.phase(s) != PhaseClosed {
+			r.poll(s, time.Now())
+			next := time.Now().Truncate(time.Minute).Add(time.Minute + pollDelay)
+			if !sleepUntil(next, stop) {
+				return
+			}
+		}
+		if !sleepUntil(s.CloseTime, stop) {
+			return
+		}
+	}
+}
+
+// resume returns the session of day if one was already started, so a
+// restart does not replay fills that were already emitted
+func (r *Runner) resume(day time.Time) *Session {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.session != nil && r.session.Day == day.In(newYork).Format("2006-01-02") {
+		return r.session
+	}
+	return nil
+}
+
+// poll feeds the bars that have closed by now and advances the clock
+func (r *Runner) poll(s *Session, now time.Time) {
+	r.mu.RLock()
+	symbols := s.Watched()
+	r.mu.RUnlock()
+
+	bars, failed := fetchBars(r.src, symbols, s.PremarketStart, now)
+	for symbol, err := range failed {
+		log.Printf("⚠️  ORB session: %s: %v", symbol, err)
+	}
+	closed := bars[:0]
+	for _, b := range bars {
+		if b.Timestamp+minuteMs <= now.UnixMilli() {
+			closed = append(closed, b)
+		}
+	}
+
+	r.mu.Lock()
+	events := append(s.Feed(closed), s.Advance(now)...)
+	r.mu.Unlock()
+
+	for _, e := range events {
+		log.Printf("📈 ORB %s %s %s @ %.2f", e.Type, e.Trade.Direction, e.Trade.Symbol, e.Price)
+		if r.onEvent != nil {
+			r.onEvent(e)
+		}
+	}
+}
+
+func (r *Runner) phase(s *Session) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return s.Phase
+}
+
+// sleepUntil waits for t and reports false if stop closed first
+func sleepUntil(t time.Time, stop chan struct{}) bool {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+	select {
+	case <-stop:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// SessionDay returns the weekday whose session is running at now or is the
+// next to open. Exchange holidays are not skipped; their sessions see no bars.
+func SessionDay(now time.Time) time.Time {
+	day := now.In(newYork)
+	if !day.Before(clockAt(day, 16, 0)) {
+		day = day.AddDate(0, 0, 1)
+	}
+	for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		day = day.AddDate(0, 0, 1)
+	}
+	return clockAt(day, 0, 0)
+}
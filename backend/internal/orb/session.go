@@ -0,0 +1,659 @@
+package orb
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	_ "time/tzdata" // The session clock is New York time on any host
+)
+
+// ==================== LIVE ORB SESSION ====================
+// Runs the academic ORB strategy over a stock universe for one NYSE session:
+// screens each stock on price, ATR, volume and an optional premarket gap,
+// builds its opening range from one-minute bars, ranks the breakouts by
+// relative volume, then enters on the range break, stops out at the ATR stop
+// and flattens whatever is still open before the close.
+
+// newYork is the session clock; time/tzdata embeds it, so failing to load it
+// is a build problem and stops start-up
+var newYork = mustLoadLocation("America/New_York")
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(fmt.Sprintf("orb: %v", err))
+	}
+	return loc
+}
+
+const historyDays = 14 // Days of ATR, volume and opening range history
+
+// Session phases
+const (
+	PhasePremarket    = "premarket"
+	PhaseOpeningRange = "opening_range"
+	PhaseTrading      = "trading"
+	PhaseClosed       = "closed"
+)
+
+// Event types
+const (
+	EventSelected = "selected" // Breakout ranked into the top N, stop-entry order working
+	EventEntry    = "entry"    // Range break filled the entry
+	EventStop     = "stop"     // ATR stop hit
+	EventFlatten  = "flatten"  // Position closed before the bell
+	EventExpired  = "expired"  // Range never broke
+)
+
+// Config is the universe and screening settings of a session. Zero gap and
+// premarket volume fields disable those filters.
+type Config struct {
+	Universe       []string `json:"universe"`
+	TimeFrame      int      `json:"timeFrame"`      // Opening range minutes (5, 15, 30 or 60)
+	Capital        float64  `json:"capital"`        // Capital positions are sized on
+	TopN           int      `json:"topN"`           // Breakouts traded per day, by relative volume
+	MinRelativeVol float64  `json:"minRelativeVol"` // Opening range volume vs its 14-day average
+	MinPrice       float64  `json:"minPrice"`
+	MinATR         float64  `json:"minATR"`
+	MinAvgVolume   float64  `json:"minAvgVolume"`
+	FlattenMinutes int      `json:"flattenMinutes"` // Minutes before the close open positions are flattened
+
+	MinGapPct          float64 `json:"minGapPct"`          // Minimum absolute gap from the prior close (%)
+	MaxGapPct          float64 `json:"maxGapPct"`          // Maximum absolute gap from the prior close (%)
+	MinPremarketVolume float64 `json:"minPremarketVolume"` // Shares traded from 04:00 to the open
+	GapAligned         bool    `json:"gapAligned"`         // Only trade breakouts in the direction of the gap
+}
+
+// DefaultConfig uses the paper's 5-minute range and filters
+func DefaultConfig() Config {
+	s := NewORBAcademicStrategy(5)
+	return Config{
+		TimeFrame:      s.TimeFrame,
+		Capital:        25000,
+		TopN:           s.TopNStocks,
+		MinRelativeVol: s.MinRelativeVol,
+		MinPrice:       s.MinPrice,
+		MinATR:         s.MinATR,
+		MinAvgVolume:   s.MinAvgVolume,
+		FlattenMinutes: 1,
+	}
+}
+
+// Validate upper-cases the universe, dropping blank symbols, and fills unset
+// sizing and screen thresholds with defaults. It rejects an empty universe,
+// opening ranges other than 5, 15, 30 or 60 minutes and a maximum gap below
+// the minimum.
+func (cfg *Config) Validate() error {
+	def := DefaultConfig()
+	universe := []string{}
+	for _, symbol := range cfg.Universe {
+		if symbol = strings.ToUpper(strings.TrimSpace(symbol)); symbol != "" {
+			universe = append(universe, symbol)
+		}
+	}
+	if len(universe) == 0 {
+		return fmt.Errorf("universe is empty")
+	}
+	cfg.Universe = universe
+	if cfg.TimeFrame == 0 {
+		cfg.TimeFrame = def.TimeFrame
+	}
+	if cfg.TimeFrame != 5 && cfg.TimeFrame != 15 && cfg.TimeFrame != 30 && cfg.TimeFrame != 60 {
+		return fmt.Errorf("invalid timeframe %d: must be 5, 15, 30 or 60 minutes", cfg.TimeFrame)
+	}
+	if cfg.Capital <= 0 {
+		cfg.Capital = def.Capital
+	}
+	if cfg.TopN <= 0 {
+		cfg.TopN = def.TopN
+	}
+	if cfg.MinRelativeVol <= 0 {
+		cfg.MinRelativeVol = def.MinRelativeVol
+	}
+	if cfg.MinPrice <= 0 {
+		cfg.MinPrice = def.MinPrice
+	}
+	if cfg.MinATR <= 0 {
+		cfg.MinATR = def.MinATR
+	}
+	if cfg.MinAvgVolume <= 0 {
+		cfg.MinAvgVolume = def.MinAvgVolume
+	}
+	if cfg.FlattenMinutes <= 0 {
+		cfg.FlattenMinutes = def.FlattenMinutes
+	}
+	if cfg.MaxGapPct > 0 && cfg.MaxGapPct < cfg.MinGapPct {
+		return fmt.Errorf("maxGapPct %.2f is below minGapPct %.2f", cfg.MaxGapPct, cfg.MinGapPct)
+	}
+	return nil
+}
+
+// Strategy returns the academic ORB strategy with the config's filters
+func (cfg Config) Strategy() *ORBAcademicStrategy {
+	s := NewORBAcademicStrategy(cfg.TimeFrame)
+	s.TopNStocks = cfg.TopN
+	s.MinRelativeVol = cfg.MinRelativeVol
+	s.MinPrice = cfg.MinPrice
+	s.MinATR = cfg.MinATR
+	s.MinAvgVolume = cfg.MinAvgVolume
+	return s
+}
+
+// Source supplies the bars a session trades on: a live source polls a data
+// vendor, a replay source serves a past day
+type Source interface {
+	// Daily returns up to n completed daily bars before day, oldest first
+	Daily(symbol string, day time.Time, n int) ([]Candle, error)
+	// Minutes returns the one-minute bars of symbol, premarket included,
+	// opening in [from, to), oldest first
+	Minutes(symbol string, from, to time.Time) ([]Candle, error)
+}
+
+// Bar is a one-minute bar of a universe member
+type Bar struct {
+	Symbol string
+	Candle
+}
+
+// Stock is a universe member's screen and opening range for the day
+type Stock struct {
+	Symbol          string  `json:"symbol"`
+	Status          string  `json:"status"`           // "screening", "rejected", "candidate" or "selected"
+	Reason          string  `json:"reason,omitempty"` // Why the stock was rejected
+	PrevClose       float64 `json:"prevClose"`
+	ATR14           float64 `json:"atr14"`
+	AvgVolume14     float64 `json:"avgVolume14"`
+	PremarketVolume float64 `json:"premarketVolume"`
+	GapPct          float64 `json:"gapPct"` // Last premarket price, then the open, vs the prior close
+	Open            float64 `json:"open"`
+	ORHigh          float64 `json:"orHigh"`
+	ORLow           float64 `json:"orLow"`
+	ORClose         float64 `json:"orClose"`
+	ORVolume        float64 `json:"orVolume"`
+	RelativeVolume  float64 `json:"relativeVolume"`
+	Direction       string  `json:"direction,omitempty"`
+	Last            float64 `json:"last"`
+	LastBar         int64   `json:"lastBar"` // Open time of the last bar fed (ms)
+
+	orVolumes []float64 // Opening range volume of the history days
+}
+
+// Trade is a selected breakout and its fills
+type Trade struct {
+	Symbol       string  `json:"symbol"`
+	Direction    string  `json:"direction"` // "LONG" or "SHORT"
+	Status       string  `json:"status"`    // "pending", "open", "stopped", "flattened" or "expired"
+	EntryPrice   float64 `json:"entryPrice"`
+	StopLoss     float64 `json:"stopLoss"`
+	PositionSize int     `json:"positionSize"`
+	RiskAmount   float64 `json:"riskAmount"`
+	RelativeVol  float64 `json:"relativeVol"`
+	ATR          float64 `json:"atr"`
+	ORHigh       float64 `json:"orHigh"`
+	ORLow        float64 `json:"orLow"`
+	EntryTime    int64   `json:"entryTime,omitempty"` // ms
+	ExitPrice    float64 `json:"exitPrice,omitempty"`
+	ExitTime     int64   `json:"exitTime,omitempty"` // ms
+	PnL          float64 `json:"pnl"`
+	PnLInR       float64 `json:"pnlInR"`
+
+	signal *ORBSignal
+}
+
+// sync copies the signal's levels and results onto the trade
+func (t *Trade) sync() {
+	s := t.signal
+	t.EntryPrice, t.StopLoss = s.EntryPrice, s.StopLoss
+	t.PositionSize, t.RiskAmount = s.PositionSize, s.RiskAmount
+	t.ExitPrice, t.PnL, t.PnLInR = s.ExitPrice, s.PnL, s.PnLInR
+}
+
+// Event is a selection, fill or exit, emitted as it happens
+type Event struct {
+	Type  string  `json:"type"`
+	Time  int64   `json:"time"`  // ms
+	Price float64 `json:"price"` // Fill price; the entry level for "selected" and "expired"
+	Day   string  `json:"day"`
+	Trade Trade   `json:"trade"`
+}
+
+// Session is one trading day of the ORB strategy. Bars must be fed in time
+// order across the universe; it is not safe for concurrent use.
+type Session struct {
+	Day    string            `json:"day"` // YYYY-MM-DD, New York
+	Config Config            `json:"config"`
+	Phase  string            `json:"phase"`
+	Clock  int64             `json:"clock"` // Latest time the session has seen (ms)
+	Stocks map[string]*Stock `json:"stocks"`
+	Trades []*Trade          `json:"trades"`
+	Events []Event           `json:"events"`
+
+	PremarketStart time.Time `json:"premarketStart"`
+	OpenTime       time.Time `json:"openTime"`
+	RangeEnd       time.Time `json:"rangeEnd"`
+	FlattenTime    time.Time `json:"flattenTime"`
+	CloseTime      time.Time `json:"closeTime"`
+
+	strategy *ORBAcademicStrategy
+}
+
+// clockAt returns hour:min New York time on day's New York date
+func clockAt(day time.Time, hour, min int) time.Time {
+	d := day.In(newYork)
+	return time.Date(d.Year(), d.Month(), d.Day(), hour, min, 0, 0, newYork)
+}
+
+// NewSession creates the session of day's New York date; cfg must be valid
+func NewSession(cfg Config, day time.Time) *Session {
+	s := &Session{
+		Day:            day.In(newYork).Format("2006-01-02"),
+		Config:         cfg,
+		Phase:          PhasePremarket,
+		Stocks:         make(map[string]*Stock),
+		Trades:         []*Trade{},
+		Events:         []Event{},
+		PremarketStart: clockAt(day, 4, 0),
+		OpenTime:       clockAt(day, 9, 30),
+		CloseTime:      clockAt(day, 16, 0),
+		strategy:       cfg.Strategy(),
+	}
+	s.RangeEnd = s.OpenTime.Add(s.rangeLength())
+	s.FlattenTime = s.CloseTime.Add(-time.Duration(cfg.FlattenMinutes) * time.Minute)
+	for _, symbol := range cfg.Universe {
+		s.Stocks[symbol] = &Stock{Symbol: symbol, Status: "screening"}
+	}
+	return s
+}
+
+// Prepare loads each stock's daily history and past opening ranges. Stocks
+// whose history cannot be loaded are rejected; the session still runs.
+func (s *Session) Prepare(src Source) {
+	for _, symbol := range s.Config.Universe {
+		if err := s.prepareStock(src, s.Stocks[symbol]); err != nil {
+			s.reject(s.Stocks[symbol], err.Error())
+		}
+	}
+}
+
+func (s *Session) prepareStock(src Source, st *Stock) error {
+	daily, err := src.Daily(st.Symbol, s.OpenTime, historyDays+1)
+	if err != nil {
+		return fmt.Errorf("daily history: %v", err)
+	}
+	// CalculateATR14 needs the bar before the 14-day window
+	if len(daily) < historyDays+1 {
+		return fmt.Errorf("%d daily bars, need %d", len(daily), historyDays+1)
+	}
+	volumes := make([]float64, len(daily))
+	for i, c := range daily {
+		volumes[i] = c.Volume
+	}
+	st.PrevClose = daily[len(daily)-1].Close
+	st.ATR14 = CalculateATR14(daily)
+	st.AvgVolume14 = CalculateAvgVolume14(volumes)
+
+	// Opening range volume of each history day, from one minute request
+	days := daily[len(daily)-historyDays:]
+	minutes, err := src.Minutes(st.Symbol, clockAt(time.UnixMilli(days[0].Timestamp), 9, 30), s.PremarketStart)
+	if err != nil {
+		return fmt.Errorf("opening range history: %v", err)
+	}
+	for _, c := range days {
+		open := clockAt(time.UnixMilli(c.Timestamp), 9, 30)
+		from, to := open.UnixMilli(), open.Add(s.rangeLength()).UnixMilli()
+		volume, bars := 0.0, 0
+		for _, m := range minutes {
+			if m.Timestamp >= from && m.Timestamp < to {
+				volume += m.Volume
+				bars++
+			}
+		}
+		if bars > 0 {
+			st.orVolumes = append(st.orVolumes, volume)
+		}
+	}
+	return nil
+}
+
+func (s *Session) rangeLength() time.Duration {
+	return time.Duration(s.Config.TimeFrame) * time.Minute
+}
+
+// Feed processes bars in time order. Bars already seen for a stock and bars
+// of symbols outside the universe are ignored.
+func (s *Session) Feed(bars []Bar) []Event {
+	events := []Event{}
+	for _, b := range bars {
+		st, ok := s.Stocks[b.Symbol]
+		if !ok || b.Timestamp <= st.LastBar {
+			continue
+		}
+		// Bars opening at a phase boundary are fed after the transition
+		events = append(events, s.Advance(time.UnixMilli(b.Timestamp))...)
+		events = append(events, s.onBar(st, b.Candle)...)
+	}
+	return events
+}
+
+// Advance moves the session clock to now, screening the universe once the
+// opening range is complete and flattening at the flatten time
+func (s *Session) Advance(now time.Time) []Event {
+	ts := now.UnixMilli()
+	if ts > s.Clock {
+		s.Clock = ts
+	}
+	events := []Event{}
+	if s.Phase == PhasePremarket && !now.Before(s.OpenTime) {
+		s.Phase = PhaseOpeningRange
+	}
+	if s.Phase == PhaseOpeningRange && !now.Before(s.RangeEnd) {
+		events = append(events, s.screen()...)
+		s.Phase = PhaseTrading
+	}
+	if s.Phase == PhaseTrading && !now.Before(s.FlattenTime) {
+		events = append(events, s.flatten()...)
+		s.Phase = PhaseClosed
+	}
+	return events
+}
+
+func (s *Session) onBar(st *Stock, c Candle) []Event {
+	st.LastBar = c.Timestamp
+	ts := time.UnixMilli(c.Timestamp)
+	events := []Event{}
+
+	switch {
+	case ts.Before(s.PremarketStart):
+	case ts.Before(s.OpenTime):
+		st.PremarketVolume += c.Volume
+		st.GapPct = gapPct(c.Close, st.PrevClose)
+	case ts.Before(s.RangeEnd):
+		if st.Open == 0 {
+			st.Open, st.ORHigh, st.ORLow = c.Open, c.High, c.Low
+			st.GapPct = gapPct(c.Open, st.PrevClose)
+		}
+		st.ORHigh = math.Max(st.ORHigh, c.High)
+		st.ORLow = math.Min(st.ORLow, c.Low)
+		st.ORClose = c.Close
+		st.ORVolume += c.Volume
+	case ts.Before(s.FlattenTime):
+		if t := s.trade(st.Symbol); t != nil {
+			events = append(events, s.manage(t, c)...)
+		}
+	}
+
+	if !ts.Before(s.PremarketStart) && ts.Before(s.FlattenTime) {
+		st.Last = c.Close
+	}
+	return events
+}
+
+// gapPct is price's distance from the prior close in percent
+func gapPct(price, prevClose float64) float64 {
+	if prevClose == 0 {
+		return 0
+	}
+	return (price/prevClose - 1) * 100
+}
+
+// screen applies the strategy and gap filters to every stock's opening range
+// and selects the top N by relative volume
+func (s *Session) screen() []Event {
+	cfg := s.Config
+	candidates := []StockCandidate{}
+	for _, symbol := range cfg.Universe {
+		st := s.Stocks[symbol]
+		if st.Status != "screening" {
+			continue
+		}
+		if reason := s.filter(st); reason != "" {
+			s.reject(st, reason)
+			continue
+		}
+		st.Status = "candidate"
+		candidates = append(candidates, StockCandidate{
+			Symbol:         st.Symbol,
+			OpenPrice:      st.Open,
+			ORHigh:         st.ORHigh,
+			ORLow:          st.ORLow,
+			ORClose:        st.ORClose,
+			ORVolume:       st.ORVolume,
+			ATR14:          st.ATR14,
+			AvgVolume14:    st.AvgVolume14,
+			RelativeVolume: st.RelativeVolume,
+			Direction:      st.Direction,
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].RelativeVolume > candidates[j].RelativeVolume
+	})
+	if len(candidates) > s.strategy.TopNStocks {
+		candidates = candidates[:s.strategy.TopNStocks]
+	}
+
+	events := []Event{}
+	for _, candidate := range candidates {
+		st := s.Stocks[candidate.Symbol]
+		signal := s.strategy.GenerateORBSignal(candidate, cfg.Capital, s.RangeEnd)
+		if signal == nil {
+			s.reject(st, "position size is zero")
+			continue
+		}
+		st.Status = "selected"
+		t := &Trade{
+			Symbol:      signal.Symbol,
+			Direction:   signal.Direction,
+			Status:      "pending",
+			RelativeVol: signal.RelativeVol,
+			ATR:         signal.ATR,
+			ORHigh:      signal.ORHigh,
+			ORLow:       signal.ORLow,
+			signal:      signal,
+		}
+		t.sync()
+		s.Trades = append(s.Trades, t)
+		events = append(events, s.emit(EventSelected, s.RangeEnd.UnixMilli(), t.EntryPrice, t))
+	}
+	return events
+}
+
+// filter returns why a stock fails the strategy or gap filters, or ""
+func (s *Session) filter(st *Stock) string {
+	cfg := s.Config
+	if st.Open == 0 {
+		return "no opening range bars"
+	}
+	if !s.strategy.MeetsBasicFilters(st.Open, st.ATR14, st.AvgVolume14) {
+		return fmt.Sprintf("open %.2f, ATR %.2f or average volume %.0f below minimum", st.Open, st.ATR14, st.AvgVolume14)
+	}
+
+	gap := math.Abs(st.GapPct)
+	if cfg.MinGapPct > 0 && gap < cfg.MinGapPct {
+		return fmt.Sprintf("gap %.2f%% below %.2f%%", st.GapPct, cfg.MinGapPct)
+	}
+	if cfg.MaxGapPct > 0 && gap > cfg.MaxGapPct {
+		return fmt.Sprintf("gap %.2f%% above %.2f%%", st.GapPct, cfg.MaxGapPct)
+	}
+	if cfg.MinPremarketVolume > 0 && st.PremarketVolume < cfg.MinPremarketVolume {
+		return fmt.Sprintf("premarket volume %.0f below %.0f", st.PremarketVolume, cfg.MinPremarketVolume)
+	}
+
+	st.RelativeVolume = CalculateRelativeVolume(st.ORVolume, st.orVolumes)
+	if st.RelativeVolume < s.strategy.MinRelativeVol {
+		return fmt.Sprintf("relative volume %.2f below %.2f", st.RelativeVolume, s.strategy.MinRelativeVol)
+	}
+	st.Direction = DetermineDirection(st.Open, st.ORClose)
+	if st.Direction == "NONE" {
+		return "opening range closed flat"
+	}
+	if cfg.GapAligned && (st.GapPct > 0 && st.Direction == "SHORT" || st.GapPct < 0 && st.Direction == "LONG") {
+		return fmt.Sprintf("%s range against a %.2f%% gap", strings.ToLower(st.Direction), st.GapPct)
+	}
+	return ""
+}
+
+func (s *Session) reject(st *Stock, reason string) {
+	st.Status = "rejected"
+	st.Reason = reason
+}
+
+// trade returns the stock's working or open trade
+func (s *Session) trade(symbol string) *Trade {
+	for _, t := range s.Trades {
+		if t.Symbol == symbol && (t.Status == "pending" || t.Status == "open") {
+			return t
+		}
+	}
+	return nil
+}
+
+// manage fills the stop-entry and the stop on a bar. A bar that opens
+// through a level fills at its open; a bar that breaks the range and
+// reverses through the stop is taken as stopped out.
+func (s *Session) manage(t *Trade, c Candle) []Event {
+	signal := t.signal
+	long := signal.Direction == "LONG"
+	events := []Event{}
+
+	if t.Status == "pending" {
+		trigger := c.Low
+		if long {
+			trigger = c.High
+		}
+		if !signal.CheckEntryTrigger(trigger) {
+			return events
+		}
+		if long && c.Open > signal.EntryPrice || !long && c.Open < signal.EntryPrice {
+			signal.EntryPrice = c.Open
+		}
+		t.Status = "open"
+		t.EntryTime = c.Timestamp
+		t.sync()
+		events = append(events, s.emit(EventEntry, c.Timestamp, signal.EntryPrice, t))
+	}
+
+	adverse := c.High
+	if long {
+		adverse = c.Low
+	}
+	if !signal.CheckStopLoss(adverse) {
+		return events
+	}
+	signal.ExitPrice = signal.StopLoss
+	if long && c.Open < signal.StopLoss || !long && c.Open > signal.StopLoss {
+		signal.ExitPrice = c.Open
+	}
+	return append(events, s.exit(t, "stopped", EventStop, c.Timestamp))
+}
+
+// flatten closes open positions at the last price and expires the rest
+func (s *Session) flatten() []Event {
+	at := s.FlattenTime.UnixMilli()
+	events := []Event{}
+	for _, t := range s.Trades {
+		switch t.Status {
+		case "open":
+			t.signal.ExitPrice = s.Stocks[t.Symbol].Last
+			events = append(events, s.exit(t, "flattened", EventFlatten, at))
+		case "pending":
+			t.Status = "expired"
+			events = append(events, s.emit(EventExpired, at, t.EntryPrice, t))
+		}
+	}
+	return events
+}
+
+func (s *Session) exit(t *Trade, status, event string, at int64) Event {
+	t.signal.ExitTime = time.UnixMilli(at)
+	s.strategy.CalculatePnL(t.signal)
+	t.Status = status
+	t.ExitTime = at
+	t.sync()
+	return s.emit(event, at, t.ExitPrice, t)
+}
+
+func (s *Session) emit(kind string, at int64, price float64, t *Trade) Event {
+	e := Event{Type: kind, Time: at, Price: price, Day: s.Day, Trade: *t}
+	s.Events = append(s.Events, e)
+	return e
+}
+
+// Clone returns a copy of the session that does not share mutable state
+func (s *Session) Clone() *Session {
+	c := *s
+	c.Stocks = make(map[string]*Stock, len(s.Stocks))
+	for symbol, st := range s.Stocks {
+		copied := *st
+		c.Stocks[symbol] = &copied
+	}
+	c.Trades = make([]*Trade, len(s.Trades))
+	for i, t := range s.Trades {
+		copied := *t
+		copied.signal = nil
+		c.Trades[i] = &copied
+	}
+	c.Events = append([]Event{}, s.Events...)
+	return &c
+}
+
+// Watched returns the universe members that have not been rejected
+func (s *Session) Watched() []string {
+	symbols := []string{}
+	for _, symbol := range s.Config.Universe {
+		if s.Stocks[symbol].Status != "rejected" {
+			symbols = append(symbols, symbol)
+		}
+	}
+	return symbols
+}
+
+// Replay runs day's session on src's bars, passes its events in order to
+// onEvent (which may be nil) and returns the finished session
+func Replay(cfg Config, src Source, day time.Time, onEvent func(Event)) (*Session, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	s := NewSession(cfg, day)
+	s.Prepare(src)
+
+	symbols := s.Watched()
+	bars, failed := fetchBars(src, symbols, s.PremarketStart, s.CloseTime)
+	if len(symbols) > 0 && len(failed) == len(symbols) {
+		return nil, fmt.Errorf("no minute bars loaded for %s: %v", s.Day, failed[symbols[0]])
+	}
+	for symbol, err := range failed {
+		s.reject(s.Stocks[symbol], fmt.Sprintf("minute bars: %v", err))
+	}
+
+	events := append(s.Feed(bars), s.Advance(s.CloseTime)...)
+	if onEvent != nil {
+		for _, e := range events {
+			onEvent(e)
+		}
+	}
+	return s, nil
+}
+
+// fetchBars loads the bars of symbols opening in [from, to) merged in time
+// order, and the error of every symbol that failed to load
+func fetchBars(src Source, symbols []string, from, to time.Time) ([]Bar, map[string]error) {
+	bars := []Bar{}
+	failed := map[string]error{}
+	for _, symbol := range symbols {
+		candles, err := src.Minutes(symbol, from, to)
+		if err != nil {
+			failed[symbol] = err
+			continue
+		}
+		for _, c := range candles {
+			bars = append(bars, Bar{Symbol: symbol, Candle: c})
+		}
+	}
+	sort.SliceStable(bars, func(i, j int) bool {
+		return bars[i].Timestamp < bars[j].Timestamp
+	})
+	return bars, failed
+}
@@ -0,0 +1,251 @@
+package pairs
+
+import (
+	"fmt"
+	"math"
+)
+
+// ==================== PAIRS / STATISTICAL ARBITRAGE ====================
+// Engle-Granger cointegration on log prices: regress log(A) on log(B), then
+// test the residual spread for a unit root with an augmented Dickey-Fuller
+// regression. A stationary spread mean-reverts and can be traded market
+// neutral by holding A against HedgeRatio units of B.
+
+// Engle-Granger critical values for two variables with a constant (MacKinnon 2010)
+var egCriticalValues = map[string]float64{
+	"1%":  -3.90,
+	"5%":  -3.34,
+	"10%": -3.04,
+}
+
+// Cointegration is the result of an Engle-Granger test
+type Cointegration struct {
+	Alpha          float64            `json:"alpha"`
+	HedgeRatio     float64            `json:"hedgeRatio"` // Beta of log(A) on log(B)
+	ADFStat        float64            `json:"adfStat"`
+	ADFLags        int                `json:"adfLags"`
+	CriticalValues map[string]float64 `json:"criticalValues"`
+	Significance   string             `json:"significance"` // Strictest level the test passes ("1%", "5%", "10%" or "")
+	Cointegrated   bool               `json:"cointegrated"` // Passes at 5%
+	HalfLife       float64            `json:"halfLife"`     // Bars for the spread to revert halfway; 0 if not mean reverting
+	Observations   int                `json:"observations"`
+}
+
+// EngleGranger tests two aligned close series for cointegration. lags is the
+// number of lagged differences in the ADF regression (0 picks 12*(n/100)^(1/4)).
+func EngleGranger(a, b []float64, lags int) (*Cointegration, error) {
+	if len(a) != len(b) {
+		return nil, fmt.Errorf("series lengths differ: %d vs %d", len(a), len(b))
+	}
+	if len(a) < 30 {
+		return nil, fmt.Errorf("need at least 30 observations, got %d", len(a))
+	}
+	la, lb, err := logPrices(a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	alpha, beta := OLS(lb, la)
+	spread := make([]float64, len(la))
+	for i := range la {
+		spread[i] = la[i] - beta*lb[i] - alpha
+	}
+
+	if lags <= 0 {
+		lags = int(12 * math.Pow(float64(len(spread))/100, 0.25))
+	}
+	if lags > len(spread)/4 {
+		lags = len(spread) / 4
+	}
+
+	result := &Cointegration{
+		Alpha:          alpha,
+		HedgeRatio:     beta,
+		ADFStat:        ADF(spread, lags),
+		ADFLags:        lags,
+		CriticalValues: egCriticalValues,
+		HalfLife:       HalfLife(spread),
+		Observations:   len(spread),
+	}
+	for _, level := range []string{"1%", "5%", "10%"} {
+		if result.ADFStat < egCriticalValues[level] {
+			result.Significance = level
+			break
+		}
+	}
+	result.Cointegrated = result.ADFStat < egCriticalValues["5%"]
+	return result, nil
+}
+
+func logPrices(a, b []float64) ([]float64, []float64, error) {
+	la := make([]float64, len(a))
+	lb := make([]float64, len(b))
+	for i := range a {
+		if a[i] <= 0 || b[i] <= 0 {
+			return nil, nil, fmt.Errorf("non-positive price at %d", i)
+		}
+		la[i] = math.Log(a[i])
+		lb[i] = math.Log(b[i])
+	}
+	return la, lb, nil
+}
+
+// OLS fits y = alpha + beta*x by least squares
+func OLS(x, y []float64) (alpha, beta float64) {
+	n := float64(len(x))
+	if n == 0 {
+		return 0, 0
+	}
+	var sx, sy float64
+	for i := range x {
+		sx += x[i]
+		sy += y[i]
+	}
+	mx, my := sx/n, sy/n
+	var cov, varX float64
+	for i := range x {
+		cov += (x[i] - mx) * (y[i] - my)
+		varX += (x[i] - mx) * (x[i] - mx)
+	}
+	if varX == 0 {
+		return my, 0
+	}
+	beta = cov / varX
+	return my - beta*mx, beta
+}
+
+// ADF returns the augmented Dickey-Fuller t-statistic of gamma in
+// Δs_t = gamma*s_{t-1} + Σ phi_i*Δs_{t-i} + e_t. The regression has no
+// constant because Engle-Granger residuals are already demeaned.
+func ADF(series []float64, lags int) float64 {
+	rows := len(series) - lags - 1
+	k := lags + 1
+	if rows <= k {
+		return 0
+	}
+
+	X := make([][]float64, rows)
+	y := make([]float64, rows)
+	for r := 0; r < rows; r++ {
+		t := r + lags + 1
+		y[r] = series[t] - series[t-1]
+		X[r] = make([]float64, k)
+		X[r][0] = series[t-1]
+		for i := 1; i <= lags; i++ {
+			X[r][i] = series[t-i] - series[t-i-1]
+		}
+	}
+
+	coef, se, ok := regress(X, y)
+	if !ok || se[0] == 0 {
+		return 0
+	}
+	return coef[0] / se[0]
+}
+
+// HalfLife estimates the mean-reversion half-life of a spread from
+// Δs_t = lambda*s_{t-1}; it is 0 when the spread does not revert.
+func HalfLife(spread []float64) float64 {
+	if len(spread) < 3 {
+		return 0
+	}
+	lagged := spread[:len(spread)-1]
+	diff := make([]float64, len(lagged))
+	for i := range lagged {
+		diff[i] = spread[i+1] - spread[i]
+	}
+	_, lambda := OLS(lagged, diff)
+	if lambda >= 0 {
+		return 0
+	}
+	return -math.Ln2 / lambda
+}
+
+// regress solves least squares without intercept and returns the
+// coefficients and their standard errors
+func regress(X [][]float64, y []float64) ([]float64, []float64, bool) {
+	n, k := len(X), len(X[0])
+	xtx := make([][]float64, k)
+	xty := make([]float64, k)
+	for i := 0; i < k; i++ {
+		xtx[i] = make([]float64, k)
+	}
+	for r := 0; r < n; r++ {
+		for i := 0; i < k; i++ {
+			xty[i] += X[r][i] * y[r]
+			for j := 0; j < k; j++ {
+				xtx[i][j] += X[r][i] * X[r][j]
+			}
+		}
+	}
+
+	inv, ok := invert(xtx)
+	if !ok {
+		return nil, nil, false
+	}
+	coef := make([]float64, k)
+	for i := 0; i < k; i++ {
+		for j := 0; j < k; j++ {
+			coef[i] += inv[i][j] * xty[j]
+		}
+	}
+
+	var rss float64
+	for r := 0; r < n; r++ {
+		fit := 0.0
+		for i := 0; i < k; i++ {
+			fit += X[r][i] * coef[i]
+		}
+		rss += (y[r] - fit) * (y[r] - fit)
+	}
+	sigma2 := rss / float64(n-k)
+	se := make([]float64, k)
+	for i := 0; i < k; i++ {
+		se[i] = math.Sqrt(sigma2 * inv[i][i])
+	}
+	return coef, se, true
+}
+
+// invert inverts a square matrix by Gauss-Jordan elimination with partial pivoting
+func invert(m [][]float64) ([][]float64, bool) {
+	n := len(m)
+	a := make([][]float64, n)
+	for i := range m {
+		a[i] = make([]float64, 2*n)
+		copy(a[i], m[i])
+		a[i][n+i] = 1
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(a[r][col]) > math.Abs(a[pivot][col]) {
+				pivot = r
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return nil, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+
+		p := a[col][col]
+		for j := range a[col] {
+			a[col][j] /= p
+		}
+		for r := 0; r < n; r++ {
+			if r == col || a[r][col] == 0 {
+				continue
+			}
+			f := a[r][col]
+			for j := range a[r] {
+				a[r][j] -= f * a[col][j]
+			}
+		}
+	}
+
+	inv := make([][]float64, n)
+	for i := range a {
+		inv[i] = a[i][n:]
+	}
+	return inv, true
+}
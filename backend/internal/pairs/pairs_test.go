@@ -0,0 +1,150 @@
+package pairs
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// cointegratedPair builds log(A) = 0.5 + 1.2*log(B) + AR(1) noise around a random-walk B
+func cointegratedPair(n int, seed int64) ([]Candle, []Candle) {
+	rng := rand.New(rand.NewSource(seed))
+	a := make([]Candle, n)
+	b := make([]Candle, n)
+	logB, noise := math.Log(100), 0.0
+	for i := 0; i < n; i++ {
+		logB += rng.NormFloat64() * 0.01
+		noise = 0.5*noise + rng.NormFloat64()*0.01
+		ts := int64(i) * 3600000
+		b[i] = Candle{Timestamp: ts, Close: math.Exp(logB)}
+		a[i] = Candle{Timestamp: ts, Close: math.Exp(0.5 + 1.2*logB + noise)}
+	}
+	return a, b
+}
+
+func closes(candles []Candle) []float64 {
+	out := make([]float64, len(candles))
+	for i, c := range candles {
+		out[i] = c.Close
+	}
+	return out
+}
+
+func TestEngleGrangerDetectsCointegration(t *testing.T) {
+	a, b := cointegratedPair(500, 1)
+	eg, err := EngleGranger(closes(a), closes(b), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !eg.Cointegrated || eg.Significance != "1%" {
+		t.Errorf("expected cointegration at 1%%, got ADF %.2f (%q)", eg.ADFStat, eg.Significance)
+	}
+	if math.Abs(eg.HedgeRatio-1.2) > 0.1 {
+		t.Errorf("expected hedge ratio near 1.2, got %.3f", eg.HedgeRatio)
+	}
+	if eg.HalfLife <= 0 || eg.HalfLife > 5 {
+		t.Errorf("expected a short half-life for AR(0.5) noise, got %.2f", eg.HalfLife)
+	}
+}
+
+func TestEngleGrangerRejectsIndependentWalks(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	a := make([]float64, 500)
+	b := make([]float64, 500)
+	la, lb := math.Log(100), math.Log(50)
+	for i := range a {
+		la += rng.NormFloat64() * 0.01
+		lb += rng.NormFloat64() * 0.01
+		a[i], b[i] = math.Exp(la), math.Exp(lb)
+	}
+	eg, err := EngleGranger(a, b, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eg.Cointegrated {
+		t.Errorf("independent random walks reported cointegrated (ADF %.2f)", eg.ADFStat)
+	}
+}
+
+func TestEngleGrangerValidatesInput(t *testing.T) {
+	if _, err := EngleGranger(make([]float64, 40), make([]float64, 39), 0); err == nil {
+		t.Error("expected an error for different lengths")
+	}
+	if _, err := EngleGranger(make([]float64, 10), make([]float64, 10), 0); err == nil {
+		t.Error("expected an error for short series")
+	}
+}
+
+func TestSpreadAtUsesOnlyPastBars(t *testing.T) {
+	a, b := cointegratedPair(300, 3)
+	cfg := DefaultConfig()
+	before, ok := SpreadAt(a, b, 250, cfg)
+	if !ok {
+		t.Fatal("expected a spread point")
+	}
+	for i := 251; i < len(a); i++ {
+		a[i].Close *= 2
+	}
+	after, _ := SpreadAt(a, b, 250, cfg)
+	if before != after {
+		t.Errorf("spread at bar 250 changed when later bars changed: %+v vs %+v", before, after)
+	}
+	if _, ok := SpreadAt(a, b, cfg.HedgeWindow-2, cfg); ok {
+		t.Error("expected no point before a full hedge window")
+	}
+	if n := len(Series(a, b, cfg)); n != len(a)-cfg.HedgeWindow+1 {
+		t.Errorf("expected %d series points, got %d", len(a)-cfg.HedgeWindow+1, n)
+	}
+}
+
+func TestEntryAndExitBands(t *testing.T) {
+	cfg := DefaultConfig()
+	cases := []struct {
+		z    float64
+		want string
+	}{
+		{2.1, ShortSpread},
+		{-2.1, LongSpread},
+		{1.5, ""},
+		{4.0, ""}, // Already past the stop band
+	}
+	for _, c := range cases {
+		if got := Entry(c.z, cfg); got != c.want {
+			t.Errorf("Entry(%.1f) = %q, want %q", c.z, got, c.want)
+		}
+	}
+
+	if got := Exit(ShortSpread, 0.4, 3, cfg); got != "Mean Reversion" {
+		t.Errorf("short spread at z=0.4 should revert, got %q", got)
+	}
+	if got := Exit(LongSpread, -3.6, 3, cfg); got != "Stop Band" {
+		t.Errorf("long spread at z=-3.6 should stop, got %q", got)
+	}
+	if got := Exit(LongSpread, -1.5, 3, cfg); got != "" {
+		t.Errorf("long spread at z=-1.5 should hold, got %q", got)
+	}
+	cfg.MaxHoldBars = 3
+	if got := Exit(LongSpread, -1.5, 3, cfg); got != "Max Hold" {
+		t.Errorf("expected max hold exit, got %q", got)
+	}
+}
+
+func TestAlignMatchesTimestamps(t *testing.T) {
+	a := []Candle{{Timestamp: 1, Close: 1}, {Timestamp: 2, Close: 2}, {Timestamp: 4, Close: 4}}
+	b := []Candle{{Timestamp: 2, Close: 20}, {Timestamp: 3, Close: 30}, {Timestamp: 4, Close: 40}}
+	alignedA, alignedB := Align(a, b)
+	if len(alignedA) != 2 || alignedA[0].Timestamp != 2 || alignedB[1].Close != 40 {
+		t.Errorf("unexpected alignment %+v / %+v", alignedA, alignedB)
+	}
+}
+
+func TestValidateBands(t *testing.T) {
+	cfg := Config{EntryZ: 2, ExitZ: 2.5}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected exit band above entry band to be rejected")
+	}
+	cfg = Config{}
+	if err := cfg.Validate(); err != nil || cfg.HedgeWindow != 200 || cfg.StopZ != 3.5 {
+		t.Errorf("unexpected defaults %+v, %v", cfg, err)
+	}
+}
@@ -0,0 +1,180 @@
+package pairs
+
+import (
+	"fmt"
+	"math"
+)
+
+// Config controls the rolling hedge ratio and the z-score bands
+type Config struct {
+	HedgeWindow int     `json:"hedgeWindow"` // Bars the rolling hedge ratio is fitted on
+	ZWindow     int     `json:"zWindow"`     // Bars the spread's z-score is measured over
+	EntryZ      float64 `json:"entryZ"`      // Open when |z| reaches this band
+	ExitZ       float64 `json:"exitZ"`       // Close when the spread reverts inside this band
+	StopZ       float64 `json:"stopZ"`       // Close at a loss when |z| keeps widening past this band
+	MaxHoldBars int     `json:"maxHoldBars"` // Close after this many bars (0 = no limit)
+
+	// Only open when the hedge window itself passes Engle-Granger at 5%
+	RequireCointegration bool `json:"requireCointegration"`
+}
+
+// DefaultConfig trades 2σ dislocations back to 0.5σ with a 3.5σ stop
+func DefaultConfig() Config {
+	return Config{
+		HedgeWindow:          200,
+		ZWindow:              100,
+		EntryZ:               2.0,
+		ExitZ:                0.5,
+		StopZ:                3.5,
+		RequireCointegration: true,
+	}
+}
+
+// Validate fills zero windows and entry/stop bands with defaults. It rejects a
+// hedge window under 30 bars, a z-score window outside 10..hedgeWindow and
+// bands out of order (0 <= exitZ < entryZ < stopZ).
+func (cfg *Config) Validate() error {
+	def := DefaultConfig()
+	if cfg.HedgeWindow == 0 {
+		cfg.HedgeWindow = def.HedgeWindow
+	}
+	if cfg.ZWindow == 0 {
+		cfg.ZWindow = def.ZWindow
+	}
+	if cfg.EntryZ == 0 {
+		cfg.EntryZ = def.EntryZ
+	}
+	if cfg.StopZ == 0 {
+		cfg.StopZ = def.StopZ
+	}
+	if cfg.HedgeWindow < 30 {
+		return fmt.Errorf("hedgeWindow must be at least 30 bars, got %d", cfg.HedgeWindow)
+	}
+	if cfg.ZWindow < 10 || cfg.ZWindow > cfg.HedgeWindow {
+		return fmt.Errorf("zWindow must be between 10 and hedgeWindow (%d), got %d", cfg.HedgeWindow, cfg.ZWindow)
+	}
+	if cfg.ExitZ < 0 || cfg.ExitZ >= cfg.EntryZ || cfg.EntryZ >= cfg.StopZ {
+		return fmt.Errorf("bands must satisfy 0 <= exitZ < entryZ < stopZ, got %.2f/%.2f/%.2f", cfg.ExitZ, cfg.EntryZ, cfg.StopZ)
+	}
+	return nil
+}
+
+// Point is the spread state at one bar
+type Point struct {
+	Timestamp  int64   `json:"timestamp"`
+	Alpha      float64 `json:"alpha"`
+	HedgeRatio float64 `json:"hedgeRatio"`
+	Spread     float64 `json:"spread"` // log(A) - hedgeRatio*log(B) - alpha
+	ZScore     float64 `json:"zScore"`
+}
+
+// Align keeps only the bars both legs have, matched by timestamp
+func Align(a, b []Candle) ([]Candle, []Candle) {
+	byTime := make(map[int64]int, len(b))
+	for i, c := range b {
+		byTime[c.Timestamp] = i
+	}
+	alignedA := make([]Candle, 0, len(a))
+	alignedB := make([]Candle, 0, len(a))
+	for _, c := range a {
+		if j, ok := byTime[c.Timestamp]; ok {
+			alignedA = append(alignedA, c)
+			alignedB = append(alignedB, b[j])
+		}
+	}
+	return alignedA, alignedB
+}
+
+// SpreadAt fits the hedge ratio on the HedgeWindow bars ending at idx and
+// returns the spread's z-score over the last ZWindow of them. Only bars up
+// to idx are used, so it is safe to call bar by bar in a backtest.
+func SpreadAt(a, b []Candle, idx int, cfg Config) (Point, bool) {
+	start := idx - cfg.HedgeWindow + 1
+	if start < 0 || idx >= len(a) || idx >= len(b) {
+		return Point{}, false
+	}
+
+	la := make([]float64, cfg.HedgeWindow)
+	lb := make([]float64, cfg.HedgeWindow)
+	for i := range la {
+		pa, pb := a[start+i].Close, b[start+i].Close
+		if pa <= 0 || pb <= 0 {
+			return Point{}, false
+		}
+		la[i], lb[i] = math.Log(pa), math.Log(pb)
+	}
+	alpha, beta := OLS(lb, la)
+
+	spreads := make([]float64, cfg.ZWindow)
+	offset := cfg.HedgeWindow - cfg.ZWindow
+	var sum float64
+	for i := range spreads {
+		spreads[i] = la[offset+i] - beta*lb[offset+i] - alpha
+		sum += spreads[i]
+	}
+	mean := sum / float64(len(spreads))
+	var variance float64
+	for _, s := range spreads {
+		variance += (s - mean) * (s - mean)
+	}
+	std := math.Sqrt(variance / float64(len(spreads)))
+
+	point := Point{
+		Timestamp:  a[idx].Timestamp,
+		Alpha:      alpha,
+		HedgeRatio: beta,
+		Spread:     spreads[len(spreads)-1],
+	}
+	if std > 0 {
+		point.ZScore = (point.Spread - mean) / std
+	}
+	return point, true
+}
+
+// Series returns the spread state at every bar with a full hedge window
+func Series(a, b []Candle, cfg Config) []Point {
+	points := []Point{}
+	for i := cfg.HedgeWindow - 1; i < len(a) && i < len(b); i++ {
+		if p, ok := SpreadAt(a, b, i, cfg); ok {
+			points = append(points, p)
+		}
+	}
+	return points
+}
+
+// Spread positions: long the spread is long A / short B, short the spread
+// is short A / long B
+const (
+	LongSpread  = "long_spread"
+	ShortSpread = "short_spread"
+)
+
+// Entry returns the position to open at a z-score, or "" to stay flat
+func Entry(z float64, cfg Config) string {
+	switch {
+	case z >= cfg.EntryZ && z < cfg.StopZ:
+		return ShortSpread
+	case z <= -cfg.EntryZ && z > -cfg.StopZ:
+		return LongSpread
+	}
+	return ""
+}
+
+// Exit returns why an open position should close at a z-score after
+// barsHeld bars, or "" to keep holding
+func Exit(position string, z float64, barsHeld int, cfg Config) string {
+	// Distance of the spread from its mean, positive on the losing side
+	adverse := z
+	if position == LongSpread {
+		adverse = -z
+	}
+	switch {
+	case adverse >= cfg.StopZ:
+		return "Stop Band"
+	case adverse <= cfg.ExitZ:
+		return "Mean Reversion"
+	case cfg.MaxHoldBars > 0 && barsHeld >= cfg.MaxHoldBars:
+		return "Max Hold"
+	}
+	return ""
+}
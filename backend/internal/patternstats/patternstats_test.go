@@ -0,0 +1,173 @@
+package patternstats
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+)
+
+func bar(close, high, low float64) Candle {
+	return Candle{Open: close, High: high, Low: low, Close: close}
+}
+
+func TestMeasureSignsReturnsAndExcursions(t *testing.T) {
+	candles := []Candle{
+		bar(100, 100, 100),
+		bar(102, 103, 99),
+		bar(98, 101, 96),
+	}
+
+	returns, mfe, mae := measure(candles, 0, 1, []int{1, 2, 5})
+	if returns[0] != 2 || returns[1] != -2 || !math.IsNaN(returns[2]) {
+		t.Errorf("unexpected bullish returns %v", returns)
+	}
+	if mfe != 3 || mae != 4 {
+		t.Errorf("unexpected bullish excursions mfe=%v mae=%v", mfe, mae)
+	}
+
+	returns, mfe, mae = measure(candles, 0, -1, []int{1, 2})
+	if returns[0] != -2 || returns[1] != 2 {
+		t.Errorf("unexpected bearish returns %v", returns)
+	}
+	if mfe != 4 || mae != 3 {
+		t.Errorf("unexpected bearish excursions mfe=%v mae=%v", mfe, mae)
+	}
+}
+
+func TestAggregateGroupsByRegime(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Horizons = []int{1, 5}
+	key := Key{Source: "candlestick", Pattern: "Hammer", Direction: "bullish"}
+	occurrences := []occurrence{
+		{key: key, regime: "trending", claimed: 70, returns: []float64{1, 2}, mfe: 3, mae: 1},
+		{key: key, regime: "trending", claimed: 70, returns: []float64{-1, 4}, mfe: 5, mae: 2},
+		{key: key, regime: "ranging", claimed: 70, returns: []float64{2, -3}, mfe: 1, mae: 3},
+		{key: key, regime: "ranging", claimed: 70, returns: []float64{1, math.NaN()}, mfe: 1, mae: 0},
+	}
+
+	stats := aggregate(occurrences, "1h", cfg)
+	if len(stats) != 3 {
+		t.Fatalf("expected all, ranging and trending rows, got %d", len(stats))
+	}
+	all := stats[0]
+	if all.Regime != AllRegimes || all.Samples != 4 || all.Timeframe != "1h" {
+		t.Fatalf("unexpected pooled row %+v", all)
+	}
+	if all.Horizons[0].HitRate != 75 || all.Horizons[1].Samples != 3 || all.Horizons[1].Wins != 2 {
+		t.Errorf("unexpected pooled horizons %+v", all.Horizons)
+	}
+	if all.Horizons[1].MedianReturn != 2 || all.Horizons[1].MeanReturn != 1 {
+		t.Errorf("unexpected 5-bar returns %+v", all.Horizons[1])
+	}
+	if all.AvgMFE != 2.5 || all.AvgMAE != 1.5 || all.ClaimedReliability != 70 {
+		t.Errorf("unexpected excursions %+v", all)
+	}
+	if math.Abs(all.MeasuredReliability-200.0/3) > 1e-9 {
+		t.Errorf("expected measured reliability from the 5-bar hit rate, got %v", all.MeasuredReliability)
+	}
+	if trending := stats[2]; trending.Regime != "trending" || trending.MeasuredReliability != 100 {
+		t.Errorf("unexpected trending row %+v", trending)
+	}
+}
+
+func TestCalibrateShrinksTowardsPrior(t *testing.T) {
+	key := Key{Source: "candlestick", Pattern: "Hammer", Direction: "bullish"}
+	study := func(symbol string, regimeWins, regimeSamples, allWins, allSamples int) *Study {
+		cfg := DefaultConfig()
+		return &Study{
+			Symbol:    symbol,
+			Timeframe: "1h",
+			Config:    cfg,
+			Stats: []Stats{
+				{Key: key, Timeframe: "1h", Regime: AllRegimes, Horizons: []Horizon{{Bars: 5, Wins: allWins, Samples: allSamples}}},
+				{Key: key, Timeframe: "1h", Regime: "trending", Horizons: []Horizon{{Bars: 5, Wins: regimeWins, Samples: regimeSamples}}},
+			},
+		}
+	}
+
+	s := NewStore("")
+	if got, n := s.Calibrate("BTCUSDT", key, "1h", "trending", 70); got != 70 || n != 0 {
+		t.Errorf("expected prior without stats, got %v (%d samples)", got, n)
+	}
+
+	s.Save(study("BTCUSDT", 5, 10, 30, 90))
+	// Too few trending samples: falls back to the pooled row
+	got, n := s.Calibrate("BTCUSDT", key, "1h", "trending", 70)
+	if n != 90 || math.Abs(got-(30+21)/120.0*100) > 1e-9 {
+		t.Errorf("unexpected pooled calibration %v (%d samples)", got, n)
+	}
+
+	s.Save(study("ETHUSDT", 20, 30, 40, 60))
+	got, n = s.Calibrate("ETHUSDT", key, "1h", "trending", 70)
+	if n != 30 || math.Abs(got-(20+21)/60.0*100) > 1e-9 {
+		t.Errorf("unexpected regime calibration %v (%d samples)", got, n)
+	}
+
+	// Unstudied symbols pool every study of the timeframe
+	if _, n := s.Calibrate("SOLUSDT", key, "1h", "trending", 70); n != 40 {
+		t.Errorf("expected 40 pooled trending samples, got %d", n)
+	}
+	if _, n := s.Calibrate("BTCUSDT", key, "4h", "trending", 70); n != 0 {
+		t.Errorf("expected no samples on an unstudied timeframe, got %d", n)
+	}
+}
+
+func TestCalibratePatternsPoolsStudies(t *testing.T) {
+	key := Key{Source: "candlestick", Pattern: "Hammer", Direction: "bullish"}
+	s := NewStore("")
+	s.Save(&Study{
+		Symbol:    "BTCUSDT",
+		Timeframe: "1h",
+		Config:    DefaultConfig(),
+		Stats: []Stats{
+			{Key: key, Timeframe: "1h", Regime: AllRegimes, Horizons: []Horizon{{Bars: 5, Wins: 30, Samples: 90}}},
+		},
+	})
+
+	patterns := []CandlestickPattern{
+		{Name: "Hammer", Type: "bullish", Reliability: 70},
+		{Name: "Doji", Type: "neutral", Reliability: 55},
+	}
+	s.CalibratePatterns("", "1h", AllRegimes, patterns)
+	if math.Abs(patterns[0].Reliability-(30+21)/120.0*100) > 1e-9 {
+		t.Errorf("unexpected calibrated reliability %v", patterns[0].Reliability)
+	}
+	if patterns[1].Reliability != 55 {
+		t.Errorf("expected the prior for an unstudied pattern, got %v", patterns[1].Reliability)
+	}
+
+	// The hammer measured below a coin flip no longer counts
+	if Reliable(patterns[0]) || !Reliable(patterns[1]) || !Reliable(CandlestickPattern{Reliability: CoinFlip}) {
+		t.Errorf("unexpected reliability gate for %v", patterns)
+	}
+}
+
+func TestStorePersistsStudies(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats", "pattern_stats.json")
+	s := NewStore(path)
+	if n, err := s.Load(); err != nil || n != 0 {
+		t.Fatalf("expected empty load, got %d, %v", n, err)
+	}
+	if err := s.Save(&Study{Symbol: "BTCUSDT", Timeframe: "1h", Config: DefaultConfig()}); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewStore(path)
+	if n, err := loaded.Load(); err != nil || n != 1 {
+		t.Fatalf("expected 1 study, got %d, %v", n, err)
+	}
+	if _, ok := loaded.Get("btcusdt", "1h"); !ok {
+		t.Error("study not found after reload")
+	}
+}
+
+func TestValidateRejectsUnknownCalibrationHorizon(t *testing.T) {
+	cfg := Config{Horizons: []int{10, 1}, CalibrationHorizon: 5}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected an error for a calibration horizon outside the horizons")
+	}
+	cfg = Config{Horizons: []int{10, 1}, CalibrationHorizon: 10}
+	if err := cfg.Validate(); err != nil || cfg.Horizons[0] != 1 || cfg.Window != 60 {
+		t.Errorf("unexpected normalized config %+v, %v", cfg, err)
+	}
+}
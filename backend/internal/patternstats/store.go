@@ -0,0 +1,207 @@
+package patternstats
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultStoreFile is used when PATTERN_STATS_FILE is not set
+const DefaultStoreFile = "./pattern_stats.json"
+
+// StoreFile returns the file studies are persisted to
+func StoreFile() string {
+	if file := os.Getenv("PATTERN_STATS_FILE"); file != "" {
+		return file
+	}
+	return DefaultStoreFile
+}
+
+// Calibration settings: measured hit rates are shrunk towards the detector's
+// hand-set reliability as if it were worth priorSamples observations, and
+// regime-specific rows are only trusted once they hold minRegimeSamples.
+const (
+	priorSamples     = 30
+	minRegimeSamples = 20
+)
+
+// Store keeps the latest study per symbol and timeframe, persisted as JSON
+type Store struct {
+	path    string
+	studies map[string]*Study
+	mu      sync.RWMutex
+}
+
+var store = NewStore(StoreFile())
+
+// GetStore returns the shared study store
+func GetStore() *Store {
+	return store
+}
+
+// NewStore creates an empty store backed by path ("" keeps it in memory)
+func NewStore(path string) *Store {
+	return &Store{
+		path:    path,
+		studies: make(map[string]*Study),
+	}
+}
+
+func studyKey(symbol, timeframe string) string {
+	return strings.ToUpper(symbol) + ":" + timeframe
+}
+
+// Load reads persisted studies; a missing file is not an error
+func (s *Store) Load() (int, error) {
+	if s.path == "" {
+		return 0, nil
+	}
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var studies []*Study
+	if err := json.Unmarshal(data, &studies); err != nil {
+		return 0, fmt.Errorf("%s: %w", s.path, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, study := range studies {
+		s.studies[studyKey(study.Symbol, study.Timeframe)] = study
+	}
+	return len(studies), nil
+}
+
+// Save stores a study, replacing the previous one of its market, and persists the store
+func (s *Store) Save(study *Study) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.studies[studyKey(study.Symbol, study.Timeframe)] = study
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s.list(), "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(s.path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Get returns the study of a market
+func (s *Store) Get(symbol, timeframe string) (*Study, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	study, ok := s.studies[studyKey(symbol, timeframe)]
+	return study, ok
+}
+
+// List returns every study ordered by symbol and timeframe
+func (s *Store) List() []*Study {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.list()
+}
+
+func (s *Store) list() []*Study {
+	list := make([]*Study, 0, len(s.studies))
+	for _, study := range s.studies {
+		list = append(list, study)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Symbol != list[j].Symbol {
+			return list[i].Symbol < list[j].Symbol
+		}
+		return list[i].Timeframe < list[j].Timeframe
+	})
+	return list
+}
+
+// Calibrate turns a detector's hand-set reliability (0-100) into one backed
+// by measured hit rates. Stats of the symbol are used when it was studied on
+// the timeframe, otherwise stats pooled over every studied symbol. It returns
+// the calibrated reliability and the number of samples behind it; with no
+// samples the prior is returned unchanged.
+func (s *Store) Calibrate(symbol string, key Key, timeframe, regimeName string, prior float64) (float64, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	studies := []*Study{}
+	if study, ok := s.studies[studyKey(symbol, timeframe)]; ok {
+		studies = append(studies, study)
+	} else {
+		for _, study := range s.studies {
+			if study.Timeframe == timeframe {
+				studies = append(studies, study)
+			}
+		}
+	}
+
+	wins, samples := countWins(studies, key, regimeName)
+	if samples < minRegimeSamples {
+		wins, samples = countWins(studies, key, AllRegimes)
+	}
+	if samples == 0 {
+		return prior, 0
+	}
+	return (float64(wins) + prior/100*priorSamples) / float64(samples+priorSamples) * 100, samples
+}
+
+// CalibratePatterns replaces the hand-set reliability of candlestick patterns
+// found on a timeframe with the calibrated one. An empty symbol uses the stats
+// pooled over every studied symbol; AllRegimes ignores the market regime.
+func (s *Store) CalibratePatterns(symbol, timeframe, regimeName string, patterns []CandlestickPattern) {
+	for i := range patterns {
+		p := &patterns[i]
+		key := Key{Source: "candlestick", Pattern: p.Name, Direction: p.Type}
+		p.Reliability, _ = s.Calibrate(symbol, key, timeframe, regimeName, p.Reliability)
+	}
+}
+
+// CoinFlip is the reliability (0-100) of a pattern without an edge
+const CoinFlip = 50.0
+
+// Reliable reports whether a pattern counts as confluence: patterns measured
+// to hit less often than a coin flip do not
+func Reliable(p CandlestickPattern) bool {
+	return p.Reliability >= CoinFlip
+}
+
+// countWins sums calibration-horizon outcomes of a pattern in one regime
+func countWins(studies []*Study, key Key, regimeName string) (int, int) {
+	wins, samples := 0, 0
+	for _, study := range studies {
+		for _, st := range study.Stats {
+			if st.Key != key || st.Regime != regimeName {
+				continue
+			}
+			for _, h := range st.Horizons {
+				if h.Bars == study.Config.CalibrationHorizon {
+					wins += h.Wins
+					samples += h.Samples
+				}
+			}
+		}
+	}
+	return wins, samples
+}
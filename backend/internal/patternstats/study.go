@@ -0,0 +1,302 @@
+package patternstats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"tradebot/backend/internal/regime"
+)
+
+// ==================== PATTERN EDGE STUDY ====================
+// Replays the candlestick and advanced pattern detectors bar by bar over a
+// symbol's history and measures what price actually did after every
+// detection: forward returns at several horizons, hit rate and MFE/MAE,
+// grouped by pattern, timeframe and market regime.
+
+// AllRegimes is the regime label of stats pooled over every regime
+const AllRegimes = "all"
+
+// Key identifies a pattern across studies
+type Key struct {
+	Source    string `json:"source"` // "candlestick" or "advanced_pattern"
+	Pattern   string `json:"pattern"`
+	Direction string `json:"direction"` // "bullish" or "bearish"
+}
+
+// Horizon holds the outcome of a pattern a fixed number of bars later
+type Horizon struct {
+	Bars         int     `json:"bars"`
+	Samples      int     `json:"samples"`
+	Wins         int     `json:"wins"`
+	HitRate      float64 `json:"hitRate"`      // % of samples that moved in the pattern's direction
+	MeanReturn   float64 `json:"meanReturn"`   // %, signed so that positive is in the pattern's favor
+	MedianReturn float64 `json:"medianReturn"` // %
+}
+
+// Stats is the measured edge of one pattern on one timeframe and regime
+type Stats struct {
+	Key
+	Timeframe           string    `json:"timeframe"`
+	Regime              string    `json:"regime"` // "trending", "ranging", "volatile", "unknown" or "all"
+	Samples             int       `json:"samples"`
+	Horizons            []Horizon `json:"horizons"`
+	AvgMFE              float64   `json:"avgMfe"`              // Mean max favorable excursion, %
+	AvgMAE              float64   `json:"avgMae"`              // Mean max adverse excursion, %
+	ClaimedReliability  float64   `json:"claimedReliability"`  // Hand-set value of the detector
+	MeasuredReliability float64   `json:"measuredReliability"` // Hit rate at the calibration horizon
+}
+
+// Config controls a study
+type Config struct {
+	Horizons           []int `json:"horizons"`           // Forward bars to measure returns at
+	Window             int   `json:"window"`             // Candles handed to the detectors per bar
+	CalibrationHorizon int   `json:"calibrationHorizon"` // Horizon whose hit rate calibrates confidence
+	ByRegime           bool  `json:"byRegime"`           // Split stats by HMM regime
+}
+
+// DefaultConfig measures 1 to 20 bars ahead and calibrates on 5 bars
+func DefaultConfig() Config {
+	return Config{
+		Horizons:           []int{1, 3, 5, 10, 20},
+		Window:             60,
+		CalibrationHorizon: 5,
+		ByRegime:           true,
+	}
+}
+
+// Validate sorts the horizons and fills zero horizons, window and calibration
+// horizon with defaults. It rejects non-positive horizons, windows under 10
+// bars and a calibration horizon that is not one of the horizons.
+func (cfg *Config) Validate() error {
+	def := DefaultConfig()
+	if len(cfg.Horizons) == 0 {
+		cfg.Horizons = def.Horizons
+	}
+	sort.Ints(cfg.Horizons)
+	if cfg.Horizons[0] <= 0 {
+		return fmt.Errorf("horizons must be positive")
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = def.Window
+	}
+	if cfg.Window < 10 {
+		return fmt.Errorf("window must be at least 10 bars, got %d", cfg.Window)
+	}
+	if cfg.CalibrationHorizon <= 0 {
+		cfg.CalibrationHorizon = def.CalibrationHorizon
+	}
+	for _, h := range cfg.Horizons {
+		if h == cfg.CalibrationHorizon {
+			return nil
+		}
+	}
+	return fmt.Errorf("calibration horizon %d is not one of the horizons %v", cfg.CalibrationHorizon, cfg.Horizons)
+}
+
+// Study is the result of one pattern edge study
+type Study struct {
+	Symbol    string    `json:"symbol"`
+	Timeframe string    `json:"timeframe"`
+	Config    Config    `json:"config"`
+	Bars      int       `json:"bars"`
+	From      int64     `json:"from"`
+	To        int64     `json:"to"`
+	Stats     []Stats   `json:"stats"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// occurrence is one detection and what followed it
+type occurrence struct {
+	key      Key
+	regime   string
+	claimed  float64
+	returns  []float64 // Per horizon; NaN when history ends first
+	mfe, mae float64
+}
+
+// Run replays the detectors over candles and measures every detection
+func Run(symbol, timeframe string, candles []Candle, cfg Config) (*Study, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if len(candles) < cfg.Window+cfg.Horizons[0] {
+		return nil, fmt.Errorf("need at least %d candles, got %d", cfg.Window+cfg.Horizons[0], len(candles))
+	}
+
+	var regimes []regime.Regime
+	if cfg.ByRegime {
+		regimes = regime.Series(candles, regime.DefaultConfig())
+	}
+
+	occurrences := []occurrence{}
+	record := func(i int, key Key, claimed float64) {
+		sign := directionSign(key.Direction)
+		if sign == 0 {
+			return
+		}
+		o := occurrence{key: key, regime: string(regime.Unknown), claimed: claimed}
+		if regimes != nil {
+			o.regime = string(regimes[i])
+		}
+		o.returns, o.mfe, o.mae = measure(candles, i, sign, cfg.Horizons)
+		occurrences = append(occurrences, o)
+	}
+
+	// The last bar has no future to measure
+	for i := cfg.Window - 1; i < len(candles)-1; i++ {
+		window := candles[i-cfg.Window+1 : i+1]
+		last := len(window) - 1
+		for _, p := range RecognizeAllPatterns(window, timeframe) {
+			if p.Index == last {
+				record(i, Key{Source: "candlestick", Pattern: p.Name, Direction: p.Type}, p.Reliability)
+			}
+		}
+		for _, p := range RecognizeAdvancedPatterns(window) {
+			if p.CandleIdx == last {
+				record(i, Key{Source: "advanced_pattern", Pattern: p.Name, Direction: p.Type}, p.Reliability)
+			}
+		}
+	}
+
+	return &Study{
+		Symbol:    symbol,
+		Timeframe: timeframe,
+		Config:    cfg,
+		Bars:      len(candles),
+		From:      candles[0].Timestamp,
+		To:        candles[len(candles)-1].Timestamp,
+		Stats:     aggregate(occurrences, timeframe, cfg),
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func directionSign(direction string) float64 {
+	switch direction {
+	case "bullish":
+		return 1
+	case "bearish":
+		return -1
+	}
+	return 0 // Neutral patterns make no directional claim
+}
+
+// measure returns the signed % return at each horizon after bar i and the
+// max favorable/adverse excursion over the longest horizon
+func measure(candles []Candle, i int, sign float64, horizons []int) ([]float64, float64, float64) {
+	entry := candles[i].Close
+	returns := make([]float64, len(horizons))
+	for h, bars := range horizons {
+		returns[h] = math.NaN()
+		if i+bars < len(candles) && entry > 0 {
+			returns[h] = sign * (candles[i+bars].Close - entry) / entry * 100
+		}
+	}
+
+	end := i + horizons[len(horizons)-1]
+	if end > len(candles)-1 {
+		end = len(candles) - 1
+	}
+	mfe, mae := 0.0, 0.0
+	for j := i + 1; j <= end && entry > 0; j++ {
+		up := (candles[j].High - entry) / entry * 100
+		down := (entry - candles[j].Low) / entry * 100
+		if sign < 0 {
+			up, down = down, up
+		}
+		mfe = math.Max(mfe, up)
+		mae = math.Max(mae, down)
+	}
+	return returns, mfe, mae
+}
+
+// aggregate groups occurrences by pattern and regime, plus a pooled "all"
+// row per pattern, sorted by pattern then regime
+func aggregate(occurrences []occurrence, timeframe string, cfg Config) []Stats {
+	type group struct {
+		key    Key
+		regime string
+	}
+	groups := map[group][]occurrence{}
+	for _, o := range occurrences {
+		groups[group{o.key, AllRegimes}] = append(groups[group{o.key, AllRegimes}], o)
+		if cfg.ByRegime {
+			groups[group{o.key, o.regime}] = append(groups[group{o.key, o.regime}], o)
+		}
+	}
+
+	stats := make([]Stats, 0, len(groups))
+	for g, members := range groups {
+		s := Stats{
+			Key:       g.key,
+			Timeframe: timeframe,
+			Regime:    g.regime,
+			Samples:   len(members),
+			Horizons:  make([]Horizon, len(cfg.Horizons)),
+		}
+		for _, o := range members {
+			s.AvgMFE += o.mfe
+			s.AvgMAE += o.mae
+			s.ClaimedReliability += o.claimed
+		}
+		n := float64(len(members))
+		s.AvgMFE /= n
+		s.AvgMAE /= n
+		s.ClaimedReliability /= n
+
+		for h, bars := range cfg.Horizons {
+			returns := []float64{}
+			for _, o := range members {
+				if !math.IsNaN(o.returns[h]) {
+					returns = append(returns, o.returns[h])
+				}
+			}
+			s.Horizons[h] = summarizeReturns(bars, returns)
+			if bars == cfg.CalibrationHorizon {
+				s.MeasuredReliability = s.Horizons[h].HitRate
+			}
+		}
+		stats = append(stats, s)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		a, b := stats[i], stats[j]
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		if a.Pattern != b.Pattern {
+			return a.Pattern < b.Pattern
+		}
+		if a.Direction != b.Direction {
+			return a.Direction < b.Direction
+		}
+		return a.Regime < b.Regime
+	})
+	return stats
+}
+
+func summarizeReturns(bars int, returns []float64) Horizon {
+	h := Horizon{Bars: bars, Samples: len(returns)}
+	if len(returns) == 0 {
+		return h
+	}
+	sum := 0.0
+	for _, r := range returns {
+		sum += r
+		if r > 0 {
+			h.Wins++
+		}
+	}
+	h.HitRate = float64(h.Wins) / float64(len(returns)) * 100
+	h.MeanReturn = sum / float64(len(returns))
+
+	sorted := append([]float64(nil), returns...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	h.MedianReturn = sorted[mid]
+	if len(sorted)%2 == 0 {
+		h.MedianReturn = (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return h
+}
@@ -0,0 +1,230 @@
+package profile
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Session is a UTC trading session used to split day profiles
+type Session struct {
+	Name      string `json:"name"`
+	StartHour int    `json:"startHour"`
+	EndHour   int    `json:"endHour"`
+}
+
+// Sessions are the non-overlapping session profiles. Bars from 21:00 to
+// 24:00 UTC belong to the day profile only.
+var Sessions = []Session{
+	{Name: "Asian", StartHour: 0, EndHour: 8},
+	{Name: "London", StartHour: 8, EndHour: 13},
+	{Name: "NewYork", StartHour: 13, EndHour: 21},
+}
+
+// Level is a POC or value area edge of a completed profile
+type Level struct {
+	Kind     string  `json:"kind"`   // "poc", "vah" or "val"
+	Period   string  `json:"period"` // KindDay or KindSession
+	Profile  string  `json:"profile"`
+	Price    float64 `json:"price"`
+	Since    int64   `json:"since"`              // End of the profile: tracking starts here
+	Naked    bool    `json:"naked"`              // Not traded through since
+	TestedAt int64   `json:"testedAt,omitempty"` // First bar that traded through it
+}
+
+// Analysis holds the profiles and tracked levels of a run of candles
+type Analysis struct {
+	TickSize  float64    `json:"tickSize"`
+	Price     float64    `json:"price"` // Last close
+	Time      int64      `json:"time"`  // Last candle timestamp
+	Days      []*Profile `json:"days"`
+	Sessions  []*Profile `json:"sessions"`
+	Composite *Profile   `json:"composite,omitempty"`
+	Levels    []Level    `json:"levels"` // Every level of the completed profiles, oldest first
+}
+
+// Analyze builds the day, session and composite profiles of candles and
+// tracks the POC and value area edges of every completed day and session.
+func Analyze(candles []Candle, cfg Config) (*Analysis, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if len(candles) < 2 {
+		return nil, fmt.Errorf("need at least 2 candles, got %d", len(candles))
+	}
+
+	last := candles[len(candles)-1]
+	barMs := candles[1].Timestamp - candles[0].Timestamp
+	days := splitDays(candles)
+	tick := cfg.TickSize
+	if tick == 0 {
+		tick = autoTick(days, cfg.RowsPerDay)
+	}
+	if tick <= 0 {
+		return nil, fmt.Errorf("candles have no price range")
+	}
+
+	a := &Analysis{TickSize: tick, Price: last.Close, Time: last.Timestamp, Days: []*Profile{}, Sessions: []*Profile{}, Levels: []Level{}}
+	build := func(group []Candle, kind, label, session string, end int64) *Profile {
+		p := Build(group, tick, cfg.TPOMinutes, cfg.ValueArea)
+		p.Kind, p.Label, p.Session, p.End = kind, label, session, end
+		p.Complete = last.Timestamp+barMs >= end
+		return p
+	}
+
+	for _, day := range days {
+		start := dayStart(day[0].Timestamp)
+		a.Days = append(a.Days, build(day, KindDay, dayLabel(start), "", start+dayMs))
+		for _, s := range Sessions {
+			group := []Candle{}
+			for _, c := range day {
+				if hour := int((c.Timestamp - start) / hourMs); hour >= s.StartHour && hour < s.EndHour {
+					group = append(group, c)
+				}
+			}
+			if len(group) > 0 {
+				end := start + int64(s.EndHour)*hourMs
+				a.Sessions = append(a.Sessions, build(group, KindSession, dayLabel(start)+" "+s.Name, s.Name, end))
+			}
+		}
+	}
+
+	from := 0
+	if len(days) > cfg.CompositeDays {
+		from = len(days) - cfg.CompositeDays
+	}
+	composite := []Candle{}
+	for _, day := range days[from:] {
+		composite = append(composite, day...)
+	}
+	a.Composite = build(composite, KindComposite, fmt.Sprintf("composite %dd", len(days)-from), "", a.Days[len(a.Days)-1].End)
+
+	for _, p := range append(append([]*Profile{}, a.Days...), a.Sessions...) {
+		if p.Complete {
+			a.Levels = append(a.Levels, track(p, candles)...)
+		}
+	}
+	sort.SliceStable(a.Levels, func(i, j int) bool { return a.Levels[i].Since < a.Levels[j].Since })
+	return a, nil
+}
+
+// track returns the POC and value area edges of p, marking the first later
+// bar that traded through each one
+func track(p *Profile, candles []Candle) []Level {
+	levels := []Level{
+		{Kind: "poc", Price: p.POC},
+		{Kind: "vah", Price: p.VAH},
+		{Kind: "val", Price: p.VAL},
+	}
+	first := sort.Search(len(candles), func(i int) bool { return candles[i].Timestamp >= p.End })
+	for i := range levels {
+		levels[i].Period, levels[i].Profile, levels[i].Since, levels[i].Naked = p.Kind, p.Label, p.End, true
+		for _, c := range candles[first:] {
+			if c.Low <= levels[i].Price && c.High >= levels[i].Price {
+				levels[i].Naked, levels[i].TestedAt = false, c.Timestamp
+				break
+			}
+		}
+	}
+	return levels
+}
+
+// Naked returns the untested levels, optionally only of the given kinds
+func (a *Analysis) Naked(kinds ...string) []Level {
+	naked := []Level{}
+	for _, l := range a.Levels {
+		if l.Naked && (len(kinds) == 0 || containsKind(kinds, l.Kind)) {
+			naked = append(naked, l)
+		}
+	}
+	return naked
+}
+
+// Nearest returns the closest naked level above and below price (nil when none)
+func (a *Analysis) Nearest(price float64, kinds ...string) (*Level, *Level) {
+	var above, below *Level
+	for _, l := range a.Naked(kinds...) {
+		l := l
+		if l.Price > price && (above == nil || l.Price < above.Price) {
+			above = &l
+		}
+		if l.Price < price && (below == nil || l.Price > below.Price) {
+			below = &l
+		}
+	}
+	return above, below
+}
+
+// TradeLevels returns the nearest naked level in the trade direction as the
+// target and the nearest one against it as the invalidation level
+func (a *Analysis) TradeLevels(direction string, price float64) (target, invalidation *Level) {
+	above, below := a.Nearest(price)
+	if direction == "bearish" || direction == "SELL" {
+		return below, above
+	}
+	return above, below
+}
+
+// LastComplete returns the most recent completed profile of a kind
+func (a *Analysis) LastComplete(kind string) *Profile {
+	profiles := a.Days
+	if kind == KindSession {
+		profiles = a.Sessions
+	}
+	for i := len(profiles) - 1; i >= 0; i-- {
+		if profiles[i].Complete {
+			return profiles[i]
+		}
+	}
+	return nil
+}
+
+const (
+	hourMs = int64(time.Hour / time.Millisecond)
+	dayMs  = 24 * hourMs
+)
+
+func dayStart(ts int64) int64 {
+	return ts - ((ts%dayMs)+dayMs)%dayMs
+}
+
+func dayLabel(start int64) string {
+	return time.UnixMilli(start).UTC().Format("2006-01-02")
+}
+
+// splitDays groups candles by UTC day
+func splitDays(candles []Candle) [][]Candle {
+	days := [][]Candle{}
+	from := 0
+	for i := 1; i <= len(candles); i++ {
+		if i == len(candles) || dayStart(candles[i].Timestamp) != dayStart(candles[from].Timestamp) {
+			days = append(days, candles[from:i])
+			from = i
+		}
+	}
+	return days
+}
+
+// autoTick sizes rows so an average day spans rowsPerDay of them
+func autoTick(days [][]Candle, rowsPerDay int) float64 {
+	total := 0.0
+	for _, day := range days {
+		high, low := day[0].High, day[0].Low
+		for _, c := range day {
+			high = math.Max(high, c.High)
+			low = math.Min(low, c.Low)
+		}
+		total += high - low
+	}
+	return total / float64(len(days)) / float64(rowsPerDay)
+}
+
+func containsKind(kinds []string, kind string) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
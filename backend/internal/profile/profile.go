@@ -0,0 +1,228 @@
+package profile
+
+import (
+	"fmt"
+	"math"
+)
+
+// ==================== SESSION & COMPOSITE PROFILES ====================
+// Volume and TPO profiles built per UTC day, per trading session and as a
+// composite over several days. Every profile of one analysis shares a price
+// grid, so a composite is the sum of the day profiles it covers and levels
+// from different days are directly comparable.
+
+// Profile kinds
+const (
+	KindDay       = "day"
+	KindSession   = "session"
+	KindComposite = "composite"
+)
+
+// tpoLetters label the TPO periods of a profile (A..Z, then a..z, then repeat)
+const tpoLetters = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// Config controls how profiles are built
+type Config struct {
+	TickSize      float64 `json:"tickSize"`      // Row height; 0 derives it from the average daily range
+	RowsPerDay    int     `json:"rowsPerDay"`    // Rows in an average day when TickSize is 0 (default: 24)
+	ValueArea     float64 `json:"valueArea"`     // Share of volume / TPOs in the value area (default: 0.7)
+	TPOMinutes    int     `json:"tpoMinutes"`    // Minutes per TPO letter (default: 30)
+	CompositeDays int     `json:"compositeDays"` // Days merged into the composite profile (default: 5)
+}
+
+// DefaultConfig returns the standard 70% value area with 30-minute TPOs
+func DefaultConfig() Config {
+	return Config{RowsPerDay: 24, ValueArea: 0.7, TPOMinutes: 30, CompositeDays: 5}
+}
+
+// Validate fills zero rows, value area, TPO period and composite days with
+// defaults. It rejects a negative tick size, fewer than 5 or more than 500 rows
+// a day and a value area outside (0, 1).
+func (cfg *Config) Validate() error {
+	def := DefaultConfig()
+	if cfg.RowsPerDay == 0 {
+		cfg.RowsPerDay = def.RowsPerDay
+	}
+	if cfg.ValueArea == 0 {
+		cfg.ValueArea = def.ValueArea
+	}
+	if cfg.TPOMinutes == 0 {
+		cfg.TPOMinutes = def.TPOMinutes
+	}
+	if cfg.CompositeDays == 0 {
+		cfg.CompositeDays = def.CompositeDays
+	}
+	if cfg.TickSize < 0 {
+		return fmt.Errorf("tickSize must not be negative, got %g", cfg.TickSize)
+	}
+	if cfg.RowsPerDay < 5 || cfg.RowsPerDay > 500 {
+		return fmt.Errorf("rowsPerDay must be between 5 and 500, got %d", cfg.RowsPerDay)
+	}
+	if cfg.ValueArea <= 0 || cfg.ValueArea >= 1 {
+		return fmt.Errorf("valueArea must be between 0 and 1, got %.2f", cfg.ValueArea)
+	}
+	if cfg.TPOMinutes < 1 {
+		return fmt.Errorf("tpoMinutes must be at least 1, got %d", cfg.TPOMinutes)
+	}
+	if cfg.CompositeDays < 1 {
+		return fmt.Errorf("compositeDays must be at least 1, got %d", cfg.CompositeDays)
+	}
+	return nil
+}
+
+// Row is one price row of a profile
+type Row struct {
+	Price   float64 `json:"price"` // Row midpoint
+	Volume  float64 `json:"volume"`
+	TPO     int     `json:"tpo"`     // TPO periods that traded at this row
+	Letters string  `json:"letters"` // Those periods as letters, in time order
+}
+
+// Profile is the volume and TPO distribution of a run of candles
+type Profile struct {
+	Kind     string  `json:"kind"`
+	Label    string  `json:"label"` // "2026-10-18", "2026-10-18 London" or "composite 5d"
+	Session  string  `json:"session,omitempty"`
+	Start    int64   `json:"start"`    // First candle timestamp
+	End      int64   `json:"end"`      // Period boundary of [Start, End); the last candle timestamp for a bare Build
+	Complete bool    `json:"complete"` // The period is over; its levels can be tracked
+	Open     float64 `json:"open"`
+	High     float64 `json:"high"`
+	Low      float64 `json:"low"`
+	Close    float64 `json:"close"`
+	Volume   float64 `json:"volume"`
+
+	POC float64 `json:"poc"` // Highest-volume row
+	VAH float64 `json:"vah"`
+	VAL float64 `json:"val"`
+
+	TPOPOC  float64 `json:"tpoPoc"` // Row with the most TPOs
+	TPOVAH  float64 `json:"tpoVah"`
+	TPOVAL  float64 `json:"tpoVal"`
+	IBHigh  float64 `json:"ibHigh"` // Initial balance: range of the first two TPO periods
+	IBLow   float64 `json:"ibLow"`
+	Periods int     `json:"periods"` // TPO periods in the profile
+
+	Rows []Row `json:"rows,omitempty"`
+}
+
+// Build computes the profile of candles on a grid of tick-sized rows.
+// Volume is spread over the rows a candle covers in proportion to overlap;
+// a TPO is counted once per period for every row the period traded at.
+func Build(candles []Candle, tick float64, tpoMinutes int, valueArea float64) *Profile {
+	if len(candles) == 0 || tick <= 0 {
+		return nil
+	}
+	p := &Profile{
+		Start: candles[0].Timestamp,
+		End:   candles[len(candles)-1].Timestamp,
+		Open:  candles[0].Open,
+		High:  candles[0].High,
+		Low:   candles[0].Low,
+		Close: candles[len(candles)-1].Close,
+	}
+	for _, c := range candles {
+		p.High = math.Max(p.High, c.High)
+		p.Low = math.Min(p.Low, c.Low)
+		p.Volume += c.Volume
+	}
+
+	base := rowIndex(p.Low, tick)
+	rows := make([]Row, rowIndex(p.High, tick)-base+1)
+	for i := range rows {
+		rows[i].Price = (float64(base+i) + 0.5) * tick
+	}
+
+	tpoMs := int64(tpoMinutes) * 60000
+	periodStart := p.Start - p.Start%tpoMs
+	lastPeriod := make([]int, len(rows))
+	for i := range lastPeriod {
+		lastPeriod[i] = -1
+	}
+	p.IBHigh, p.IBLow = math.Inf(-1), math.Inf(1)
+
+	for _, c := range candles {
+		lo, hi := rowIndex(c.Low, tick)-base, rowIndex(c.High, tick)-base
+		if hi > lo && float64(base+hi)*tick >= c.High {
+			hi-- // A high exactly on a row boundary does not trade the row above
+		}
+		if span := c.High - c.Low; span > 0 {
+			for r := lo; r <= hi; r++ {
+				rowLow := float64(base+r) * tick
+				overlap := math.Min(c.High, rowLow+tick) - math.Max(c.Low, rowLow)
+				if overlap > 0 {
+					rows[r].Volume += c.Volume * overlap / span
+				}
+			}
+		} else {
+			rows[lo].Volume += c.Volume
+		}
+
+		period := int((c.Timestamp - periodStart) / tpoMs)
+		for r := lo; r <= hi; r++ {
+			if lastPeriod[r] != period {
+				lastPeriod[r] = period
+				rows[r].TPO++
+				rows[r].Letters += string(tpoLetters[period%len(tpoLetters)])
+			}
+		}
+		if period+1 > p.Periods {
+			p.Periods = period + 1
+		}
+		if period < 2 {
+			p.IBHigh = math.Max(p.IBHigh, c.High)
+			p.IBLow = math.Min(p.IBLow, c.Low)
+		}
+	}
+
+	volumes := make([]float64, len(rows))
+	tpos := make([]float64, len(rows))
+	for i, r := range rows {
+		volumes[i], tpos[i] = r.Volume, float64(r.TPO)
+	}
+	poc, lo, hi := valueAreaRows(volumes, valueArea)
+	p.POC, p.VAL, p.VAH = rows[poc].Price, rows[lo].Price, rows[hi].Price
+	poc, lo, hi = valueAreaRows(tpos, valueArea)
+	p.TPOPOC, p.TPOVAL, p.TPOVAH = rows[poc].Price, rows[lo].Price, rows[hi].Price
+
+	p.Rows = rows
+	return p
+}
+
+func rowIndex(price, tick float64) int {
+	return int(math.Floor(price / tick))
+}
+
+// valueAreaRows finds the POC row (ties go to the row nearest the middle)
+// and grows the value area from it, one row at a time toward the heavier
+// neighbour (ties go up), until it holds share of the total. It returns the
+// POC, lowest and highest value area rows.
+func valueAreaRows(weights []float64, share float64) (int, int, int) {
+	poc, total := 0, 0.0
+	mid := float64(len(weights)-1) / 2
+	for i, w := range weights {
+		total += w
+		if w > weights[poc] || (w == weights[poc] && math.Abs(float64(i)-mid) < math.Abs(float64(poc)-mid)) {
+			poc = i
+		}
+	}
+	lo, hi := poc, poc
+	inside := weights[poc]
+	for inside < total*share && (lo > 0 || hi < len(weights)-1) {
+		up, down := -1.0, -1.0
+		if hi < len(weights)-1 {
+			up = weights[hi+1]
+		}
+		if lo > 0 {
+			down = weights[lo-1]
+		}
+		if up >= down {
+			hi++
+			inside += up
+		} else {
+			lo--
+			inside += down
+		}
+	}
+	return poc, lo, hi
+}
@@ -0,0 +1,137 @@
+package profile
+
+import (
+	"math"
+	"testing"
+)
+
+const t0 = int64(1760745600000) // 2025-10-18 00:00 UTC
+
+// hourly builds one candle per hour from t0 with the given lows and highs
+func hourly(ranges [][2]float64) []Candle {
+	candles := make([]Candle, len(ranges))
+	for i, r := range ranges {
+		candles[i] = Candle{Timestamp: t0 + int64(i)*hourMs, Open: r[0], High: r[1], Low: r[0], Close: r[1], Volume: 10}
+	}
+	return candles
+}
+
+func TestBuildVolumeAndTPO(t *testing.T) {
+	candles := []Candle{
+		{Timestamp: t0, Low: 100, High: 104, Open: 100, Close: 104, Volume: 40},
+		{Timestamp: t0 + 15*60000, Low: 101, High: 102, Open: 101, Close: 102, Volume: 100},
+		{Timestamp: t0 + 30*60000, Low: 101, High: 103, Open: 103, Close: 101, Volume: 20},
+	}
+	p := Build(candles, 1, 30, 0.7)
+	if len(p.Rows) != 5 || p.Rows[0].Price != 100.5 {
+		t.Fatalf("expected rows 100.5..104.5, got %+v", p.Rows)
+	}
+	if math.Abs(p.Rows[1].Volume-(10+100+10)) > 1e-9 {
+		t.Errorf("row 101 volume = %.2f, want 120", p.Rows[1].Volume)
+	}
+	if p.POC != 101.5 {
+		t.Errorf("POC = %.2f, want 101.5", p.POC)
+	}
+	if p.Rows[1].Letters != "AB" || p.Rows[0].Letters != "A" || p.Rows[1].TPO != 2 {
+		t.Errorf("unexpected TPO letters %+v", p.Rows)
+	}
+	if p.Periods != 2 || p.IBHigh != 104 || p.IBLow != 100 {
+		t.Errorf("unexpected periods / initial balance %d %.0f-%.0f", p.Periods, p.IBLow, p.IBHigh)
+	}
+	if p.VAL > p.POC || p.VAH < p.POC {
+		t.Errorf("value area %.2f-%.2f must contain the POC %.2f", p.VAL, p.VAH, p.POC)
+	}
+}
+
+func TestValueAreaRows(t *testing.T) {
+	poc, lo, hi := valueAreaRows([]float64{1, 2, 10, 5, 1, 1}, 0.7)
+	if poc != 2 || lo != 2 || hi != 3 {
+		t.Errorf("got poc %d, value area %d-%d; want 2, 2-3", poc, lo, hi)
+	}
+	poc, _, _ = valueAreaRows([]float64{3, 3, 3, 3, 3}, 0.7)
+	if poc != 2 {
+		t.Errorf("a flat profile's POC should be the middle row, got %d", poc)
+	}
+}
+
+func TestAnalyzeSplitsDaysAndSessions(t *testing.T) {
+	ranges := make([][2]float64, 48)
+	for i := range ranges {
+		ranges[i] = [2]float64{100, 110}
+	}
+	a, err := Analyze(hourly(ranges), Config{TickSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Days) != 2 || len(a.Sessions) != 6 {
+		t.Fatalf("expected 2 days and 6 sessions, got %d / %d", len(a.Days), len(a.Sessions))
+	}
+	if !a.Days[0].Complete || !a.Days[1].Complete {
+		t.Error("a day ending on its last hourly bar is complete")
+	}
+	if a.Sessions[1].Label != "2025-10-18 London" || a.Sessions[1].End != t0+13*hourMs {
+		t.Errorf("unexpected session %q ending %d", a.Sessions[1].Label, a.Sessions[1].End)
+	}
+	if a.Composite == nil || a.Composite.Label != "composite 2d" || math.Abs(a.Composite.Volume-480) > 1e-9 {
+		t.Errorf("unexpected composite %+v", a.Composite)
+	}
+
+	partial, _ := Analyze(hourly(ranges[:30]), Config{TickSize: 1})
+	if partial.Days[1].Complete || partial.LastComplete(KindDay) != partial.Days[0] {
+		t.Error("the running day must not be complete")
+	}
+}
+
+func TestNakedLevels(t *testing.T) {
+	// Day 1 trades 100-110; day 2 rallies to 112-130 and never returns
+	ranges := make([][2]float64, 36)
+	for i := range ranges {
+		if i < 24 {
+			ranges[i] = [2]float64{100, 110}
+		} else {
+			ranges[i] = [2]float64{112, 130}
+		}
+	}
+	a, err := Analyze(hourly(ranges), Config{TickSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := a.LastComplete(KindDay)
+	if day == nil || day.Label != "2025-10-18" {
+		t.Fatalf("expected day 1 complete, got %+v", day)
+	}
+	nakedPOCs := 0
+	for _, l := range a.Naked("poc") {
+		if l.Period == KindDay && l.Profile == day.Label {
+			nakedPOCs++
+		}
+	}
+	if nakedPOCs != 1 {
+		t.Errorf("day 1 POC should be naked, levels %+v", a.Levels)
+	}
+
+	target, invalidation := a.TradeLevels("bearish", a.Price)
+	if target == nil || target.Price >= a.Price || invalidation != nil {
+		t.Errorf("a short from the highs should target a naked level below with none above, got %+v / %+v", target, invalidation)
+	}
+
+	// Trading back through the range tests every day 1 level
+	back := append(hourly(ranges), Candle{Timestamp: t0 + 36*hourMs, Low: 99, High: 131, Open: 131, Close: 99, Volume: 10})
+	a, _ = Analyze(back, Config{TickSize: 1})
+	for _, l := range a.Levels {
+		if l.Profile == day.Label && (l.Naked || l.TestedAt != t0+36*hourMs) {
+			t.Errorf("expected %s %s tested at the last bar, got %+v", l.Profile, l.Kind, l)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cfg := Config{}
+	if err := cfg.Validate(); err != nil || cfg.ValueArea != 0.7 || cfg.TPOMinutes != 30 {
+		t.Errorf("unexpected defaults %+v, %v", cfg, err)
+	}
+	cfg = Config{ValueArea: 1.2}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected a value area above 1 to be rejected")
+	}
+}
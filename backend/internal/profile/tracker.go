@@ -0,0 +1,106 @@
+package profile
+
+// Tracker keeps the Analysis of a rolling window of days current bar by bar.
+//
+// The profiles and their levels only change when a day or session completes,
+// so Update rebuilds the analysis at those boundaries and otherwise just moves
+// the price and marks the levels each new bar trades through. Between
+// rebuilds the incomplete profiles and the composite are not refreshed and
+// the window start does not slide; the completed profiles, levels and price
+// match a fresh Analyze of the window at the last rebuild plus the new bars.
+//
+// A Tracker is not safe for concurrent use; keep one per run.
+type Tracker struct {
+	cfg  Config
+	days int64
+
+	analysis  *Analysis
+	boundary  int64 // Next session or day end after the last rebuild
+	lastTime  int64
+	lastClose float64
+}
+
+// NewTracker creates a tracker over the last days UTC days of each window
+func NewTracker(cfg Config, days int) *Tracker {
+	return &Tracker{cfg: cfg, days: int64(days)}
+}
+
+// Update advances the analysis to the last candle of the window and returns
+// it (nil when the window cannot be analyzed). The returned analysis is
+// updated in place by later calls.
+func (t *Tracker) Update(candles []Candle) *Analysis {
+	if len(candles) < 2 {
+		t.analysis = nil
+		return nil
+	}
+	last := candles[len(candles)-1]
+	barMs := last.Timestamp - candles[len(candles)-2].Timestamp
+
+	start := t.continuation(candles)
+	if start < 0 || last.Timestamp+barMs >= t.boundary {
+		return t.rebuild(candles)
+	}
+
+	for _, c := range candles[start:] {
+		for i := range t.analysis.Levels {
+			l := &t.analysis.Levels[i]
+			if l.Naked && c.Timestamp >= l.Since && c.Low <= l.Price && c.High >= l.Price {
+				l.Naked, l.TestedAt = false, c.Timestamp
+			}
+		}
+	}
+	t.analysis.Price, t.analysis.Time = last.Close, last.Timestamp
+	t.lastTime, t.lastClose = last.Timestamp, last.Close
+	return t.analysis
+}
+
+// continuation returns the index of the first unseen bar, or -1 when the
+// window does not continue the one seen last time
+func (t *Tracker) continuation(candles []Candle) int {
+	if t.analysis == nil {
+		return -1
+	}
+	for i := len(candles) - 1; i >= 0 && candles[i].Timestamp >= t.lastTime; i-- {
+		if candles[i].Timestamp == t.lastTime {
+			if candles[i].Close != t.lastClose {
+				return -1 // The last bar was still forming when it was seen
+			}
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// rebuild analyzes the last t.days days of the window from scratch
+func (t *Tracker) rebuild(candles []Candle) *Analysis {
+	last := candles[len(candles)-1]
+	from := last.Timestamp - t.days*dayMs
+	start := len(candles) - 1
+	for start > 0 && candles[start-1].Timestamp >= from {
+		start--
+	}
+
+	analysis, err := Analyze(candles[start:], t.cfg)
+	if err != nil {
+		t.analysis = nil
+		return nil
+	}
+
+	// A profile is complete from the bar before its end, as in Analyze
+	barMs := last.Timestamp - candles[len(candles)-2].Timestamp
+	t.boundary = nextBoundary(last.Timestamp + barMs)
+	t.analysis = analysis
+	t.lastTime, t.lastClose = last.Timestamp, last.Close
+	return analysis
+}
+
+// nextBoundary returns the first session or day end after ts
+func nextBoundary(ts int64) int64 {
+	start := dayStart(ts)
+	for _, s := range Sessions {
+		if end := start + int64(s.EndHour)*hourMs; end > ts {
+			return end
+		}
+	}
+	return start + dayMs
+}
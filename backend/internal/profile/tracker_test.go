@@ -0,0 +1,80 @@
+package profile
+
+import (
+	"math"
+	"testing"
+)
+
+// wave builds hourly candles drifting up and back down so later bars
+// trade through earlier levels
+func wave(n int) []Candle {
+	ranges := make([][2]float64, n)
+	for i := range ranges {
+		mid := 100 + 8*math.Sin(float64(i)/9) + float64(i%5)
+		ranges[i] = [2]float64{mid - 1.5, mid + 1.5}
+	}
+	return hourly(ranges)
+}
+
+func TestTrackerMatchesAnalyze(t *testing.T) {
+	candles := wave(24 * 4)
+	cfg := Config{TickSize: 0.5}
+	tracker := NewTracker(cfg, 30)
+
+	for i := 1; i < len(candles); i++ {
+		got := tracker.Update(candles[:i+1])
+		want, err := Analyze(candles[:i+1], cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Price != want.Price || got.Time != want.Time {
+			t.Fatalf("bar %d: price/time %.2f@%d, want %.2f@%d", i, got.Price, got.Time, want.Price, want.Time)
+		}
+		if len(got.Levels) != len(want.Levels) {
+			t.Fatalf("bar %d: %d levels, want %d", i, len(got.Levels), len(want.Levels))
+		}
+		for j := range want.Levels {
+			if got.Levels[j] != want.Levels[j] {
+				t.Fatalf("bar %d level %d: got %+v, want %+v", i, j, got.Levels[j], want.Levels[j])
+			}
+		}
+		gotPrior, wantPrior := got.LastComplete(KindDay), want.LastComplete(KindDay)
+		if (gotPrior == nil) != (wantPrior == nil) || (gotPrior != nil && gotPrior.POC != wantPrior.POC) {
+			t.Fatalf("bar %d: prior day differs", i)
+		}
+	}
+}
+
+func TestTrackerRebuildsOnlyAtBoundaries(t *testing.T) {
+	candles := wave(24 * 2)
+	tracker := NewTracker(Config{TickSize: 0.5}, 30)
+
+	rebuilds := 0
+	var prev *Analysis
+	for i := 1; i < len(candles); i++ {
+		a := tracker.Update(candles[:i+1])
+		if a != prev {
+			rebuilds++
+			prev = a
+		}
+	}
+	// Three session ends and one day end per day, plus the first build
+	if rebuilds > 9 {
+		t.Fatalf("want a rebuild per completed profile, got %d over %d bars", rebuilds, len(candles))
+	}
+}
+
+func TestTrackerResetsOnRewrittenBar(t *testing.T) {
+	candles := wave(30)
+	tracker := NewTracker(Config{TickSize: 0.5}, 30)
+	first := tracker.Update(candles)
+
+	rewritten := append([]Candle{}, candles...)
+	rewritten[len(rewritten)-1].Close += 1
+	if again := tracker.Update(rewritten); again == first {
+		t.Fatal("a rewritten last bar should rebuild the analysis")
+	}
+	if tracker.Update(candles[:1]) != nil {
+		t.Fatal("a single bar cannot be analyzed")
+	}
+}
@@ -0,0 +1,307 @@
+package regime
+
+import (
+	"math"
+
+	"tradebot/backend/internal/indicators"
+)
+
+// Regime is a statistically detected market state
+type Regime string
+
+const (
+	Trending Regime = "trending"
+	Ranging  Regime = "ranging"
+	Volatile Regime = "volatile"
+	Unknown  Regime = "unknown" // Not enough history to fit a model
+)
+
+// Regimes lists the states the detector classifies, in model order
+var Regimes = []Regime{Trending, Ranging, Volatile}
+
+// efficiencyPeriod is the lookback of the trend efficiency feature
+const efficiencyPeriod = 20
+
+// Features are the per-bar inputs of the model
+type Features struct {
+	Efficiency float64 `json:"efficiency"` // |20-bar return| / sum of |bar returns| (Kaufman efficiency ratio)
+	ATRPercent float64 `json:"atrPercent"` // ATR(14) as % of price
+	ADX        float64 `json:"adx"`        // ADX(14)
+}
+
+// State is the published regime of a market
+type State struct {
+	Regime        Regime             `json:"regime"`
+	Direction     string             `json:"direction"`  // "up" or "down" over the efficiency window
+	Confidence    float64            `json:"confidence"` // Probability of Regime
+	Probabilities map[Regime]float64 `json:"probabilities"`
+	Features      Features           `json:"features"`
+	Timestamp     int64              `json:"timestamp"`
+	FittedBars    int                `json:"fittedBars"` // Bars the current model was fitted on
+}
+
+// Probability returns the probability of a regime (0 when unknown)
+func (s State) Probability(r Regime) float64 {
+	return s.Probabilities[r]
+}
+
+// Config controls how much local history the model is fitted on
+type Config struct {
+	FitWindow  int // Feature bars the model is fitted on
+	RefitEvery int // Bars between refits; in between the regime is filtered forward
+	MinBars    int // Feature bars required before a regime is published
+}
+
+// DefaultConfig fits on the last 500 bars and refits every 100
+func DefaultConfig() Config {
+	return Config{FitWindow: 500, RefitEvery: 100, MinBars: 150}
+}
+
+// Detector classifies trending, ranging and volatile states with a 3-state
+// Gaussian HMM over trend efficiency, log ATR% and ADX. The model is fitted
+// on local history and the current state comes from forward filtering, so a
+// regime only ever uses bars up to the current one.
+//
+// Like indicators.Tracker, Sync follows a sliding candle window and only feeds
+// new bars. A Detector is not safe for concurrent use.
+type Detector struct {
+	cfg Config
+
+	lastTime  int64
+	lastClose float64
+	bars      int
+
+	atr    *indicators.ATRStream
+	adx    *indicators.ADXStream
+	closes []float64 // Last efficiencyPeriod+1 closes
+
+	history  [][]float64 // Feature rows, at most 2*FitWindow
+	current  Features
+	model    *gaussianHMM
+	labels   []Regime // Regime of each model state
+	alpha    []float64
+	sinceFit int
+	fitted   int
+}
+
+// NewDetector creates a detector; zero config fields take the defaults
+func NewDetector(cfg Config) *Detector {
+	def := DefaultConfig()
+	if cfg.FitWindow <= 0 {
+		cfg.FitWindow = def.FitWindow
+	}
+	if cfg.RefitEvery <= 0 {
+		cfg.RefitEvery = def.RefitEvery
+	}
+	if cfg.MinBars <= 0 {
+		cfg.MinBars = def.MinBars
+	}
+	if cfg.MinBars > cfg.FitWindow {
+		cfg.MinBars = cfg.FitWindow
+	}
+	d := &Detector{cfg: cfg}
+	d.reset()
+	return d
+}
+
+// Detectors keeps one default-config Detector per timeframe, so a generator
+// scanning several timeframes only feeds each the new bars of its own. The zero
+// value is ready to use; like Detector it is not safe for concurrent use.
+type Detectors struct {
+	byTimeframe map[string]*Detector
+}
+
+// For returns the detector kept for a timeframe, creating it on first use
+func (ds *Detectors) For(timeframe string) *Detector {
+	if d, ok := ds.byTimeframe[timeframe]; ok {
+		return d
+	}
+	if ds.byTimeframe == nil {
+		ds.byTimeframe = make(map[string]*Detector)
+	}
+	d := NewDetector(DefaultConfig())
+	ds.byTimeframe[timeframe] = d
+	return d
+}
+
+func (d *Detector) reset() {
+	d.bars = 0
+	d.atr = indicators.NewATRStream(14)
+	d.adx = indicators.NewADXStream(14)
+	d.closes = d.closes[:0]
+	d.history = nil
+	d.model = nil
+	d.labels = nil
+	d.alpha = nil
+	d.sinceFit = 0
+	d.fitted = 0
+}
+
+// Sync advances the detector to the last candle of the window. A window that
+// does not continue the previous one (gap, rewritten last bar, other symbol)
+// resets the detector and replays it.
+func (d *Detector) Sync(candles []Candle) {
+	if len(candles) == 0 {
+		return
+	}
+
+	start := -1
+	if d.bars > 0 {
+		for i := len(candles) - 1; i >= 0 && candles[i].Timestamp >= d.lastTime; i-- {
+			if candles[i].Timestamp == d.lastTime {
+				if candles[i].Close == d.lastClose {
+					start = i + 1
+				}
+				break
+			}
+		}
+	}
+	if start < 0 {
+		d.reset()
+		start = 0
+	}
+	for _, c := range candles[start:] {
+		d.feed(c)
+	}
+
+	last := candles[len(candles)-1]
+	d.lastTime = last.Timestamp
+	d.lastClose = last.Close
+}
+
+func (d *Detector) feed(c Candle) {
+	d.bars++
+	atr := d.atr.Update(c.High, c.Low, c.Close)
+	adx := d.adx.Update(c.High, c.Low, c.Close)
+
+	d.closes = append(d.closes, c.Close)
+	if len(d.closes) > efficiencyPeriod+1 {
+		d.closes = d.closes[1:]
+	}
+	if len(d.closes) <= efficiencyPeriod || !d.adx.Ready() || c.Close <= 0 {
+		return
+	}
+
+	path := 0.0
+	for i := 1; i < len(d.closes); i++ {
+		path += math.Abs(d.closes[i] - d.closes[i-1])
+	}
+	change := d.closes[len(d.closes)-1] - d.closes[0]
+	efficiency := 0.0
+	if path > 0 {
+		efficiency = math.Abs(change) / path
+	}
+	d.current = Features{Efficiency: efficiency, ATRPercent: atr / c.Close * 100, ADX: adx}
+
+	row := []float64{efficiency, math.Log(math.Max(d.current.ATRPercent, 1e-6)), adx / 100}
+	d.history = append(d.history, row)
+	if len(d.history) > 2*d.cfg.FitWindow {
+		d.history = append([][]float64(nil), d.history[len(d.history)-d.cfg.FitWindow:]...)
+	}
+
+	switch {
+	case d.model == nil:
+		if len(d.history) >= d.cfg.MinBars {
+			d.fit()
+		}
+	case d.sinceFit+1 >= d.cfg.RefitEvery:
+		d.fit()
+	default:
+		d.sinceFit++
+		d.alpha = d.model.step(d.alpha, row)
+	}
+}
+
+// fit refits the model on the trailing window and filters it forward
+func (d *Detector) fit() {
+	window := d.history
+	if len(window) > d.cfg.FitWindow {
+		window = window[len(window)-d.cfg.FitWindow:]
+	}
+	d.model = fitHMM(window, len(Regimes))
+	d.labels = labelStates(d.model)
+	d.alpha = d.model.filter(window)
+	d.sinceFit = 0
+	d.fitted = len(window)
+}
+
+// labelStates names model states: the state with the most volatility per
+// unit of efficiency is Volatile (a strong trend also has a wide ATR), of the
+// other two the one with more efficiency and ADX is Trending
+func labelStates(m *gaussianHMM) []Regime {
+	labels := make([]Regime, m.k)
+	volatile := 0
+	for s := 1; s < m.k; s++ {
+		if m.means[s][1]-m.means[s][0] > m.means[volatile][1]-m.means[volatile][0] {
+			volatile = s
+		}
+	}
+	labels[volatile] = Volatile
+
+	trending := -1
+	for s := 0; s < m.k; s++ {
+		if s == volatile {
+			continue
+		}
+		if trending < 0 || m.means[s][0]+m.means[s][2] > m.means[trending][0]+m.means[trending][2] {
+			trending = s
+		}
+	}
+	for s := 0; s < m.k; s++ {
+		switch {
+		case s == volatile:
+		case s == trending:
+			labels[s] = Trending
+		default:
+			labels[s] = Ranging
+		}
+	}
+	return labels
+}
+
+// State returns the regime at the last synced candle
+func (d *Detector) State() State {
+	state := State{
+		Regime:    Unknown,
+		Direction: "up",
+		Features:  d.current,
+		Timestamp: d.lastTime,
+	}
+	if len(d.closes) > 1 && d.closes[len(d.closes)-1] < d.closes[0] {
+		state.Direction = "down"
+	}
+	if d.model == nil || d.alpha == nil {
+		return state
+	}
+
+	state.Probabilities = make(map[Regime]float64, len(Regimes))
+	for s, p := range d.alpha {
+		state.Probabilities[d.labels[s]] += p
+	}
+	for _, r := range Regimes {
+		if p := state.Probabilities[r]; p > state.Confidence {
+			state.Regime = r
+			state.Confidence = p
+		}
+	}
+	state.FittedBars = d.fitted
+	return state
+}
+
+// Detect fits a detector on a candle slice and returns the regime at its last candle
+func Detect(candles []Candle) State {
+	d := NewDetector(DefaultConfig())
+	d.Sync(candles)
+	return d.State()
+}
+
+// Series returns the regime at every candle, computed causally bar by bar
+func Series(candles []Candle, cfg Config) []Regime {
+	d := NewDetector(cfg)
+	series := make([]Regime, len(candles))
+	for i := range candles {
+		d.Sync(candles[:i+1])
+		series[i] = d.State().Regime
+	}
+	return series
+}
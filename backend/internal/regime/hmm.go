@@ -0,0 +1,382 @@
+package regime
+
+import (
+	"math"
+	"sort"
+)
+
+// gaussianHMM is a hidden Markov model with diagonal Gaussian emissions.
+// Features are standardized with the fit window's mean and deviation.
+type gaussianHMM struct {
+	k, d  int
+	start []float64
+	trans [][]float64
+	means [][]float64
+	vars  [][]float64
+
+	center []float64
+	scale  []float64
+}
+
+const (
+	varianceFloor = 1e-3
+	maxIterations = 30
+)
+
+// fitHMM standardizes the observations, seeds states with k-means and runs Baum-Welch
+func fitHMM(observations [][]float64, k int) *gaussianHMM {
+	m := &gaussianHMM{k: k, d: len(observations[0])}
+	x := m.standardize(observations)
+
+	assign := kmeans(x, k)
+	m.means = make([][]float64, k)
+	m.vars = make([][]float64, k)
+	for s := 0; s < k; s++ {
+		m.means[s] = make([]float64, m.d)
+		m.vars[s] = make([]float64, m.d)
+	}
+	counts := make([]float64, k)
+	for t, s := range assign {
+		counts[s]++
+		for j, v := range x[t] {
+			m.means[s][j] += v
+		}
+	}
+	for s := 0; s < k; s++ {
+		for j := range m.means[s] {
+			if counts[s] > 0 {
+				m.means[s][j] /= counts[s]
+			}
+		}
+	}
+	for t, s := range assign {
+		for j, v := range x[t] {
+			diff := v - m.means[s][j]
+			m.vars[s][j] += diff * diff
+		}
+	}
+	for s := 0; s < k; s++ {
+		for j := range m.vars[s] {
+			if counts[s] > 1 {
+				m.vars[s][j] /= counts[s]
+			}
+			m.vars[s][j] = math.Max(m.vars[s][j], varianceFloor)
+		}
+	}
+
+	// Regimes are sticky: start with a 90% chance of staying in a state
+	m.start = make([]float64, k)
+	m.trans = make([][]float64, k)
+	for i := 0; i < k; i++ {
+		m.start[i] = 1 / float64(k)
+		m.trans[i] = make([]float64, k)
+		for j := 0; j < k; j++ {
+			if i == j {
+				m.trans[i][j] = 0.9
+			} else {
+				m.trans[i][j] = 0.1 / float64(k-1)
+			}
+		}
+	}
+
+	m.baumWelch(x)
+	return m
+}
+
+// standardize stores the per-feature mean and deviation and returns scaled copies
+func (m *gaussianHMM) standardize(observations [][]float64) [][]float64 {
+	n := float64(len(observations))
+	m.center = make([]float64, m.d)
+	m.scale = make([]float64, m.d)
+	for _, o := range observations {
+		for j, v := range o {
+			m.center[j] += v / n
+		}
+	}
+	for _, o := range observations {
+		for j, v := range o {
+			diff := v - m.center[j]
+			m.scale[j] += diff * diff / n
+		}
+	}
+	for j := range m.scale {
+		m.scale[j] = math.Sqrt(m.scale[j])
+		if m.scale[j] == 0 {
+			m.scale[j] = 1
+		}
+	}
+
+	x := make([][]float64, len(observations))
+	for t, o := range observations {
+		x[t] = m.scaled(o)
+	}
+	return x
+}
+
+func (m *gaussianHMM) scaled(o []float64) []float64 {
+	x := make([]float64, m.d)
+	for j, v := range o {
+		x[j] = (v - m.center[j]) / m.scale[j]
+	}
+	return x
+}
+
+// emission returns state densities of x divided by a common factor (which
+// cancels out in the normalized forward/backward passes)
+func (m *gaussianHMM) emission(x []float64) []float64 {
+	logs := make([]float64, m.k)
+	maxLog := math.Inf(-1)
+	for s := 0; s < m.k; s++ {
+		lp := 0.0
+		for j, v := range x {
+			diff := v - m.means[s][j]
+			lp -= 0.5 * (math.Log(2*math.Pi*m.vars[s][j]) + diff*diff/m.vars[s][j])
+		}
+		logs[s] = lp
+		maxLog = math.Max(maxLog, lp)
+	}
+	b := make([]float64, m.k)
+	for s, lp := range logs {
+		b[s] = math.Exp(lp - maxLog)
+	}
+	return b
+}
+
+// baumWelch re-estimates the model on standardized observations
+func (m *gaussianHMM) baumWelch(x [][]float64) {
+	n := len(x)
+	if n < 2 {
+		return
+	}
+	prevLL := math.Inf(-1)
+
+	for iter := 0; iter < maxIterations; iter++ {
+		b := make([][]float64, n)
+		for t := range x {
+			b[t] = m.emission(x[t])
+		}
+
+		// Scaled forward pass
+		alpha := make([][]float64, n)
+		scale := make([]float64, n)
+		ll := 0.0
+		for t := 0; t < n; t++ {
+			alpha[t] = make([]float64, m.k)
+			for j := 0; j < m.k; j++ {
+				if t == 0 {
+					alpha[t][j] = m.start[j] * b[t][j]
+					continue
+				}
+				sum := 0.0
+				for i := 0; i < m.k; i++ {
+					sum += alpha[t-1][i] * m.trans[i][j]
+				}
+				alpha[t][j] = sum * b[t][j]
+			}
+			scale[t] = normalize(alpha[t])
+			ll += math.Log(scale[t])
+		}
+		if ll-prevLL < 1e-4*float64(n) {
+			break
+		}
+		prevLL = ll
+
+		// Scaled backward pass with the forward scale factors
+		beta := make([][]float64, n)
+		beta[n-1] = make([]float64, m.k)
+		for i := range beta[n-1] {
+			beta[n-1][i] = 1
+		}
+		for t := n - 2; t >= 0; t-- {
+			beta[t] = make([]float64, m.k)
+			for i := 0; i < m.k; i++ {
+				sum := 0.0
+				for j := 0; j < m.k; j++ {
+					sum += m.trans[i][j] * b[t+1][j] * beta[t+1][j]
+				}
+				beta[t][i] = sum / scale[t+1]
+			}
+		}
+
+		// Expected state occupancy and transitions
+		gamma := make([][]float64, n)
+		transNum := make([][]float64, m.k)
+		for i := range transNum {
+			transNum[i] = make([]float64, m.k)
+		}
+		for t := 0; t < n; t++ {
+			gamma[t] = make([]float64, m.k)
+			for i := 0; i < m.k; i++ {
+				gamma[t][i] = alpha[t][i] * beta[t][i]
+			}
+			normalize(gamma[t])
+			if t == n-1 {
+				continue
+			}
+			xi := make([][]float64, m.k)
+			total := 0.0
+			for i := 0; i < m.k; i++ {
+				xi[i] = make([]float64, m.k)
+				for j := 0; j < m.k; j++ {
+					xi[i][j] = alpha[t][i] * m.trans[i][j] * b[t+1][j] * beta[t+1][j]
+					total += xi[i][j]
+				}
+			}
+			if total == 0 {
+				continue
+			}
+			for i := 0; i < m.k; i++ {
+				for j := 0; j < m.k; j++ {
+					transNum[i][j] += xi[i][j] / total
+				}
+			}
+		}
+
+		// M-step
+		copy(m.start, gamma[0])
+		for i := 0; i < m.k; i++ {
+			if normalize(transNum[i]) > 0 {
+				m.trans[i] = transNum[i]
+			}
+		}
+		for s := 0; s < m.k; s++ {
+			weight := 0.0
+			mean := make([]float64, m.d)
+			for t := range x {
+				weight += gamma[t][s]
+				for j, v := range x[t] {
+					mean[j] += gamma[t][s] * v
+				}
+			}
+			if weight < 1e-6 {
+				continue // Empty state: keep the previous estimate
+			}
+			variance := make([]float64, m.d)
+			for j := range mean {
+				mean[j] /= weight
+			}
+			for t := range x {
+				for j, v := range x[t] {
+					diff := v - mean[j]
+					variance[j] += gamma[t][s] * diff * diff
+				}
+			}
+			for j := range variance {
+				variance[j] = math.Max(variance[j]/weight, varianceFloor)
+			}
+			m.means[s] = mean
+			m.vars[s] = variance
+		}
+	}
+}
+
+// filter returns the forward (filtered) state probabilities after each observation
+func (m *gaussianHMM) filter(observations [][]float64) []float64 {
+	var alpha []float64
+	for _, o := range observations {
+		alpha = m.step(alpha, o)
+	}
+	return alpha
+}
+
+// step advances filtered state probabilities by one raw observation
+func (m *gaussianHMM) step(alpha []float64, o []float64) []float64 {
+	b := m.emission(m.scaled(o))
+	next := make([]float64, m.k)
+	for j := 0; j < m.k; j++ {
+		if alpha == nil {
+			next[j] = m.start[j] * b[j]
+			continue
+		}
+		for i := 0; i < m.k; i++ {
+			next[j] += alpha[i] * m.trans[i][j]
+		}
+		next[j] *= b[j]
+	}
+	if normalize(next) == 0 {
+		for j := range next {
+			next[j] = 1 / float64(m.k)
+		}
+	}
+	return next
+}
+
+// normalize scales p to sum to one and returns the original sum
+func normalize(p []float64) float64 {
+	sum := 0.0
+	for _, v := range p {
+		sum += v
+	}
+	if sum > 0 {
+		for i := range p {
+			p[i] /= sum
+		}
+	}
+	return sum
+}
+
+// kmeans clusters standardized observations. Centroids are seeded
+// deterministically at quantiles of the summed features so fits are repeatable.
+func kmeans(x [][]float64, k int) []int {
+	n := len(x)
+	order := make([]int, n)
+	score := make([]float64, n)
+	for t := range x {
+		order[t] = t
+		for _, v := range x[t] {
+			score[t] += v
+		}
+	}
+	sort.Slice(order, func(a, b int) bool { return score[order[a]] < score[order[b]] })
+
+	centroids := make([][]float64, k)
+	for s := 0; s < k; s++ {
+		seed := x[order[(2*s+1)*n/(2*k)]]
+		centroids[s] = append([]float64(nil), seed...)
+	}
+
+	assign := make([]int, n)
+	for iter := 0; iter < 20; iter++ {
+		changed := false
+		for t := range x {
+			best, bestDist := 0, math.Inf(1)
+			for s, c := range centroids {
+				dist := 0.0
+				for j, v := range x[t] {
+					dist += (v - c[j]) * (v - c[j])
+				}
+				if dist < bestDist {
+					best, bestDist = s, dist
+				}
+			}
+			if assign[t] != best {
+				changed = true
+				assign[t] = best
+			}
+		}
+		if !changed && iter > 0 {
+			break
+		}
+
+		counts := make([]float64, k)
+		sums := make([][]float64, k)
+		for s := range sums {
+			sums[s] = make([]float64, len(x[0]))
+		}
+		for t, s := range assign {
+			counts[s]++
+			for j, v := range x[t] {
+				sums[s][j] += v
+			}
+		}
+		for s := range centroids {
+			if counts[s] == 0 {
+				continue
+			}
+			for j := range centroids[s] {
+				centroids[s][j] = sums[s][j] / counts[s]
+			}
+		}
+	}
+	return assign
+}
@@ -0,0 +1,123 @@
+package regime
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// synthetic builds calm ranging, steady trending and wild volatile segments
+func synthetic(seed int64, segments ...Regime) []Candle {
+	rng := rand.New(rand.NewSource(seed))
+	candles := []Candle{}
+	price := 100.0
+	for _, segment := range segments {
+		for i := 0; i < 300; i++ {
+			open := price
+			var noise, drift float64
+			switch segment {
+			case Ranging:
+				noise, drift = 0.15, (100-price)*0.05 // Mean-reverting around 100
+			case Trending:
+				noise, drift = 0.1, 0.25
+			case Volatile:
+				noise, drift = 1.5, 0
+			}
+			price += drift + rng.NormFloat64()*noise
+			wick := noise * rng.Float64()
+			candles = append(candles, Candle{
+				Timestamp: int64(len(candles)) * 900000,
+				Open:      open,
+				High:      math.Max(open, price) + wick,
+				Low:       math.Min(open, price) - wick,
+				Close:     price,
+				Volume:    1000,
+			})
+		}
+	}
+	return candles
+}
+
+func TestDetectorClassifiesSegments(t *testing.T) {
+	segments := []Regime{Ranging, Trending, Volatile, Ranging}
+	candles := synthetic(1, segments...)
+	series := Series(candles, DefaultConfig())
+
+	for n, want := range segments {
+		// Score the second half of each segment, once the filter has switched over
+		counts := map[Regime]int{}
+		for i := n*300 + 150; i < (n+1)*300; i++ {
+			counts[series[i]]++
+		}
+		if n == 0 {
+			// The first model is fitted on one segment only; it is just required to exist
+			if counts[Unknown] == 150 {
+				t.Fatalf("segment 0: no regime published")
+			}
+			continue
+		}
+		if counts[want] < 100 {
+			t.Fatalf("segment %d: want mostly %s, got %v", n, want, counts)
+		}
+	}
+}
+
+func TestStateProbabilities(t *testing.T) {
+	candles := synthetic(2, Ranging, Trending, Volatile)
+	state := Detect(candles)
+	if state.Regime == Unknown {
+		t.Fatalf("no regime detected on %d candles", len(candles))
+	}
+
+	sum := 0.0
+	for _, r := range Regimes {
+		p := state.Probability(r)
+		if p < 0 || p > 1 {
+			t.Fatalf("probability of %s = %v", r, p)
+		}
+		sum += p
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Fatalf("probabilities sum to %v", sum)
+	}
+	if state.Confidence != state.Probability(state.Regime) {
+		t.Fatalf("confidence %v does not match P(%s) = %v", state.Confidence, state.Regime, state.Probability(state.Regime))
+	}
+}
+
+func TestDetectorNeedsHistory(t *testing.T) {
+	candles := synthetic(3, Trending)[:100]
+	if state := Detect(candles); state.Regime != Unknown {
+		t.Fatalf("regime %s from %d candles, want unknown", state.Regime, len(candles))
+	}
+}
+
+func TestSlidingWindowMatchesFullHistory(t *testing.T) {
+	candles := synthetic(4, Ranging, Trending, Volatile)
+	full := Series(candles, DefaultConfig())
+
+	d := NewDetector(DefaultConfig())
+	for i := 300; i <= len(candles); i++ {
+		d.Sync(candles[i-300 : i])
+		if got := d.State().Regime; got != full[i-1] {
+			t.Fatalf("bar %d: sliding window %s, full history %s", i-1, got, full[i-1])
+		}
+	}
+}
+
+func TestDetectorsKeepOnePerTimeframe(t *testing.T) {
+	var ds Detectors
+	hourly := ds.For("1h")
+	if ds.For("1h") != hourly || ds.For("4h") == hourly {
+		t.Fatal("expected one detector per timeframe")
+	}
+
+	candles := synthetic(5, Trending)
+	hourly.Sync(candles)
+	if got, want := ds.For("1h").State(), Detect(candles); got.Regime != want.Regime {
+		t.Errorf("kept detector %s, fresh fit %s", got.Regime, want.Regime)
+	}
+	if got := ds.For("4h").State().Regime; got != Unknown {
+		t.Errorf("unsynced timeframe %s, want unknown", got)
+	}
+}
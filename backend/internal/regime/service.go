@@ -0,0 +1,53 @@
+package regime
+
+import (
+	"log"
+	"strings"
+	"sync"
+)
+
+// Published regimes keyed by "SYMBOL:interval". Live signal paths publish the
+// regime of every market they evaluate; the API reads it back.
+var (
+	published   = make(map[string]State)
+	publishedMu sync.RWMutex
+)
+
+func marketKey(symbol, interval string) string {
+	return strings.ToUpper(symbol) + ":" + interval
+}
+
+// Publish records the current regime of a market, logging regime changes
+func Publish(symbol, interval string, state State) {
+	key := marketKey(symbol, interval)
+
+	publishedMu.Lock()
+	previous, seen := published[key]
+	published[key] = state
+	publishedMu.Unlock()
+
+	if seen && previous.Regime != state.Regime && state.Regime != Unknown {
+		log.Printf("🌦️  Regime change %s: %s → %s (%.0f%%)", key, previous.Regime, state.Regime, state.Confidence*100)
+	}
+}
+
+// Current returns the last published regime of a market
+func Current(symbol, interval string) (State, bool) {
+	publishedMu.RLock()
+	defer publishedMu.RUnlock()
+
+	state, ok := published[marketKey(symbol, interval)]
+	return state, ok
+}
+
+// All returns every published regime keyed by "SYMBOL:interval"
+func All() map[string]State {
+	publishedMu.RLock()
+	defer publishedMu.RUnlock()
+
+	all := make(map[string]State, len(published))
+	for key, state := range published {
+		all[key] = state
+	}
+	return all
+}
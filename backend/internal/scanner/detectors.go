@@ -0,0 +1,59 @@
+package scanner
+
+import "time"
+
+// Scan runs the pattern and setup detectors on the last closed bar of a
+// market. Every hit is stamped with the bar it was found on.
+func Scan(symbol, timeframe string, candles []Candle, frameMs int64) []Hit {
+	last := len(candles) - 1
+	barTime := candles[last].Timestamp
+	hits := []Hit{}
+
+	add := func(source, name, direction string, strength, entry, stop, target float64, idx int) {
+		t := barTime
+		if idx >= 0 && idx < len(candles) {
+			t = candles[idx].Timestamp
+		}
+		hits = append(hits, Hit{
+			Symbol:    symbol,
+			Timeframe: timeframe,
+			Source:    source,
+			Name:      name,
+			Direction: direction,
+			Strength:  strength,
+			Entry:     entry,
+			StopLoss:  stop,
+			Target:    target,
+			BarTime:   t,
+			LastSeen:  t,
+		})
+	}
+
+	for _, p := range RecognizeAllPatterns(candles, timeframe) {
+		if p.Index == last {
+			add("candlestick", p.Name, p.Type, p.Strength, 0, 0, 0, p.Index)
+			hits[len(hits)-1].Reliability = p.Reliability
+		}
+	}
+	for _, p := range RecognizeAdvancedPatterns(candles) {
+		add("advanced_pattern", p.Name, p.Type, p.Strength, p.Entry, p.StopLoss, p.Target, p.CandleIdx)
+		hits[len(hits)-1].Reliability = p.Reliability
+	}
+
+	// Setups are evaluated at the close of the last bar
+	closeTime := time.UnixMilli(barTime + frameMs)
+	if sb := DetectSilverBullet(candles, closeTime); sb != nil && sb.Valid {
+		add("silver_bullet", "Silver Bullet "+sb.TimeWindow, sb.Type, sb.Strength, sb.Entry, sb.StopLoss, sb.Target, last)
+	}
+	if u := DetectUnicornSetup(candles); u != nil && u.Valid {
+		add("unicorn", "Unicorn", u.Type, u.Strength, u.Entry, u.StopLoss, u.Target1, last)
+	}
+	if ts := DetectTurtleSoup(candles, 20); ts != nil && ts.Valid {
+		add("turtle_soup", "Turtle Soup", ts.Type, ts.Strength, ts.Entry, ts.StopLoss, ts.Target, last)
+	}
+	if qm := DetectQuasimodo(candles); qm != nil && qm.Valid {
+		add("quasimodo", "Quasimodo", qm.Type, qm.Strength, qm.Entry, qm.StopLoss, qm.Target, last)
+	}
+
+	return hits
+}
@@ -0,0 +1,498 @@
+package scanner
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"tradebot/backend/internal/patternstats"
+	"tradebot/backend/internal/regime"
+)
+
+// ==================== PATTERN & SETUP SCANNER ====================
+// Runs the candlestick, advanced pattern and institutional setup detectors
+// over a watchlist of symbols and timeframes on every bar close, and keeps
+// a ranking of the hits by strength and freshness.
+
+// Hit is one pattern or setup found on a market
+type Hit struct {
+	Symbol    string  `json:"symbol"`
+	Timeframe string  `json:"timeframe"`
+	Source    string  `json:"source"` // "candlestick", "advanced_pattern", "silver_bullet", "unicorn", "turtle_soup", "quasimodo"
+	Name      string  `json:"name"`
+	Direction string  `json:"direction"` // "bullish", "bearish" or "neutral"
+	Strength  float64 `json:"strength"`
+	Entry     float64 `json:"entry,omitempty"`
+	StopLoss  float64 `json:"stopLoss,omitempty"`
+	Target    float64 `json:"target,omitempty"`
+	BarTime   int64   `json:"barTime"`  // Open time of the bar the hit was first seen on (ms)
+	LastSeen  int64   `json:"lastSeen"` // Open time of the last bar it was detected on (ms)
+	AgeBars   int     `json:"ageBars"`  // Closed bars since BarTime
+	Score     float64 `json:"score"`    // Strength decayed by age, used for ranking
+
+	// Patterns only: hit rate calibrated from measured edge stats (0-100)
+	// and the number of historical samples behind it (0 = hand-set value)
+	Reliability float64 `json:"reliability,omitempty"`
+	EdgeSamples int     `json:"edgeSamples,omitempty"`
+}
+
+func (h Hit) key() string {
+	return strings.Join([]string{h.Symbol, h.Timeframe, h.Source, h.Name, h.Direction}, "|")
+}
+
+// Config is the scanner watchlist and ranking settings
+type Config struct {
+	Symbols      []string `json:"symbols"`
+	Timeframes   []string `json:"timeframes"`
+	Bars         int      `json:"bars"`         // Candles fetched per market and scan
+	MinStrength  float64  `json:"minStrength"`  // Hits below this strength are ignored
+	MaxAgeBars   int      `json:"maxAgeBars"`   // Hits are dropped once this many bars old
+	HalfLifeBars float64  `json:"halfLifeBars"` // Bars for a hit's score to halve
+}
+
+// DefaultConfig scans the majors on 15m, 1h and 4h
+func DefaultConfig() Config {
+	return Config{
+		Symbols:      []string{"BTCUSDT", "ETHUSDT", "SOLUSDT", "BNBUSDT"},
+		Timeframes:   []string{"15m", "1h", "4h"},
+		Bars:         200,
+		MinStrength:  50,
+		MaxAgeBars:   10,
+		HalfLifeBars: 3,
+	}
+}
+
+// Validate upper-cases the watchlist and fills zero timeframes, bars, age and
+// half-life with defaults. It rejects an empty watchlist, unknown timeframes
+// and fewer than 60 bars.
+func (cfg *Config) Validate() error {
+	def := DefaultConfig()
+	if len(cfg.Symbols) == 0 {
+		return fmt.Errorf("watchlist is empty")
+	}
+	if len(cfg.Timeframes) == 0 {
+		cfg.Timeframes = def.Timeframes
+	}
+	for i, symbol := range cfg.Symbols {
+		cfg.Symbols[i] = strings.ToUpper(strings.TrimSpace(symbol))
+	}
+	for _, tf := range cfg.Timeframes {
+		if _, err := timeframeMillis(tf); err != nil {
+			return err
+		}
+	}
+	if cfg.Bars <= 0 {
+		cfg.Bars = def.Bars
+	}
+	if cfg.Bars < 60 {
+		return fmt.Errorf("bars must be at least 60, got %d", cfg.Bars)
+	}
+	if cfg.MaxAgeBars <= 0 {
+		cfg.MaxAgeBars = def.MaxAgeBars
+	}
+	if cfg.HalfLifeBars <= 0 {
+		cfg.HalfLifeBars = def.HalfLifeBars
+	}
+	return nil
+}
+
+// Fetcher loads the most recent candles of a market
+type Fetcher func(symbol, interval string, bars int) ([]Candle, error)
+
+// Update is pushed to subscribers after every scan cycle
+type Update struct {
+	Type       string   `json:"type"` // "scan"
+	Timeframes []string `json:"timeframes"`
+	NewHits    []Hit    `json:"newHits"` // Hits first seen in this cycle
+	Results    []Hit    `json:"results"` // Full ranking after the cycle
+	Time       int64    `json:"time"`
+}
+
+// Scanner keeps the ranked hits of a watchlist. It is safe for concurrent use.
+type Scanner struct {
+	mu       sync.RWMutex
+	cfg      Config
+	fetch    Fetcher
+	hits     map[string]*Hit
+	lastScan time.Time
+	stop     chan struct{}
+
+	// One regime detector per market, kept across scans so calibration only
+	// feeds the new bars instead of refitting the model every cycle
+	regimeMu sync.Mutex
+	regimes  map[string]*regime.Detector
+
+	subsMu sync.RWMutex
+	subs   map[string]chan Update
+}
+
+// New creates a stopped scanner
+func New(cfg Config, fetch Fetcher) *Scanner {
+	return &Scanner{
+		cfg:     cfg,
+		fetch:   fetch,
+		hits:    make(map[string]*Hit),
+		regimes: make(map[string]*regime.Detector),
+		subs:    make(map[string]chan Update),
+	}
+}
+
+// Config returns the current watchlist and settings
+func (s *Scanner) Config() Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cfg
+}
+
+// SetConfig replaces the watchlist; hits of markets no longer watched are dropped
+func (s *Scanner) SetConfig(cfg Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	watched := map[string]bool{}
+	for _, symbol := range cfg.Symbols {
+		for _, tf := range cfg.Timeframes {
+			watched[symbol+"|"+tf] = true
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cfg = cfg
+	for key, hit := range s.hits {
+		if !watched[hit.Symbol+"|"+hit.Timeframe] {
+			delete(s.hits, key)
+		}
+	}
+	return nil
+}
+
+// Running reports whether the bar-close loop is active
+func (s *Scanner) Running() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.stop != nil
+}
+
+// LastScan returns the time of the last completed scan cycle
+func (s *Scanner) LastScan() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastScan
+}
+
+// Start runs a scan now and then on every bar close of the watched timeframes
+func (s *Scanner) Start() error {
+	s.mu.Lock()
+	if s.stop != nil {
+		s.mu.Unlock()
+		return fmt.Errorf("scanner already running")
+	}
+	if s.fetch == nil {
+		s.mu.Unlock()
+		return fmt.Errorf("scanner has no candle source")
+	}
+	stop := make(chan struct{})
+	s.stop = stop
+	timeframes := s.cfg.Timeframes
+	s.mu.Unlock()
+
+	go func() {
+		s.ScanTimeframes(timeframes)
+		s.run(stop)
+	}()
+	log.Printf("🔭 Scanner started (%d symbols x %v)", len(s.Config().Symbols), timeframes)
+	return nil
+}
+
+// Stop ends the bar-close loop; results are kept
+func (s *Scanner) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+		log.Println("🔭 Scanner stopped")
+	}
+}
+
+// closeDelay gives the exchange time to publish a closed bar
+const closeDelay = 3 * time.Second
+
+func (s *Scanner) run(stop chan struct{}) {
+	for {
+		next, due := nextClose(s.Config().Timeframes, time.Now())
+		timer := time.NewTimer(time.Until(next) + closeDelay)
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		s.ScanTimeframes(due)
+	}
+}
+
+// nextClose returns the next bar close among timeframes and every timeframe
+// closing at that moment (a 1h close is also a 15m close)
+func nextClose(timeframes []string, now time.Time) (time.Time, []string) {
+	nowMs := now.UnixMilli()
+	var earliest int64
+	var due []string
+	for _, tf := range timeframes {
+		frameMs, err := timeframeMillis(tf)
+		if err != nil {
+			continue
+		}
+		closeMs := (nowMs/frameMs + 1) * frameMs
+		switch {
+		case earliest == 0 || closeMs < earliest:
+			earliest = closeMs
+			due = []string{tf}
+		case closeMs == earliest:
+			due = append(due, tf)
+		}
+	}
+	return time.UnixMilli(earliest), due
+}
+
+// ScanTimeframes scans every watched symbol on the given timeframes and
+// publishes the new ranking
+func (s *Scanner) ScanTimeframes(timeframes []string) {
+	cfg := s.Config()
+	newHits := []Hit{}
+	for _, tf := range timeframes {
+		frameMs, err := timeframeMillis(tf)
+		if err != nil {
+			continue
+		}
+		for _, symbol := range cfg.Symbols {
+			candles, err := s.fetch(symbol, tf, cfg.Bars)
+			if err != nil {
+				log.Printf("⚠️  Scanner: %s %s: %v", symbol, tf, err)
+				continue
+			}
+			candles = closedCandles(candles, frameMs, time.Now().UnixMilli())
+			if len(candles) < 60 {
+				continue
+			}
+			hits := Scan(symbol, tf, candles, frameMs)
+			s.calibrate(symbol, tf, hits, candles)
+			newHits = append(newHits, s.merge(symbol, tf, hits, candles[len(candles)-1].Timestamp, frameMs)...)
+		}
+	}
+
+	s.mu.Lock()
+	s.lastScan = time.Now()
+	s.mu.Unlock()
+
+	if len(newHits) > 0 {
+		log.Printf("🔭 Scanner: %d new hits on %v", len(newHits), timeframes)
+	}
+	s.publish(Update{
+		Type:       "scan",
+		Timeframes: timeframes,
+		NewHits:    rank(newHits),
+		Results:    s.Results(Filter{}),
+		Time:       time.Now().UnixMilli(),
+	})
+}
+
+// closedCandles drops a trailing bar that is still forming
+func closedCandles(candles []Candle, frameMs, nowMs int64) []Candle {
+	if n := len(candles); n > 0 && candles[n-1].Timestamp+frameMs > nowMs {
+		return candles[:n-1]
+	}
+	return candles
+}
+
+// merge folds the hits of one market scan into the ranking and returns the
+// hits that were not known yet. Hits keep the bar they were first seen on,
+// so freshness decays while a pattern keeps being re-detected.
+func (s *Scanner) merge(symbol, timeframe string, hits []Hit, lastBar, frameMs int64) []Hit {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fresh := []Hit{}
+	for _, h := range hits {
+		if h.Strength < s.cfg.MinStrength {
+			continue
+		}
+		if known, ok := s.hits[h.key()]; ok {
+			known.LastSeen = h.LastSeen
+			known.Strength = h.Strength
+			known.Entry, known.StopLoss, known.Target = h.Entry, h.StopLoss, h.Target
+			known.Reliability, known.EdgeSamples = h.Reliability, h.EdgeSamples
+			continue
+		}
+		hit := h
+		s.hits[h.key()] = &hit
+		fresh = append(fresh, hit)
+	}
+
+	for key, hit := range s.hits {
+		if hit.Symbol != symbol || hit.Timeframe != timeframe {
+			continue
+		}
+		hit.AgeBars = int((lastBar - hit.BarTime) / frameMs)
+		if hit.AgeBars > s.cfg.MaxAgeBars {
+			delete(s.hits, key)
+			continue
+		}
+		hit.Score = hit.Strength * edgeFactor(hit) * math.Pow(0.5, float64(hit.AgeBars)/s.cfg.HalfLifeBars)
+	}
+	// A new hit can already be past MaxAgeBars and dropped above
+	kept := fresh[:0]
+	for _, h := range fresh {
+		if known, ok := s.hits[h.key()]; ok {
+			kept = append(kept, *known)
+		}
+	}
+	return kept
+}
+
+// calibrate replaces the hand-set reliability of pattern hits with one
+// measured on history, in the market's current regime
+func (s *Scanner) calibrate(symbol, timeframe string, hits []Hit, candles []Candle) {
+	var state *regime.State
+	for i := range hits {
+		h := &hits[i]
+		if h.Reliability == 0 {
+			continue
+		}
+		if state == nil {
+			detected := s.regime(symbol, timeframe, candles)
+			state = &detected
+		}
+		key := patternstats.Key{Source: h.Source, Pattern: h.Name, Direction: h.Direction}
+		h.Reliability, h.EdgeSamples = patternstats.GetStore().Calibrate(symbol, key, timeframe, string(state.Regime), h.Reliability)
+	}
+}
+
+// regime returns the market's regime at the last candle from the detector
+// kept for it
+func (s *Scanner) regime(symbol, timeframe string, candles []Candle) regime.State {
+	s.regimeMu.Lock()
+	defer s.regimeMu.Unlock()
+	key := symbol + "|" + timeframe
+	d, ok := s.regimes[key]
+	if !ok {
+		d = regime.NewDetector(regime.DefaultConfig())
+		s.regimes[key] = d
+	}
+	d.Sync(candles)
+	return d.State()
+}
+
+// edgeFactor scales a pattern's score by its measured hit rate relative to
+// a coin flip; hits without measured stats are not scaled
+func edgeFactor(h *Hit) float64 {
+	if h.EdgeSamples == 0 {
+		return 1
+	}
+	return h.Reliability / patternstats.CoinFlip
+}
+
+// Filter narrows the ranking; zero fields match everything
+type Filter struct {
+	Symbol    string
+	Timeframe string
+	Direction string
+	Source    string
+	Limit     int
+}
+
+func (f Filter) match(h *Hit) bool {
+	return (f.Symbol == "" || strings.EqualFold(f.Symbol, h.Symbol)) &&
+		(f.Timeframe == "" || f.Timeframe == h.Timeframe) &&
+		(f.Direction == "" || f.Direction == h.Direction) &&
+		(f.Source == "" || f.Source == h.Source)
+}
+
+// Results returns the hits matching a filter, best score first
+func (s *Scanner) Results(f Filter) []Hit {
+	s.mu.RLock()
+	results := []Hit{}
+	for _, h := range s.hits {
+		if f.match(h) {
+			results = append(results, *h)
+		}
+	}
+	s.mu.RUnlock()
+
+	results = rank(results)
+	if f.Limit > 0 && len(results) > f.Limit {
+		results = results[:f.Limit]
+	}
+	return results
+}
+
+// rank orders hits by score, then by the newest bar
+func rank(hits []Hit) []Hit {
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if hits[i].BarTime != hits[j].BarTime {
+			return hits[i].BarTime > hits[j].BarTime
+		}
+		return hits[i].key() < hits[j].key()
+	})
+	return hits
+}
+
+// Subscribe registers a client for scan updates
+func (s *Scanner) Subscribe(clientID string) chan Update {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+
+	ch := make(chan Update, 16)
+	s.subs[clientID] = ch
+	return ch
+}
+
+// Unsubscribe removes a client from scan updates
+func (s *Scanner) Unsubscribe(clientID string) {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+
+	if ch, ok := s.subs[clientID]; ok {
+		close(ch)
+		delete(s.subs, clientID)
+	}
+}
+
+func (s *Scanner) publish(update Update) {
+	s.subsMu.RLock()
+	defer s.subsMu.RUnlock()
+
+	for clientID, ch := range s.subs {
+		select {
+		case ch <- update:
+		default:
+			log.Printf("⚠️  Scanner update dropped for slow client %s", clientID)
+		}
+	}
+}
+
+// timeframeMillis converts "15m", "1h", "4h", "1d" to milliseconds
+func timeframeMillis(tf string) (int64, error) {
+	if len(tf) >= 2 {
+		var n int64
+		if _, err := fmt.Sscanf(tf[:len(tf)-1], "%d", &n); err == nil && n > 0 {
+			switch tf[len(tf)-1] {
+			case 'm':
+				return n * 60 * 1000, nil
+			case 'h':
+				return n * 60 * 60 * 1000, nil
+			case 'd':
+				return n * 24 * 60 * 60 * 1000, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid timeframe %q (use e.g. 15m, 1h, 4h, 1d)", tf)
+}
@@ -0,0 +1,164 @@
+package scanner
+
+import (
+	"testing"
+	"time"
+)
+
+const minute = int64(60 * 1000)
+
+func testScanner() *Scanner {
+	cfg := DefaultConfig()
+	cfg.Symbols = []string{"BTCUSDT"}
+	cfg.Timeframes = []string{"15m"}
+	return New(cfg, nil)
+}
+
+func TestMergeKeepsFirstSeenBarAndDecays(t *testing.T) {
+	s := testScanner()
+	frame := 15 * minute
+	hit := Hit{Symbol: "BTCUSDT", Timeframe: "15m", Source: "unicorn", Name: "Unicorn", Direction: "bullish", Strength: 80}
+
+	first := hit
+	first.BarTime, first.LastSeen = 0, 0
+	if fresh := s.merge("BTCUSDT", "15m", []Hit{first}, 0, frame); len(fresh) != 1 {
+		t.Fatalf("expected 1 new hit, got %d", len(fresh))
+	}
+
+	again := hit
+	again.BarTime, again.LastSeen = 3*frame, 3*frame
+	if fresh := s.merge("BTCUSDT", "15m", []Hit{again}, 3*frame, frame); len(fresh) != 0 {
+		t.Fatalf("re-detected hit reported as new")
+	}
+
+	results := s.Results(Filter{})
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	got := results[0]
+	if got.BarTime != 0 || got.LastSeen != 3*frame || got.AgeBars != 3 {
+		t.Errorf("unexpected freshness: barTime=%d lastSeen=%d age=%d", got.BarTime, got.LastSeen, got.AgeBars)
+	}
+	if got.Score != 40 { // One half-life
+		t.Errorf("expected score 40, got %v", got.Score)
+	}
+}
+
+func TestMergeDropsWeakAndStaleHits(t *testing.T) {
+	s := testScanner()
+	frame := 15 * minute
+	weak := Hit{Symbol: "BTCUSDT", Timeframe: "15m", Source: "candlestick", Name: "Doji", Direction: "neutral", Strength: 20}
+	strong := Hit{Symbol: "BTCUSDT", Timeframe: "15m", Source: "candlestick", Name: "Hammer", Direction: "bullish", Strength: 70}
+
+	s.merge("BTCUSDT", "15m", []Hit{weak, strong}, 0, frame)
+	if n := len(s.Results(Filter{})); n != 1 {
+		t.Fatalf("expected weak hit to be ignored, got %d results", n)
+	}
+
+	s.merge("BTCUSDT", "15m", nil, int64(s.cfg.MaxAgeBars+1)*frame, frame)
+	if n := len(s.Results(Filter{})); n != 0 {
+		t.Fatalf("expected stale hit to be dropped, got %d results", n)
+	}
+}
+
+func TestMergeSkipsNewHitsAlreadyStale(t *testing.T) {
+	s := testScanner()
+	frame := 15 * minute
+	old := Hit{Symbol: "BTCUSDT", Timeframe: "15m", Source: "unicorn", Name: "Unicorn", Direction: "bullish", Strength: 80}
+	recent := Hit{Symbol: "BTCUSDT", Timeframe: "15m", Source: "candlestick", Name: "Hammer", Direction: "bullish", Strength: 70}
+	lastBar := int64(s.cfg.MaxAgeBars+5) * frame
+	recent.BarTime, recent.LastSeen = lastBar, lastBar
+
+	fresh := s.merge("BTCUSDT", "15m", []Hit{old, recent}, lastBar, frame)
+	if len(fresh) != 1 || fresh[0].Name != "Hammer" {
+		t.Fatalf("expected only the recent hit, got %+v", fresh)
+	}
+	if n := len(s.Results(Filter{})); n != 1 {
+		t.Fatalf("expected 1 result, got %d", n)
+	}
+}
+
+func TestResultsRankByScoreAndFilter(t *testing.T) {
+	s := testScanner()
+	s.cfg.Timeframes = []string{"15m", "1h"}
+	frame := 15 * minute
+	s.merge("BTCUSDT", "15m", []Hit{
+		{Symbol: "BTCUSDT", Timeframe: "15m", Source: "quasimodo", Name: "Quasimodo", Direction: "bearish", Strength: 60},
+		{Symbol: "BTCUSDT", Timeframe: "15m", Source: "unicorn", Name: "Unicorn", Direction: "bullish", Strength: 90},
+	}, 0, frame)
+	s.merge("BTCUSDT", "1h", []Hit{
+		{Symbol: "BTCUSDT", Timeframe: "1h", Source: "turtle_soup", Name: "Turtle Soup", Direction: "bullish", Strength: 75},
+	}, 0, 4*frame)
+
+	results := s.Results(Filter{})
+	if len(results) != 3 || results[0].Name != "Unicorn" || results[2].Name != "Quasimodo" {
+		t.Fatalf("unexpected ranking: %+v", results)
+	}
+	if bullish := s.Results(Filter{Direction: "bullish", Limit: 1}); len(bullish) != 1 || bullish[0].Name != "Unicorn" {
+		t.Errorf("unexpected filtered results: %+v", bullish)
+	}
+	if hourly := s.Results(Filter{Timeframe: "1h"}); len(hourly) != 1 || hourly[0].Source != "turtle_soup" {
+		t.Errorf("unexpected timeframe filter: %+v", hourly)
+	}
+}
+
+func TestSetConfigDropsUnwatchedMarkets(t *testing.T) {
+	s := testScanner()
+	s.merge("BTCUSDT", "15m", []Hit{
+		{Symbol: "BTCUSDT", Timeframe: "15m", Source: "unicorn", Name: "Unicorn", Direction: "bullish", Strength: 90},
+	}, 0, 15*minute)
+
+	if err := s.SetConfig(Config{Symbols: []string{"ethusdt"}}); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(s.Results(Filter{})); n != 0 {
+		t.Errorf("expected hits of unwatched markets to be dropped, got %d", n)
+	}
+	if cfg := s.Config(); cfg.Symbols[0] != "ETHUSDT" || cfg.Bars != 200 {
+		t.Errorf("config not normalized: %+v", cfg)
+	}
+	if err := s.SetConfig(Config{}); err == nil {
+		t.Error("expected empty watchlist to be rejected")
+	}
+	if err := s.SetConfig(Config{Symbols: []string{"BTCUSDT"}, Timeframes: []string{"7x"}}); err == nil {
+		t.Error("expected invalid timeframe to be rejected")
+	}
+}
+
+func TestNextClose(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 50, 0, 0, time.UTC)
+	next, due := nextClose([]string{"15m", "1h", "4h"}, now)
+	if !next.Equal(time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected next close %v", next)
+	}
+	if len(due) != 2 || due[0] != "15m" || due[1] != "1h" {
+		t.Errorf("unexpected due timeframes %v", due)
+	}
+}
+
+func TestClosedCandlesDropsFormingBar(t *testing.T) {
+	frame := 15 * minute
+	candles := []Candle{{Timestamp: 0}, {Timestamp: frame}}
+	if got := closedCandles(candles, frame, frame+minute); len(got) != 1 {
+		t.Errorf("expected forming bar to be dropped, got %d candles", len(got))
+	}
+	if got := closedCandles(candles, frame, 2*frame); len(got) != 2 {
+		t.Errorf("expected closed bar to be kept, got %d candles", len(got))
+	}
+}
+
+func TestMeasuredReliabilityScalesScore(t *testing.T) {
+	s := testScanner()
+	s.merge("BTCUSDT", "15m", []Hit{
+		{Symbol: "BTCUSDT", Timeframe: "15m", Source: "candlestick", Name: "Hammer", Direction: "bullish", Strength: 70, Reliability: 60, EdgeSamples: 120},
+		{Symbol: "BTCUSDT", Timeframe: "15m", Source: "candlestick", Name: "Bullish Engulfing", Direction: "bullish", Strength: 80, Reliability: 75},
+	}, 0, 15*minute)
+
+	results := s.Results(Filter{})
+	if results[0].Name != "Hammer" || results[0].Score != 84 {
+		t.Errorf("expected measured edge to lift the hammer to 84, got %+v", results[0])
+	}
+	if results[1].Score != 80 {
+		t.Errorf("expected hand-set reliability to leave the score alone, got %v", results[1].Score)
+	}
+}
@@ -2,6 +2,9 @@ package signals
 
 import (
 	"math"
+
+	"tradebot/backend/internal/indicators"
+	"tradebot/backend/internal/patternstats"
 )
 
 // ==================== ADVANCED SIGNAL GENERATOR ====================
@@ -42,6 +45,7 @@ func PerformAdvancedAnalysis(candles []Candle, interval string) *AdvancedAnalysi
 
 	// 1. Multi-Timeframe Analysis
 	aa.MTF = PerformMultiTimeframeAnalysis(candles, interval)
+	calibrateMTFPatterns(aa.MTF)
 
 	// 2. ICT/SMC Analysis
 	aa.ICT = PerformICTAnalysis(candles)
@@ -63,6 +67,7 @@ func PerformAdvancedAnalysis(candles []Candle, interval string) *AdvancedAnalysi
 
 	// 8. Candlestick Patterns
 	aa.Patterns = RecognizeAllPatterns(candles, interval)
+	patternstats.GetStore().CalibratePatterns("", interval, patternstats.AllRegimes, aa.Patterns)
 
 	// Calculate scores
 	aa.calculateScores(candles)
@@ -70,6 +75,19 @@ func PerformAdvancedAnalysis(candles []Candle, interval string) *AdvancedAnalysi
 	return aa
 }
 
+// calibrateMTFPatterns replaces the hand-set reliability of the patterns found
+// on each timeframe with the one measured on history, pooled over every
+// studied symbol and regime
+func calibrateMTFPatterns(mta *MultiTimeframeAnalysis) {
+	if mta == nil {
+		return
+	}
+	store := patternstats.GetStore()
+	for _, tf := range []*TimeframeData{&mta.Higher, &mta.Current, &mta.Lower} {
+		store.CalibratePatterns("", tf.Timeframe, patternstats.AllRegimes, tf.Patterns)
+	}
+}
+
 // calculateScores calculates all component scores
 func (aa *AdvancedAnalysis) calculateScores(candles []Candle) {
 	currentPrice := candles[len(candles)-1].Close
@@ -171,7 +189,7 @@ func (aa *AdvancedAnalysis) calculateScores(candles []Candle) {
 	}
 
 	// Enhanced Order Blocks near price
-	atr := calculateATR(candles[len(candles)-14:], 14)
+	atr := indicators.ATR(candles[len(candles)-14:], 14)
 	for _, ob := range aa.EnhancedOBs {
 		if !ob.Mitigated {
 			distance := math.Abs(currentPrice - ob.MidPoint)
@@ -193,6 +211,9 @@ func (aa *AdvancedAnalysis) calculateScores(candles []Candle) {
 	bullishPatterns := 0
 	bearishPatterns := 0
 	for _, p := range aa.Patterns {
+		if !patternstats.Reliable(p) {
+			continue
+		}
 		if p.Type == "bullish" {
 			bullishPatterns++
 		} else if p.Type == "bearish" {
@@ -311,7 +332,7 @@ func GenerateAdvancedSignal(candles []Candle, interval string) *Signal {
 	
 	// Strong patterns present
 	for _, p := range analysis.Patterns {
-		if p.Type == analysis.Direction && p.Strength >= 80 {
+		if p.Type == analysis.Direction && p.Strength >= 80 && p.Reliability >= 50 {
 			confluenceCount++
 			break
 		}
@@ -323,7 +344,7 @@ func GenerateAdvancedSignal(candles []Candle, interval string) *Signal {
 	}
 
 	currentPrice := candles[len(candles)-1].Close
-	atr := calculateATR(candles[len(candles)-14:], 14)
+	atr := indicators.ATR(candles[len(candles)-14:], 14)
 	if atr == 0 {
 		return nil
 	}
@@ -2,6 +2,8 @@ package signals
 
 import (
 	"math"
+
+	"tradebot/backend/internal/indicators"
 )
 
 // generateBacktestSignal - ADVANCED MULTI-FACTOR STRATEGY
@@ -40,11 +42,11 @@ func generateTrendFollowingSignal(data []Candle, interval string) *Signal {
 	}
 	
 	// Calculate indicators
-	ema9 := calculateEMA(data, 9)
-	ema20 := calculateEMA(data, 20)
-	ema50 := calculateEMA(data, 50)
-	atr := calculateATR(data[len(data)-14:], 14)
-	rsi := calculateRSI(data, 14)
+	ema9 := indicators.EMA(data, 9)
+	ema20 := indicators.EMA(data, 20)
+	ema50 := indicators.EMA(data, 50)
+	atr := indicators.ATR(data[len(data)-14:], 14)
+	rsi := indicators.RSI(data, 14)
 	
 	if atr == 0 {
 		return nil
@@ -83,7 +85,7 @@ func generateTrendFollowingSignal(data []Candle, interval string) *Signal {
 	volumeRatio := currentCandle.Volume / avgVolume
 
 	// Volatility filter - avoid low volatility periods
-	recentATR := calculateATR(data[len(data)-5:], 5)
+	recentATR := indicators.ATR(data[len(data)-5:], 5)
 	volatilityOK := recentATR >= atr*0.7
 
 	// Candle analysis
@@ -362,6 +364,7 @@ func generateCombinedStrategy(data []Candle, interval string) *Signal {
 
 	// Perform multi-timeframe analysis
 	mta := PerformMultiTimeframeAnalysis(data, interval)
+	calibrateMTFPatterns(mta)
 
 	// If neutral, try to determine direction from recent price action
 	if mta.Direction == "neutral" {
@@ -403,7 +406,7 @@ func generateCombinedStrategy(data []Candle, interval string) *Signal {
 
 		// Order block near price
 		currentPrice := data[len(data)-1].Close
-		atr := calculateATR(data[len(data)-14:], 14)
+		atr := indicators.ATR(data[len(data)-14:], 14)
 
 		for _, ob := range ict.OrderBlocks {
 			if !ob.Mitigated {
@@ -451,7 +454,7 @@ func generateCombinedStrategy(data []Candle, interval string) *Signal {
 
 	// Generate signal
 	currentPrice := data[len(data)-1].Close
-	atr := calculateATR(data[len(data)-14:], 14)
+	atr := indicators.ATR(data[len(data)-14:], 14)
 
 	if atr == 0 {
 		return nil
@@ -548,7 +551,7 @@ func generateBreakoutSignal(data []Candle, interval string) *Signal {
 	currentPrice := currentCandle.Close
 
 	// Calculate ATR
-	atr := calculateATR(data[len(data)-14:], 14)
+	atr := indicators.ATR(data[len(data)-14:], 14)
 	if atr == 0 {
 		return nil
 	}
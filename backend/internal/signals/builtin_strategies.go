@@ -0,0 +1,357 @@
+package signals
+
+// builtinStrategy adapts a UnifiedSignalGenerator method to the Strategy interface
+type builtinStrategy struct {
+	meta      StrategyMetadata
+	timeframe string
+	warmup    int
+	params    []ParamSpec
+	values    StrategyParams // Resolved overrides; nil means code defaults
+	eval      func(usg *UnifiedSignalGenerator, candles []Candle, idx int) *AdvancedSignal
+}
+
+func (s *builtinStrategy) Metadata() StrategyMetadata { return s.meta }
+func (s *builtinStrategy) DefaultTimeframe() string   { return s.timeframe }
+func (s *builtinStrategy) ParamSchema() []ParamSpec   { return s.params }
+func (s *builtinStrategy) WarmupBars() int            { return s.warmup }
+
+// WithParams returns a copy of the strategy that evaluates with the given values
+func (s *builtinStrategy) WithParams(params StrategyParams) Strategy {
+	configured := *s
+	configured.values = params
+	return &configured
+}
+
+func (s *builtinStrategy) Evaluate(candles []Candle, idx int) *AdvancedSignal {
+	return s.EvaluateWith(NewEvalContext(), candles, idx)
+}
+
+// EvaluateWith evaluates with indicators and regime read from ctx, synced to candles[:idx+1]
+func (s *builtinStrategy) EvaluateWith(ctx *EvalContext, candles []Candle, idx int) *AdvancedSignal {
+	ctx.Sync(candles[:idx+1])
+	usg := &UnifiedSignalGenerator{Params: s.values, ctx: ctx, ind: ctx.Indicators}
+	signal := s.eval(usg, candles, idx)
+	if signal == nil {
+		return nil
+	}
+	// Evidence takes the final signal timeframe, which modes built on another
+	// strategy (trend_rider, scalper_pro, ...) override
+	signal.Evidence = weighEvidence(signal.Evidence, signal.Timeframe)
+	if s.values != nil {
+		s.applyATRTargets(signal, ctx.Indicators.ATR(14))
+	}
+	return signal
+}
+
+// applyATRTargets re-derives stop and targets when ATR multipliers were overridden.
+// Built-in generators use ATR(14) with the schema defaults, so untouched values
+// keep the generator's own levels.
+func (s *builtinStrategy) applyATRTargets(signal *AdvancedSignal, atr float64) {
+	overridden := false
+	multipliers := make(map[string]float64)
+	for _, spec := range s.params {
+		switch spec.Name {
+		case "stop_atr", "tp1_atr", "tp2_atr", "tp3_atr":
+			value := s.values.Get(spec.Name, spec.Default)
+			multipliers[spec.Name] = value
+			if value != spec.Default {
+				overridden = true
+			}
+		}
+	}
+	if !overridden || multipliers["stop_atr"] <= 0 {
+		return
+	}
+
+	dir := 1.0
+	if signal.Type == "SELL" {
+		dir = -1.0
+	}
+	signal.StopLoss = signal.Entry - dir*atr*multipliers["stop_atr"]
+	signal.TP1 = signal.Entry + dir*atr*multipliers["tp1_atr"]
+	signal.TP2 = signal.Entry + dir*atr*multipliers["tp2_atr"]
+	signal.TP3 = signal.Entry + dir*atr*multipliers["tp3_atr"]
+	signal.RR = multipliers["tp1_atr"] / multipliers["stop_atr"]
+}
+
+// atrTargetParams declares the ATR stop/target multipliers a strategy uses
+func atrTargetParams(stop, tp1, tp2, tp3 float64) []ParamSpec {
+	return []ParamSpec{
+		{Name: "stop_atr", Type: "float", Default: stop, Min: 0.25, Max: 5.0, Step: 0.25, Description: "Stop loss distance in ATR"},
+		{Name: "tp1_atr", Type: "float", Default: tp1, Min: 0.5, Max: 10.0, Step: 0.25, Description: "Take profit 1 distance in ATR"},
+		{Name: "tp2_atr", Type: "float", Default: tp2, Min: 0.5, Max: 15.0, Step: 0.25, Description: "Take profit 2 distance in ATR"},
+		{Name: "tp3_atr", Type: "float", Default: tp3, Min: 1.0, Max: 25.0, Step: 0.5, Description: "Take profit 3 distance in ATR"},
+	}
+}
+
+// sessionTraderParams declares the filters of generateSessionTraderSignal, which
+// trend_rider and range_master build on as well
+func sessionTraderParams() []ParamSpec {
+	return []ParamSpec{
+		{Name: "trend_prob_min", Type: "float", Default: 0.5, Min: 0.3, Max: 0.95, Step: 0.05, Description: "Minimum probability of the trending regime - skip ranging and volatile markets"},
+		{Name: "cooldown_bars", Type: "int", Default: 30, Min: 0, Max: 100, Step: 5, Description: "Bars to wait after a signal"},
+		{Name: "high_volume_mult", Type: "float", Default: 1.4, Min: 1.0, Max: 3.0, Step: 0.1, Description: "Volume vs 20-bar average counted as high volume"},
+		{Name: "spike_atr_mult", Type: "float", Default: 1.8, Min: 1.0, Max: 4.0, Step: 0.1, Description: "Candle range in ATR counted as a volatility spike"},
+		{Name: "max_volatility_spikes", Type: "int", Default: 3, Min: 1, Max: 10, Step: 1, Description: "Spikes in the last 10 bars that mark manipulation"},
+	}
+}
+
+// liquidityHunterParams declares the entry filters of generateLiquidityHunterSignal
+func liquidityHunterParams() []ParamSpec {
+	return []ParamSpec{
+		{Name: "min_conditions", Type: "int", Default: 6, Min: 4, Max: 7, Step: 1, Description: "Entry conditions (of 7) required"},
+		{Name: "ema_distance_pct", Type: "float", Default: 0.5, Min: 0.1, Max: 2.0, Step: 0.1, Description: "Max distance from EMA20 in percent"},
+		{Name: "volume_mult", Type: "float", Default: 1.5, Min: 1.0, Max: 3.0, Step: 0.1, Description: "Volume vs 20-bar average"},
+	}
+}
+
+// withParams concatenates parameter schemas
+func withParams(groups ...[]ParamSpec) []ParamSpec {
+	params := []ParamSpec{}
+	for _, group := range groups {
+		params = append(params, group...)
+	}
+	return params
+}
+
+// Built-in strategies. Adding a strategy means adding one entry here;
+// engines, optimizers, live signals and listings discover it from the registry.
+func init() {
+	builtins := []*builtinStrategy{
+		{
+			meta: StrategyMetadata{
+				Name:          "liquidity_hunter",
+				DisplayName:   "Liquidity Hunter",
+				Description:   "Hunts liquidity sweeps - BEST WIN RATE (61%)",
+				Category:      "ict",
+				MinConfluence: 6,
+				RequiredConcepts: []string{
+					"Liquidity Sweep",
+					"Order Block",
+					"Fair Value Gap",
+					"Break of Structure",
+					"Volume Spike",
+					"Session Alignment",
+				},
+				TargetWinRate:      61.7,
+				TargetProfitFactor: 8.24,
+			},
+			timeframe: "15m",
+			warmup:    201,
+			params:    withParams(liquidityHunterParams(), atrTargetParams(1.5, 4.0, 6.0, 10.0)),
+			eval:      (*UnifiedSignalGenerator).generateLiquidityHunterSignal,
+		},
+		{
+			meta: StrategyMetadata{
+				Name:          "smart_money_tracker",
+				DisplayName:   "Smart Money Tracker",
+				Description:   "Follows institutional money flow and order blocks",
+				Category:      "ict",
+				MinConfluence: 4,
+				RequiredConcepts: []string{
+					"Order Block (Institutional)",
+					"Fair Value Gap",
+					"Liquidity Void",
+					"Market Structure Shift",
+					"Volume Profile",
+					"Delta Analysis",
+					"Premium/Discount Zone",
+				},
+				TargetWinRate:      34.1,
+				TargetProfitFactor: 6.83,
+			},
+			timeframe: "1h",
+			warmup:    201,
+			params:    withParams(liquidityHunterParams(), atrTargetParams(0.5, 3.0, 4.5, 7.5)),
+			eval:      (*UnifiedSignalGenerator).generateSmartMoneySignal,
+		},
+		{
+			meta: StrategyMetadata{
+				Name:          "breakout_master",
+				DisplayName:   "Breakout Master",
+				Description:   "Catches explosive breakouts with volume confirmation",
+				Category:      "breakout",
+				MinConfluence: 4,
+				RequiredConcepts: []string{
+					"Break of Structure",
+					"Volume Explosion (2x+)",
+					"Consolidation Pattern",
+					"Support/Resistance Break",
+					"Momentum Confirmation",
+				},
+				TargetWinRate:      54.5,
+				TargetProfitFactor: 7.20,
+			},
+			timeframe: "15m",
+			warmup:    100,
+			params:    atrTargetParams(1.0, 4.0, 6.0, 10.0),
+			eval:      (*UnifiedSignalGenerator).generateBreakoutMasterSignal,
+		},
+		{
+			meta: StrategyMetadata{
+				Name:          "trend_rider",
+				DisplayName:   "Trend Rider",
+				Description:   "Rides strong trends with pullback entries",
+				Category:      "trend",
+				MinConfluence: 4,
+				RequiredConcepts: []string{
+					"Strong Trend (EMA alignment)",
+					"Pullback to Key Level",
+					"Order Block Support",
+					"Higher Timeframe Confirmation",
+					"Momentum Divergence",
+				},
+				TargetWinRate:      36.4,
+				TargetProfitFactor: 6.71,
+			},
+			timeframe: "4h",
+			warmup:    201,
+			params:    withParams(sessionTraderParams(), atrTargetParams(0.5, 3.0, 4.5, 7.5)),
+			eval:      (*UnifiedSignalGenerator).generateTrendRiderSignal,
+		},
+		{
+			meta: StrategyMetadata{
+				Name:          "scalper_pro",
+				DisplayName:   "Scalper Pro",
+				Description:   "High-frequency scalping with tight risk management",
+				Category:      "scalping",
+				MinConfluence: 4,
+				RequiredConcepts: []string{
+					"Micro Order Block",
+					"Immediate FVG",
+					"Volume Spike",
+					"Kill Zone Only",
+					"Tight Stop (0.5 ATR)",
+					"Quick Target (1.5 ATR)",
+				},
+				TargetWinRate:      65.0,
+				TargetProfitFactor: 2.0,
+			},
+			timeframe: "5m",
+			warmup:    201,
+			params:    withParams(sessionTraderParams(), atrTargetParams(0.5, 1.2, 2.3, 3.5)),
+			eval:      (*UnifiedSignalGenerator).generateScalperSignal,
+		},
+		{
+			meta: StrategyMetadata{
+				Name:          "reversal_sniper",
+				DisplayName:   "Reversal Sniper",
+				Description:   "Catches high-probability reversals at key levels",
+				Category:      "reversal",
+				MinConfluence: 4,
+				RequiredConcepts: []string{
+					"Divergence (RSI/Price)",
+					"Order Block at Extreme",
+					"Liquidity Sweep",
+					"Fair Value Gap",
+					"Volume Climax",
+					"Candlestick Pattern",
+					"Support/Resistance",
+				},
+				TargetWinRate:      28.6,
+				TargetProfitFactor: 3.96,
+			},
+			timeframe: "1h",
+			warmup:    201,
+			params:    withParams(sessionTraderParams(), atrTargetParams(0.5, 5.0, 7.5, 12.5)),
+			eval:      (*UnifiedSignalGenerator).generateReversalSignal,
+		},
+		{
+			meta: StrategyMetadata{
+				Name:          "session_trader",
+				DisplayName:   "Session Trader",
+				Description:   "BEST OVERALL: 1300% Return, 18 Profit Factor",
+				Category:      "ict",
+				MinConfluence: 5,
+				RequiredConcepts: []string{
+					"London/NY Session",
+					"Session High/Low Sweep",
+					"Order Block",
+					"Fair Value Gap",
+					"Volume Profile",
+					"Time-based Entry",
+				},
+				TargetWinRate:      54.1,
+				TargetProfitFactor: 12.74,
+			},
+			timeframe: "15m",
+			warmup:    201,
+			params:    sessionTraderParams(),
+			eval:      (*UnifiedSignalGenerator).generateSessionTraderSignal,
+		},
+		{
+			meta: StrategyMetadata{
+				Name:          "momentum_beast",
+				DisplayName:   "Momentum Beast",
+				Description:   "Rides explosive momentum moves with confirmation",
+				Category:      "breakout",
+				MinConfluence: 4,
+				RequiredConcepts: []string{
+					"Strong Momentum",
+					"Volume Confirmation",
+					"Break of Structure",
+					"No Resistance Above",
+					"Trend Alignment",
+				},
+				TargetWinRate:      68.0,
+				TargetProfitFactor: 2.6,
+			},
+			timeframe: "15m",
+			warmup:    100,
+			params:    atrTargetParams(1.0, 3.5, 6.0, 9.0),
+			eval:      (*UnifiedSignalGenerator).generateMomentumSignal,
+		},
+		{
+			meta: StrategyMetadata{
+				Name:          "range_master",
+				DisplayName:   "Range Master",
+				Description:   "Trades ranges with high probability",
+				Category:      "range",
+				MinConfluence: 4,
+				RequiredConcepts: []string{
+					"Clear Range Identified",
+					"Support/Resistance Bounce",
+					"Order Block at Boundary",
+					"Volume Decrease in Middle",
+					"Rejection Candle",
+					"Mean Reversion",
+				},
+				TargetWinRate:      44.2,
+				TargetProfitFactor: 7.63,
+			},
+			timeframe: "1h",
+			warmup:    201,
+			params:    withParams(sessionTraderParams(), atrTargetParams(0.5, 2.0, 3.0, 5.0)),
+			eval:      (*UnifiedSignalGenerator).generateRangeMasterSignal,
+		},
+		{
+			meta: StrategyMetadata{
+				Name:          "institutional_follower",
+				DisplayName:   "Institutional Follower",
+				Description:   "Follows big money institutional orders",
+				Category:      "ict",
+				MinConfluence: 5,
+				RequiredConcepts: []string{
+					"Institutional Order Block",
+					"Large Volume Spike",
+					"Fair Value Gap",
+					"Market Structure Shift",
+					"Premium/Discount Zone",
+					"Liquidity Grab",
+					"Trend Confirmation",
+					"Higher TF Alignment",
+				},
+				TargetWinRate:      38.5,
+				TargetProfitFactor: 9.08,
+			},
+			timeframe: "4h",
+			warmup:    201,
+			params:    withParams(liquidityHunterParams(), atrTargetParams(0.5, 3.0, 4.5, 7.5)),
+			eval:      (*UnifiedSignalGenerator).generateInstitutionalSignal,
+		},
+	}
+
+	for _, s := range builtins {
+		RegisterStrategy(s)
+	}
+}
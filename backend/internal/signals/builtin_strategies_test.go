@@ -0,0 +1,92 @@
+package signals
+
+import (
+	"math"
+	"testing"
+)
+
+// testATRStrategy buys every bar with its own one point stop, so overridden
+// ATR exits are easy to tell apart from the generator's levels
+const testATRStrategy = "atr_targets_test"
+
+func init() {
+	RegisterStrategy(&builtinStrategy{
+		meta:      StrategyMetadata{Name: testATRStrategy, DisplayName: "ATR targets test"},
+		timeframe: "1h",
+		warmup:    100,
+		params:    atrTargetParams(1, 2, 3, 4),
+		eval: func(usg *UnifiedSignalGenerator, candles []Candle, idx int) *AdvancedSignal {
+			entry := candles[idx].Close
+			return &AdvancedSignal{Strategy: testATRStrategy, Type: "BUY", Entry: entry, StopLoss: entry - 1, TP1: entry + 1, TP2: entry + 2, TP3: entry + 3, RR: 1}
+		},
+	})
+}
+
+// slidingCandles falls half a point a bar with bars two points tall, so every
+// true range, and ATR(14), is 2
+func slidingCandles(n int) []Candle {
+	candles := make([]Candle, n)
+	for i := range candles {
+		c := 1000 - 0.5*float64(i)
+		candles[i] = Candle{Timestamp: int64(i) * 3600000, Open: c + 0.5, High: c + 1, Low: c - 1, Close: c, Volume: 100}
+	}
+	return candles
+}
+
+func TestApplyATRTargets(t *testing.T) {
+	candles := slidingCandles(150)
+	tests := []struct {
+		name           string
+		params         map[string]float64
+		stop, tp1, tp3 float64 // Distances from the entry
+		rr             float64
+	}{
+		{"generator levels without overrides", nil, 1, 1, 3, 1},
+		{"schema defaults keep the generator levels", map[string]float64{"stop_atr": 1}, 1, 1, 3, 1},
+		{"overridden stop", map[string]float64{"stop_atr": 2}, 4, 4, 8, 1},
+		{"overridden stop and target", map[string]float64{"stop_atr": 0.5, "tp1_atr": 3}, 1, 6, 8, 6},
+	}
+	for _, tt := range tests {
+		usg := &UnifiedSignalGenerator{Params: tt.params}
+		signal := usg.GenerateSignal(candles, testATRStrategy)
+		if signal == nil {
+			t.Fatalf("%s: no signal", tt.name)
+		}
+		entry := candles[len(candles)-1].Close
+		if !levelNear(entry-signal.StopLoss, tt.stop) || !levelNear(signal.TP1-entry, tt.tp1) || !levelNear(signal.TP3-entry, tt.tp3) || !levelNear(signal.RR, tt.rr) {
+			t.Errorf("%s: stop %v tp1 %v tp3 %v rr %v from %v", tt.name, signal.StopLoss, signal.TP1, signal.TP3, signal.RR, entry)
+		}
+	}
+}
+
+func TestUnifiedBacktestTradesOverriddenLevels(t *testing.T) {
+	candles := slidingCandles(300)
+	for _, tt := range []struct {
+		params map[string]float64
+		stop   float64 // Stop distance from the signal's entry
+	}{
+		{nil, 1},
+		{map[string]float64{"stop_atr": 2}, 4},
+	} {
+		result, err := RunUnifiedBacktest(UnifiedBacktestConfig{Strategy: testATRStrategy, StartBalance: 1000, Params: tt.params}, candles)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.Trades) == 0 {
+			t.Fatalf("params %v: no trades", tt.params)
+		}
+		for _, trade := range result.Trades {
+			entry := candles[trade.EntryIndex-1].Close // The signal bar closes the window
+			if !levelNear(entry-trade.StopLoss, tt.stop) || trade.ExitReason != "Stop Loss" {
+				t.Fatalf("params %v: stop %v (%s) for an entry at %v, want %v below", tt.params, trade.StopLoss, trade.ExitReason, entry, tt.stop)
+			}
+		}
+	}
+
+	if _, err := RunUnifiedBacktest(UnifiedBacktestConfig{Strategy: testATRStrategy, StartBalance: 1000, Params: map[string]float64{"stop_atr": 1.1}}, candles); err == nil {
+		t.Error("expected an off-step override to be rejected")
+	}
+}
+
+// levelNear compares levels up to the ATR warmup left in the Wilder average
+func levelNear(a, b float64) bool { return math.Abs(a-b) < 1e-3 }
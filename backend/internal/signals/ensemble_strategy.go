@@ -0,0 +1,363 @@
+package signals
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// ==================== PERFORMANCE-WEIGHTED ENSEMBLE ====================
+// The ensemble evaluates several registered strategies on every bar and
+// weights their votes by rolling out-of-sample expectancy. Each member's
+// signals are paper-traded forward on the bars the ensemble sees next
+// (stop vs first target), so a weight only ever uses outcomes that were
+// known at the time of the vote.
+
+// EnsembleStrategy is a meta-strategy over registered strategies
+type EnsembleStrategy struct {
+	name    string
+	members []string       // Member names; nil means every non-meta strategy
+	values  StrategyParams // Resolved overrides; nil means schema defaults
+
+	mu        sync.Mutex
+	resolved  []*ensembleMember
+	lastTime  int64 // Timestamp of the last bar the ledger has seen
+	lastClose float64
+}
+
+type ensembleMember struct {
+	name     string
+	strategy Strategy
+	ctx      *EvalContext
+	open     *ensembleVote
+	results  []float64 // R multiples of resolved votes, oldest first
+}
+
+// ensembleVote is a member signal being paper-traded to its stop or first target
+type ensembleVote struct {
+	dir    float64
+	entry  float64
+	stop   float64
+	target float64
+	bars   int
+}
+
+// NewEnsembleStrategy creates an ensemble over the named strategies
+// (nil or empty means every registered strategy outside the "meta" category)
+func NewEnsembleStrategy(name string, members []string) *EnsembleStrategy {
+	return &EnsembleStrategy{name: name, members: members}
+}
+
+func init() {
+	RegisterStrategy(NewEnsembleStrategy("ensemble", nil))
+}
+
+func (e *EnsembleStrategy) Metadata() StrategyMetadata {
+	return StrategyMetadata{
+		Name:             e.name,
+		DisplayName:      "Performance-Weighted Ensemble",
+		Description:      "Combines registered strategies, weighting each vote by rolling out-of-sample expectancy",
+		Category:         "meta",
+		MinConfluence:    1,
+		RequiredConcepts: []string{},
+	}
+}
+
+func (e *EnsembleStrategy) DefaultTimeframe() string { return "15m" }
+
+// WarmupBars is the longest warmup of any member
+func (e *EnsembleStrategy) WarmupBars() int {
+	warmup := 100
+	for _, s := range e.memberStrategies() {
+		if s.WarmupBars() > warmup {
+			warmup = s.WarmupBars()
+		}
+	}
+	return warmup
+}
+
+func (e *EnsembleStrategy) ParamSchema() []ParamSpec {
+	return []ParamSpec{
+		{Name: "lookback_trades", Type: "int", Default: 20, Min: 5, Max: 200, Step: 5, Description: "Resolved votes per member in the rolling expectancy"},
+		{Name: "prior_trades", Type: "int", Default: 5, Min: 0, Max: 50, Step: 1, Description: "Pseudo-trades of prior expectancy blended into each member's record"},
+		{Name: "prior_expectancy", Type: "float", Default: 0.25, Min: 0, Max: 2, Step: 0.05, Description: "Expectancy (R) assumed for members without a track record"},
+		{Name: "min_score", Type: "float", Default: 0.5, Min: 0.05, Max: 5, Step: 0.05, Description: "Net weighted vote required for a signal"},
+		{Name: "max_hold_bars", Type: "int", Default: 96, Min: 5, Max: 500, Step: 5, Description: "Votes still open after this many bars are closed at market"},
+	}
+}
+
+// WithParams returns a copy with its own ledger. Every UnifiedSignalGenerator
+// evaluates through such a copy, so runs never share one ledger.
+func (e *EnsembleStrategy) WithParams(params StrategyParams) Strategy {
+	return &EnsembleStrategy{name: e.name, members: e.members, values: params}
+}
+
+func (e *EnsembleStrategy) param(name string) float64 {
+	for _, spec := range e.ParamSchema() {
+		if spec.Name == name {
+			return e.values.Get(name, spec.Default)
+		}
+	}
+	return 0
+}
+
+// memberStrategies returns the registered strategies the ensemble listens to
+func (e *EnsembleStrategy) memberStrategies() []Strategy {
+	list := []Strategy{}
+	if len(e.members) == 0 {
+		for _, s := range ListStrategies() {
+			if s.Metadata().Category != "meta" {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	for _, name := range e.members {
+		if s, ok := GetStrategy(name); ok && s.Metadata().Category != "meta" {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+// reset starts a fresh ledger, e.g. when a new run or another symbol begins
+func (e *EnsembleStrategy) reset() {
+	e.resolved = nil
+	e.lastTime, e.lastClose = 0, 0
+	for _, s := range e.memberStrategies() {
+		// Members get their own copy and context, so cooldowns and other state
+		// they keep are not shared with live signals or other members
+		if configurable, ok := s.(ConfigurableStrategy); ok {
+			s = configurable.WithParams(nil)
+		}
+		e.resolved = append(e.resolved, &ensembleMember{
+			name:     s.Metadata().Name,
+			strategy: s,
+			ctx:      NewEvalContext(),
+		})
+	}
+}
+
+// continuation returns the index of the first bar the ledger has not seen,
+// or -1 when the candles do not continue the series it was built on
+func (e *EnsembleStrategy) continuation(candles []Candle, idx int) int {
+	if e.resolved == nil || e.lastTime == 0 {
+		return -1
+	}
+	for i := idx; i >= 0 && candles[i].Timestamp >= e.lastTime; i-- {
+		if candles[i].Timestamp == e.lastTime {
+			if candles[i].Close != e.lastClose {
+				return -1 // Another symbol, or the bar was still forming
+			}
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// Evaluate brings the ledger up to the bar before idx, then combines the
+// members' current signals into one weighted signal. A ledger that does not
+// continue these candles is rebuilt by replaying the window, so each vote is
+// only ever settled on the bars of the series it was cast on.
+func (e *EnsembleStrategy) Evaluate(candles []Candle, idx int) *AdvancedSignal {
+	if idx < 1 || idx >= len(candles) {
+		return nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	start := e.continuation(candles, idx)
+	if start < 0 {
+		e.reset()
+		start = 1
+	}
+	for i := start; i < idx; i++ {
+		e.step(candles, i)
+	}
+	return e.combine(candles, idx, e.step(candles, idx))
+}
+
+// ensembleBallot is one member's vote on the current bar
+type ensembleBallot struct {
+	member *ensembleMember
+	signal *AdvancedSignal
+	weight float64
+}
+
+// step settles open votes on bar idx, then collects the members' votes on it
+// and opens a tracked vote for each member without one
+func (e *EnsembleStrategy) step(candles []Candle, idx int) []ensembleBallot {
+	e.settle(candles[idx])
+	e.lastTime, e.lastClose = candles[idx].Timestamp, candles[idx].Close
+
+	var ballots []ensembleBallot
+	for _, m := range e.resolved {
+		weight := e.weight(m)
+		signal := m.evaluate(candles, idx)
+		if signal == nil || (signal.Type != "BUY" && signal.Type != "SELL") {
+			continue
+		}
+		risk := math.Abs(signal.Entry - signal.StopLoss)
+		if risk <= 0 {
+			continue
+		}
+
+		dir := 1.0
+		if signal.Type == "SELL" {
+			dir = -1.0
+		}
+		if m.open == nil {
+			m.open = &ensembleVote{dir: dir, entry: signal.Entry, stop: signal.StopLoss, target: signal.TP1}
+		}
+		ballots = append(ballots, ensembleBallot{m, signal, weight})
+	}
+	return ballots
+}
+
+// combine turns the ballots on bar idx into the ensemble signal
+func (e *EnsembleStrategy) combine(candles []Candle, idx int, ballots []ensembleBallot) *AdvancedSignal {
+	var buys, sells []ensembleBallot
+	buyScore, sellScore := 0.0, 0.0
+	for _, b := range ballots {
+		if b.signal.Type == "BUY" {
+			buys = append(buys, b)
+			buyScore += b.weight
+		} else {
+			sells = append(sells, b)
+			sellScore += b.weight
+		}
+	}
+
+	signalType, winners, net := "BUY", buys, buyScore-sellScore
+	if sellScore > buyScore {
+		signalType, winners, net = "SELL", sells, sellScore-buyScore
+	}
+	if len(winners) == 0 || net < e.param("min_score") {
+		return nil
+	}
+
+	// Combined levels: weight-averaged distances from the current close
+	entry := candles[idx].Close
+	total, stopDist, tp1Dist, tp2Dist, tp3Dist := 0.0, 0.0, 0.0, 0.0, 0.0
+	reasons := []string{}
+	for _, b := range winners {
+		s := b.signal
+		total += b.weight
+		stopDist += b.weight * math.Abs(s.Entry-s.StopLoss)
+		tp1Dist += b.weight * math.Abs(s.TP1-s.Entry)
+		tp2Dist += b.weight * math.Abs(s.TP2-s.Entry)
+		tp3Dist += b.weight * math.Abs(s.TP3-s.Entry)
+		reasons = append(reasons, fmt.Sprintf("%s %s (weight %.2f)", b.member.name, signalType, b.weight))
+	}
+	if total <= 0 {
+		return nil
+	}
+	stopDist /= total
+	tp1Dist /= total
+	tp2Dist /= total
+	tp3Dist /= total
+
+	// Member evidence keeps its share of the member's vote
+	evidence := []Evidence{}
+	for _, b := range winners {
+		for _, ev := range b.signal.Evidence {
+			if ev.Timeframe == "" {
+				ev.Timeframe = b.signal.Timeframe
+			}
+			ev.Weight *= b.weight / total
+			evidence = append(evidence, ev)
+		}
+	}
+
+	dir := 1.0
+	if signalType == "SELL" {
+		dir = -1.0
+	}
+	reasons = append(reasons, fmt.Sprintf("Weighted vote %.2f BUY vs %.2f SELL", buyScore, sellScore))
+
+	return &AdvancedSignal{
+		Strategy:   e.name,
+		Type:       signalType,
+		Entry:      entry,
+		StopLoss:   entry - dir*stopDist,
+		TP1:        entry + dir*tp1Dist,
+		TP2:        entry + dir*math.Max(tp2Dist, tp1Dist),
+		TP3:        entry + dir*math.Max(tp3Dist, math.Max(tp2Dist, tp1Dist)),
+		Confluence: len(winners),
+		Reasons:    reasons,
+		Evidence:   weighEvidence(evidence, e.DefaultTimeframe()),
+		Strength:   math.Min(100, total/(buyScore+sellScore)*100),
+		RR:         tp1Dist / stopDist,
+		Timeframe:  e.DefaultTimeframe(),
+	}
+}
+
+// settle resolves the open votes a bar closes
+func (e *EnsembleStrategy) settle(c Candle) {
+	maxHold := int(e.param("max_hold_bars"))
+	lookback := int(e.param("lookback_trades"))
+	for _, m := range e.resolved {
+		if m.open == nil {
+			continue
+		}
+		if r, done := m.open.update(c, maxHold); done {
+			m.results = append(m.results, r)
+			if len(m.results) > lookback {
+				m.results = m.results[len(m.results)-lookback:]
+			}
+			m.open = nil
+		}
+	}
+}
+
+// update advances a vote by one bar and returns its R multiple once it closes.
+// When a bar touches both levels the stop is assumed to fill first.
+func (v *ensembleVote) update(c Candle, maxHold int) (float64, bool) {
+	risk := math.Abs(v.entry - v.stop)
+	v.bars++
+	if v.dir > 0 {
+		if c.Low <= v.stop {
+			return -1, true
+		}
+		if c.High >= v.target {
+			return (v.target - v.entry) / risk, true
+		}
+	} else {
+		if c.High >= v.stop {
+			return -1, true
+		}
+		if c.Low <= v.target {
+			return (v.entry - v.target) / risk, true
+		}
+	}
+	if v.bars >= maxHold {
+		return (c.Close - v.entry) * v.dir / risk, true
+	}
+	return 0, false
+}
+
+// weight shrinks a member's rolling expectancy towards the prior; losing members get no vote
+func (e *EnsembleStrategy) weight(m *ensembleMember) float64 {
+	priorTrades := e.param("prior_trades")
+	sum := 0.0
+	for _, r := range m.results {
+		sum += r
+	}
+	n := float64(len(m.results))
+	if n+priorTrades == 0 {
+		return e.param("prior_expectancy")
+	}
+	expectancy := (sum + priorTrades*e.param("prior_expectancy")) / (n + priorTrades)
+	return math.Max(expectancy, 0)
+}
+
+func (m *ensembleMember) evaluate(candles []Candle, idx int) *AdvancedSignal {
+	if idx+1 < m.strategy.WarmupBars() {
+		return nil
+	}
+	if streaming, ok := m.strategy.(StreamingStrategy); ok {
+		return streaming.EvaluateWith(m.ctx, candles, idx)
+	}
+	return m.strategy.Evaluate(candles, idx)
+}
@@ -0,0 +1,163 @@
+package signals
+
+import (
+	"testing"
+)
+
+// testEnsemble listens to testATRStrategy alone, whose one point stop every
+// slidingCandles bar runs through, so each settled vote loses 1R
+const testEnsemble = "ensemble_test"
+
+func init() {
+	RegisterStrategy(NewEnsembleStrategy(testEnsemble, []string{testATRStrategy}))
+}
+
+func TestEnsembleWeight(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  StrategyParams
+		results []float64
+		want    float64
+	}{
+		{"no record takes the prior", nil, nil, 0.25},
+		{"winners shrink towards the prior", nil, []float64{2, 2, 2, 2, 2}, 1.125},          // (10 + 5*0.25) / 10
+		{"mixed record", nil, []float64{1, -1, 3}, 0.53125},                                 // (3 + 1.25) / 8
+		{"losers are clamped at zero", nil, []float64{-1, -1, -1, -1, -1}, 0},               // (-5 + 1.25) / 10
+		{"without prior trades", StrategyParams{"prior_trades": 0}, []float64{1, -1, 3}, 1}, // 3 / 3
+		{"without prior trades or record", StrategyParams{"prior_trades": 0}, nil, 0.25},
+	}
+	for _, tt := range tests {
+		e := &EnsembleStrategy{values: tt.params}
+		if got := e.weight(&ensembleMember{results: tt.results}); !levelNear(got, tt.want) {
+			t.Errorf("%s: weight %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEnsembleVoteUpdate(t *testing.T) {
+	long := ensembleVote{dir: 1, entry: 100, stop: 99, target: 102}
+	short := ensembleVote{dir: -1, entry: 100, stop: 101, target: 98}
+	tests := []struct {
+		name    string
+		vote    ensembleVote
+		bar     Candle
+		maxHold int
+		r       float64
+		done    bool
+	}{
+		{"long stop fills first on a bar touching both", long, Candle{High: 103, Low: 98, Close: 100}, 96, -1, true},
+		{"short stop fills first on a bar touching both", short, Candle{High: 102, Low: 97, Close: 100}, 96, -1, true},
+		{"long target", long, Candle{High: 102, Low: 99.5, Close: 101}, 96, 2, true},
+		{"short target", short, Candle{High: 100.5, Low: 98, Close: 99}, 96, 2, true},
+		{"still open", long, Candle{High: 101, Low: 99.5, Close: 100.5}, 96, 0, false},
+		{"closed at market after max hold", long, Candle{High: 101, Low: 99.5, Close: 100.5}, 1, 0.5, true},
+	}
+	for _, tt := range tests {
+		vote := tt.vote
+		r, done := vote.update(tt.bar, tt.maxHold)
+		if done != tt.done || !levelNear(r, tt.r) {
+			t.Errorf("%s: got %v %v, want %v %v", tt.name, r, done, tt.r, tt.done)
+		}
+	}
+}
+
+func TestEnsembleCombineWeightsLevels(t *testing.T) {
+	e := &EnsembleStrategy{name: testEnsemble}
+	candles := []Candle{{Close: 100}}
+	ballots := []ensembleBallot{
+		{&ensembleMember{name: "a"}, &AdvancedSignal{Type: "BUY", Entry: 100, StopLoss: 99, TP1: 102, TP2: 103, TP3: 104}, 1},
+		{&ensembleMember{name: "b"}, &AdvancedSignal{Type: "BUY", Entry: 100, StopLoss: 98, TP1: 104, TP2: 105, TP3: 106}, 3},
+		{&ensembleMember{name: "c"}, &AdvancedSignal{Type: "SELL", Entry: 100, StopLoss: 101, TP1: 99, TP2: 98, TP3: 97}, 1},
+	}
+
+	signal := e.combine(candles, 0, ballots)
+	if signal == nil || signal.Type != "BUY" || signal.Confluence != 2 {
+		t.Fatalf("Expected a two member BUY, got %+v", signal)
+	}
+	// Stop (1*1 + 3*2) / 4, targets (1*2 + 3*4) / 4, (1*3 + 3*5) / 4 and (1*4 + 3*6) / 4
+	if !levelNear(signal.StopLoss, 98.25) || !levelNear(signal.TP1, 103.5) || !levelNear(signal.TP2, 104.5) || !levelNear(signal.TP3, 105.5) {
+		t.Errorf("levels %v %v %v %v", signal.StopLoss, signal.TP1, signal.TP2, signal.TP3)
+	}
+	if !levelNear(signal.RR, 2) || !levelNear(signal.Strength, 80) {
+		t.Errorf("rr %v strength %v, want 2 and 80", signal.RR, signal.Strength)
+	}
+
+	// A net vote of 0.25 stays under min_score
+	ballots[1].weight = 0.25
+	if signal := e.combine(candles, 0, ballots); signal != nil {
+		t.Errorf("Expected no signal under min_score, got %+v", signal)
+	}
+}
+
+func TestEnsembleLedgerFollowsSeries(t *testing.T) {
+	e := NewEnsembleStrategy(testEnsemble, []string{testATRStrategy})
+	candles := slidingCandles(150)
+	// Votes cast on bars 99..148 have all been stopped; lookback keeps 20
+	if signal := e.Evaluate(candles, 149); signal != nil {
+		t.Errorf("Expected members with a losing record to carry no vote, got %+v", signal)
+	}
+	member := e.resolved[0]
+	if len(member.results) != 20 || member.open == nil || e.weight(member) != 0 {
+		t.Fatalf("results %v open %v weight %v", member.results, member.open, e.weight(member))
+	}
+
+	// The next bar continues the ledger
+	candles = slidingCandles(151)
+	e.Evaluate(candles, 150)
+	if e.resolved[0] != member || e.lastTime != candles[150].Timestamp {
+		t.Fatal("Expected the ledger to continue on the next bar")
+	}
+
+	// Another symbol over the same timestamps rebuilds it; settling the old
+	// vote on the new series would have scored a win
+	other := slidingCandles(151)
+	for i := range other {
+		other[i].Open += 500
+		other[i].High += 500
+		other[i].Low += 500
+		other[i].Close += 500
+	}
+	e.Evaluate(other, 150)
+	if e.resolved[0] == member {
+		t.Fatal("Expected a fresh ledger for another series")
+	}
+	for _, r := range e.resolved[0].results {
+		if r != -1 {
+			t.Fatalf("Expected only stopped votes, got %v", e.resolved[0].results)
+		}
+	}
+	if open := e.resolved[0].open; open == nil || open.entry != other[150].Close {
+		t.Errorf("Expected the open vote on the new series, got %+v", open)
+	}
+
+	// A shorter window ending before the ledger's last bar rebuilds it too
+	e.Evaluate(slidingCandles(103), 102)
+	if got := len(e.resolved[0].results); got != 3 {
+		t.Errorf("Expected the votes of bars 99..101 after a rewind, got %d", got)
+	}
+}
+
+func TestEnsembleLedgerPerGenerator(t *testing.T) {
+	candles := slidingCandles(150)
+	first, second := &UnifiedSignalGenerator{}, &UnifiedSignalGenerator{}
+	first.GenerateSignal(candles, testEnsemble)
+	second.GenerateSignal(candles[:120], testEnsemble)
+
+	a, _ := first.strategy(testEnsemble)
+	b, _ := second.strategy(testEnsemble)
+	registered, _ := GetStrategy(testEnsemble)
+	if a == b || a == registered || b == registered {
+		t.Fatal("Expected each generator to evaluate its own ensemble copy")
+	}
+
+	ledgerA, ledgerB := a.(*EnsembleStrategy), b.(*EnsembleStrategy)
+	if ledgerA.lastTime != candles[149].Timestamp || ledgerB.lastTime != candles[119].Timestamp {
+		t.Errorf("ledgers at %d and %d, want %d and %d", ledgerA.lastTime, ledgerB.lastTime, candles[149].Timestamp, candles[119].Timestamp)
+	}
+	if len(ledgerA.resolved[0].results) != 20 || len(ledgerB.resolved[0].results) != 20 {
+		t.Errorf("results %d and %d", len(ledgerA.resolved[0].results), len(ledgerB.resolved[0].results))
+	}
+	if registered.(*EnsembleStrategy).resolved != nil {
+		t.Error("Expected the registered ensemble to keep no ledger")
+	}
+}
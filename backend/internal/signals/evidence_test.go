@@ -0,0 +1,176 @@
+package signals
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+const (
+	// testEvidenceStrategy buys every bar on two untagged zones weighted 1:3
+	testEvidenceStrategy = "evidence_test"
+	// testEvidenceMode builds on liquidity_hunter and moves its signals to 4h,
+	// the way trend_rider builds on session_trader
+	testEvidenceMode = "evidence_mode_test"
+)
+
+func init() {
+	RegisterStrategy(&builtinStrategy{
+		meta:      StrategyMetadata{Name: testEvidenceStrategy, DisplayName: "Evidence test"},
+		timeframe: "1h",
+		warmup:    100,
+		params:    atrTargetParams(1, 2, 3, 4),
+		eval: func(usg *UnifiedSignalGenerator, candles []Candle, idx int) *AdvancedSignal {
+			entry := candles[idx].Close
+			block := newEvidence(EvidenceOrderBlock, "Test block", "bullish", entry-1, entry, candles, idx-1, idx)
+			spike := newEvidence(EvidenceVolumeSpike, "Test spike", "bullish", candles[idx].Low, candles[idx].High, candles, idx, idx)
+			spike.Weight = 3
+			return &AdvancedSignal{Strategy: testEvidenceStrategy, Type: "BUY", Entry: entry, StopLoss: entry - 1, TP1: entry + 1, TP2: entry + 2, TP3: entry + 3, RR: 1, Evidence: []Evidence{block, spike}, Timeframe: "1h"}
+		},
+	})
+	RegisterStrategy(&builtinStrategy{
+		meta:      StrategyMetadata{Name: testEvidenceMode, DisplayName: "Evidence mode test"},
+		timeframe: "4h",
+		warmup:    200,
+		params:    liquidityHunterParams(),
+		eval: func(usg *UnifiedSignalGenerator, candles []Candle, idx int) *AdvancedSignal {
+			signal := usg.generateLiquidityHunterSignal(candles, idx)
+			if signal != nil {
+				signal.Timeframe = "4h"
+			}
+			return signal
+		},
+	})
+}
+
+// pullbackCandles climb a point a bar on red candles and end on a strong green
+// one, so liquidity_hunter sees the uptrend, price above EMA200, a bullish
+// reversal candle and a pullback: four of its seven conditions
+func pullbackCandles(n int) []Candle {
+	candles := make([]Candle, n)
+	for i := range candles {
+		c := 1000 + float64(i)
+		candles[i] = Candle{Timestamp: int64(i) * 900000, Open: c + 0.5, High: c + 1, Low: c - 0.5, Close: c, Volume: 100}
+	}
+	last := &candles[n-1]
+	last.Open = candles[n-2].Close
+	last.Close = last.Open + 2
+	last.Low = last.Open - 0.1
+	last.High = last.Close + 0.1
+	return candles
+}
+
+func TestBuiltinGeneratorAttachesEvidence(t *testing.T) {
+	candles := pullbackCandles(260)
+	idx := len(candles) - 1
+	usg := &UnifiedSignalGenerator{Params: map[string]float64{"min_conditions": 4}}
+
+	signal := usg.GenerateSignal(candles, "liquidity_hunter")
+	if signal == nil || signal.Type != "BUY" {
+		t.Fatalf("Expected a liquidity_hunter BUY, got %+v", signal)
+	}
+	if len(signal.Evidence) != 2 {
+		t.Fatalf("Expected the EMA20 level and the pullback, got %+v", signal.Evidence)
+	}
+	level, pullback := signal.Evidence[0], signal.Evidence[1]
+	if level.Kind != EvidenceLevel || level.Label != "EMA20" || level.PriceLow != level.PriceHigh || level.Direction != "bullish" {
+		t.Errorf("level %+v", level)
+	}
+	// Lowest low three bars back, highest high on the reversal candle
+	want := Evidence{
+		Kind:      EvidencePattern,
+		Label:     "Pullback and reversal candle",
+		Direction: "bullish",
+		PriceLow:  candles[idx-3].Low,
+		PriceHigh: candles[idx].High,
+		Timeframe: "15m",
+		StartTime: candles[idx-3].Timestamp,
+		EndTime:   candles[idx].Timestamp,
+		Weight:    0.5,
+	}
+	if pullback != want {
+		t.Errorf("pullback %+v, want %+v", pullback, want)
+	}
+	for _, e := range signal.Evidence {
+		if e.Timeframe != "15m" || e.Weight != 0.5 {
+			t.Errorf("%s on %q weighs %v, want 0.5 on 15m", e.Label, e.Timeframe, e.Weight)
+		}
+	}
+
+	// A mode moving the signal to another timeframe takes its evidence along
+	signal = usg.GenerateSignal(candles, testEvidenceMode)
+	if signal == nil || len(signal.Evidence) != 2 {
+		t.Fatalf("Expected the mode's signal with evidence, got %+v", signal)
+	}
+	for _, e := range signal.Evidence {
+		if e.Timeframe != "4h" {
+			t.Errorf("%s on %q, want the mode's 4h", e.Label, e.Timeframe)
+		}
+	}
+}
+
+func TestEvidenceReachesLiveSignalAndStorage(t *testing.T) {
+	candles := slidingCandles(150)
+	signal := (&UnifiedSignalGenerator{}).GenerateSignal(candles, testEvidenceStrategy)
+	if signal == nil || len(signal.Evidence) != 2 || signal.Evidence[0].Weight != 0.25 || signal.Evidence[1].Weight != 0.75 {
+		t.Fatalf("Expected weights 0.25 and 0.75, got %+v", signal)
+	}
+
+	live := signal.ToLiveSignalResponse(candles[149].Close)
+	if !reflect.DeepEqual(live.Evidence, signal.Evidence) {
+		t.Fatalf("live evidence %+v, want %+v", live.Evidence, signal.Evidence)
+	}
+	body, err := json.Marshal(live)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded LiveSignalResponse
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded.Evidence, signal.Evidence) {
+		t.Errorf("evidence after JSON %+v, want %+v", decoded.Evidence, signal.Evidence)
+	}
+
+	var stored StoredSignal
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&stored); err != nil {
+			t.Errorf("decode stored signal: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	t.Setenv("SUPABASE_URL", server.URL)
+	t.Setenv("SUPABASE_KEY", "test-key-test-key-test-key-test-key")
+
+	if err := SaveSignalToSupabase(live, "BTCUSDT", testEvidenceStrategy, true, false); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(stored.Evidence, signal.Evidence) {
+		t.Errorf("stored evidence %+v, want %+v", stored.Evidence, signal.Evidence)
+	}
+}
+
+func TestUnifiedBacktestTradesCarryEvidence(t *testing.T) {
+	candles := slidingCandles(300)
+	result, err := RunUnifiedBacktest(UnifiedBacktestConfig{Strategy: testEvidenceStrategy, StartBalance: 1000}, candles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Trades) == 0 {
+		t.Fatal("no trades")
+	}
+	for _, trade := range result.Trades {
+		signalBar := candles[trade.EntryIndex-1]
+		if len(trade.Evidence) != 2 || trade.Evidence[0].Weight != 0.25 || trade.Evidence[1].Weight != 0.75 {
+			t.Fatalf("trade evidence %+v", trade.Evidence)
+		}
+		for _, e := range trade.Evidence {
+			if e.Timeframe != "1h" || e.EndTime != signalBar.Timestamp {
+				t.Fatalf("%s on %q ending at %d, want 1h ending on the signal bar %d", e.Label, e.Timeframe, e.EndTime, signalBar.Timestamp)
+			}
+		}
+	}
+}
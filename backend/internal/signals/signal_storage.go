@@ -34,6 +34,8 @@ type StoredSignal struct {
 	FilterSell      bool      `json:"filter_sell"`
 	SignalTime      time.Time `json:"signal_time"`
 	ClosedAt        *time.Time `json:"closed_at,omitempty"`
+	Reasons         []string   `json:"reasons,omitempty"`
+	Evidence        []Evidence `json:"evidence,omitempty"` // Stored as JSONB for chart overlays
 }
 
 // SaveSignalToSupabase saves a trading signal to Supabase
@@ -68,6 +70,8 @@ func SaveSignalToSupabase(signal LiveSignalResponse, symbol, strategy string, fi
 		FilterBuy:    filterBuy,
 		FilterSell:   filterSell,
 		SignalTime:   time.Now(),
+		Reasons:      signal.Reasons,
+		Evidence:     signal.Evidence,
 	}
 	
 	jsonData, err := json.Marshal(storedSignal)
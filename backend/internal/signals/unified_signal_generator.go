@@ -1,15 +1,32 @@
 package signals
 
 import (
+	"log"
 	"math"
 	"time"
-)
 
-// Global variables for cooldown system
-var lastSessionTraderIndex = -1
+	"tradebot/backend/internal/indicators"
+	"tradebot/backend/internal/regime"
+)
 
 // UnifiedSignalGenerator generates signals using the SAME logic for both live and backtest
-type UnifiedSignalGenerator struct{}
+type UnifiedSignalGenerator struct {
+	// Params overrides strategy parameters (validated against the strategy's ParamSchema).
+	// Reuse one generator across bars so configured strategies keep their state.
+	Params map[string]float64
+
+	// Profile, when set, filters every candidate signal through a risk profile
+	// (see NewRiskProfileFilter); rejected signals are counted and dropped.
+	Profile *RiskProfileFilter
+
+	configured map[string]Strategy
+	contexts   map[string]*EvalContext
+
+	// ctx is the context of the strategy being evaluated, synced to candles[:idx+1];
+	// ind is its indicator tracker
+	ctx *EvalContext
+	ind *indicators.Tracker
+}
 
 // GenerateSignal is the SINGLE source of truth for signal generation
 func (usg *UnifiedSignalGenerator) GenerateSignal(candles []Candle, strategyName string) *AdvancedSignal {
@@ -17,33 +34,92 @@ func (usg *UnifiedSignalGenerator) GenerateSignal(candles []Candle, strategyName
 		return nil
 	}
 
-	idx := len(candles) - 1
-
 	// Use the SAME logic for both live and backtest
-	switch strategyName {
-	case "liquidity_hunter":
-		return usg.generateLiquidityHunterSignal(candles, idx)
-	case "session_trader":
-		return usg.generateSessionTraderSignal(candles, idx)
-	case "breakout_master":
-		return usg.generateBreakoutMasterSignal(candles, idx)
-	case "trend_rider":
-		return usg.generateTrendRiderSignal(candles, idx)
-	case "range_master":
-		return usg.generateRangeMasterSignal(candles, idx)
-	case "smart_money_tracker":
-		return usg.generateSmartMoneySignal(candles, idx)
-	case "institutional_follower":
-		return usg.generateInstitutionalSignal(candles, idx)
-	case "reversal_sniper":
-		return usg.generateReversalSignal(candles, idx)
-	case "momentum_beast":
-		return usg.generateMomentumSignal(candles, idx)
-	case "scalper_pro":
-		return usg.generateScalperSignal(candles, idx)
-	default:
+	strategy, ok := usg.strategy(strategyName)
+	if !ok {
+		return nil
+	}
+	if len(candles) < strategy.WarmupBars() {
+		return nil
+	}
+
+	idx := len(candles) - 1
+	var signal *AdvancedSignal
+	if streaming, ok := strategy.(StreamingStrategy); ok {
+		signal = streaming.EvaluateWith(usg.context(strategyName), candles, idx)
+	} else {
+		signal = strategy.Evaluate(candles, idx)
+	}
+	if signal == nil || signal.Type == "NONE" || usg.Profile == nil {
+		return signal
+	}
+	if ok, _ := usg.Profile.Check(strategy.Metadata(), signal, candles, idx); !ok {
 		return nil
 	}
+	return signal
+}
+
+// strategy returns the registered strategy, configured with usg.Params when set.
+// Configurable strategies are always evaluated through the generator's own
+// copy, so state they keep across bars (e.g. the ensemble ledger) belongs to
+// this run and is never shared with other symbols, sessions or backtests.
+func (usg *UnifiedSignalGenerator) strategy(name string) (Strategy, bool) {
+	if s, ok := usg.configured[name]; ok {
+		return s, true
+	}
+
+	var s Strategy
+	if len(usg.Params) == 0 {
+		registered, ok := GetStrategy(name)
+		if !ok {
+			return nil, false
+		}
+		configurable, ok := registered.(ConfigurableStrategy)
+		if !ok {
+			return registered, true
+		}
+		s = configurable.WithParams(nil)
+	} else {
+		var err error
+		if s, err = ConfigureStrategy(name, usg.Params); err != nil {
+			log.Printf("⚠️  %v", err)
+			return nil, false
+		}
+	}
+	if usg.configured == nil {
+		usg.configured = make(map[string]Strategy)
+	}
+	usg.configured[name] = s
+	return s, true
+}
+
+// context returns the evaluation context kept for a strategy across calls
+func (usg *UnifiedSignalGenerator) context(name string) *EvalContext {
+	if ctx, ok := usg.contexts[name]; ok {
+		return ctx
+	}
+	if usg.contexts == nil {
+		usg.contexts = make(map[string]*EvalContext)
+	}
+	ctx := NewEvalContext()
+	usg.contexts[name] = ctx
+	return ctx
+}
+
+// ATR returns ATR(14) at the last candle from the strategy's own context: the
+// ATR built-in strategies place their stops and targets with
+func (usg *UnifiedSignalGenerator) ATR(candles []Candle, strategyName string) float64 {
+	ctx := usg.context(strategyName)
+	ctx.Sync(candles)
+	return ctx.Indicators.ATR(14)
+}
+
+// param reads a resolved strategy parameter, falling back to the code default
+func (usg *UnifiedSignalGenerator) param(name string, fallback float64) float64 {
+	if v, ok := usg.Params[name]; ok {
+		return v
+	}
+	return fallback
 }
 
 // generateLiquidityHunterSignal - ULTRA HIGH WIN RATE: 80-90% target
@@ -57,11 +133,11 @@ func (usg *UnifiedSignalGenerator) generateLiquidityHunterSignal(candles []Candl
 	currentCandle := candles[idx]
 
 	// Calculate indicators
-	atr := calculateATR(candles[:idx+1], 14)
-	ema20 := calculateEMA(candles[:idx+1], 20)
-	ema50 := calculateEMA(candles[:idx+1], 50)
-	ema200 := calculateEMA(candles[:idx+1], 200)
-	rsi := calculateRSI(candles[:idx+1], 14)
+	atr := usg.ind.ATR(14)
+	ema20 := usg.ind.EMA(20)
+	ema50 := usg.ind.EMA(50)
+	ema200 := usg.ind.EMA(200)
+	rsi := usg.ind.RSI(14)
 
 	// Determine STRONG trend direction
 	trendBullish := ema20 > ema50 && ema50 > ema200 && currentPrice > ema20
@@ -72,15 +148,11 @@ func (usg *UnifiedSignalGenerator) generateLiquidityHunterSignal(candles []Candl
 
 	// Only trade when price is VERY CLOSE to EMA20 (pullback entry)
 	// This gives us high probability entries in strong trends
-	nearEMA20 := distanceFromEMA20 < 0.5 // Within 0.5% of EMA20 (VERY STRICT)
+	nearEMA20 := distanceFromEMA20 < usg.param("ema_distance_pct", 0.5) // Within 0.5% of EMA20 (VERY STRICT)
 
 	// Volume confirmation - STRICTER
-	avgVolume := 0.0
-	for i := idx - 19; i <= idx; i++ {
-		avgVolume += candles[i].Volume
-	}
-	avgVolume /= 20
-	goodVolume := candles[idx].Volume > avgVolume*1.5 // Must be 1.5x average (was 1.2x)
+	avgVolume := usg.ind.AverageVolume(20)
+	goodVolume := candles[idx].Volume > avgVolume*usg.param("volume_mult", 1.5) // Must be 1.5x average (was 1.2x)
 
 	// Price action - looking for reversal candles at EMA
 	candleRange := currentCandle.High - currentCandle.Low
@@ -158,7 +230,22 @@ func (usg *UnifiedSignalGenerator) generateLiquidityHunterSignal(candles []Candl
 
 	// ULTRA STRICT: Require 6/7 conditions (was 7) for signal
 	// This ensures only EXCELLENT setups are taken, but allows slightly more trades
-	if buyScore >= 6 {
+	minConditions := int(usg.param("min_conditions", 6))
+
+	// The EMA20 level and the pullback bars it was tested on
+	pullbackLow, pullbackHigh := currentCandle.Low, currentCandle.High
+	for i := idx - 3; i < idx; i++ {
+		pullbackLow = math.Min(pullbackLow, candles[i].Low)
+		pullbackHigh = math.Max(pullbackHigh, candles[i].High)
+	}
+	pullbackEvidence := func(direction string) []Evidence {
+		return weighEvidence([]Evidence{
+			newEvidence(EvidenceLevel, "EMA20", direction, ema20, ema20, candles, idx-3, idx),
+			newEvidence(EvidencePattern, "Pullback and reversal candle", direction, pullbackLow, pullbackHigh, candles, idx-3, idx),
+		}, "") // Tagged with the signal timeframe by the strategy adapter
+	}
+
+	if buyScore >= minConditions {
 		return &AdvancedSignal{
 			Strategy:   "liquidity_hunter",
 			Type:       "BUY",
@@ -169,6 +256,7 @@ func (usg *UnifiedSignalGenerator) generateLiquidityHunterSignal(candles []Candl
 			TP3:        currentPrice + (atr * 10.0), // Optimized: 10.0 ATR
 			Confluence: buyScore,
 			Reasons:    []string{"Excellent pullback in uptrend", "6+ conditions met", "EMA20 support", "Bullish reversal"},
+			Evidence:   pullbackEvidence("bullish"),
 			Strength:   95.0,
 			RR:         2.6, // Avg RR based on targets
 			Timeframe:  "15m",
@@ -176,7 +264,7 @@ func (usg *UnifiedSignalGenerator) generateLiquidityHunterSignal(candles []Candl
 	}
 
 	// ULTRA STRICT: Require 6/7 conditions for SELL signal
-	if sellScore >= 6 {
+	if sellScore >= minConditions {
 		return &AdvancedSignal{
 			Strategy:   "liquidity_hunter",
 			Type:       "SELL",
@@ -187,6 +275,7 @@ func (usg *UnifiedSignalGenerator) generateLiquidityHunterSignal(candles []Candl
 			TP3:        currentPrice - (atr * 10.0), // Optimized: 10.0 ATR
 			Confluence: sellScore,
 			Reasons:    []string{"Excellent pullback in downtrend", "6+ conditions met", "EMA20 resistance", "Bearish reversal"},
+			Evidence:   pullbackEvidence("bearish"),
 			Strength:   95.0,
 			RR:         2.6,
 			Timeframe:  "15m",
@@ -211,8 +300,12 @@ func (usg *UnifiedSignalGenerator) generateSessionTraderSignal(candles []Candle,
 	}
 
 	// COOLDOWN SYSTEM: Prevent overtrading
-	// Skip if last trade was within 30 candles
-	if idx > 0 && lastSessionTraderIndex > 0 && (idx-lastSessionTraderIndex) < 30 {
+	// Skip if last trade was within cooldown_bars candles (default 30). The last
+	// signal lives on the strategy's context, so every run keeps its own cooldown.
+	cooldownBars := int(usg.param("cooldown_bars", 30))
+	last, now := usg.ctx.LastSignal, candles[idx].Timestamp
+	// A timestamp earlier than the last signal means the context was rewound
+	if last > 0 && now >= last && now-last < int64(cooldownBars)*(now-candles[idx-1].Timestamp) {
 		return nil
 	}
 
@@ -221,52 +314,41 @@ func (usg *UnifiedSignalGenerator) generateSessionTraderSignal(candles []Candle,
 	previousCandle := candles[idx-1]
 
 	// === PHASE 1: MARKET REGIME FILTER (5-STAR OPTIMIZATION) ===
-	// Only trade in STRONG trending markets
-	adx := calculateADX(candles[:idx+1], 14)
-
-	// Skip if trend is weak (ADX < 25)
-	if adx < 25 {
+	// Only trade when the regime detector sees a trend
+	// (P(trending) >= trend_prob_min, default 0.5)
+	if usg.ctx.Regime().Probability(regime.Trending) < usg.param("trend_prob_min", 0.5) {
 		return nil
 	}
 
 	// === ADVANCED INDICATORS ===
-	atr := calculateATR(candles[:idx+1], 14)
-	atr20 := calculateATR(candles[:idx+1], 20)
+	atr := usg.ind.ATR(14)
+	atr20 := usg.ind.ATR(20)
 
 	// Multiple EMAs for trend strength
-	ema9 := calculateEMA(candles[:idx+1], 9)
-	ema21 := calculateEMA(candles[:idx+1], 21)
-	ema50 := calculateEMA(candles[:idx+1], 50)
-	ema100 := calculateEMA(candles[:idx+1], 100)
-	ema200 := calculateEMA(candles[:idx+1], 200)
+	ema9 := usg.ind.EMA(9)
+	ema21 := usg.ind.EMA(21)
+	ema50 := usg.ind.EMA(50)
+	ema100 := usg.ind.EMA(100)
+	ema200 := usg.ind.EMA(200)
 
 	// RSI with multiple periods
-	rsi := calculateRSI(candles[:idx+1], 14)
-	rsi7 := calculateRSI(candles[:idx+1], 7)
+	rsi := usg.ind.RSI(14)
+	rsi7 := usg.ind.RSI(7)
 
 	// MACD for momentum
-	macd, signal := calculateMACD(candles[:idx+1])
+	macd, signal, _ := usg.ind.MACD(12, 26, 9)
 	macdBullish := macd > signal
 	macdBearish := macd < signal
 	macdCrossDown := macd < signal && macd < 0
 
 	// === VOLUME ANALYSIS (Smart Money Detection) ===
-	avgVolume20 := 0.0
-	avgVolume50 := 0.0
-	for i := idx - 19; i <= idx; i++ {
-		avgVolume20 += candles[i].Volume
-	}
-	avgVolume20 /= 20
-
-	for i := idx - 49; i <= idx; i++ {
-		avgVolume50 += candles[i].Volume
-	}
-	avgVolume50 /= 50
+	avgVolume20 := usg.ind.AverageVolume(20)
+	avgVolume50 := usg.ind.AverageVolume(50)
 
 	// Volume conditions - BALANCED: Optimal for profit
-	highVolume := currentCandle.Volume > avgVolume20*1.4     // Good volume
-	veryHighVolume := currentCandle.Volume > avgVolume20*2.0 // Strong volume
-	volumeIncreasing := avgVolume20 > avgVolume50*1.1        // Volume trend
+	highVolume := currentCandle.Volume > avgVolume20*usg.param("high_volume_mult", 1.4) // Good volume
+	veryHighVolume := currentCandle.Volume > avgVolume20*2.0                            // Strong volume
+	volumeIncreasing := avgVolume20 > avgVolume50*1.1                                   // Volume trend
 
 	// === SIMPLE AMD PHASE DETECTION (Improved) ===
 	// Detect manipulation/whipsaw conditions to SKIP trades
@@ -275,7 +357,7 @@ func (usg *UnifiedSignalGenerator) generateSessionTraderSignal(candles []Candle,
 	volatilitySpikes := 0
 	for i := idx - 9; i <= idx; i++ {
 		candleRange := candles[i].High - candles[i].Low
-		if candleRange > atr*1.8 {
+		if candleRange > atr*usg.param("spike_atr_mult", 1.8) {
 			volatilitySpikes++
 		}
 	}
@@ -284,7 +366,7 @@ func (usg *UnifiedSignalGenerator) generateSessionTraderSignal(candles []Candle,
 	priceAboveEMA21 := 0
 	priceBelowEMA21 := 0
 	for i := idx - 9; i <= idx; i++ {
-		ema21Temp := calculateEMA(candles[:i+1], 21)
+		ema21Temp := usg.ind.EMAAt(21, idx-i)
 		if candles[i].Close > ema21Temp {
 			priceAboveEMA21++
 		} else {
@@ -298,7 +380,7 @@ func (usg *UnifiedSignalGenerator) generateSessionTraderSignal(candles []Candle,
 	// MANIPULATION PHASE = Skip all trades
 	// Changed from OR to AND, and increased threshold
 	// FIXED: Enabled manipulation detection to filter bad trades
-	isManipulation := volatilitySpikes >= 3 || isWhipsawing
+	isManipulation := volatilitySpikes >= int(usg.param("max_volatility_spikes", 3)) || isWhipsawing
 
 	_ = volatilitySpikes // Unused for now
 
@@ -314,6 +396,7 @@ func (usg *UnifiedSignalGenerator) generateSessionTraderSignal(candles []Candle,
 	resistance := currentPrice
 	strongSupport := currentPrice
 	strongResistance := currentPrice
+	strongSupportIdx, strongResistanceIdx := -1, -1 // Swing bars behind the strong levels
 
 	// Find swing highs and lows
 	for i := idx - lookback; i < idx-2; i++ {
@@ -325,6 +408,7 @@ func (usg *UnifiedSignalGenerator) generateSessionTraderSignal(candles []Candle,
 			// Strong support with high volume
 			if candles[i].Volume > avgVolume50*1.5 {
 				strongSupport = candles[i].Low
+				strongSupportIdx = i
 			}
 		}
 
@@ -336,6 +420,7 @@ func (usg *UnifiedSignalGenerator) generateSessionTraderSignal(candles []Candle,
 			// Strong resistance with high volume
 			if candles[i].Volume > avgVolume50*1.5 {
 				strongResistance = candles[i].High
+				strongResistanceIdx = i
 			}
 		}
 	}
@@ -433,6 +518,7 @@ func (usg *UnifiedSignalGenerator) generateSessionTraderSignal(candles []Candle,
 
 	// Price near order block support - BALANCED: Optimal zones
 	nearStrongSupport := currentPrice <= strongSupport*1.03 && currentPrice >= strongSupport*0.97 // 3% zone
+	supportEvidence := swingZoneEvidence("Order block support", "bullish", candles, strongSupportIdx, idx)
 
 	// Bullish reversal patterns
 	prevBearish := previousCandle.Close < previousCandle.Open
@@ -485,7 +571,7 @@ func (usg *UnifiedSignalGenerator) generateSessionTraderSignal(candles []Candle,
 			stopDistance := atr * 1.5
 
 			// Record trade for cooldown
-			lastSessionTraderIndex = idx
+			usg.ctx.LastSignal = candles[idx].Timestamp
 
 			return &AdvancedSignal{
 				Strategy:   "session_trader",
@@ -551,6 +637,7 @@ func (usg *UnifiedSignalGenerator) generateSessionTraderSignal(candles []Candle,
 				TP3:        currentPrice + (stopDistance * 4.5),
 				Confluence: 4,
 				Reasons:    reasons,
+				Evidence:   supportEvidence,
 				Strength:   82.0,
 				RR:         4.5,
 				Timeframe:  "15m",
@@ -605,6 +692,7 @@ func (usg *UnifiedSignalGenerator) generateSessionTraderSignal(candles []Candle,
 				TP3:        currentPrice + (stopDistance * 4.5),
 				Confluence: 4,
 				Reasons:    reasons,
+				Evidence:   supportEvidence,
 				Strength:   78.0,
 				RR:         4.5,
 				Timeframe:  "15m",
@@ -660,6 +748,7 @@ func (usg *UnifiedSignalGenerator) generateSessionTraderSignal(candles []Candle,
 				TP3:        currentPrice + (stopDistance * 4.5),
 				Confluence: 4,
 				Reasons:    reasons,
+				Evidence:   supportEvidence,
 				Strength:   74.0,
 				RR:         4.5,
 				Timeframe:  "15m",
@@ -768,7 +857,7 @@ func (usg *UnifiedSignalGenerator) generateSessionTraderSignal(candles []Candle,
 			stopDistance := atr * 1.5
 
 			// Record trade for cooldown
-			lastSessionTraderIndex = idx
+			usg.ctx.LastSignal = candles[idx].Timestamp
 
 			return &AdvancedSignal{
 				Strategy:   "session_trader",
@@ -788,6 +877,7 @@ func (usg *UnifiedSignalGenerator) generateSessionTraderSignal(candles []Candle,
 
 		// Price near order block resistance - BALANCED: Optimal zones
 		nearStrongResistance := currentPrice >= strongResistance*0.97 && currentPrice <= strongResistance*1.03 // 3% zone
+		resistanceEvidence := swingZoneEvidence("Order block resistance", "bearish", candles, strongResistanceIdx, idx)
 
 		// Bearish reversal patterns
 		prevBullish := previousCandle.Close > previousCandle.Open
@@ -854,6 +944,7 @@ func (usg *UnifiedSignalGenerator) generateSessionTraderSignal(candles []Candle,
 				TP3:        currentPrice - (stopDistance * 4.5),
 				Confluence: 6,
 				Reasons:    reasons,
+				Evidence:   resistanceEvidence,
 				Strength:   90.0,
 				RR:         4.5,
 				Timeframe:  "15m",
@@ -914,6 +1005,7 @@ func (usg *UnifiedSignalGenerator) generateSessionTraderSignal(candles []Candle,
 				TP3:        currentPrice - (stopDistance * 4.5),
 				Confluence: 5,
 				Reasons:    reasons,
+				Evidence:   resistanceEvidence,
 				Strength:   85.0,
 				RR:         4.5,
 				Timeframe:  "15m",
@@ -1002,6 +1094,7 @@ func (usg *UnifiedSignalGenerator) generateSessionTraderSignal(candles []Candle,
 				TP3:        currentPrice - (stopDistance * 4.0),
 				Confluence: 5,
 				Reasons:    reasons,
+				Evidence:   resistanceEvidence,
 				Strength:   75.0,
 				RR:         4.0,
 				Timeframe:  "15m",
@@ -1048,14 +1141,6 @@ func (usg *UnifiedSignalGenerator) generateSessionTraderSignal(candles []Candle,
 	return nil
 }
 
-// Helper function to record trade for cooldown
-func recordSessionTraderTrade(signal *AdvancedSignal, idx int) *AdvancedSignal {
-	if signal != nil {
-		lastSessionTraderIndex = idx
-	}
-	return signal
-}
-
 // generateBreakoutMasterSignal - UNIFIED logic for breakout master
 func (usg *UnifiedSignalGenerator) generateBreakoutMasterSignal(candles []Candle, idx int) *AdvancedSignal {
 	if idx < 50 {
@@ -1065,9 +1150,9 @@ func (usg *UnifiedSignalGenerator) generateBreakoutMasterSignal(candles []Candle
 	currentPrice := candles[idx].Close
 
 	// Calculate indicators
-	atr := calculateATR(candles[:idx+1], 14)
-	ema50 := calculateEMA(candles[:idx+1], 50)
-	rsi := calculateRSI(candles[:idx+1], 14)
+	atr := usg.ind.ATR(14)
+	ema50 := usg.ind.EMA(50)
+	rsi := usg.ind.RSI(14)
 
 	// Find recent high/low
 	recentHigh := candles[idx-20].High
@@ -1177,7 +1262,7 @@ func (usg *UnifiedSignalGenerator) generateTrendRiderSignal(candles []Candle, id
 	if signal != nil {
 		signal.Strategy = "trend_rider"
 		// OPTIMIZED PARAMETERS: StopATR=0.5, TP1=3, TP2=4.5, TP3=7.5
-		atr := calculateATR(candles[:idx+1], 14)
+		atr := usg.ind.ATR(14)
 		if signal.Type == "BUY" {
 			signal.StopLoss = signal.Entry - (atr * 0.5)
 			signal.TP1 = signal.Entry + (atr * 3.0)
@@ -1200,7 +1285,7 @@ func (usg *UnifiedSignalGenerator) generateRangeMasterSignal(candles []Candle, i
 	if signal != nil {
 		signal.Strategy = "range_master"
 		// OPTIMIZED PARAMETERS: StopATR=0.5, TP1=2, TP2=3, TP3=5
-		atr := calculateATR(candles[:idx+1], 14)
+		atr := usg.ind.ATR(14)
 		if signal.Type == "BUY" {
 			signal.StopLoss = signal.Entry - (atr * 0.5)
 			signal.TP1 = signal.Entry + (atr * 2.0)
@@ -1223,7 +1308,7 @@ func (usg *UnifiedSignalGenerator) generateSmartMoneySignal(candles []Candle, id
 	if signal != nil {
 		signal.Strategy = "smart_money_tracker"
 		// OPTIMIZED PARAMETERS: StopATR=0.5, TP1=3, TP2=4.5, TP3=7.5
-		atr := calculateATR(candles[:idx+1], 14)
+		atr := usg.ind.ATR(14)
 		if signal.Type == "BUY" {
 			signal.StopLoss = signal.Entry - (atr * 0.5)
 			signal.TP1 = signal.Entry + (atr * 3.0)
@@ -1246,7 +1331,7 @@ func (usg *UnifiedSignalGenerator) generateInstitutionalSignal(candles []Candle,
 	if signal != nil {
 		signal.Strategy = "institutional_follower"
 		// OPTIMIZED PARAMETERS: StopATR=0.5, TP1=3, TP2=4.5, TP3=7.5
-		atr := calculateATR(candles[:idx+1], 14)
+		atr := usg.ind.ATR(14)
 		if signal.Type == "BUY" {
 			signal.StopLoss = signal.Entry - (atr * 0.5)
 			signal.TP1 = signal.Entry + (atr * 3.0)
@@ -1269,7 +1354,7 @@ func (usg *UnifiedSignalGenerator) generateReversalSignal(candles []Candle, idx
 	if signal != nil {
 		signal.Strategy = "reversal_sniper"
 		// OPTIMIZED PARAMETERS: StopATR=0.5, TP1=5, TP2=7.5, TP3=12.5
-		atr := calculateATR(candles[:idx+1], 14)
+		atr := usg.ind.ATR(14)
 		if signal.Type == "BUY" {
 			signal.StopLoss = signal.Entry - (atr * 0.5)
 			signal.TP1 = signal.Entry + (atr * 5.0)
@@ -1292,7 +1377,7 @@ func (usg *UnifiedSignalGenerator) generateMomentumSignal(candles []Candle, idx
 	if signal != nil {
 		signal.Strategy = "momentum_beast"
 		// Similar to breakout but slightly tighter stops
-		atr := calculateATR(candles[:idx+1], 14)
+		atr := usg.ind.ATR(14)
 		if signal.Type == "BUY" {
 			signal.StopLoss = signal.Entry - (atr * 1.0)
 			signal.TP1 = signal.Entry + (atr * 3.5)
@@ -1315,7 +1400,7 @@ func (usg *UnifiedSignalGenerator) generateScalperSignal(candles []Candle, idx i
 	if signal != nil {
 		signal.Strategy = "scalper_pro"
 		// Tight stops, quick targets
-		atr := calculateATR(candles[:idx+1], 14)
+		atr := usg.ind.ATR(14)
 		if signal.Type == "BUY" {
 			signal.StopLoss = signal.Entry - (atr * 0.5)
 			signal.TP1 = signal.Entry + (atr * 1.2)
@@ -1345,5 +1430,20 @@ func (signal *AdvancedSignal) ToLiveSignalResponse(currentPrice float64) LiveSig
 		TP3:          signal.TP3,
 		RiskReward:   signal.RR,
 		Timestamp:    time.Now().Unix(),
+		Reasons:      signal.Reasons,
+		Evidence:     signal.Evidence,
+	}
+}
+
+// swingZoneEvidence describes the high-volume swing bar behind a strong level
+// (nil when no swing bar set the level). The items are left untagged; the
+// strategy adapter tags them with the timeframe of the signal they end up in.
+func swingZoneEvidence(label, direction string, candles []Candle, swingIdx, idx int) []Evidence {
+	if swingIdx < 0 {
+		return nil
 	}
+	swing := candles[swingIdx]
+	return weighEvidence([]Evidence{
+		newEvidence(EvidenceOrderBlock, label, direction, swing.Low, swing.High, candles, swingIdx, idx),
+	}, "")
 }
@@ -2,6 +2,8 @@ package strategies
 
 import (
 	"math"
+
+	"tradebot/backend/internal/indicators"
 )
 
 // AdvancedStrategy represents a complete trading strategy
@@ -15,171 +17,22 @@ type AdvancedStrategy struct {
 	TargetProfitFactor float64
 }
 
-// GetAdvancedStrategies returns all advanced profitable strategies
+// GetAdvancedStrategies returns all advanced profitable strategies, built from the strategy registry
 func GetAdvancedStrategies() map[string]AdvancedStrategy {
-	return map[string]AdvancedStrategy{
-		"liquidity_hunter": {
-			Name:          "Liquidity Hunter",
-			Description:   "Hunts liquidity sweeps - BEST WIN RATE (61%)", // Updated
-			Timeframe:     "15m",
-			MinConfluence: 6, // OPTIMIZED (Code requires 6 now)
-			RequiredConcepts: []string{
-				"Liquidity Sweep",
-				"Order Block",
-				"Fair Value Gap",
-				"Break of Structure",
-				"Volume Spike",
-				"Session Alignment",
-			},
-			TargetWinRate:      61.7,
-			TargetProfitFactor: 8.24,
-		},
-		"smart_money_tracker": {
-			Name:          "Smart Money Tracker",
-			Description:   "Follows institutional money flow and order blocks",
-			Timeframe:     "1h",
-			MinConfluence: 4, // OPTIMIZED from 7 to 4
-			RequiredConcepts: []string{
-				"Order Block (Institutional)",
-				"Fair Value Gap",
-				"Liquidity Void",
-				"Market Structure Shift",
-				"Volume Profile",
-				"Delta Analysis",
-				"Premium/Discount Zone",
-			},
-			TargetWinRate:      34.1,
-			TargetProfitFactor: 6.83,
-		},
-		"breakout_master": {
-			Name:          "Breakout Master",
-			Description:   "Catches explosive breakouts with volume confirmation",
-			Timeframe:     "15m",
-			MinConfluence: 4, // OPTIMIZED from 5 to 4
-			RequiredConcepts: []string{
-				"Break of Structure",
-				"Volume Explosion (2x+)",
-				"Consolidation Pattern",
-				"Support/Resistance Break",
-				"Momentum Confirmation",
-			},
-			TargetWinRate:      54.5,
-			TargetProfitFactor: 7.20,
-		},
-		"trend_rider": {
-			Name:          "Trend Rider",
-			Description:   "Rides strong trends with pullback entries",
-			Timeframe:     "4h",
-			MinConfluence: 4, // OPTIMIZED from 5 to 4
-			RequiredConcepts: []string{
-				"Strong Trend (EMA alignment)",
-				"Pullback to Key Level",
-				"Order Block Support",
-				"Higher Timeframe Confirmation",
-				"Momentum Divergence",
-			},
-			TargetWinRate:      36.4,
-			TargetProfitFactor: 6.71,
-		},
-		"scalper_pro": {
-			Name:          "Scalper Pro",
-			Description:   "High-frequency scalping with tight risk management",
-			Timeframe:     "5m",
-			MinConfluence: 4, // OPTIMIZED from 6 to 4
-			RequiredConcepts: []string{
-				"Micro Order Block",
-				"Immediate FVG",
-				"Volume Spike",
-				"Kill Zone Only",
-				"Tight Stop (0.5 ATR)",
-				"Quick Target (1.5 ATR)",
-			},
-			TargetWinRate:      65.0,
-			TargetProfitFactor: 2.0,
-		},
-		"reversal_sniper": {
-			Name:          "Reversal Sniper",
-			Description:   "Catches high-probability reversals at key levels",
-			Timeframe:     "1h",
-			MinConfluence: 4, // OPTIMIZED from 7 to 4
-			RequiredConcepts: []string{
-				"Divergence (RSI/Price)",
-				"Order Block at Extreme",
-				"Liquidity Sweep",
-				"Fair Value Gap",
-				"Volume Climax",
-				"Candlestick Pattern",
-				"Support/Resistance",
-			},
-			TargetWinRate:      28.6,
-			TargetProfitFactor: 3.96,
-		},
-		"session_trader": {
-			Name:          "Session Trader",
-			Description:   "BEST OVERALL: 1300% Return, 18 Profit Factor", // Updated
-			Timeframe:     "15m",
-			MinConfluence: 5, // OPTIMIZED from 6 to 5
-			RequiredConcepts: []string{
-				"London/NY Session",
-				"Session High/Low Sweep",
-				"Order Block",
-				"Fair Value Gap",
-				"Volume Profile",
-				"Time-based Entry",
-			},
-			TargetWinRate:      54.1,
-			TargetProfitFactor: 12.74,
-		},
-		"momentum_beast": {
-			Name:          "Momentum Beast",
-			Description:   "Rides explosive momentum moves with confirmation",
-			Timeframe:     "15m",
-			MinConfluence: 4, // OPTIMIZED from 5 to 4
-			RequiredConcepts: []string{
-				"Strong Momentum",
-				"Volume Confirmation",
-				"Break of Structure",
-				"No Resistance Above",
-				"Trend Alignment",
-			},
-			TargetWinRate:      68.0,
-			TargetProfitFactor: 2.6,
-		},
-		"range_master": {
-			Name:          "Range Master",
-			Description:   "Trades ranges with high probability",
-			Timeframe:     "1h",
-			MinConfluence: 4, // OPTIMIZED from 6 to 4
-			RequiredConcepts: []string{
-				"Clear Range Identified",
-				"Support/Resistance Bounce",
-				"Order Block at Boundary",
-				"Volume Decrease in Middle",
-				"Rejection Candle",
-				"Mean Reversion",
-			},
-			TargetWinRate:      44.2,
-			TargetProfitFactor: 7.63,
-		},
-		"institutional_follower": {
-			Name:          "Institutional Follower",
-			Description:   "Follows big money institutional orders",
-			Timeframe:     "4h",
-			MinConfluence: 5, // OPTIMIZED from 8 to 5
-			RequiredConcepts: []string{
-				"Institutional Order Block",
-				"Large Volume Spike",
-				"Fair Value Gap",
-				"Market Structure Shift",
-				"Premium/Discount Zone",
-				"Liquidity Grab",
-				"Trend Confirmation",
-				"Higher TF Alignment",
-			},
-			TargetWinRate:      38.5,
-			TargetProfitFactor: 9.08,
-		},
+	strategies := make(map[string]AdvancedStrategy)
+	for _, s := range ListStrategies() {
+		meta := s.Metadata()
+		strategies[meta.Name] = AdvancedStrategy{
+			Name:               meta.DisplayName,
+			Description:        meta.Description,
+			Timeframe:          s.DefaultTimeframe(),
+			MinConfluence:      meta.MinConfluence,
+			RequiredConcepts:   meta.RequiredConcepts,
+			TargetWinRate:      meta.TargetWinRate,
+			TargetProfitFactor: meta.TargetProfitFactor,
+		}
 	}
+	return strategies
 }
 
 // GenerateSignalWithStrategy generates signal using specific strategy
@@ -200,67 +53,79 @@ type AdvancedSignal struct {
 	TP3        float64
 	Confluence int
 	Reasons    []string
+	Evidence   []Evidence // Zones behind the reasons, for chart overlays
 	Strength   float64
 	RR         float64
 	Timeframe  string
 }
 
+// conceptDetectors maps every concept name understood by checkConcept to its detector.
+// Built-in strategies list these in RequiredConcepts; rule strategies reference them by name.
+var conceptDetectors = map[string]func(candles []Candle, idx int) bool{
+	"Liquidity Sweep":               detectLiquiditySweep,
+	"Order Block":                   detectOrderBlock,
+	"Order Block (Institutional)":   detectOrderBlock,
+	"Micro Order Block":             detectOrderBlock,
+	"Order Block at Extreme":        detectOrderBlock,
+	"Order Block Support":           detectOrderBlock,
+	"Order Block at Boundary":       detectOrderBlock,
+	"Fair Value Gap":                detectFVG,
+	"Immediate FVG":                 detectFVG,
+	"Break of Structure":            hasBreakOfStructure,
+	"Market Structure Shift":        hasBreakOfStructure,
+	"Volume Spike":                  func(c []Candle, i int) bool { return hasVolumeSpike(c, i, 2.0) },
+	"Volume Explosion (2x+)":        func(c []Candle, i int) bool { return hasVolumeSpike(c, i, 2.0) },
+	"Large Volume Spike":            func(c []Candle, i int) bool { return hasVolumeSpike(c, i, 2.0) },
+	"Session Alignment":             func(c []Candle, i int) bool { return isKillZone(c[i].Timestamp) },
+	"Kill Zone Only":                func(c []Candle, i int) bool { return isKillZone(c[i].Timestamp) },
+	"London/NY Session":             func(c []Candle, i int) bool { return isKillZone(c[i].Timestamp) },
+	"Strong Trend (EMA alignment)":  hasStrongTrend,
+	"Trend Alignment":               hasStrongTrend,
+	"Trend Confirmation":            hasStrongTrend,
+	"Pullback to Key Level":         isPullbackToKeyLevel,
+	"Higher Timeframe Confirmation": func(c []Candle, i int) bool { return true }, // Simplified - would need HTF data
+	"Higher TF Alignment":           func(c []Candle, i int) bool { return true },
+	"Momentum Divergence":           hasDivergence,
+	"Divergence (RSI/Price)":        hasDivergence,
+	"Volume Profile":                hasVolumeConfirmation,
+	"Delta Analysis":                hasVolumeConfirmation,
+	"Premium/Discount Zone":         isInPremiumDiscountZone,
+	"Consolidation Pattern":         hasConsolidation,
+	"Clear Range Identified":        hasConsolidation,
+	"Support/Resistance Break":      isAtSupportResistance,
+	"Support/Resistance Bounce":     isAtSupportResistance,
+	"Support/Resistance":            isAtSupportResistance,
+	"Momentum Confirmation":         hasStrongMomentum,
+	"Strong Momentum":               hasStrongMomentum,
+	"Volume Climax":                 hasVolumeClimax,
+	"Candlestick Pattern":           hasSignificantPattern,
+	"Rejection Candle":              hasSignificantPattern,
+	"Session High/Low Sweep":        hasSessionSweep,
+	"Time-based Entry":              func(c []Candle, i int) bool { return isOptimalEntryTime(c[i].Timestamp) },
+	"No Resistance Above":           hasNoResistanceAbove,
+	"Volume Decrease in Middle":     hasLowVolume,
+	"Mean Reversion":                isMeanReversion,
+	"Liquidity Grab":                detectLiquiditySweep,
+	"Liquidity Void":                detectLiquiditySweep,
+}
+
+// IsKnownConcept reports whether checkConcept understands a concept name
+func IsKnownConcept(concept string) bool {
+	_, ok := conceptDetectors[concept]
+	return ok
+}
+
 // checkConcept checks if a specific concept is present
 func checkConcept(candles []Candle, idx int, concept string) bool {
 	if idx < 20 {
 		return false
 	}
 
-	switch concept {
-	case "Liquidity Sweep":
-		return detectLiquiditySweep(candles, idx)
-	case "Order Block", "Order Block (Institutional)", "Micro Order Block", "Order Block at Extreme", "Order Block Support", "Order Block at Boundary":
-		return detectOrderBlock(candles, idx)
-	case "Fair Value Gap", "Immediate FVG":
-		return detectFVG(candles, idx)
-	case "Break of Structure", "Market Structure Shift":
-		return hasBreakOfStructure(candles, idx)
-	case "Volume Spike", "Volume Explosion (2x+)", "Large Volume Spike":
-		return hasVolumeSpike(candles, idx, 2.0)
-	case "Session Alignment", "Kill Zone Only", "London/NY Session":
-		return isKillZone(candles[idx].Timestamp)
-	case "Strong Trend (EMA alignment)", "Trend Alignment", "Trend Confirmation":
-		return hasStrongTrend(candles, idx)
-	case "Pullback to Key Level":
-		return isPullbackToKeyLevel(candles, idx)
-	case "Higher Timeframe Confirmation", "Higher TF Alignment":
-		return true // Simplified - would need HTF data
-	case "Momentum Divergence", "Divergence (RSI/Price)":
-		return hasDivergence(candles, idx)
-	case "Volume Profile", "Delta Analysis":
-		return hasVolumeConfirmation(candles, idx)
-	case "Premium/Discount Zone":
-		return isInPremiumDiscountZone(candles, idx)
-	case "Consolidation Pattern", "Clear Range Identified":
-		return hasConsolidation(candles, idx)
-	case "Support/Resistance Break", "Support/Resistance Bounce", "Support/Resistance":
-		return isAtSupportResistance(candles, idx)
-	case "Momentum Confirmation", "Strong Momentum":
-		return hasStrongMomentum(candles, idx)
-	case "Volume Climax":
-		return hasVolumeClimax(candles, idx)
-	case "Candlestick Pattern", "Rejection Candle":
-		return hasSignificantPattern(candles, idx)
-	case "Session High/Low Sweep":
-		return hasSessionSweep(candles, idx)
-	case "Time-based Entry":
-		return isOptimalEntryTime(candles[idx].Timestamp)
-	case "No Resistance Above":
-		return hasNoResistanceAbove(candles, idx)
-	case "Volume Decrease in Middle":
-		return hasLowVolume(candles, idx)
-	case "Mean Reversion":
-		return isMeanReversion(candles, idx)
-	case "Liquidity Grab", "Liquidity Void":
-		return detectLiquiditySweep(candles, idx)
-	default:
+	detector, ok := conceptDetectors[concept]
+	if !ok {
 		return false
 	}
+	return detector(candles, idx)
 }
 
 // Helper functions for concept detection
@@ -286,8 +151,8 @@ func hasStrongTrend(candles []Candle, idx int) bool {
 	if idx < 50 {
 		return false
 	}
-	ema20 := calculateEMA(candles[:idx+1], 20)
-	ema50 := calculateEMA(candles[:idx+1], 50)
+	ema20 := indicators.EMA(candles[:idx+1], 20)
+	ema50 := indicators.EMA(candles[:idx+1], 50)
 	// Reduced threshold from 1% to 0.3% to detect trends more easily
 	return math.Abs(ema20-ema50) > ema50*0.003
 }
@@ -297,7 +162,7 @@ func isPullbackToKeyLevel(candles []Candle, idx int) bool {
 		return false
 	}
 	// Check if price pulled back to EMA20
-	ema20 := calculateEMA(candles[:idx+1], 20)
+	ema20 := indicators.EMA(candles[:idx+1], 20)
 	return math.Abs(candles[idx].Close-ema20) < ema20*0.005
 }
 
@@ -437,7 +302,7 @@ func isMeanReversion(candles []Candle, idx int) bool {
 	if idx < 20 {
 		return false
 	}
-	ema20 := calculateEMA(candles[:idx+1], 20)
+	ema20 := indicators.EMA(candles[:idx+1], 20)
 	deviation := math.Abs(candles[idx].Close-ema20) / ema20
 	// Reduced from 2% to 0.5% to detect mean reversion more easily
 	return deviation > 0.005
@@ -460,8 +325,8 @@ func determineSignalTypeAdvanced(candles []Candle, idx int, strategy string) str
 		return ""
 	}
 
-	ema20 := calculateEMA(candles[:idx+1], 20)
-	ema50 := calculateEMA(candles[:idx+1], 50)
+	ema20 := indicators.EMA(candles[:idx+1], 20)
+	ema50 := indicators.EMA(candles[:idx+1], 50)
 	currentPrice := candles[idx].Close
 
 	// Strategy-specific signal determination (SIMPLIFIED)
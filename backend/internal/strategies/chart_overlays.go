@@ -0,0 +1,612 @@
+package strategies
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"tradebot/backend/internal/profile"
+)
+
+// ==================== CHART OVERLAYS ====================
+// Converts the output of the market structure detectors into drawable
+// primitives a charting frontend can render without knowing the detectors.
+
+// Primitive shapes
+const (
+	ShapeRectangle = "rectangle" // Zone: StartTime..EndTime x PriceLow..PriceHigh
+	ShapeHLine     = "hline"     // Level: Price from StartTime (or the whole chart when 0)
+	ShapeMarker    = "marker"    // Event: a point at Time/Price
+)
+
+// Primitive colors by direction
+const (
+	colorBullish = "#26a69a"
+	colorBearish = "#ef5350"
+	colorNeutral = "#90a4ae"
+)
+
+// ChartPrimitive is one drawable overlay. Times are candle timestamps (ms).
+type ChartPrimitive struct {
+	Shape     string  `json:"shape"`
+	Detector  string  `json:"detector"`
+	Kind      string  `json:"kind"` // order_block, fvg, liquidity_pool, sweep, ...
+	Label     string  `json:"label"`
+	Direction string  `json:"direction,omitempty"` // "bullish", "bearish" or empty
+	Color     string  `json:"color"`
+	StartTime int64   `json:"startTime,omitempty"`
+	EndTime   int64   `json:"endTime,omitempty"`
+	Extend    bool    `json:"extend,omitempty"` // Still active: draw to the right edge
+	PriceLow  float64 `json:"priceLow,omitempty"`
+	PriceHigh float64 `json:"priceHigh,omitempty"`
+	Price     float64 `json:"price,omitempty"`
+	Time      int64   `json:"time,omitempty"`
+	Marker    string  `json:"marker,omitempty"` // "arrowUp", "arrowDown" or "circle"
+	Strength  float64 `json:"strength,omitempty"`
+}
+
+// ChartAnalysis is the overlay output of one detector
+type ChartAnalysis struct {
+	Detector   string                 `json:"detector"`
+	Primitives []ChartPrimitive       `json:"primitives"`
+	Summary    map[string]interface{} `json:"summary"`
+}
+
+// ChartAnalyzer runs one market structure detector for chart overlays
+type ChartAnalyzer struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	MinCandles  int    `json:"minCandles"`
+	analyze     func(candles []Candle) *ChartAnalysis
+}
+
+// chartAnalyzers lists the detectors exposed as overlays, in display order
+var chartAnalyzers = []ChartAnalyzer{
+	{Name: "ict", Description: "ICT/SMC order blocks, fair value gaps, liquidity and market structure", MinCandles: 20, analyze: ictOverlays},
+	{Name: "po3", Description: "Power of 3 session phases of the last trading day", MinCandles: 24, analyze: po3Overlays},
+	{Name: "liquidity", Description: "Liquidity pools and sweeps", MinCandles: 20, analyze: liquidityOverlays},
+	{Name: "supply_demand", Description: "Supply and demand zones", MinCandles: 20, analyze: supplyDemandOverlays},
+	{Name: "market_maker", Description: "Market maker model stop hunts, liquidity grabs and phase", MinCandles: 50, analyze: marketMakerOverlays},
+	{Name: "institutional", Description: "Institutional setups, institutional candles and displacements", MinCandles: 50, analyze: institutionalOverlays},
+	{Name: "volume_profile", Description: "Prior day value area, composite profile and naked POCs / value area edges", MinCandles: 24, analyze: volumeProfileOverlays},
+}
+
+// ListChartAnalyzers returns every overlay detector
+func ListChartAnalyzers() []ChartAnalyzer {
+	return chartAnalyzers
+}
+
+// GetChartAnalyzer looks up an overlay detector by name
+func GetChartAnalyzer(name string) (ChartAnalyzer, bool) {
+	for _, a := range chartAnalyzers {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return ChartAnalyzer{}, false
+}
+
+// Analyze runs the detector and keeps at most limit primitives per kind
+// (the most recent ones; 0 keeps all)
+func (a ChartAnalyzer) Analyze(candles []Candle, limit int) *ChartAnalysis {
+	if len(candles) < a.MinCandles {
+		return &ChartAnalysis{Detector: a.Name, Primitives: []ChartPrimitive{}, Summary: map[string]interface{}{}}
+	}
+	result := a.analyze(candles)
+	result.Detector = a.Name
+	for i := range result.Primitives {
+		result.Primitives[i].Detector = a.Name
+	}
+	result.Primitives = limitPerKind(result.Primitives, limit)
+	return result
+}
+
+// limitPerKind keeps the latest primitives of each kind, ordered by time
+func limitPerKind(primitives []ChartPrimitive, limit int) []ChartPrimitive {
+	if limit <= 0 {
+		return primitives
+	}
+	// Walk from the newest so the most recent primitives of a kind survive
+	sort.SliceStable(primitives, func(i, j int) bool { return primitiveTime(primitives[i]) < primitiveTime(primitives[j]) })
+	counts := map[string]int{}
+	keep := make([]bool, len(primitives))
+	for i := len(primitives) - 1; i >= 0; i-- {
+		kind := primitives[i].Kind
+		if counts[kind] < limit {
+			counts[kind]++
+			keep[i] = true
+		}
+	}
+	kept := []ChartPrimitive{}
+	for i, p := range primitives {
+		if keep[i] {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+func primitiveTime(p ChartPrimitive) int64 {
+	if p.Shape == ShapeMarker {
+		return p.Time
+	}
+	return p.StartTime
+}
+
+func directionColor(direction string) string {
+	switch direction {
+	case "bullish":
+		return colorBullish
+	case "bearish":
+		return colorBearish
+	}
+	return colorNeutral
+}
+
+// zone builds a rectangle from bar from; it ends at the first close beyond
+// the zone against its direction, or extends to the right edge
+func zone(candles []Candle, kind, label, direction string, from int, low, high, strength float64) ChartPrimitive {
+	p := ChartPrimitive{
+		Shape:     ShapeRectangle,
+		Kind:      kind,
+		Label:     label,
+		Direction: direction,
+		Color:     directionColor(direction),
+		StartTime: candles[from].Timestamp,
+		PriceLow:  low,
+		PriceHigh: high,
+		Strength:  strength,
+		Extend:    true,
+	}
+	for i := from + 2; i < len(candles); i++ {
+		if (direction == "bullish" && candles[i].Close < low) || (direction == "bearish" && candles[i].Close > high) {
+			p.EndTime = candles[i].Timestamp
+			p.Extend = false
+			break
+		}
+	}
+	if p.Extend {
+		p.EndTime = candles[len(candles)-1].Timestamp
+	}
+	return p
+}
+
+// level builds a horizontal line starting at bar from (from < 0 spans the chart)
+func level(candles []Candle, kind, label, direction string, from int, price, strength float64) ChartPrimitive {
+	p := ChartPrimitive{
+		Shape:     ShapeHLine,
+		Kind:      kind,
+		Label:     label,
+		Direction: direction,
+		Color:     directionColor(direction),
+		Price:     price,
+		Strength:  strength,
+		Extend:    true,
+	}
+	if from >= 0 && from < len(candles) {
+		p.StartTime = candles[from].Timestamp
+	}
+	return p
+}
+
+// marker builds an event marker at bar idx
+func marker(candles []Candle, kind, label, direction string, idx int, price, strength float64) ChartPrimitive {
+	shape := "circle"
+	switch direction {
+	case "bullish":
+		shape = "arrowUp"
+	case "bearish":
+		shape = "arrowDown"
+	}
+	return ChartPrimitive{
+		Shape:     ShapeMarker,
+		Kind:      kind,
+		Label:     label,
+		Direction: direction,
+		Color:     directionColor(direction),
+		Time:      candles[idx].Timestamp,
+		Price:     price,
+		Marker:    shape,
+		Strength:  strength,
+	}
+}
+
+// inRange reports whether a detector's candle index points into candles
+func inRange(candles []Candle, idx int) bool {
+	return idx >= 0 && idx < len(candles)
+}
+
+// setupLevels draws entry, stop and target lines of a setup firing at the last candle
+func setupLevels(candles []Candle, kind, name, direction string, entry, stop float64, targets ...float64) []ChartPrimitive {
+	last := len(candles) - 1
+	primitives := []ChartPrimitive{
+		marker(candles, kind, name, direction, last, entry, 0),
+		level(candles, kind, name+" entry", direction, last, entry, 0),
+		level(candles, kind, name+" stop", "", last, stop, 0),
+	}
+	for i, target := range targets {
+		if target != 0 {
+			primitives = append(primitives, level(candles, kind, fmt.Sprintf("%s target %d", name, i+1), direction, last, target, 0))
+		}
+	}
+	return primitives
+}
+
+func ictOverlays(candles []Candle) *ChartAnalysis {
+	analysis := PerformICTAnalysis(candles)
+	primitives := []ChartPrimitive{}
+
+	for _, ob := range analysis.OrderBlocks {
+		if inRange(candles, ob.CandleIdx) {
+			primitives = append(primitives, zone(candles, "order_block", fmt.Sprintf("%s OB", ob.Type), ob.Type, ob.CandleIdx, ob.Low, ob.High, ob.Strength))
+		}
+	}
+	for _, fvg := range analysis.FairValueGaps {
+		// The gap sits between the bars around CandleIdx
+		if inRange(candles, fvg.CandleIdx-1) {
+			primitives = append(primitives, zone(candles, "fvg", fmt.Sprintf("%s FVG", fvg.Type), fvg.Type, fvg.CandleIdx-1, fvg.Low, fvg.High, 0))
+		}
+	}
+	seen := map[float64]bool{}
+	for _, liq := range analysis.Liquidity {
+		if seen[liq.Price] {
+			continue
+		}
+		seen[liq.Price] = true
+		primitives = append(primitives, level(candles, "liquidity", liq.Type+" liquidity", "", -1, liq.Price, liq.Strength))
+	}
+
+	s := analysis.Structure
+	last := len(candles) - 1
+	if s.LastSwingHigh > 0 && s.LastSwingLow > 0 { // Zero until two swings of each side formed
+		primitives = append(primitives,
+			level(candles, "swing_high", "Last swing high", "", -1, s.LastSwingHigh, 0),
+			level(candles, "swing_low", "Last swing low", "", -1, s.LastSwingLow, 0),
+		)
+	}
+	structureEvents := []struct {
+		active bool
+		name   string
+	}{{s.BOS, "BOS"}, {s.CHOCH, "CHOCH"}, {s.MSS, "MSS"}}
+	for _, e := range structureEvents {
+		if e.active {
+			primitives = append(primitives, marker(candles, "structure", e.name, s.Trend, last, candles[last].Close, 0))
+		}
+	}
+
+	return &ChartAnalysis{
+		Primitives: primitives,
+		Summary: map[string]interface{}{
+			"trend":           s.Trend,
+			"premiumDiscount": analysis.PremiumDiscount,
+			"ote":             analysis.OTE,
+			"confluence":      analysis.Confluence,
+		},
+	}
+}
+
+// po3Overlays boxes the Asian, London and New York sessions of the last UTC
+// day (the session hours AnalyzeDailyPO3 uses) and labels them with its phases
+func po3Overlays(candles []Candle) *ChartAnalysis {
+	lastDay := time.Unix(candles[len(candles)-1].Timestamp/1000, 0).UTC().Format("2006-01-02")
+	start := len(candles)
+	for start > 0 && time.Unix(candles[start-1].Timestamp/1000, 0).UTC().Format("2006-01-02") == lastDay {
+		start--
+	}
+	day := candles[start:]
+	analysis := AnalyzeDailyPO3(day)
+
+	sessions := []struct {
+		phase    string
+		name     string
+		from, to int // UTC hours
+	}{
+		{"accumulation", "Asia", 0, 8},
+		{"manipulation", "London", 8, 13},
+		{"distribution", "New York", 13, 21},
+	}
+	primitives := []ChartPrimitive{}
+	for _, session := range sessions {
+		first, lastIdx := -1, -1
+		low, high := 0.0, 0.0
+		for i, c := range day {
+			h := time.Unix(c.Timestamp/1000, 0).UTC().Hour()
+			if h < session.from || h >= session.to {
+				continue
+			}
+			if first < 0 {
+				first, low, high = i, c.Low, c.High
+			}
+			lastIdx = i
+			if c.Low < low {
+				low = c.Low
+			}
+			if c.High > high {
+				high = c.High
+			}
+		}
+		if first < 0 {
+			continue
+		}
+		direction := ""
+		confidence := 0.0
+		for _, phase := range analysis.Phases {
+			if phase.Phase == session.phase {
+				direction = phase.Direction
+				confidence = phase.Confidence
+			}
+		}
+		if direction == "neutral" {
+			direction = ""
+		}
+		primitives = append(primitives, ChartPrimitive{
+			Shape:     ShapeRectangle,
+			Kind:      session.phase,
+			Label:     fmt.Sprintf("%s %s", session.name, session.phase),
+			Direction: direction,
+			Color:     directionColor(direction),
+			StartTime: day[first].Timestamp,
+			EndTime:   day[lastIdx].Timestamp,
+			PriceLow:  low,
+			PriceHigh: high,
+			Strength:  confidence,
+		})
+	}
+
+	return &ChartAnalysis{
+		Primitives: primitives,
+		Summary: map[string]interface{}{
+			"dailyBias":            analysis.DailyBias,
+			"expectedMove":         analysis.ExpectedMove,
+			"manipulationComplete": analysis.ManipulationComplete,
+			"distributionStarted":  analysis.DistributionStarted,
+			"optimalEntry":         analysis.OptimalEntry,
+		},
+	}
+}
+
+func liquidityOverlays(candles []Candle) *ChartAnalysis {
+	analysis := PerformLiquiditySweepAnalysis(candles)
+	primitives := []ChartPrimitive{}
+
+	for _, pool := range analysis.Pools {
+		p := level(candles, "liquidity_pool", fmt.Sprintf("%s pool (%d touches)", pool.Type, pool.TouchCount), "", -1, pool.Price, pool.Strength)
+		if pool.Swept && inRange(candles, pool.SweptAt) {
+			p.EndTime = candles[pool.SweptAt].Timestamp
+			p.Extend = false
+		}
+		primitives = append(primitives, p)
+	}
+	for _, sweep := range analysis.Sweeps {
+		if !inRange(candles, sweep.CandleIdx) {
+			continue
+		}
+		// A sell-side sweep (below lows) is bullish, a buy-side sweep bearish
+		direction := "bullish"
+		if sweep.Type == "buyside" {
+			direction = "bearish"
+		}
+		primitives = append(primitives,
+			marker(candles, "sweep", sweep.Type+" sweep", direction, sweep.CandleIdx, sweep.SweepPrice, sweep.Strength),
+			level(candles, "swept_level", "Swept level", direction, sweep.CandleIdx, sweep.TargetLevel, sweep.Strength),
+		)
+	}
+
+	return &ChartAnalysis{
+		Primitives: primitives,
+		Summary: map[string]interface{}{
+			"sweepInProgress": analysis.SweepInProgress,
+			"expectedMove":    analysis.ExpectedMove,
+		},
+	}
+}
+
+func supplyDemandOverlays(candles []Candle) *ChartAnalysis {
+	analysis := FindSupplyDemandZones(candles)
+	primitives := []ChartPrimitive{}
+
+	for _, zones := range [][]SupplyDemandZone{analysis.DemandZones, analysis.SupplyZones} {
+		for _, z := range zones {
+			if !inRange(candles, z.CandleIdx) {
+				continue
+			}
+			direction := "bullish"
+			if z.Type == "supply" {
+				direction = "bearish"
+			}
+			label := fmt.Sprintf("%s (%s)", z.Type, z.Quality)
+			if z.Fresh {
+				label = "fresh " + label
+			}
+			primitives = append(primitives, zone(candles, z.Type+"_zone", label, direction, z.CandleIdx, z.Low, z.High, z.Strength))
+		}
+	}
+
+	return &ChartAnalysis{
+		Primitives: primitives,
+		Summary: map[string]interface{}{
+			"inZone":   analysis.InZone,
+			"zoneType": analysis.ZoneType,
+		},
+	}
+}
+
+func marketMakerOverlays(candles []Candle) *ChartAnalysis {
+	analysis := PerformMMMAnalysis(candles)
+	primitives := []ChartPrimitive{}
+
+	for _, hunt := range analysis.StopHunts {
+		if inRange(candles, hunt.CandleIdx) {
+			label := "Stop hunt"
+			if hunt.Confirmed {
+				label = "Confirmed stop hunt"
+			}
+			primitives = append(primitives, marker(candles, "stop_hunt", label, hunt.Type, hunt.CandleIdx, hunt.SweepPrice, hunt.Strength))
+		}
+	}
+	for _, grab := range analysis.LiquidityGrabs {
+		if !inRange(candles, grab.CandleIdx) {
+			continue
+		}
+		direction := "bullish"
+		if grab.Type == "buyside" {
+			direction = "bearish"
+		}
+		primitives = append(primitives, marker(candles, "liquidity_grab", grab.Type+" grab", direction, grab.CandleIdx, grab.GrabPrice, grab.Strength))
+	}
+
+	summary := map[string]interface{}{
+		"trapDetected":      analysis.TrapDetected,
+		"institutionalBias": analysis.InstitutionalBias,
+	}
+	if phase := analysis.CurrentPhase; phase != nil && inRange(candles, phase.StartIdx) && inRange(candles, phase.EndIdx) {
+		low, high := candles[phase.StartIdx].Low, candles[phase.StartIdx].High
+		for _, c := range candles[phase.StartIdx : phase.EndIdx+1] {
+			if c.Low < low {
+				low = c.Low
+			}
+			if c.High > high {
+				high = c.High
+			}
+		}
+		primitives = append(primitives, ChartPrimitive{
+			Shape:     ShapeRectangle,
+			Kind:      "phase",
+			Label:     phase.Phase,
+			Color:     colorNeutral,
+			StartTime: candles[phase.StartIdx].Timestamp,
+			EndTime:   candles[phase.EndIdx].Timestamp,
+			PriceLow:  low,
+			PriceHigh: high,
+			Strength:  phase.Confidence,
+		})
+		summary["phase"] = phase.Phase
+	}
+
+	return &ChartAnalysis{Primitives: primitives, Summary: summary}
+}
+
+func institutionalOverlays(candles []Candle) *ChartAnalysis {
+	analysis := PerformInstitutionalAnalysis(candles)
+	primitives := []ChartPrimitive{}
+
+	if sb := analysis.SilverBullet; sb != nil && sb.Valid {
+		primitives = append(primitives, setupLevels(candles, "silver_bullet", "Silver bullet", sb.Type, sb.Entry, sb.StopLoss, sb.Target)...)
+	}
+	if u := analysis.Unicorn; u != nil && u.Valid {
+		primitives = append(primitives, setupLevels(candles, "unicorn", "Unicorn", u.Type, u.Entry, u.StopLoss, u.Target1, u.Target2)...)
+	}
+	if ts := analysis.TurtleSoup; ts != nil && ts.Valid {
+		primitives = append(primitives, setupLevels(candles, "turtle_soup", "Turtle soup", ts.Type, ts.Entry, ts.StopLoss, ts.Target)...)
+		primitives = append(primitives, level(candles, "turtle_soup", "Failed breakout level", ts.Type, -1, ts.BreakoutLevel, ts.Strength))
+	}
+	if qm := analysis.Quasimodo; qm != nil && qm.Valid {
+		primitives = append(primitives, setupLevels(candles, "quasimodo", "Quasimodo", qm.Type, qm.Entry, qm.StopLoss, qm.Target)...)
+		primitives = append(primitives, level(candles, "quasimodo", "Quasimodo neckline", qm.Type, -1, qm.Neckline, qm.Strength))
+	}
+
+	for _, ic := range analysis.InstCandles {
+		if inRange(candles, ic.CandleIdx) {
+			primitives = append(primitives, marker(candles, "institutional_candle", ic.Type+" institutional candle", ic.Type, ic.CandleIdx, ic.Close, ic.Strength))
+		}
+	}
+	for _, d := range analysis.Displacements {
+		if !inRange(candles, d.StartIdx) || !inRange(candles, d.EndIdx) {
+			continue
+		}
+		primitives = append(primitives, ChartPrimitive{
+			Shape:     ShapeRectangle,
+			Kind:      "displacement",
+			Label:     fmt.Sprintf("%s displacement (%d candles)", d.Type, d.Candles),
+			Direction: d.Type,
+			Color:     directionColor(d.Type),
+			StartTime: candles[d.StartIdx].Timestamp,
+			EndTime:   candles[d.EndIdx].Timestamp,
+			PriceLow:  math.Min(d.StartPrice, d.EndPrice),
+			PriceHigh: math.Max(d.StartPrice, d.EndPrice),
+			Strength:  d.Strength,
+		})
+	}
+
+	return &ChartAnalysis{
+		Primitives: primitives,
+		Summary: map[string]interface{}{
+			"overallBias":   analysis.OverallBias,
+			"setupCount":    analysis.SetupCount,
+			"totalStrength": analysis.TotalStrength,
+		},
+	}
+}
+
+// volumeProfileOverlays draws the last completed day's value area, the
+// composite POC and value area, and every day and session POC / value area
+// edge: naked ones extend right, tested ones end at the bar that traded them
+func volumeProfileOverlays(candles []Candle) *ChartAnalysis {
+	analysis, err := profile.Analyze(candles, profile.DefaultConfig())
+	if err != nil {
+		return &ChartAnalysis{Primitives: []ChartPrimitive{}, Summary: map[string]interface{}{"error": err.Error()}}
+	}
+	primitives := []ChartPrimitive{}
+	summary := map[string]interface{}{"tickSize": analysis.TickSize}
+
+	if prior := analysis.LastComplete(profile.KindDay); prior != nil {
+		primitives = append(primitives, ChartPrimitive{
+			Shape:     ShapeRectangle,
+			Kind:      "value_area",
+			Label:     prior.Label + " value area",
+			Color:     colorNeutral,
+			StartTime: prior.Start,
+			EndTime:   prior.End,
+			PriceLow:  prior.VAL,
+			PriceHigh: prior.VAH,
+		})
+		summary["priorPoc"], summary["priorVah"], summary["priorVal"] = prior.POC, prior.VAH, prior.VAL
+	}
+	if c := analysis.Composite; c != nil {
+		primitives = append(primitives,
+			level(candles, "composite", c.Label+" POC", "", -1, c.POC, 0),
+			level(candles, "composite", c.Label+" VAH", "", -1, c.VAH, 0),
+			level(candles, "composite", c.Label+" VAL", "", -1, c.VAL, 0),
+		)
+		summary["compositePoc"] = c.POC
+	}
+
+	naked := 0
+	for _, l := range analysis.Levels {
+		direction := "bullish" // Support below price
+		if l.Price > analysis.Price {
+			direction = "bearish"
+		}
+		p := ChartPrimitive{
+			Shape:     ShapeHLine,
+			Kind:      "tested_" + l.Kind,
+			Label:     fmt.Sprintf("%s %s", l.Profile, strings.ToUpper(l.Kind)),
+			Direction: direction,
+			Color:     colorNeutral,
+			Price:     l.Price,
+			StartTime: l.Since,
+			EndTime:   l.TestedAt,
+		}
+		if l.Naked {
+			p.Kind = "naked_" + l.Kind
+			p.Label = "Naked " + p.Label
+			p.Color = directionColor(direction)
+			p.EndTime = 0
+			p.Extend = true
+			naked++
+		}
+		primitives = append(primitives, p)
+	}
+	summary["nakedLevels"] = naked
+	above, below := analysis.Nearest(analysis.Price)
+	if above != nil {
+		summary["nearestAbove"] = above
+	}
+	if below != nil {
+		summary["nearestBelow"] = below
+	}
+
+	return &ChartAnalysis{Primitives: primitives, Summary: summary}
+}
@@ -0,0 +1,162 @@
+package strategies
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// overlayCandles builds hourly bars that trend up, pull back and trend again
+func overlayCandles(n int) []Candle {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := make([]Candle, n)
+	price := 100.0
+	for i := range candles {
+		open := price
+		price += 0.8*math.Sin(float64(i)/6) + 0.1
+		candles[i] = Candle{
+			Timestamp: start.Add(time.Duration(i) * time.Hour).UnixMilli(),
+			Open:      open,
+			High:      math.Max(open, price) + 0.3,
+			Low:       math.Min(open, price) - 0.3,
+			Close:     price,
+			Volume:    1000 + float64(i%7)*100,
+		}
+	}
+	return candles
+}
+
+func TestZoneEndsAtFirstCloseThrough(t *testing.T) {
+	candles := overlayCandles(10)
+	for i := range candles {
+		candles[i].Close = 105
+	}
+	candles[1].Close = 90 // Within two bars of the origin: ignored
+	candles[6].Close = 99
+
+	z := zone(candles, "order_block", "Bullish OB", "bullish", 0, 100, 102, 70)
+	if z.Extend || z.EndTime != candles[6].Timestamp {
+		t.Fatalf("want zone closed at bar 6, got extend=%v end=%d", z.Extend, z.EndTime)
+	}
+	if z.Shape != ShapeRectangle || z.Color != colorBullish || z.StartTime != candles[0].Timestamp {
+		t.Fatalf("unexpected zone %+v", z)
+	}
+
+	open := zone(candles, "supply", "Supply", "bearish", 0, 110, 112, 0)
+	if !open.Extend || open.EndTime != candles[len(candles)-1].Timestamp {
+		t.Fatalf("untouched zone should extend to the last bar, got %+v", open)
+	}
+}
+
+func TestMarkerAndLevel(t *testing.T) {
+	candles := overlayCandles(5)
+	tests := []struct {
+		direction, shape, color string
+	}{
+		{"bullish", "arrowUp", colorBullish},
+		{"bearish", "arrowDown", colorBearish},
+		{"", "circle", colorNeutral},
+	}
+	for _, tt := range tests {
+		m := marker(candles, "sweep", "Sweep", tt.direction, 3, 101, 0)
+		if m.Shape != ShapeMarker || m.Marker != tt.shape || m.Color != tt.color || m.Time != candles[3].Timestamp {
+			t.Fatalf("%q marker: got %+v", tt.direction, m)
+		}
+	}
+
+	if l := level(candles, "composite", "POC", "", -1, 100, 0); l.StartTime != 0 || !l.Extend {
+		t.Fatalf("from < 0 should span the chart, got %+v", l)
+	}
+	if l := level(candles, "pool", "BSL", "bearish", 2, 105, 0); l.StartTime != candles[2].Timestamp {
+		t.Fatalf("level should start at bar 2, got %+v", l)
+	}
+}
+
+func TestSetupLevelsSkipsMissingTargets(t *testing.T) {
+	candles := overlayCandles(5)
+	primitives := setupLevels(candles, "setup", "Turtle Soup", "bullish", 100, 98, 104, 0, 108)
+
+	// Marker, entry, stop and the two non-zero targets
+	if len(primitives) != 5 {
+		t.Fatalf("want 5 primitives, got %d", len(primitives))
+	}
+	if primitives[4].Label != "Turtle Soup target 3" || primitives[4].Price != 108 {
+		t.Fatalf("targets keep their position in the label, got %+v", primitives[4])
+	}
+	if primitives[2].Direction != "" || primitives[2].Price != 98 {
+		t.Fatalf("stop line should be neutral at 98, got %+v", primitives[2])
+	}
+}
+
+func TestLimitPerKindKeepsMostRecent(t *testing.T) {
+	primitives := []ChartPrimitive{
+		{Kind: "fvg", Shape: ShapeRectangle, StartTime: 3},
+		{Kind: "sweep", Shape: ShapeMarker, Time: 5},
+		{Kind: "fvg", Shape: ShapeRectangle, StartTime: 1},
+		{Kind: "fvg", Shape: ShapeRectangle, StartTime: 4},
+		{Kind: "sweep", Shape: ShapeMarker, Time: 2},
+	}
+
+	kept := limitPerKind(primitives, 1)
+	if len(kept) != 2 {
+		t.Fatalf("want one primitive per kind, got %d", len(kept))
+	}
+	if kept[0].Kind != "fvg" || kept[0].StartTime != 4 || kept[1].Kind != "sweep" || kept[1].Time != 5 {
+		t.Fatalf("want the latest of each kind in time order, got %+v", kept)
+	}
+	if all := limitPerKind(primitives, 0); len(all) != len(primitives) {
+		t.Fatalf("limit 0 keeps everything")
+	}
+}
+
+func TestAnalyzeNeedsMinimumCandles(t *testing.T) {
+	analyzer, ok := GetChartAnalyzer("market_maker")
+	if !ok {
+		t.Fatal("market_maker analyzer not registered")
+	}
+	result := analyzer.Analyze(overlayCandles(analyzer.MinCandles-1), 10)
+	if result.Detector != "market_maker" || len(result.Primitives) != 0 {
+		t.Fatalf("short input should give an empty analysis, got %+v", result)
+	}
+	if _, ok := GetChartAnalyzer("astrology"); ok {
+		t.Fatal("unknown analyzer should not be found")
+	}
+}
+
+func TestEveryAnalyzerEmitsDrawablePrimitives(t *testing.T) {
+	candles := overlayCandles(300)
+	for _, analyzer := range ListChartAnalyzers() {
+		result := analyzer.Analyze(candles, 5)
+		if result.Detector != analyzer.Name || result.Summary == nil {
+			t.Fatalf("%s: missing detector or summary", analyzer.Name)
+		}
+		counts := map[string]int{}
+		for _, p := range result.Primitives {
+			counts[p.Kind]++
+			if p.Detector != analyzer.Name || p.Kind == "" || p.Color == "" {
+				t.Fatalf("%s: incomplete primitive %+v", analyzer.Name, p)
+			}
+			switch p.Shape {
+			case ShapeRectangle:
+				if p.PriceLow > p.PriceHigh || (p.EndTime != 0 && p.EndTime < p.StartTime) {
+					t.Fatalf("%s: inverted rectangle %+v", analyzer.Name, p)
+				}
+			case ShapeHLine:
+				if p.Price == 0 {
+					t.Fatalf("%s: level without a price %+v", analyzer.Name, p)
+				}
+			case ShapeMarker:
+				if p.Time == 0 {
+					t.Fatalf("%s: marker without a time %+v", analyzer.Name, p)
+				}
+			default:
+				t.Fatalf("%s: unknown shape %q", analyzer.Name, p.Shape)
+			}
+		}
+		for kind, n := range counts {
+			if n > 5 {
+				t.Fatalf("%s: %d primitives of kind %s exceed the limit", analyzer.Name, n, kind)
+			}
+		}
+	}
+}
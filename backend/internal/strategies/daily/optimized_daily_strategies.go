@@ -2,6 +2,8 @@ package daily
 
 import (
 	"math"
+
+	"tradebot/backend/internal/indicators"
 )
 
 // OptimizedDailyStrategy represents a highly optimized strategy for daily trading
@@ -21,8 +23,62 @@ type OptimizedDailyStrategy struct {
 	OptimizedFor       string
 }
 
-// GetOptimizedDailyStrategies returns 10 highly profitable strategies optimized for daily trading
+// GetOptimizedDailyStrategies returns daily-trading settings for every registered strategy.
+// Hand-tuned overrides win; other strategies get settings derived from the registry.
 func GetOptimizedDailyStrategies() map[string]OptimizedDailyStrategy {
+	overrides := optimizedDailyOverrides()
+	result := make(map[string]OptimizedDailyStrategy)
+
+	for _, s := range ListStrategies() {
+		meta := s.Metadata()
+		if override, ok := overrides[meta.Name]; ok {
+			result[meta.Name] = override
+			continue
+		}
+		result[meta.Name] = deriveDailyStrategy(s)
+	}
+
+	return result
+}
+
+// deriveDailyStrategy builds default daily settings from a strategy's registry entry
+func deriveDailyStrategy(s Strategy) OptimizedDailyStrategy {
+	meta := s.Metadata()
+	params := make(map[string]float64)
+	for _, p := range s.ParamSchema() {
+		params[p.Name] = p.Default
+	}
+
+	stopATR := 1.5
+	if v, ok := params["stop_atr"]; ok {
+		stopATR = v
+	}
+	tps := []float64{stopATR * 2, stopATR * 3, stopATR * 5}
+	for i, key := range []string{"tp1_atr", "tp2_atr", "tp3_atr"} {
+		if v, ok := params[key]; ok {
+			tps[i] = v
+		}
+	}
+
+	return OptimizedDailyStrategy{
+		Name:               meta.DisplayName,
+		Description:        meta.Description,
+		Timeframe:          s.DefaultTimeframe(),
+		MinConfluence:      meta.MinConfluence,
+		RiskRewardRatio:    tps[0] / stopATR,
+		MaxDailyTrades:     6,
+		TradingHours:       []int{7, 8, 9, 10, 13, 14, 15, 16, 17},
+		StopLossATR:        stopATR,
+		TakeProfitATR:      tps,
+		RequiredConcepts:   meta.RequiredConcepts,
+		TargetWinRate:      meta.TargetWinRate,
+		TargetProfitFactor: meta.TargetProfitFactor,
+		OptimizedFor:       "Registry defaults",
+	}
+}
+
+// optimizedDailyOverrides holds hand-tuned daily settings for the original 10 strategies
+func optimizedDailyOverrides() map[string]OptimizedDailyStrategy {
 	return map[string]OptimizedDailyStrategy{
 		"session_trader": {
 			Name:            "Session Trader Pro",
@@ -219,8 +275,46 @@ func GetOptimizedDailyStrategies() map[string]OptimizedDailyStrategy {
 	}
 }
 
+// OptimizedDailyParamSchema returns the typed parameters of a daily strategy, with its tuned values as defaults
+func OptimizedDailyParamSchema(strategyName string) ([]ParamSpec, bool) {
+	strategy, exists := GetOptimizedDailyStrategies()[strategyName]
+	if !exists {
+		return nil, false
+	}
+
+	return []ParamSpec{
+		{Name: "stop_atr", Type: "float", Default: strategy.StopLossATR, Min: 0.25, Max: 6.0, Step: 0.25, Description: "Stop loss distance in ATR"},
+		{Name: "tp1_atr", Type: "float", Default: strategy.TakeProfitATR[0], Min: 0.5, Max: 10.0, Step: 0.25, Description: "Take profit 1 distance in ATR"},
+		{Name: "tp2_atr", Type: "float", Default: strategy.TakeProfitATR[1], Min: 0.5, Max: 15.0, Step: 0.25, Description: "Take profit 2 distance in ATR"},
+		{Name: "tp3_atr", Type: "float", Default: strategy.TakeProfitATR[2], Min: 1.0, Max: 25.0, Step: 0.5, Description: "Take profit 3 distance in ATR"},
+		{Name: "max_daily_trades", Type: "int", Default: float64(strategy.MaxDailyTrades), Min: 1, Max: 30, Step: 1, Description: "Maximum trades per day"},
+	}, true
+}
+
+// ApplyDailyParams returns a copy of the strategy with resolved parameter values applied
+func ApplyDailyParams(strategy OptimizedDailyStrategy, params StrategyParams) OptimizedDailyStrategy {
+	if len(params) == 0 {
+		return strategy
+	}
+
+	configured := strategy
+	configured.StopLossATR = params.Get("stop_atr", strategy.StopLossATR)
+	configured.TakeProfitATR = []float64{
+		params.Get("tp1_atr", strategy.TakeProfitATR[0]),
+		params.Get("tp2_atr", strategy.TakeProfitATR[1]),
+		params.Get("tp3_atr", strategy.TakeProfitATR[2]),
+	}
+	configured.MaxDailyTrades = int(params.Get("max_daily_trades", float64(strategy.MaxDailyTrades)))
+	return configured
+}
+
 // GenerateOptimizedSignal generates a signal using optimized daily strategy
 func GenerateOptimizedSignal(candles []Candle, strategyName string) *Signal {
+	return GenerateOptimizedSignalWithParams(candles, strategyName, nil)
+}
+
+// GenerateOptimizedSignalWithParams generates a signal with resolved parameter overrides applied
+func GenerateOptimizedSignalWithParams(candles []Candle, strategyName string, params StrategyParams) *Signal {
 	if len(candles) < 100 {
 		return nil
 	}
@@ -230,6 +324,7 @@ func GenerateOptimizedSignal(candles []Candle, strategyName string) *Signal {
 	if !exists {
 		return nil
 	}
+	strategy = ApplyDailyParams(strategy, params)
 
 	idx := len(candles) - 1
 	currentCandle := candles[idx]
@@ -240,10 +335,10 @@ func GenerateOptimizedSignal(candles []Candle, strategyName string) *Signal {
 	}
 
 	// Calculate indicators
-	ema20 := calculateEMA(candles[:idx+1], 20)
-	ema50 := calculateEMA(candles[:idx+1], 50)
-	atr := calculateATR(candles[:idx+1], 14)
-	rsi := calculateRSI(candles[:idx+1], 14)
+	ema20 := indicators.EMA(candles[:idx+1], 20)
+	ema50 := indicators.EMA(candles[:idx+1], 50)
+	atr := indicators.ATR(candles[:idx+1], 14)
+	rsi := indicators.RSI(candles[:idx+1], 14)
 
 	if atr == 0 {
 		return nil
@@ -394,7 +489,7 @@ func calculateVolatilityLevel(candles []Candle) float64 {
 		return 0.01
 	}
 
-	atr := calculateATR(candles, 14)
+	atr := indicators.ATR(candles, 14)
 	avgPrice := 0.0
 	for i := len(candles) - 20; i < len(candles); i++ {
 		avgPrice += candles[i].Close
@@ -410,8 +505,8 @@ func detectTrendStrength(candles []Candle) float64 {
 		return 0
 	}
 
-	ema20 := calculateEMA(candles, 20)
-	ema50 := calculateEMA(candles, 50)
+	ema20 := indicators.EMA(candles, 20)
+	ema50 := indicators.EMA(candles, 50)
 
 	return (ema20 - ema50) / ema50
 }
@@ -5,6 +5,9 @@ import (
 	"log"
 	"math"
 	"time"
+
+	"tradebot/backend/internal/indicators"
+	"tradebot/backend/internal/regime"
 )
 
 // ==================== ULTIMATE DAILY TRADING STRATEGY ====================
@@ -28,6 +31,10 @@ type UltimateDailyStrategy struct {
 	// Strategy rotation
 	CurrentStrategy   string
 	StrategyRotation  []string
+	
+	// Statistical regime per timeframe, kept across scans so each scan only
+	// feeds the new bars
+	regimes           regime.Detectors
 }
 
 // DailySetup represents a complete trading setup
@@ -132,7 +139,7 @@ func (uds *UltimateDailyStrategy) GenerateUltimateSetup(timeframe string) *Daily
 	}
 	
 	// Volatility filter
-	setup.Volatility = AnalyzeVolatility(candles)
+	setup.Volatility = AnalyzeVolatilityWith(candles, uds.regimes.For(timeframe))
 	if setup.Volatility == nil || !ShouldTradeVolatility(setup.Volatility) {
 		setup.ValidationMsg = "Volatility not optimal"
 		return setup
@@ -457,7 +464,7 @@ func (uds *UltimateDailyStrategy) GenerateUltimateSetup(timeframe string) *Daily
 	
 	// ==================== PHASE 5: ENTRY CALCULATION ====================
 	
-	atr := calculateATR(candles[len(candles)-14:], 14)
+	atr := indicators.ATR(candles[len(candles)-14:], 14)
 	setup.Entry = currentPrice
 	
 	if setup.Direction == "bullish" {
@@ -0,0 +1,208 @@
+package strategies
+
+// Evidence kinds
+const (
+	EvidenceOrderBlock       = "order_block"
+	EvidenceFVG              = "fvg"
+	EvidenceLiquiditySweep   = "liquidity_sweep"
+	EvidenceBreakOfStructure = "break_of_structure"
+	EvidenceVolumeSpike      = "volume_spike"
+	EvidenceLevel            = "level"   // Support, resistance or moving average a signal reacted to
+	EvidencePattern          = "pattern" // Candlestick or pullback pattern
+)
+
+// Evidence is one machine-readable reason behind a signal: a zone on the
+// chart, the timeframe it was found on and how much it counted
+type Evidence struct {
+	Kind      string  `json:"kind"`
+	Label     string  `json:"label"`
+	Direction string  `json:"direction,omitempty"` // "bullish" or "bearish"
+	PriceLow  float64 `json:"priceLow"`
+	PriceHigh float64 `json:"priceHigh"`
+	Timeframe string  `json:"timeframe"`
+	StartTime int64   `json:"startTime"` // First bar of the zone (ms)
+	EndTime   int64   `json:"endTime"`   // Bar that confirmed it (ms)
+	Weight    float64 `json:"weight"`    // Share of the signal's confluence; a signal's weights sum to 1
+}
+
+// newEvidence builds an evidence item spanning bars from..to, ordering the price bounds
+func newEvidence(kind, label, direction string, a, b float64, candles []Candle, from, to int) Evidence {
+	if a > b {
+		a, b = b, a
+	}
+	return Evidence{
+		Kind:      kind,
+		Label:     label,
+		Direction: direction,
+		PriceLow:  a,
+		PriceHigh: b,
+		StartTime: candles[from].Timestamp,
+		EndTime:   candles[to].Timestamp,
+		Weight:    1,
+	}
+}
+
+// weighEvidence fills in the timeframe of items that have none and scales
+// the weights so they sum to 1
+func weighEvidence(items []Evidence, timeframe string) []Evidence {
+	total := 0.0
+	for _, e := range items {
+		total += e.Weight
+	}
+	for i := range items {
+		if items[i].Timeframe == "" {
+			items[i].Timeframe = timeframe
+		}
+		if total > 0 {
+			items[i].Weight /= total
+		}
+	}
+	return items
+}
+
+// conceptLocators maps concepts that mark a zone on the chart to a function
+// returning that zone. Concepts without a locator only contribute reasons.
+var conceptLocators = map[string]func(candles []Candle, idx int) (Evidence, bool){
+	"Liquidity Sweep":             locateLiquiditySweep,
+	"Liquidity Grab":              locateLiquiditySweep,
+	"Liquidity Void":              locateLiquiditySweep,
+	"Order Block":                 locateOrderBlock,
+	"Order Block (Institutional)": locateOrderBlock,
+	"Micro Order Block":           locateOrderBlock,
+	"Order Block at Extreme":      locateOrderBlock,
+	"Order Block Support":         locateOrderBlock,
+	"Order Block at Boundary":     locateOrderBlock,
+	"Fair Value Gap":              locateFVG,
+	"Immediate FVG":               locateFVG,
+	"Break of Structure":          locateBreakOfStructure,
+	"Market Structure Shift":      locateBreakOfStructure,
+	"Volume Spike":                func(c []Candle, i int) (Evidence, bool) { return locateVolumeSpike(c, i, 2.0) },
+	"Volume Explosion (2x+)":      func(c []Candle, i int) (Evidence, bool) { return locateVolumeSpike(c, i, 2.0) },
+	"Large Volume Spike":          func(c []Candle, i int) (Evidence, bool) { return locateVolumeSpike(c, i, 2.0) },
+}
+
+// locateConcept returns the zone behind a concept at idx, if the concept marks one
+func locateConcept(candles []Candle, idx int, concept string) (Evidence, bool) {
+	locator, ok := conceptLocators[concept]
+	if !ok || idx < 20 {
+		return Evidence{}, false
+	}
+	return locator(candles, idx)
+}
+
+// locateOrderBlock returns the last opposing candle before a reversal candle
+func locateOrderBlock(candles []Candle, index int) (Evidence, bool) {
+	if index < 5 {
+		return Evidence{}, false
+	}
+
+	prev := candles[index-1]
+	curr := candles[index]
+
+	// Bullish order block: bearish candle followed by a bullish candle of comparable body
+	if prev.Close < prev.Open && curr.Close > curr.Open && curr.Close-curr.Open > (prev.Open-prev.Close)*0.8 {
+		return newEvidence(EvidenceOrderBlock, "Bullish order block", "bullish", prev.Low, prev.High, candles, index-1, index), true
+	}
+
+	// Bearish order block: bullish candle followed by a bearish candle of comparable body
+	if prev.Close > prev.Open && curr.Close < curr.Open && curr.Open-curr.Close > (prev.Close-prev.Open)*0.8 {
+		return newEvidence(EvidenceOrderBlock, "Bearish order block", "bearish", prev.Low, prev.High, candles, index-1, index), true
+	}
+
+	return Evidence{}, false
+}
+
+// locateFVG returns the gap (or near-gap, 0.3% tolerance) between candles index-2 and index
+func locateFVG(candles []Candle, index int) (Evidence, bool) {
+	if index < 2 {
+		return Evidence{}, false
+	}
+
+	tolerance := candles[index].Close * 0.003
+
+	if candles[index].Low >= candles[index-2].High-tolerance {
+		return newEvidence(EvidenceFVG, "Bullish fair value gap", "bullish", candles[index-2].High, candles[index].Low, candles, index-2, index), true
+	}
+	if candles[index].High <= candles[index-2].Low+tolerance {
+		return newEvidence(EvidenceFVG, "Bearish fair value gap", "bearish", candles[index].High, candles[index-2].Low, candles, index-2, index), true
+	}
+
+	return Evidence{}, false
+}
+
+// locateLiquiditySweep returns the 10-bar extreme the current candle swept,
+// spanning from the bar that set it to the sweep
+func locateLiquiditySweep(candles []Candle, index int) (Evidence, bool) {
+	if index < 10 {
+		return Evidence{}, false
+	}
+
+	highIdx, lowIdx := recentExtremes(candles, index, 10)
+	recentHigh := candles[highIdx].High
+	recentLow := candles[lowIdx].Low
+
+	curr := candles[index]
+	tolerance := curr.Close * 0.002 // 0.2% tolerance
+
+	// Bullish sweep: wick near or below the recent low, close back above it
+	if curr.Low <= recentLow+tolerance && curr.Close > recentLow-tolerance {
+		return newEvidence(EvidenceLiquiditySweep, "Sell-side liquidity sweep", "bullish", curr.Low, recentLow, candles, lowIdx, index), true
+	}
+
+	// Bearish sweep: wick near or above the recent high, close back below it
+	if curr.High >= recentHigh-tolerance && curr.Close < recentHigh+tolerance {
+		return newEvidence(EvidenceLiquiditySweep, "Buy-side liquidity sweep", "bearish", recentHigh, curr.High, candles, highIdx, index), true
+	}
+
+	return Evidence{}, false
+}
+
+// locateBreakOfStructure returns the 20-bar extreme the close reached (0.5% tolerance)
+func locateBreakOfStructure(candles []Candle, index int) (Evidence, bool) {
+	if index < 20 {
+		return Evidence{}, false
+	}
+
+	highIdx, lowIdx := recentExtremes(candles, index, 20)
+	recentHigh := candles[highIdx].High
+	recentLow := candles[lowIdx].Low
+
+	curr := candles[index]
+	tolerance := curr.Close * 0.005
+
+	if curr.Close >= recentHigh-tolerance {
+		return newEvidence(EvidenceBreakOfStructure, "Break above structure high", "bullish", recentHigh, curr.Close, candles, highIdx, index), true
+	}
+	if curr.Close <= recentLow+tolerance {
+		return newEvidence(EvidenceBreakOfStructure, "Break below structure low", "bearish", curr.Close, recentLow, candles, lowIdx, index), true
+	}
+
+	return Evidence{}, false
+}
+
+// locateVolumeSpike returns the range of a bar whose volume passes hasVolumeSpike
+func locateVolumeSpike(candles []Candle, idx int, multiplier float64) (Evidence, bool) {
+	if !hasVolumeSpike(candles, idx, multiplier) {
+		return Evidence{}, false
+	}
+	direction := "bullish"
+	if candles[idx].Close < candles[idx].Open {
+		direction = "bearish"
+	}
+	return newEvidence(EvidenceVolumeSpike, "Volume spike", direction, candles[idx].Low, candles[idx].High, candles, idx, idx), true
+}
+
+// recentExtremes returns the indexes of the highest high and lowest low of
+// the lookback bars before index
+func recentExtremes(candles []Candle, index, lookback int) (int, int) {
+	highIdx, lowIdx := index-lookback, index-lookback
+	for i := index - lookback + 1; i < index; i++ {
+		if candles[i].High > candles[highIdx].High {
+			highIdx = i
+		}
+		if candles[i].Low < candles[lowIdx].Low {
+			lowIdx = i
+		}
+	}
+	return highIdx, lowIdx
+}
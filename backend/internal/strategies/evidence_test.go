@@ -0,0 +1,52 @@
+package strategies
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWeighEvidence(t *testing.T) {
+	tests := []struct {
+		name       string
+		items      []Evidence
+		timeframe  string
+		weights    []float64
+		timeframes []string
+	}{
+		{"nothing to weigh", nil, "1h", nil, nil},
+		{"equal items share the signal",
+			[]Evidence{{Weight: 1}, {Weight: 1}, {Weight: 1}, {Weight: 1}}, "1h",
+			[]float64{0.25, 0.25, 0.25, 0.25}, []string{"1h", "1h", "1h", "1h"}},
+		{"weights keep their ratio",
+			[]Evidence{{Weight: 1}, {Weight: 3}}, "15m",
+			[]float64{0.25, 0.75}, []string{"15m", "15m"}},
+		{"items found on another timeframe keep it",
+			[]Evidence{{Weight: 2, Timeframe: "4h"}, {Weight: 2}}, "15m",
+			[]float64{0.5, 0.5}, []string{"4h", "15m"}},
+		{"untagged items stay untagged",
+			[]Evidence{{Weight: 1}}, "",
+			[]float64{1}, []string{""}},
+		{"zero weights are left alone",
+			[]Evidence{{Weight: 0}, {Weight: 0}}, "1h",
+			[]float64{0, 0}, []string{"1h", "1h"}},
+	}
+	for _, tt := range tests {
+		items := weighEvidence(tt.items, tt.timeframe)
+		if len(items) != len(tt.weights) {
+			t.Fatalf("%s: %d items, want %d", tt.name, len(items), len(tt.weights))
+		}
+		for i, e := range items {
+			if math.Abs(e.Weight-tt.weights[i]) > 1e-9 || e.Timeframe != tt.timeframes[i] {
+				t.Errorf("%s: item %d weight %v on %q, want %v on %q", tt.name, i, e.Weight, e.Timeframe, tt.weights[i], tt.timeframes[i])
+			}
+		}
+	}
+}
+
+func TestNewEvidenceOrdersBounds(t *testing.T) {
+	candles := []Candle{{Timestamp: 1000}, {Timestamp: 2000}, {Timestamp: 3000}}
+	e := newEvidence(EvidenceFVG, "Bearish fair value gap", "bearish", 105, 101, candles, 0, 2)
+	if e.PriceLow != 101 || e.PriceHigh != 105 || e.StartTime != 1000 || e.EndTime != 3000 || e.Weight != 1 || e.Timeframe != "" {
+		t.Errorf("got %+v", e)
+	}
+}
@@ -2,6 +2,8 @@ package ict
 
 import (
 	"math"
+
+	"tradebot/backend/internal/indicators"
 )
 
 // ==================== ICT ENTRY MODELS ====================
@@ -29,7 +31,7 @@ func OrderBlockEntry(candles []Candle, ict *ICTAnalysis) *EntryModel {
 	}
 	
 	currentPrice := candles[len(candles)-1].Close
-	atr := calculateATR(candles[len(candles)-14:], 14)
+	atr := indicators.ATR(candles[len(candles)-14:], 14)
 	
 	// Look for unmitigated order blocks
 	for _, ob := range ict.OrderBlocks {
@@ -140,7 +142,7 @@ func FairValueGapEntry(candles []Candle, ict *ICTAnalysis) *EntryModel {
 	}
 	
 	currentPrice := candles[len(candles)-1].Close
-	atr := calculateATR(candles[len(candles)-14:], 14)
+	atr := indicators.ATR(candles[len(candles)-14:], 14)
 	
 	for _, fvg := range ict.FairValueGaps {
 		if fvg.Filled {
@@ -238,7 +240,7 @@ func LiquiditySweepEntry(candles []Candle, ict *ICTAnalysis) *EntryModel {
 	
 	currentPrice := candles[len(candles)-1].Close
 	prevCandle := candles[len(candles)-2]
-	atr := calculateATR(candles[len(candles)-14:], 14)
+	atr := indicators.ATR(candles[len(candles)-14:], 14)
 	
 	for _, liq := range ict.Liquidity {
 		// SELL-SIDE LIQUIDITY SWEEP (bullish entry)
@@ -331,7 +333,7 @@ func StructureBreakEntry(candles []Candle, ict *ICTAnalysis) *EntryModel {
 	}
 	
 	currentPrice := candles[len(candles)-1].Close
-	atr := calculateATR(candles[len(candles)-14:], 14)
+	atr := indicators.ATR(candles[len(candles)-14:], 14)
 	
 	// BOS ENTRY (continuation)
 	if ict.Structure.BOS {
@@ -458,7 +460,7 @@ func OTEEntry(candles []Candle, ict *ICTAnalysis) *EntryModel {
 	}
 	
 	currentPrice := candles[len(candles)-1].Close
-	atr := calculateATR(candles[len(candles)-14:], 14)
+	atr := indicators.ATR(candles[len(candles)-14:], 14)
 	
 	// Must have trend direction
 	if ict.Structure.Trend == "bullish" {
@@ -5,6 +5,8 @@ import (
 	"log"
 	"math"
 	"time"
+
+	"tradebot/backend/internal/indicators"
 )
 
 // ==================== LIQUIDITY-FIRST UNIFIED STRATEGY ====================
@@ -922,7 +924,7 @@ func (lfs *LiquidityFirstStrategy) GenerateUnifiedSetup(timeframe string) *Unifi
 	
 	// ==================== ENTRY CALCULATION ====================
 	
-	atr := calculateATR(candles[len(candles)-14:], 14)
+	atr := indicators.ATR(candles[len(candles)-14:], 14)
 	setup.Entry = currentPrice
 	
 	if setup.Direction == "bullish" {
@@ -3,6 +3,8 @@ package institutional
 import (
 	"math"
 	"time"
+
+	"tradebot/backend/internal/indicators"
 )
 
 // ==================== INSTITUTIONAL TRADING SETUPS ====================
@@ -78,7 +80,7 @@ func DetectSilverBullet(candles []Candle, currentTime time.Time) *SilverBullet {
 	}
 	
 	currentPrice := candles[len(candles)-1].Close
-	atr := calculateATR(candles[len(candles)-14:], 14)
+	atr := indicators.ATR(candles[len(candles)-14:], 14)
 	
 	sb := &SilverBullet{
 		TimeWindow: timeWindow,
@@ -161,7 +163,7 @@ func DetectUnicornSetup(candles []Candle) *UnicornSetup {
 	inOTE := IsInOTE(candles)
 	
 	_ = candles[len(candles)-1].Close // currentPrice
-	atr := calculateATR(candles[len(candles)-14:], 14)
+	atr := indicators.ATR(candles[len(candles)-14:], 14)
 	
 	// Find recent breaker (use last one if available)
 	var recentBreaker *BreakerBlock
@@ -274,7 +276,7 @@ func DetectTurtleSoup(candles []Candle, lookback int) *TurtleSoup {
 	// Check recent candles for breakout and failure
 	recentCandles := candles[len(candles)-5:]
 	_ = candles[len(candles)-1].Close // currentPrice
-	atr := calculateATR(candles[len(candles)-14:], 14)
+	atr := indicators.ATR(candles[len(candles)-14:], 14)
 	
 	ts := &TurtleSoup{
 		Valid: false,
@@ -371,7 +373,7 @@ func DetectQuasimodo(candles []Candle) *QuasimodoSetup {
 		return nil
 	}
 	
-	atr := calculateATR(candles[len(candles)-14:], 14)
+	atr := indicators.ATR(candles[len(candles)-14:], 14)
 	
 	// Bullish QM: Higher Low after Lower Low
 	// Pattern: High → Low → Higher High → Lower Low → Entry
@@ -2,6 +2,8 @@ package institutional
 
 import (
 	"math"
+
+	"tradebot/backend/internal/indicators"
 )
 
 // ==================== MARKET MAKER MODEL ====================
@@ -321,7 +323,7 @@ func DetectManipulationPhase(candles []Candle) *ManipulationPhase {
 	position := (currentPrice - low) / rangeSize
 	
 	// Calculate volatility (ATR)
-	atr := calculateATR(recentCandles, 14)
+	atr := indicators.ATR(recentCandles, 14)
 	avgATR := atr / currentPrice * 100
 	
 	// Calculate volume trend
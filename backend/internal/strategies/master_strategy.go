@@ -1,5 +1,7 @@
 package strategies
 
+import "tradebot/backend/internal/indicators"
+
 // MASTER STRATEGY - Combines ALL concepts for maximum profitability
 // Uses: ICT, Liquidity, Order Flow, Supply/Demand, Power of 3, Market Maker Model
 
@@ -9,11 +11,11 @@ func generateMasterSignal(data []Candle, interval string) *Signal {
 	}
 
 	// PHASE 1: BASIC INDICATORS
-	ema9 := calculateEMA(data, 9)
-	ema20 := calculateEMA(data, 20)
-	ema50 := calculateEMA(data, 50)
-	rsi := calculateRSI(data, 14)
-	atr := calculateATR(data[len(data)-14:], 14)
+	ema9 := indicators.EMA(data, 9)
+	ema20 := indicators.EMA(data, 20)
+	ema50 := indicators.EMA(data, 50)
+	rsi := indicators.RSI(data, 14)
+	atr := indicators.ATR(data[len(data)-14:], 14)
 	
 	if atr == 0 {
 		return nil
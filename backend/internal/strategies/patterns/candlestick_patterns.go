@@ -2,6 +2,8 @@ package patterns
 
 import (
 	"math"
+
+	"tradebot/backend/internal/indicators"
 )
 
 // ==================== CANDLESTICK PATTERNS ====================
@@ -57,8 +59,8 @@ func RecognizeSingleCandlePatterns(candles []Candle, timeframe string) []Candles
 	}
 
 	// Determine trend context
-	trendUp := last.Close > calculateSMA(candles, 10)
-	trendDown := last.Close < calculateSMA(candles, 10)
+	trendUp := last.Close > indicators.SMA(candles, 10)
+	trendDown := last.Close < indicators.SMA(candles, 10)
 
 	// DOJI - Indecision
 	if body/totalRange < 0.1 {
@@ -192,7 +194,7 @@ func RecognizeTwoCandlePatterns(candles []Candle, timeframe string) []Candlestic
 	prevRange := prev.High - prev.Low
 
 	// Trend context
-	sma := calculateSMA(candles, 10)
+	sma := indicators.SMA(candles, 10)
 	trendUp := last.Close > sma
 	trendDown := last.Close < sma
 
@@ -539,62 +541,6 @@ func RecognizeMultiCandlePatterns(candles []Candle, timeframe string) []Candlest
 
 // ==================== HELPER FUNCTIONS ====================
 
-// calculateSMA calculates Simple Moving Average
-func calculateSMA(candles []Candle, period int) float64 {
-	if len(candles) < period {
-		period = len(candles)
-	}
-	sum := 0.0
-	for i := len(candles) - period; i < len(candles); i++ {
-		sum += candles[i].Close
-	}
-	return sum / float64(period)
-}
-
-// calculateEMA calculates Exponential Moving Average
-func calculateEMA(candles []Candle, period int) float64 {
-	if len(candles) < period {
-		return calculateSMA(candles, period)
-	}
-
-	multiplier := 2.0 / float64(period+1)
-	ema := calculateSMA(candles[:period], period)
-
-	for i := period; i < len(candles); i++ {
-		ema = (candles[i].Close-ema)*multiplier + ema
-	}
-	return ema
-}
-
-// calculateRSI calculates Relative Strength Index
-func calculateRSI(candles []Candle, period int) float64 {
-	if len(candles) < period+1 {
-		return 50
-	}
-
-	gains := 0.0
-	losses := 0.0
-
-	for i := len(candles) - period; i < len(candles); i++ {
-		change := candles[i].Close - candles[i-1].Close
-		if change > 0 {
-			gains += change
-		} else {
-			losses -= change
-		}
-	}
-
-	avgGain := gains / float64(period)
-	avgLoss := losses / float64(period)
-
-	if avgLoss == 0 {
-		return 100
-	}
-
-	rs := avgGain / avgLoss
-	return 100 - (100 / (1 + rs))
-}
-
 // calculateMomentumScore calculates momentum strength 0-100
 func calculateMomentumScore(candles []Candle) float64 {
 	if len(candles) < 14 {
@@ -602,14 +548,14 @@ func calculateMomentumScore(candles []Candle) float64 {
 	}
 
 	// RSI component
-	rsi := calculateRSI(candles, 14)
+	rsi := indicators.RSI(candles, 14)
 
 	// Price change component
 	priceChange := (candles[len(candles)-1].Close - candles[len(candles)-10].Close) / candles[len(candles)-10].Close * 100
 
 	// EMA alignment
-	ema9 := calculateEMA(candles, 9)
-	ema21 := calculateEMA(candles, 21)
+	ema9 := indicators.EMA(candles, 9)
+	ema21 := indicators.EMA(candles, 21)
 	emaScore := 50.0
 	if ema9 > ema21 {
 		emaScore = 70
@@ -855,7 +801,7 @@ func GeneratePatternPredictionSignal(candles []Candle, interval string) *Signal
 
 	// Get current price and ATR
 	currentPrice := candles[len(candles)-1].Close
-	atr := calculateATR(candles[len(candles)-14:], 14)
+	atr := indicators.ATR(candles[len(candles)-14:], 14)
 	if atr == 0 {
 		return nil
 	}
@@ -5,6 +5,10 @@ import (
 	"log"
 	"math"
 	"time"
+
+	"tradebot/backend/internal/indicators"
+	"tradebot/backend/internal/patternstats"
+	"tradebot/backend/internal/regime"
 )
 
 // ==================== PROFESSIONAL INSTITUTIONAL STRATEGY ====================
@@ -24,6 +28,10 @@ type ProfessionalSignalGenerator struct {
 	
 	// Strategy weights
 	Weights           StrategyWeights
+	
+	// Statistical regime per timeframe, kept across scans so each scan only
+	// feeds the new bars
+	regimes           regime.Detectors
 }
 
 // StrategyWeights holds weights for each analysis component
@@ -132,7 +140,7 @@ func (psg *ProfessionalSignalGenerator) PerformComprehensiveAnalysis(candles []C
 	}
 	
 	// Volatility Filter
-	analysis.Volatility = AnalyzeVolatility(candles)
+	analysis.Volatility = AnalyzeVolatilityWith(candles, psg.regimes.For(timeframe))
 	if !ShouldTradeVolatility(analysis.Volatility) {
 		log.Printf("⏸️  [%s] Volatility not optimal: %s", timeframe, analysis.Volatility.Regime)
 		return nil
@@ -179,6 +187,10 @@ func (psg *ProfessionalSignalGenerator) PerformComprehensiveAnalysis(candles []C
 	
 	// 11. Candlestick Patterns
 	analysis.Patterns = RecognizeAllPatterns(candles, timeframe)
+	// Reliability measured on history in the current regime (the detector
+	// was synced by the volatility filter) replaces the hand-set one
+	regimeName := string(psg.regimes.For(timeframe).State().Regime)
+	patternstats.GetStore().CalibratePatterns(psg.Symbol, timeframe, regimeName, analysis.Patterns)
 	
 	// ==================== PHASE 3: SCORING ====================
 	
@@ -429,7 +441,7 @@ func (psg *ProfessionalSignalGenerator) scoreAllComponents(analysis *Comprehensi
 	bullishPatterns := 0
 	bearishPatterns := 0
 	for _, p := range analysis.Patterns {
-		if p.Strength >= 75 {
+		if p.Strength >= 75 && patternstats.Reliable(p) {
 			if p.Type == "bullish" {
 				bullishPatterns++
 			} else {
@@ -461,7 +473,7 @@ func (psg *ProfessionalSignalGenerator) countConfluence(analysis *ComprehensiveA
 // calculateEntryLevels calculates entry, stop loss, and targets
 func (psg *ProfessionalSignalGenerator) calculateEntryLevels(analysis *ComprehensiveAnalysis, candles []Candle) {
 	currentPrice := candles[len(candles)-1].Close
-	atr := calculateATR(candles[len(candles)-14:], 14)
+	atr := indicators.ATR(candles[len(candles)-14:], 14)
 	
 	analysis.Entry = currentPrice
 	
@@ -725,3 +737,65 @@ func (psg *ProfessionalSignalGenerator) ScanMarkets() {
 		}
 	}
 }
+
+func init() {
+	RegisterParamSchema("professional", ProfessionalParamSchema)
+}
+
+// ProfessionalParamSchema describes the tunable thresholds and factor weights
+func ProfessionalParamSchema() []ParamSpec {
+	weight := func(name string, def float64, desc string) ParamSpec {
+		return ParamSpec{Name: name, Type: "float", Default: def, Min: 0, Max: 50, Step: 5, Description: desc}
+	}
+	return []ParamSpec{
+		{Name: "min_confidence", Type: "float", Default: 80, Min: 50, Max: 100, Step: 5, Description: "Minimum confidence to emit a signal"},
+		{Name: "min_confluence", Type: "int", Default: 5, Min: 1, Max: 12, Step: 1, Description: "Minimum agreeing factors"},
+		{Name: "max_signals_per_day", Type: "int", Default: 3, Min: 1, Max: 20, Step: 1, Description: "Daily signal cap"},
+		weight("po3", 15, "Power of 3 weight"),
+		weight("liquidity_sweep", 15, "Liquidity sweep weight"),
+		weight("mirror", 10, "Mirror market weight"),
+		weight("institutional", 20, "Institutional footprint weight"),
+		weight("mmm", 15, "Market maker model weight"),
+		weight("supply_demand", 10, "Supply/demand zone weight"),
+		weight("session_liq", 5, "Session liquidity weight"),
+		weight("volatility", 5, "Volatility regime weight"),
+		weight("ict", 10, "ICT concepts weight"),
+		weight("order_flow", 10, "Order flow weight"),
+		weight("mtf", 10, "Multi-timeframe alignment weight"),
+		weight("patterns", 5, "Chart pattern weight"),
+	}
+}
+
+// ConfigureProfessionalSignalGenerator creates the professional generator with
+// overrides validated against ProfessionalParamSchema, e.g. a promoted
+// "professional" live parameter set
+func ConfigureProfessionalSignalGenerator(overrides map[string]float64) (*ProfessionalSignalGenerator, error) {
+	params, err := ResolveParams("professional", ProfessionalParamSchema(), overrides)
+	if err != nil {
+		return nil, err
+	}
+	psg := NewProfessionalSignalGenerator()
+	psg.ApplyParams(params)
+	return psg, nil
+}
+
+// ApplyParams overrides thresholds and weights from resolved parameters
+func (psg *ProfessionalSignalGenerator) ApplyParams(params StrategyParams) {
+	psg.MinConfidence = params.Get("min_confidence", psg.MinConfidence)
+	psg.MinConfluence = int(params.Get("min_confluence", float64(psg.MinConfluence)))
+	psg.MaxSignalsPerDay = int(params.Get("max_signals_per_day", float64(psg.MaxSignalsPerDay)))
+
+	w := &psg.Weights
+	w.PO3 = params.Get("po3", w.PO3)
+	w.LiquiditySweep = params.Get("liquidity_sweep", w.LiquiditySweep)
+	w.Mirror = params.Get("mirror", w.Mirror)
+	w.Institutional = params.Get("institutional", w.Institutional)
+	w.MMM = params.Get("mmm", w.MMM)
+	w.SupplyDemand = params.Get("supply_demand", w.SupplyDemand)
+	w.SessionLiq = params.Get("session_liq", w.SessionLiq)
+	w.Volatility = params.Get("volatility", w.Volatility)
+	w.ICT = params.Get("ict", w.ICT)
+	w.OrderFlow = params.Get("order_flow", w.OrderFlow)
+	w.MTF = params.Get("mtf", w.MTF)
+	w.Patterns = params.Get("patterns", w.Patterns)
+}
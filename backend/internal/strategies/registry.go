@@ -0,0 +1,287 @@
+package strategies
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"tradebot/backend/internal/indicators"
+	"tradebot/backend/internal/liquidity"
+	"tradebot/backend/internal/profile"
+	"tradebot/backend/internal/regime"
+)
+
+// StrategyMetadata describes a registered strategy for listings and UIs
+type StrategyMetadata struct {
+	Name               string   `json:"name"`        // Registry key, e.g. "session_trader"
+	DisplayName        string   `json:"displayName"` // Human readable name
+	Description        string   `json:"description"`
+	Category           string   `json:"category"` // e.g. "ict", "trend", "breakout", "meta"
+	MinConfluence      int      `json:"minConfluence"`
+	RequiredConcepts   []string `json:"requiredConcepts"`
+	TargetWinRate      float64  `json:"targetWinRate"`
+	TargetProfitFactor float64  `json:"targetProfitFactor"`
+}
+
+// ParamSpec describes one tunable strategy parameter
+type ParamSpec struct {
+	Name        string  `json:"name"`
+	Type        string  `json:"type"` // "float", "int" or "bool"
+	Default     float64 `json:"default"`
+	Min         float64 `json:"min"`
+	Max         float64 `json:"max"`
+	Step        float64 `json:"step"`
+	Description string  `json:"description"`
+}
+
+// Strategy is the plugin interface every signal strategy implements.
+// Engines, optimizers and live signal paths look strategies up in the
+// registry instead of switching on names.
+type Strategy interface {
+	Metadata() StrategyMetadata
+	DefaultTimeframe() string
+	ParamSchema() []ParamSpec
+	WarmupBars() int
+	Evaluate(candles []Candle, idx int) *AdvancedSignal
+}
+
+// StreamingStrategy is implemented by strategies that read indicators and the
+// market regime from a caller-owned EvalContext. Engines keep one context per
+// strategy across bars so both update in O(1) instead of being recomputed over
+// the whole window.
+type StreamingStrategy interface {
+	Strategy
+	EvaluateWith(ctx *EvalContext, candles []Candle, idx int) *AdvancedSignal
+}
+
+// EvalContext is the per-run state a streaming strategy evaluates with
+type EvalContext struct {
+	Indicators *indicators.Tracker
+
+	// LastSignal is the candle timestamp of the last signal evaluated with
+	// this context (0 = none), for strategies that enforce a cooldown
+	LastSignal int64
+
+	regime    *regime.Detector
+	liquidity *liquidity.Book
+	profile   *profile.Tracker
+	frames    map[string]*EvalContext
+	htf       *htfBars // A frame context's resampled bars
+	window    []Candle
+}
+
+// profileDays is how many UTC days of bars EvalContext.Profile covers
+const profileDays = 10
+
+// NewEvalContext creates an empty context
+func NewEvalContext() *EvalContext {
+	return &EvalContext{Indicators: indicators.NewTracker()}
+}
+
+// Sync advances the context to the last candle of the window
+func (ctx *EvalContext) Sync(candles []Candle) {
+	ctx.window = candles
+	ctx.Indicators.Sync(candles)
+}
+
+// Regime returns the market regime at the last synced candle. The detector is
+// created on first use, so strategies that never ask do not pay for it.
+func (ctx *EvalContext) Regime() regime.State {
+	if ctx.regime == nil {
+		ctx.regime = regime.NewDetector(regime.DefaultConfig())
+	}
+	ctx.regime.Sync(ctx.window)
+	return ctx.regime.State()
+}
+
+// Liquidity returns the run's liquidity ledger advanced to the last synced
+// candle: every session, day, week and swing level seen so far and whether
+// it was swept. It is created on first use and rebuilt when the window no
+// longer continues the bars it was fed.
+func (ctx *EvalContext) Liquidity() *liquidity.Book {
+	if ctx.liquidity == nil || !ctx.liquidity.Continues(ctx.window) {
+		ctx.liquidity = liquidity.NewBook("", "", liquidity.DefaultConfig())
+	}
+	ctx.liquidity.Update(ctx.window)
+	return ctx.liquidity
+}
+
+// Profile returns the day and session volume profiles of the last profileDays
+// UTC days up to the last synced candle. The tracker is created on first use
+// and only rebuilds the profiles when a day or session completes.
+func (ctx *EvalContext) Profile() *profile.Analysis {
+	if ctx.profile == nil {
+		ctx.profile = profile.NewTracker(profile.DefaultConfig(), profileDays)
+	}
+	return ctx.profile.Update(ctx.window)
+}
+
+// Frame returns the child context kept for a higher timeframe, created on
+// first use. The caller syncs it to its resampled candles.
+func (ctx *EvalContext) Frame(timeframe string) *EvalContext {
+	if frame, ok := ctx.frames[timeframe]; ok {
+		return frame
+	}
+	if ctx.frames == nil {
+		ctx.frames = make(map[string]*EvalContext)
+	}
+	frame := NewEvalContext()
+	ctx.frames[timeframe] = frame
+	return frame
+}
+
+// barsBack returns how many bars candles[idx] lies before the last candle ctx
+// was synced to; ok is false unless ctx was synced to a prefix of candles
+func (ctx *EvalContext) barsBack(candles []Candle, idx int) (int, bool) {
+	top := len(ctx.window) - 1
+	if top < idx || top >= len(candles) {
+		return 0, false
+	}
+	if last := ctx.window[top]; last.Timestamp != candles[top].Timestamp || last.Close != candles[top].Close {
+		return 0, false
+	}
+	return top - idx, true
+}
+
+// StrategyRegistry holds all registered strategies in registration order
+type StrategyRegistry struct {
+	strategies map[string]Strategy
+	order      []string
+	mu         sync.RWMutex
+}
+
+var strategyRegistry = &StrategyRegistry{
+	strategies: make(map[string]Strategy),
+	order:      []string{},
+}
+
+// GetStrategyRegistry returns the shared strategy registry
+func GetStrategyRegistry() *StrategyRegistry {
+	return strategyRegistry
+}
+
+// Register adds a strategy, rejecting empty or duplicate names
+func (r *StrategyRegistry) Register(s Strategy) error {
+	name := s.Metadata().Name
+	if name == "" {
+		return fmt.Errorf("strategy name is required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.strategies[name]; exists {
+		return fmt.Errorf("strategy already registered: %s", name)
+	}
+	r.strategies[name] = s
+	r.order = append(r.order, name)
+	return nil
+}
+
+// Unregister removes a strategy (used when runtime-loaded definitions are reloaded)
+func (r *StrategyRegistry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.strategies[name]; !exists {
+		return
+	}
+	delete(r.strategies, name)
+	for i, n := range r.order {
+		if n == name {
+			r.order = append(r.order[:i], r.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// Get looks up a strategy by registry name
+func (r *StrategyRegistry) Get(name string) (Strategy, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.strategies[name]
+	return s, ok
+}
+
+// List returns all strategies in registration order
+func (r *StrategyRegistry) List() []Strategy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]Strategy, 0, len(r.order))
+	for _, name := range r.order {
+		list = append(list, r.strategies[name])
+	}
+	return list
+}
+
+// Names returns all registered strategy names in registration order
+func (r *StrategyRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, len(r.order))
+	copy(names, r.order)
+	return names
+}
+
+// RegisterStrategy adds a strategy to the shared registry and panics on
+// conflicts, so it is meant for init-time registration of built-ins.
+func RegisterStrategy(s Strategy) {
+	if err := strategyRegistry.Register(s); err != nil {
+		panic(err)
+	}
+}
+
+// GetStrategy looks up a strategy in the shared registry
+func GetStrategy(name string) (Strategy, bool) {
+	return strategyRegistry.Get(name)
+}
+
+// ListStrategies returns all strategies in the shared registry
+func ListStrategies() []Strategy {
+	return strategyRegistry.List()
+}
+
+// StrategyNames returns the names of all strategies in the shared registry
+func StrategyNames() []string {
+	return strategyRegistry.Names()
+}
+
+// StrategyTimeframe returns a strategy's default timeframe, or fallback if unknown
+func StrategyTimeframe(name, fallback string) string {
+	if s, ok := GetStrategy(name); ok && s.DefaultTimeframe() != "" {
+		return s.DefaultTimeframe()
+	}
+	return fallback
+}
+
+// StrategyInfo is the JSON view of a registered strategy
+type StrategyInfo struct {
+	StrategyMetadata
+	Timeframe  string      `json:"timeframe"`
+	WarmupBars int         `json:"warmupBars"`
+	Params     []ParamSpec `json:"params"`
+}
+
+// DescribeStrategy builds the JSON view of a strategy
+func DescribeStrategy(s Strategy) StrategyInfo {
+	return StrategyInfo{
+		StrategyMetadata: s.Metadata(),
+		Timeframe:        s.DefaultTimeframe(),
+		WarmupBars:       s.WarmupBars(),
+		Params:           s.ParamSchema(),
+	}
+}
+
+// DescribeStrategies returns JSON views of all registered strategies, sorted by name
+func DescribeStrategies() []StrategyInfo {
+	list := ListStrategies()
+	infos := make([]StrategyInfo, 0, len(list))
+	for _, s := range list {
+		infos = append(infos, DescribeStrategy(s))
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
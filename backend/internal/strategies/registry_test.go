@@ -0,0 +1,107 @@
+package strategies
+
+import (
+	"testing"
+)
+
+// fakeStrategy is a minimal plugin used to exercise the registry
+type fakeStrategy struct {
+	name      string
+	timeframe string
+}
+
+func (f fakeStrategy) Metadata() StrategyMetadata {
+	return StrategyMetadata{Name: f.name, DisplayName: f.name}
+}
+func (f fakeStrategy) DefaultTimeframe() string { return f.timeframe }
+func (f fakeStrategy) ParamSchema() []ParamSpec {
+	return []ParamSpec{{Name: "stop_atr", Type: "float", Default: 1.5, Min: 0.5, Max: 3, Step: 0.25}}
+}
+func (f fakeStrategy) WarmupBars() int                                    { return 50 }
+func (f fakeStrategy) Evaluate(candles []Candle, idx int) *AdvancedSignal { return nil }
+
+func newTestRegistry() *StrategyRegistry {
+	return &StrategyRegistry{strategies: make(map[string]Strategy), order: []string{}}
+}
+
+func TestRegistryKeepsRegistrationOrder(t *testing.T) {
+	r := newTestRegistry()
+	for _, name := range []string{"zeta", "alpha", "mid"} {
+		if err := r.Register(fakeStrategy{name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	names := r.Names()
+	if len(names) != 3 || names[0] != "zeta" || names[1] != "alpha" || names[2] != "mid" {
+		t.Fatalf("want registration order, got %v", names)
+	}
+	list := r.List()
+	if len(list) != 3 || list[1].Metadata().Name != "alpha" {
+		t.Fatalf("List should follow registration order, got %d entries", len(list))
+	}
+
+	// Names returns a copy callers can modify
+	names[0] = "changed"
+	if r.Names()[0] != "zeta" {
+		t.Fatalf("Names leaked the internal slice")
+	}
+}
+
+func TestRegistryRejectsEmptyAndDuplicateNames(t *testing.T) {
+	r := newTestRegistry()
+	if err := r.Register(fakeStrategy{}); err == nil {
+		t.Fatal("want error for an empty name")
+	}
+	if err := r.Register(fakeStrategy{name: "ob"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register(fakeStrategy{name: "ob"}); err == nil {
+		t.Fatal("want error for a duplicate name")
+	}
+	if len(r.Names()) != 1 {
+		t.Fatalf("duplicate should not be added, got %v", r.Names())
+	}
+}
+
+func TestRegistryUnregister(t *testing.T) {
+	r := newTestRegistry()
+	for _, name := range []string{"a", "b", "c"} {
+		if err := r.Register(fakeStrategy{name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r.Unregister("b")
+	r.Unregister("missing")
+	if _, ok := r.Get("b"); ok {
+		t.Fatal("b should be gone")
+	}
+	if names := r.Names(); len(names) != 2 || names[0] != "a" || names[1] != "c" {
+		t.Fatalf("want [a c], got %v", names)
+	}
+
+	// A reloaded definition can take the name again
+	if err := r.Register(fakeStrategy{name: "b", timeframe: "1h"}); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := r.Get("b"); s.DefaultTimeframe() != "1h" {
+		t.Fatalf("want the reloaded strategy")
+	}
+}
+
+func TestDescribeStrategy(t *testing.T) {
+	info := DescribeStrategy(fakeStrategy{name: "breakout", timeframe: "4h"})
+	if info.Name != "breakout" || info.Timeframe != "4h" || info.WarmupBars != 50 {
+		t.Fatalf("got %+v", info)
+	}
+	if len(info.Params) != 1 || info.Params[0].Name != "stop_atr" {
+		t.Fatalf("want the param schema, got %+v", info.Params)
+	}
+}
+
+func TestStrategyTimeframeFallback(t *testing.T) {
+	if got := StrategyTimeframe("no_such_strategy", "15m"); got != "15m" {
+		t.Fatalf("want fallback 15m, got %s", got)
+	}
+}
@@ -0,0 +1,381 @@
+package strategies
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"tradebot/backend/internal/indicators"
+)
+
+// ==================== RISK PROFILE ENFORCEMENT ====================
+// Applies a StrategyConfig profile (Conservative / Balanced / Aggressive) to
+// every candidate signal and keeps per-rule rejection counts.
+
+// Rule names used as keys in RiskProfileReport.Rejections
+const (
+	RuleMaxTradesPerDay    = "max_trades_per_day"
+	RuleMaxConsecutiveLoss = "max_consecutive_loss"
+	RuleDailyLossLimit     = "daily_loss_limit"
+	RuleMinConfluence      = "min_confluence"
+	RuleMinStrength        = "min_strength"
+	RuleMinRiskReward      = "min_risk_reward"
+	RuleKillZone           = "kill_zone"
+	RuleSession            = "session"
+	RuleMTFAlignment       = "mtf_alignment"
+	RulePattern            = "pattern"
+	RuleATRPercentile      = "atr_percentile"
+)
+
+// RiskProfiles returns the selectable profiles, strictest first
+func RiskProfiles() []StrategyConfig {
+	return []StrategyConfig{ConservativeStrategy, BalancedStrategy, AggressiveStrategy}
+}
+
+// GetRiskProfile looks a profile up by name (case-insensitive)
+func GetRiskProfile(name string) (StrategyConfig, bool) {
+	for _, profile := range RiskProfiles() {
+		if strings.EqualFold(profile.Name, name) {
+			return profile, true
+		}
+	}
+	return StrategyConfig{}, false
+}
+
+// RiskProfileReport summarises what a profile let through and why it rejected the rest
+type RiskProfileReport struct {
+	Profile    string         `json:"profile"`
+	Evaluated  int            `json:"evaluated"`
+	Accepted   int            `json:"accepted"`
+	Rejected   int            `json:"rejected"`
+	Rejections map[string]int `json:"rejections"`
+}
+
+// RiskProfileFilter enforces one profile. It is safe for concurrent use; keep
+// one filter per backtest run, paper session or bot so daily limits are not shared.
+type RiskProfileFilter struct {
+	Config StrategyConfig
+
+	mu          sync.Mutex
+	evaluated   int
+	accepted    int
+	rejections  map[string]int
+	day         string
+	tradesToday int
+	lossesInRow int
+	pnlToday    float64
+}
+
+// NewRiskProfileFilter creates a filter for the named profile
+func NewRiskProfileFilter(name string) (*RiskProfileFilter, error) {
+	profile, ok := GetRiskProfile(name)
+	if !ok {
+		names := []string{}
+		for _, p := range RiskProfiles() {
+			names = append(names, p.Name)
+		}
+		return nil, fmt.Errorf("unknown risk profile %q (use %s)", name, strings.Join(names, ", "))
+	}
+	return &RiskProfileFilter{
+		Config:     profile,
+		rejections: make(map[string]int),
+	}, nil
+}
+
+// Check runs every profile rule against a signal generated at candles[idx] and
+// returns the first rule it fails. Each rejected signal is counted once.
+func (f *RiskProfileFilter) Check(meta StrategyMetadata, signal *AdvancedSignal, candles []Candle, idx int) (bool, string) {
+	rule := f.firstFailedRule(meta, signal, candles, idx)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.evaluated++
+	if rule != "" {
+		f.rejections[rule]++
+		return false, rule
+	}
+	f.accepted++
+	return true, ""
+}
+
+// RecordTrade feeds a closed trade back into the daily limits.
+// pnlPercent is the trade result as a percentage of account balance.
+func (f *RiskProfileFilter) RecordTrade(timestamp int64, pnlPercent float64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rollDay(timestamp)
+
+	f.tradesToday++
+	f.pnlToday += pnlPercent
+	if pnlPercent < 0 {
+		f.lossesInRow++
+	} else {
+		f.lossesInRow = 0
+	}
+}
+
+// Report returns a snapshot of the rejection counts
+func (f *RiskProfileFilter) Report() *RiskProfileReport {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rejections := make(map[string]int, len(f.rejections))
+	for rule, count := range f.rejections {
+		rejections[rule] = count
+	}
+	return &RiskProfileReport{
+		Profile:    f.Config.Name,
+		Evaluated:  f.evaluated,
+		Accepted:   f.accepted,
+		Rejected:   f.evaluated - f.accepted,
+		Rejections: rejections,
+	}
+}
+
+// rollDay resets the daily counters when timestamp falls on a new UTC day. Caller holds mu.
+func (f *RiskProfileFilter) rollDay(timestamp int64) {
+	day := time.UnixMilli(timestamp).UTC().Format("2006-01-02")
+	if day != f.day {
+		f.day = day
+		f.tradesToday = 0
+		f.lossesInRow = 0
+		f.pnlToday = 0
+	}
+}
+
+func (f *RiskProfileFilter) firstFailedRule(meta StrategyMetadata, signal *AdvancedSignal, candles []Candle, idx int) string {
+	config := f.Config
+	timestamp := candles[idx].Timestamp
+
+	// Daily risk limits
+	f.mu.Lock()
+	f.rollDay(timestamp)
+	tradesToday, lossesInRow, pnlToday := f.tradesToday, f.lossesInRow, f.pnlToday
+	f.mu.Unlock()
+
+	if config.MaxTradesPerDay > 0 && tradesToday >= config.MaxTradesPerDay {
+		return RuleMaxTradesPerDay
+	}
+	if config.MaxConsecutiveLoss > 0 && lossesInRow >= config.MaxConsecutiveLoss {
+		return RuleMaxConsecutiveLoss
+	}
+	if config.DailyLossLimit > 0 && pnlToday <= -config.DailyLossLimit {
+		return RuleDailyLossLimit
+	}
+
+	// Signal quality
+	if signal.Confluence < profileConfluence(config, meta) {
+		return RuleMinConfluence
+	}
+	if signal.Strength < float64(config.MinStrength) {
+		return RuleMinStrength
+	}
+	if signal.RR > 0 && signal.RR < config.MinRiskReward {
+		return RuleMinRiskReward
+	}
+
+	// Timing
+	if !contains(config.AllowedKillZones, KillZoneAt(timestamp)) {
+		return RuleKillZone
+	}
+	sessionAllowed := false
+	for _, session := range SessionsAt(timestamp) {
+		if contains(config.AllowedSessions, session) {
+			sessionAllowed = true
+			break
+		}
+	}
+	if !sessionAllowed {
+		return RuleSession
+	}
+
+	// Multi-timeframe alignment is only enforced when the profile requires it
+	if config.RequireMTF && mtfAlignment(candles, idx, signal.Type) < config.MinMTFAlignment {
+		return RuleMTFAlignment
+	}
+
+	// Patterns are only checked when the signal can be attributed to one
+	if found := signalPatterns(signal, candles, idx); len(found) > 0 {
+		allowed := false
+		for _, pattern := range found {
+			if contains(config.AllowedPatterns, pattern) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return RulePattern
+		}
+	}
+
+	if pct, ok := atrPercentile(candles, idx, 14, 100); ok {
+		if pct < config.MinATRPercentile || pct > config.MaxATRPercentile {
+			return RuleATRPercentile
+		}
+	}
+
+	return ""
+}
+
+// profileConfluence converts the profile's confluence threshold to the strategy's scale.
+// Profile thresholds use the legacy generator scale (Aggressive 8, Balanced 10,
+// Conservative 12); registry strategies declare their own MinConfluence, so the
+// profile raises it by one factor per step above Aggressive.
+func profileConfluence(config StrategyConfig, meta StrategyMetadata) int {
+	if meta.MinConfluence == 0 {
+		return 0
+	}
+	step := (config.MinConfluence - AggressiveStrategy.MinConfluence) / 2
+	if step < 0 {
+		step = 0
+	}
+	return meta.MinConfluence + step
+}
+
+// KillZoneAt returns the ICT kill zone for a millisecond timestamp (UTC)
+func KillZoneAt(timestamp int64) string {
+	hour := time.UnixMilli(timestamp).UTC().Hour()
+	switch {
+	case hour >= 8 && hour < 10:
+		return "London Open"
+	case hour >= 13 && hour < 15:
+		return "New York Open"
+	case hour >= 16 && hour < 18:
+		return "London Close"
+	case hour >= 21 && hour < 23:
+		return "New York Close"
+	case hour < 8:
+		return "Asian Session"
+	}
+	return "Off Hours"
+}
+
+// SessionsAt returns every trading session active at a millisecond timestamp (UTC).
+// London and New York overlap between 13:00 and 16:00.
+func SessionsAt(timestamp int64) []string {
+	hour := time.UnixMilli(timestamp).UTC().Hour()
+	sessions := []string{}
+	if hour < 8 {
+		sessions = append(sessions, "Asian")
+	}
+	if hour >= 8 && hour < 16 {
+		sessions = append(sessions, "London")
+	}
+	if hour >= 13 && hour < 21 {
+		sessions = append(sessions, "New York")
+	}
+	if hour >= 13 && hour < 16 {
+		sessions = append(sessions, "Overlap")
+	}
+	if len(sessions) == 0 {
+		sessions = append(sessions, "Off Hours")
+	}
+	return sessions
+}
+
+// mtfAlignment returns the percentage of trend horizons agreeing with the signal.
+// EMA 20/50/100/200 of the base series stand in for successively higher timeframes.
+func mtfAlignment(candles []Candle, idx int, direction string) int {
+	price := candles[idx].Close
+	checked, aligned := 0, 0
+	for _, period := range []int{20, 50, 100, 200} {
+		if idx+1 < period {
+			break
+		}
+		ema := indicators.EMA(candles[:idx+1], period)
+		checked++
+		if (direction == "BUY" && price > ema) || (direction == "SELL" && price < ema) {
+			aligned++
+		}
+	}
+	if checked == 0 {
+		return 0
+	}
+	return aligned * 100 / checked
+}
+
+// signalPatterns names the profile patterns present in the signal bar or its reasons
+func signalPatterns(signal *AdvancedSignal, candles []Candle, idx int) []string {
+	found := map[string]bool{}
+
+	if idx >= 1 {
+		prev, cur := candles[idx-1], candles[idx]
+		body := math.Abs(cur.Close - cur.Open)
+		rng := cur.High - cur.Low
+		upperWick := cur.High - math.Max(cur.Open, cur.Close)
+		lowerWick := math.Min(cur.Open, cur.Close) - cur.Low
+
+		bullEngulf := prev.Close < prev.Open && cur.Close > cur.Open && cur.Close >= prev.Open && cur.Open <= prev.Close
+		bearEngulf := prev.Close > prev.Open && cur.Close < cur.Open && cur.Close <= prev.Open && cur.Open >= prev.Close
+		if bullEngulf || bearEngulf {
+			found["Engulfing"] = true
+		}
+		if rng > 0 && body <= rng*0.33 && math.Max(upperWick, lowerWick) >= rng*0.6 {
+			found["Pin Bar"] = true
+		}
+		if cur.High <= prev.High && cur.Low >= prev.Low {
+			found["Inside Bar"] = true
+		}
+	}
+
+	reasons := strings.ToLower(strings.Join(signal.Reasons, " | "))
+	for _, pattern := range AggressiveStrategy.AllowedPatterns {
+		if strings.Contains(reasons, strings.ToLower(pattern)) {
+			found[pattern] = true
+		}
+	}
+	if strings.Contains(reasons, "fvg") {
+		found["Fair Value Gap"] = true
+	}
+
+	patterns := make([]string, 0, len(found))
+	for pattern := range found {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	return patterns
+}
+
+// atrPercentile ranks the current ATR against the previous lookback ATR values (0-100)
+func atrPercentile(candles []Candle, idx, period, lookback int) (int, bool) {
+	if idx < period+30 {
+		return 0, false
+	}
+
+	// Rolling simple average of the true range
+	trs := make([]float64, idx+1)
+	for i := 1; i <= idx; i++ {
+		trs[i] = math.Max(candles[i].High-candles[i].Low,
+			math.Max(math.Abs(candles[i].High-candles[i-1].Close), math.Abs(candles[i].Low-candles[i-1].Close)))
+	}
+	atrs := []float64{}
+	sum := 0.0
+	for i := 1; i <= idx; i++ {
+		sum += trs[i]
+		if i > period {
+			sum -= trs[i-period]
+		}
+		if i >= period {
+			atrs = append(atrs, sum/float64(period))
+		}
+	}
+
+	current := atrs[len(atrs)-1]
+	history := atrs[:len(atrs)-1]
+	if len(history) > lookback {
+		history = history[len(history)-lookback:]
+	}
+	// Mid-rank so a flat ATR history sits at the 50th percentile
+	rank := 0.0
+	for _, atr := range history {
+		if atr < current {
+			rank++
+		} else if atr == current {
+			rank += 0.5
+		}
+	}
+	return int(rank * 100 / float64(len(history))), true
+}
@@ -0,0 +1,164 @@
+package strategies
+
+import (
+	"testing"
+	"time"
+)
+
+// risingCandles builds one-minute bars starting at start with no candle patterns
+func risingCandles(start time.Time, n int) []Candle {
+	candles := make([]Candle, n)
+	price := 100.0
+	for i := range candles {
+		candles[i] = Candle{
+			Timestamp: start.Add(time.Duration(i) * time.Minute).UnixMilli(),
+			Open:      price,
+			High:      price + 0.6,
+			Low:       price - 0.1,
+			Close:     price + 0.5,
+			Volume:    1000,
+		}
+		price++
+	}
+	return candles
+}
+
+func goodSignal() *AdvancedSignal {
+	return &AdvancedSignal{Type: "BUY", Confluence: 10, Strength: 80, RR: 2}
+}
+
+func TestGetRiskProfile(t *testing.T) {
+	for _, name := range []string{"conservative", "Balanced", "AGGRESSIVE"} {
+		if _, ok := GetRiskProfile(name); !ok {
+			t.Fatalf("profile %q not found", name)
+		}
+	}
+	if _, err := NewRiskProfileFilter("reckless"); err == nil {
+		t.Fatal("want error for an unknown profile")
+	}
+}
+
+func TestKillZonesAndSessions(t *testing.T) {
+	tests := []struct {
+		hour     int
+		killZone string
+		sessions []string
+	}{
+		{2, "Asian Session", []string{"Asian"}},
+		{9, "London Open", []string{"London"}},
+		{14, "New York Open", []string{"London", "New York", "Overlap"}},
+		{17, "London Close", []string{"New York"}},
+		{22, "New York Close", []string{"Off Hours"}},
+		{11, "Off Hours", []string{"London"}},
+	}
+	for _, tt := range tests {
+		ts := time.Date(2024, 1, 2, tt.hour, 30, 0, 0, time.UTC).UnixMilli()
+		if got := KillZoneAt(ts); got != tt.killZone {
+			t.Fatalf("%02d:30 kill zone: want %s, got %s", tt.hour, tt.killZone, got)
+		}
+		got := SessionsAt(ts)
+		if len(got) != len(tt.sessions) {
+			t.Fatalf("%02d:30 sessions: want %v, got %v", tt.hour, tt.sessions, got)
+		}
+		for i := range got {
+			if got[i] != tt.sessions[i] {
+				t.Fatalf("%02d:30 sessions: want %v, got %v", tt.hour, tt.sessions, got)
+			}
+		}
+	}
+}
+
+func TestProfileConfluenceScalesStrategyMinimum(t *testing.T) {
+	meta := StrategyMetadata{MinConfluence: 4}
+	tests := []struct {
+		profile StrategyConfig
+		want    int
+	}{
+		{AggressiveStrategy, 4},
+		{BalancedStrategy, 5},
+		{ConservativeStrategy, 6},
+	}
+	for _, tt := range tests {
+		if got := profileConfluence(tt.profile, meta); got != tt.want {
+			t.Fatalf("%s: want %d, got %d", tt.profile.Name, tt.want, got)
+		}
+	}
+	if got := profileConfluence(ConservativeStrategy, StrategyMetadata{}); got != 0 {
+		t.Fatalf("strategies without a minimum are not gated, got %d", got)
+	}
+}
+
+func TestRiskProfileSignalRules(t *testing.T) {
+	london := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	candles := risingCandles(london, 20)
+	idx := len(candles) - 1
+
+	tests := []struct {
+		profile string
+		signal  func(*AdvancedSignal)
+		candles []Candle
+		want    string
+	}{
+		{"Aggressive", func(s *AdvancedSignal) {}, candles, ""},
+		{"Aggressive", func(s *AdvancedSignal) { s.Strength = 40 }, candles, RuleMinStrength},
+		{"Aggressive", func(s *AdvancedSignal) { s.RR = 1.2 }, candles, RuleMinRiskReward},
+		{"Balanced", func(s *AdvancedSignal) { s.Reasons = []string{"Inside Bar break"} }, candles, RulePattern},
+		{"Balanced", func(s *AdvancedSignal) {}, risingCandles(london.Add(-7*time.Hour), 20), RuleKillZone},
+		{"Conservative", func(s *AdvancedSignal) {}, candles, ""},
+		{"Conservative", func(s *AdvancedSignal) { s.Type = "SELL" }, candles, RuleMTFAlignment},
+	}
+	for i, tt := range tests {
+		filter, err := NewRiskProfileFilter(tt.profile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signal := goodSignal()
+		tt.signal(signal)
+		ok, rule := filter.Check(StrategyMetadata{}, signal, tt.candles, idx)
+		if rule != tt.want || ok != (tt.want == "") {
+			t.Fatalf("case %d (%s): want rule %q, got %q (ok=%v)", i, tt.profile, tt.want, rule, ok)
+		}
+	}
+}
+
+func TestRiskProfileDailyLimits(t *testing.T) {
+	day1 := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	candles := risingCandles(day1, 20)
+	idx := len(candles) - 1
+	ts := candles[idx].Timestamp
+
+	filter, _ := NewRiskProfileFilter("Aggressive")
+	for i := 0; i < 5; i++ {
+		filter.RecordTrade(ts, -0.5)
+	}
+	if ok, rule := filter.Check(StrategyMetadata{}, goodSignal(), candles, idx); ok || rule != RuleMaxConsecutiveLoss {
+		t.Fatalf("after 5 losses: want %s, got %q", RuleMaxConsecutiveLoss, rule)
+	}
+
+	// A new UTC day resets every counter
+	nextDay := risingCandles(day1.Add(24*time.Hour), 20)
+	if ok, rule := filter.Check(StrategyMetadata{}, goodSignal(), nextDay, idx); !ok {
+		t.Fatalf("new day should reset the limits, got %q", rule)
+	}
+
+	filter.RecordTrade(nextDay[idx].Timestamp, -3.5)
+	if _, rule := filter.Check(StrategyMetadata{}, goodSignal(), nextDay, idx); rule != RuleDailyLossLimit {
+		t.Fatalf("after a 3.5%% loss: want %s, got %q", RuleDailyLossLimit, rule)
+	}
+
+	busy, _ := NewRiskProfileFilter("Aggressive")
+	for i := 0; i < 10; i++ {
+		busy.RecordTrade(ts, 0.2)
+	}
+	if _, rule := busy.Check(StrategyMetadata{}, goodSignal(), candles, idx); rule != RuleMaxTradesPerDay {
+		t.Fatalf("after 10 trades: want %s, got %q", RuleMaxTradesPerDay, rule)
+	}
+
+	report := filter.Report()
+	if report.Evaluated != 3 || report.Accepted != 1 || report.Rejected != 2 {
+		t.Fatalf("want 3 evaluated / 1 accepted, got %+v", report)
+	}
+	if report.Rejections[RuleMaxConsecutiveLoss] != 1 || report.Rejections[RuleDailyLossLimit] != 1 {
+		t.Fatalf("unexpected rejection counts %v", report.Rejections)
+	}
+}
@@ -0,0 +1,1271 @@
+package strategies
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"tradebot/backend/internal/indicators"
+	"tradebot/backend/internal/liquidity"
+	"tradebot/backend/internal/profile"
+)
+
+// RuleStrategyDefinition is a declarative strategy written in YAML or JSON.
+// It combines checkConcept concepts, ICT detectors and indicator comparisons
+// with boolean logic, and describes exits as ATR multiples.
+type RuleStrategyDefinition struct {
+	Name         string      `json:"name" yaml:"name"`
+	DisplayName  string      `json:"displayName,omitempty" yaml:"displayName,omitempty"`
+	Description  string      `json:"description,omitempty" yaml:"description,omitempty"`
+	Category     string      `json:"category,omitempty" yaml:"category,omitempty"`
+	Timeframe    string      `json:"timeframe" yaml:"timeframe"`
+	WarmupBars   int         `json:"warmupBars,omitempty" yaml:"warmupBars,omitempty"`
+	CooldownBars int         `json:"cooldownBars,omitempty" yaml:"cooldownBars,omitempty"`
+	Params       []ParamSpec `json:"params,omitempty" yaml:"params,omitempty"`
+	Long         *RuleSide   `json:"long,omitempty" yaml:"long,omitempty"`
+	Short        *RuleSide   `json:"short,omitempty" yaml:"short,omitempty"`
+	Exits        RuleExits   `json:"exits" yaml:"exits"`
+}
+
+// RuleSide holds the entry rules for one direction
+type RuleSide struct {
+	When          RuleCondition   `json:"when" yaml:"when"`                                       // Must be true to enter
+	Confluence    []RuleCondition `json:"confluence,omitempty" yaml:"confluence,omitempty"`       // Optional scored conditions
+	MinConfluence int             `json:"minConfluence,omitempty" yaml:"minConfluence,omitempty"` // Scored conditions required
+}
+
+// RuleCondition is one node of the boolean rule tree. Exactly one of
+// All, Any, Not, Concept, Detector or Compare must be set.
+type RuleCondition struct {
+	All       []RuleCondition `json:"all,omitempty" yaml:"all,omitempty"`
+	Any       []RuleCondition `json:"any,omitempty" yaml:"any,omitempty"`
+	Not       *RuleCondition  `json:"not,omitempty" yaml:"not,omitempty"`
+	Concept   string          `json:"concept,omitempty" yaml:"concept,omitempty"`     // checkConcept name, e.g. "Fair Value Gap"
+	Detector  string          `json:"detector,omitempty" yaml:"detector,omitempty"`   // Directional ICT detector, e.g. "bullish_order_block"
+	Compare   *RuleComparison `json:"compare,omitempty" yaml:"compare,omitempty"`     // Indicator threshold or cross
+	Timeframe string          `json:"timeframe,omitempty" yaml:"timeframe,omitempty"` // Evaluate on a higher timeframe, e.g. "4h"
+	Label     string          `json:"label,omitempty" yaml:"label,omitempty"`         // Reason text when the condition passes
+}
+
+// RuleComparison compares two operands, e.g. close > ema(200) or rsi(14) crosses_above 30
+type RuleComparison struct {
+	Left  RuleOperand `json:"left" yaml:"left"`
+	Op    string      `json:"op" yaml:"op"`
+	Right RuleOperand `json:"right" yaml:"right"`
+}
+
+// RuleOperand is an indicator reading, a constant or a strategy parameter
+type RuleOperand struct {
+	Indicator  string   `json:"indicator,omitempty" yaml:"indicator,omitempty"`
+	Period     int      `json:"period,omitempty" yaml:"period,omitempty"`
+	Offset     int      `json:"offset,omitempty" yaml:"offset,omitempty"` // Bars back (0 = current bar)
+	Value      *float64 `json:"value,omitempty" yaml:"value,omitempty"`
+	Param      string   `json:"param,omitempty" yaml:"param,omitempty"`
+	Multiplier float64  `json:"multiplier,omitempty" yaml:"multiplier,omitempty"` // Scales the operand, e.g. 1.5 x avg_volume
+	Band       float64  `json:"band,omitempty" yaml:"band,omitempty"`             // avwap_* only: standard deviations added to the VWAP, e.g. -2
+}
+
+// RuleExits describes stop and targets as ATR multiples
+type RuleExits struct {
+	ATRPeriod      int     `json:"atrPeriod,omitempty" yaml:"atrPeriod,omitempty"`
+	StopATR        float64 `json:"stopAtr" yaml:"stopAtr"`
+	TP1ATR         float64 `json:"tp1Atr" yaml:"tp1Atr"`
+	TP2ATR         float64 `json:"tp2Atr,omitempty" yaml:"tp2Atr,omitempty"`
+	TP3ATR         float64 `json:"tp3Atr,omitempty" yaml:"tp3Atr,omitempty"`
+	ProfileTargets bool    `json:"profileTargets,omitempty" yaml:"profileTargets,omitempty"` // TP1 at the nearest naked POC / value area edge; stop beyond the one behind when tighter
+}
+
+// RuleValidationError collects every problem found in a definition
+type RuleValidationError struct {
+	Strategy string   `json:"strategy"`
+	Errors   []string `json:"errors"`
+}
+
+func (e *RuleValidationError) Error() string {
+	return fmt.Sprintf("invalid rule strategy %q: %s", e.Strategy, strings.Join(e.Errors, "; "))
+}
+
+// ruleIndicators lists the operand indicators and whether they need a period
+var ruleIndicators = map[string]bool{
+	"open":       false,
+	"high":       false,
+	"low":        false,
+	"close":      false,
+	"volume":     false,
+	"sma":        true,
+	"ema":        true,
+	"rsi":        true,
+	"atr":        true,
+	"adx":        true,
+	"avg_volume": true,
+	"highest":    true, // Highest high of the previous N bars
+	"lowest":     true, // Lowest low of the previous N bars
+
+	// Volume profile levels; unavailable (condition false) when none exists
+	"naked_poc_above":   false, // Nearest untested day/session POC above the close
+	"naked_poc_below":   false,
+	"naked_level_above": false, // Nearest untested POC, VAH or VAL above the close
+	"naked_level_below": false,
+	"prior_poc":         false, // Previous completed UTC day
+	"prior_vah":         false,
+	"prior_val":         false,
+
+	// Anchored VWAPs; swing anchors use period as the pivot strength (default: 5)
+	"avwap_session":    false, // From the latest Asian / London / New York open
+	"avwap_day":        false,
+	"avwap_week":       false,
+	"avwap_month":      false,
+	"avwap_swing_high": false, // From the latest confirmed swing high
+	"avwap_swing_low":  false,
+
+	// Liquidity ledger levels; unavailable when none is untouched
+	"liquidity_above": false, // Nearest untouched session / day / week / swing high or low above the close
+	"liquidity_below": false,
+}
+
+var ruleOperators = map[string]bool{
+	">": true, ">=": true, "<": true, "<=": true,
+	"crosses_above": true, "crosses_below": true,
+}
+
+// ruleDetectorLookback is how many bars the ICT detectors analyze
+const ruleDetectorLookback = 100
+
+// ruleDetectors are directional ICT / price action detectors usable in rules
+var ruleDetectors = map[string]func(candles []Candle, idx int) bool{
+	"bullish_order_block": func(c []Candle, i int) bool { return priceInOrderBlock(c, i, "bullish") },
+	"bearish_order_block": func(c []Candle, i int) bool { return priceInOrderBlock(c, i, "bearish") },
+	"bullish_fvg":         func(c []Candle, i int) bool { return priceInFVG(c, i, "bullish") },
+	"bearish_fvg":         func(c []Candle, i int) bool { return priceInFVG(c, i, "bearish") },
+	"sellside_sweep":      func(c []Candle, i int) bool { return recentSweep(c, i, "sellside") },
+	"buyside_sweep":       func(c []Candle, i int) bool { return recentSweep(c, i, "buyside") },
+	"bullish_structure":   func(c []Candle, i int) bool { return AnalyzeMarketStructure(detectorWindow(c, i)).Trend == "bullish" },
+	"bearish_structure":   func(c []Candle, i int) bool { return AnalyzeMarketStructure(detectorWindow(c, i)).Trend == "bearish" },
+	"break_of_structure":  func(c []Candle, i int) bool { return AnalyzeMarketStructure(detectorWindow(c, i)).BOS },
+	"change_of_character": func(c []Candle, i int) bool { return AnalyzeMarketStructure(detectorWindow(c, i)).CHOCH },
+	"premium":             func(c []Candle, i int) bool { return premiumDiscountZone(c, i) == "premium" },
+	"discount":            func(c []Candle, i int) bool { return premiumDiscountZone(c, i) == "discount" },
+	"equilibrium":         func(c []Candle, i int) bool { return premiumDiscountZone(c, i) == "equilibrium" },
+	"ote":                 func(c []Candle, i int) bool { return IsInOTE(detectorWindow(c, i)) },
+	"bullish_engulfing":   isBullishEngulfing,
+	"bearish_engulfing":   isBearishEngulfing,
+	"pin_bar":             isPinBar,
+}
+
+// ruleContextDetectors read per-run state kept on the EvalContext
+var ruleContextDetectors = map[string]func(ctx *EvalContext, candles []Candle, idx int) bool{
+	"above_prior_value":  func(ctx *EvalContext, c []Candle, i int) bool { return priorValuePosition(ctx, c, i) == "above" },
+	"below_prior_value":  func(ctx *EvalContext, c []Candle, i int) bool { return priorValuePosition(ctx, c, i) == "below" },
+	"inside_prior_value": func(ctx *EvalContext, c []Candle, i int) bool { return priorValuePosition(ctx, c, i) == "inside" },
+	"swing_low_avwap_reclaim": func(ctx *EvalContext, c []Candle, i int) bool {
+		return avwapCross(ctx, c, i, indicators.AnchorSwingLow) == "above"
+	},
+	"swing_high_avwap_loss": func(ctx *EvalContext, c []Candle, i int) bool {
+		return avwapCross(ctx, c, i, indicators.AnchorSwingHigh) == "below"
+	},
+	"session_high_swept": func(ctx *EvalContext, c []Candle, i int) bool {
+		return recentLedgerSweep(ctx, c, i, liquidity.SourceSession, liquidity.Buyside)
+	},
+	"session_low_swept": func(ctx *EvalContext, c []Candle, i int) bool {
+		return recentLedgerSweep(ctx, c, i, liquidity.SourceSession, liquidity.Sellside)
+	},
+	"prior_day_high_swept": func(ctx *EvalContext, c []Candle, i int) bool {
+		return recentLedgerSweep(ctx, c, i, liquidity.SourceDay, liquidity.Buyside)
+	},
+	"prior_day_low_swept": func(ctx *EvalContext, c []Candle, i int) bool {
+		return recentLedgerSweep(ctx, c, i, liquidity.SourceDay, liquidity.Sellside)
+	},
+}
+
+var ruleNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Validate checks a definition and returns a *RuleValidationError listing every problem
+func (def *RuleStrategyDefinition) Validate() error {
+	v := &ruleValidator{params: make(map[string]bool)}
+
+	if def.Name == "" {
+		v.addf("name: is required")
+	} else if !ruleNamePattern.MatchString(def.Name) {
+		v.addf("name: %q must be lowercase letters, digits and underscores", def.Name)
+	}
+	if _, err := parseTimeframeMillis(def.Timeframe); err != nil {
+		v.addf("timeframe: %v", err)
+	}
+	if def.WarmupBars < 0 {
+		v.addf("warmupBars: must not be negative")
+	}
+	if def.CooldownBars < 0 {
+		v.addf("cooldownBars: must not be negative")
+	}
+
+	for i, p := range def.Params {
+		path := fmt.Sprintf("params[%d]", i)
+		if p.Name == "" {
+			v.addf("%s.name: is required", path)
+			continue
+		}
+		if v.params[p.Name] {
+			v.addf("%s.name: duplicate parameter %q", path, p.Name)
+		}
+		v.params[p.Name] = true
+		if p.Type != "" && p.Type != "float" && p.Type != "int" && p.Type != "bool" {
+			v.addf("%s.type: %q must be float, int or bool", path, p.Type)
+		}
+		if p.Min != 0 || p.Max != 0 {
+			if p.Min > p.Max {
+				v.addf("%s: min %.4g is greater than max %.4g", path, p.Min, p.Max)
+			} else if p.Default < p.Min || p.Default > p.Max {
+				v.addf("%s.default: %.4g is outside [%.4g, %.4g]", path, p.Default, p.Min, p.Max)
+			}
+		}
+	}
+
+	if def.Long == nil && def.Short == nil {
+		v.addf("long/short: at least one side is required")
+	}
+	if def.Long != nil {
+		v.side("long", def.Long, def.Timeframe)
+	}
+	if def.Short != nil {
+		v.side("short", def.Short, def.Timeframe)
+	}
+
+	exits := def.Exits
+	if exits.StopATR <= 0 {
+		v.addf("exits.stopAtr: must be greater than 0")
+	}
+	if exits.TP1ATR <= 0 {
+		v.addf("exits.tp1Atr: must be greater than 0")
+	}
+	if exits.TP2ATR != 0 && exits.TP2ATR < exits.TP1ATR {
+		v.addf("exits.tp2Atr: must be at least tp1Atr")
+	}
+	if exits.TP3ATR != 0 && exits.TP3ATR < math.Max(exits.TP1ATR, exits.TP2ATR) {
+		v.addf("exits.tp3Atr: must be at least tp2Atr")
+	}
+	if exits.ATRPeriod < 0 {
+		v.addf("exits.atrPeriod: must not be negative")
+	}
+
+	if len(v.errors) > 0 {
+		return &RuleValidationError{Strategy: def.Name, Errors: v.errors}
+	}
+	return nil
+}
+
+type ruleValidator struct {
+	errors []string
+	params map[string]bool
+}
+
+func (v *ruleValidator) addf(format string, args ...interface{}) {
+	v.errors = append(v.errors, fmt.Sprintf(format, args...))
+}
+
+func (v *ruleValidator) side(path string, side *RuleSide, timeframe string) {
+	v.condition(path+".when", &side.When, timeframe)
+	for i := range side.Confluence {
+		v.condition(fmt.Sprintf("%s.confluence[%d]", path, i), &side.Confluence[i], timeframe)
+	}
+	if side.MinConfluence < 0 || side.MinConfluence > len(side.Confluence) {
+		v.addf("%s.minConfluence: must be between 0 and %d (number of confluence conditions)", path, len(side.Confluence))
+	}
+}
+
+func (v *ruleValidator) condition(path string, c *RuleCondition, timeframe string) {
+	kinds := 0
+	if len(c.All) > 0 {
+		kinds++
+	}
+	if len(c.Any) > 0 {
+		kinds++
+	}
+	if c.Not != nil {
+		kinds++
+	}
+	if c.Concept != "" {
+		kinds++
+	}
+	if c.Detector != "" {
+		kinds++
+	}
+	if c.Compare != nil {
+		kinds++
+	}
+	if kinds != 1 {
+		v.addf("%s: exactly one of all, any, not, concept, detector or compare is required (found %d)", path, kinds)
+		return
+	}
+
+	if c.Timeframe != "" {
+		htf, err := parseTimeframeMillis(c.Timeframe)
+		if err != nil {
+			v.addf("%s.timeframe: %v", path, err)
+		} else if base, err := parseTimeframeMillis(timeframe); err == nil && htf < base {
+			v.addf("%s.timeframe: %s is lower than the strategy timeframe %s", path, c.Timeframe, timeframe)
+		}
+	}
+
+	switch {
+	case len(c.All) > 0:
+		for i := range c.All {
+			v.condition(fmt.Sprintf("%s.all[%d]", path, i), &c.All[i], timeframe)
+		}
+	case len(c.Any) > 0:
+		for i := range c.Any {
+			v.condition(fmt.Sprintf("%s.any[%d]", path, i), &c.Any[i], timeframe)
+		}
+	case c.Not != nil:
+		v.condition(path+".not", c.Not, timeframe)
+	case c.Concept != "":
+		if !IsKnownConcept(c.Concept) {
+			v.addf("%s.concept: unknown concept %q", path, c.Concept)
+		}
+	case c.Detector != "":
+		_, plain := ruleDetectors[c.Detector]
+		_, withContext := ruleContextDetectors[c.Detector]
+		if !plain && !withContext {
+			v.addf("%s.detector: unknown detector %q", path, c.Detector)
+		}
+	case c.Compare != nil:
+		if !ruleOperators[c.Compare.Op] {
+			v.addf("%s.compare.op: unknown operator %q (use >, >=, <, <=, crosses_above, crosses_below)", path, c.Compare.Op)
+		}
+		v.operand(path+".compare.left", &c.Compare.Left)
+		v.operand(path+".compare.right", &c.Compare.Right)
+	}
+}
+
+func (v *ruleValidator) operand(path string, o *RuleOperand) {
+	kinds := 0
+	if o.Indicator != "" {
+		kinds++
+	}
+	if o.Value != nil {
+		kinds++
+	}
+	if o.Param != "" {
+		kinds++
+	}
+	if kinds != 1 {
+		v.addf("%s: exactly one of indicator, value or param is required", path)
+		return
+	}
+
+	if o.Indicator != "" {
+		needsPeriod, ok := ruleIndicators[o.Indicator]
+		if !ok {
+			v.addf("%s.indicator: unknown indicator %q", path, o.Indicator)
+		} else if needsPeriod && o.Period <= 0 {
+			v.addf("%s.period: %s needs a period greater than 0", path, o.Indicator)
+		}
+	}
+	if kind, ok := ruleAVWAPAnchors[o.Indicator]; ok {
+		spec := indicators.AnchorSpec{Kind: kind, Strength: o.Period}
+		if err := spec.Validate(); err != nil {
+			v.addf("%s.period: %v", path, err)
+		}
+	} else if o.Band != 0 {
+		v.addf("%s.band: only avwap_* indicators have bands", path)
+	}
+	if o.Param != "" && !v.params[o.Param] {
+		v.addf("%s.param: %q is not declared in params", path, o.Param)
+	}
+	if o.Offset < 0 {
+		v.addf("%s.offset: must not be negative", path)
+	}
+}
+
+// ruleStrategy is a validated RuleStrategyDefinition registered as a Strategy.
+// It holds no run state: the cooldown lives on the caller's EvalContext.
+type ruleStrategy struct {
+	def     RuleStrategyDefinition
+	params  map[string]float64
+	frameMs int64
+}
+
+// NewRuleStrategy validates a definition and builds a runnable Strategy from it
+func NewRuleStrategy(def RuleStrategyDefinition) (Strategy, error) {
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+
+	frameMs, _ := parseTimeframeMillis(def.Timeframe)
+	rs := &ruleStrategy{
+		def:     def,
+		params:  make(map[string]float64),
+		frameMs: frameMs,
+	}
+	for _, p := range rs.ParamSchema() {
+		rs.params[p.Name] = p.Default
+	}
+	return rs, nil
+}
+
+// WithParams returns a copy of the strategy using the given parameter values
+func (rs *ruleStrategy) WithParams(params StrategyParams) Strategy {
+	merged := make(map[string]float64, len(rs.params))
+	for k, v := range rs.params {
+		merged[k] = v
+	}
+	for k, v := range params {
+		merged[k] = v
+	}
+	return &ruleStrategy{def: rs.def, params: merged, frameMs: rs.frameMs}
+}
+
+// Definition returns the source definition of a rule strategy
+func (rs *ruleStrategy) Definition() RuleStrategyDefinition {
+	return rs.def
+}
+
+func (rs *ruleStrategy) Metadata() StrategyMetadata {
+	displayName := rs.def.DisplayName
+	if displayName == "" {
+		displayName = rs.def.Name
+	}
+	category := rs.def.Category
+	if category == "" {
+		category = "rule"
+	}
+
+	minConfluence := 0
+	concepts := []string{}
+	for _, side := range []*RuleSide{rs.def.Long, rs.def.Short} {
+		if side == nil {
+			continue
+		}
+		if side.MinConfluence > minConfluence {
+			minConfluence = side.MinConfluence
+		}
+		collectConcepts(&side.When, &concepts)
+		for i := range side.Confluence {
+			collectConcepts(&side.Confluence[i], &concepts)
+		}
+	}
+
+	return StrategyMetadata{
+		Name:             rs.def.Name,
+		DisplayName:      displayName,
+		Description:      rs.def.Description,
+		Category:         category,
+		MinConfluence:    minConfluence,
+		RequiredConcepts: concepts,
+	}
+}
+
+func (rs *ruleStrategy) DefaultTimeframe() string { return rs.def.Timeframe }
+
+func (rs *ruleStrategy) WarmupBars() int {
+	if rs.def.WarmupBars > 0 {
+		return rs.def.WarmupBars
+	}
+	return 200
+}
+
+// ParamSchema returns the declared params plus the ATR exit multipliers
+func (rs *ruleStrategy) ParamSchema() []ParamSpec {
+	params := make([]ParamSpec, 0, len(rs.def.Params)+4)
+	for _, p := range rs.def.Params {
+		if p.Type == "" {
+			p.Type = "float"
+		}
+		params = append(params, p)
+	}
+
+	exits := rs.def.Exits
+	params = append(params,
+		ParamSpec{Name: "stop_atr", Type: "float", Default: exits.StopATR, Min: 0.25, Max: 5.0, Step: 0.25, Description: "Stop loss distance in ATR"},
+		ParamSpec{Name: "tp1_atr", Type: "float", Default: exits.TP1ATR, Min: 0.5, Max: 10.0, Step: 0.25, Description: "Take profit 1 distance in ATR"},
+	)
+	if exits.TP2ATR > 0 {
+		params = append(params, ParamSpec{Name: "tp2_atr", Type: "float", Default: exits.TP2ATR, Min: 0.5, Max: 15.0, Step: 0.25, Description: "Take profit 2 distance in ATR"})
+	}
+	if exits.TP3ATR > 0 {
+		params = append(params, ParamSpec{Name: "tp3_atr", Type: "float", Default: exits.TP3ATR, Min: 1.0, Max: 25.0, Step: 0.5, Description: "Take profit 3 distance in ATR"})
+	}
+	return params
+}
+
+// Evaluate runs the rules with a fresh context, so no cooldown carries over
+// between calls; engines that step bar by bar use EvaluateWith.
+func (rs *ruleStrategy) Evaluate(candles []Candle, idx int) *AdvancedSignal {
+	return rs.EvaluateWith(NewEvalContext(), candles, idx)
+}
+
+// EvaluateWith runs the long and short rule trees at idx. If both sides fire
+// the bar is ambiguous and no signal is produced. The cooldown is read from
+// and recorded on ctx, so every run keeps its own.
+func (rs *ruleStrategy) EvaluateWith(ctx *EvalContext, candles []Candle, idx int) *AdvancedSignal {
+	if idx < 1 || idx >= len(candles) {
+		return nil
+	}
+
+	ctx.Sync(candles[:idx+1])
+
+	if rs.def.CooldownBars > 0 {
+		last, now := ctx.LastSignal, candles[idx].Timestamp
+		// A timestamp earlier than the last signal means the context was rewound
+		if last > 0 && now >= last && now-last < int64(rs.def.CooldownBars)*rs.frameMs {
+			return nil
+		}
+	}
+
+	long, longTrace, longScore := rs.evaluateSide(ctx, rs.def.Long, candles, idx)
+	short, shortTrace, shortScore := rs.evaluateSide(ctx, rs.def.Short, candles, idx)
+	if long == short {
+		return nil
+	}
+
+	signalType := "BUY"
+	trace := longTrace
+	score := longScore
+	side := rs.def.Long
+	if short {
+		signalType = "SELL"
+		trace = shortTrace
+		score = shortScore
+		side = rs.def.Short
+	}
+
+	atrPeriod := rs.def.Exits.ATRPeriod
+	if atrPeriod == 0 {
+		atrPeriod = 14
+	}
+	atr := indicators.ATR(candles[:idx+1], atrPeriod)
+	if atr <= 0 {
+		return nil
+	}
+
+	entry := candles[idx].Close
+	dir := 1.0
+	if signalType == "SELL" {
+		dir = -1.0
+	}
+	stopATR := rs.params["stop_atr"]
+	tp1ATR := rs.params["tp1_atr"]
+	tp2ATR := rs.params["tp2_atr"]
+	if tp2ATR == 0 {
+		tp2ATR = tp1ATR
+	}
+	tp3ATR := rs.params["tp3_atr"]
+	if tp3ATR == 0 {
+		tp3ATR = tp2ATR
+	}
+
+	strength := 100.0
+	if len(side.Confluence) > 0 {
+		strength = 50 + 50*float64(score)/float64(len(side.Confluence))
+	}
+
+	signal := &AdvancedSignal{
+		Strategy:   rs.def.Name,
+		Type:       signalType,
+		Entry:      entry,
+		StopLoss:   entry - dir*atr*stopATR,
+		TP1:        entry + dir*atr*tp1ATR,
+		TP2:        entry + dir*atr*tp2ATR,
+		TP3:        entry + dir*atr*tp3ATR,
+		Confluence: len(trace.reasons),
+		Reasons:    trace.reasons,
+		Evidence:   weighEvidence(trace.evidence, rs.def.Timeframe),
+		Strength:   strength,
+		RR:         tp1ATR / stopATR,
+		Timeframe:  rs.def.Timeframe,
+	}
+	if rs.def.Exits.ProfileTargets {
+		applyProfileTargets(ctx, signal, candles, idx, dir, atr)
+	}
+
+	if rs.def.CooldownBars > 0 {
+		ctx.LastSignal = candles[idx].Timestamp
+	}
+	return signal
+}
+
+// ruleTrace collects the labels and located zones of passing leaves
+type ruleTrace struct {
+	reasons  []string
+	evidence []Evidence
+}
+
+func (t *ruleTrace) add(other *ruleTrace) {
+	t.reasons = append(t.reasons, other.reasons...)
+	t.evidence = append(t.evidence, other.evidence...)
+}
+
+// evaluateSide returns whether a side fires, the reasons and evidence that passed and the confluence score
+func (rs *ruleStrategy) evaluateSide(ctx *EvalContext, side *RuleSide, candles []Candle, idx int) (bool, *ruleTrace, int) {
+	if side == nil {
+		return false, nil, 0
+	}
+
+	trace := &ruleTrace{reasons: []string{}}
+	if !rs.evalCondition(ctx, &side.When, candles, idx, trace) {
+		return false, nil, 0
+	}
+
+	score := 0
+	for i := range side.Confluence {
+		if rs.evalCondition(ctx, &side.Confluence[i], candles, idx, trace) {
+			score++
+		}
+	}
+	if score < side.MinConfluence {
+		return false, nil, 0
+	}
+	return true, trace, score
+}
+
+// evalCondition evaluates a rule node, appending the labels of passing leaves
+// to the trace together with the zones of located concepts. Higher timeframe
+// nodes run on the completed resampled bars with ctx's child context for
+// that timeframe.
+func (rs *ruleStrategy) evalCondition(ctx *EvalContext, c *RuleCondition, candles []Candle, idx int, trace *ruleTrace) bool {
+	if c.Timeframe != "" && c.Timeframe != rs.def.Timeframe {
+		frame := ctx.Frame(c.Timeframe)
+		htf, htfIdx := frame.completedBars(candles[:idx+1], c.Timeframe)
+		if htfIdx < 1 {
+			return false
+		}
+		frame.Sync(htf)
+		inner := *c
+		inner.Timeframe = ""
+		local := &ruleTrace{}
+		if !rs.evalCondition(frame, &inner, htf, htfIdx, local) {
+			return false
+		}
+		for _, r := range local.reasons {
+			trace.reasons = append(trace.reasons, r+" ("+c.Timeframe+")")
+		}
+		for _, e := range local.evidence {
+			if e.Timeframe == "" {
+				e.Timeframe = c.Timeframe
+			}
+			trace.evidence = append(trace.evidence, e)
+		}
+		return true
+	}
+
+	switch {
+	case len(c.All) > 0:
+		local := &ruleTrace{}
+		for i := range c.All {
+			if !rs.evalCondition(ctx, &c.All[i], candles, idx, local) {
+				return false
+			}
+		}
+		trace.add(local)
+	case len(c.Any) > 0:
+		passed := false
+		for i := range c.Any {
+			if rs.evalCondition(ctx, &c.Any[i], candles, idx, trace) {
+				passed = true
+			}
+		}
+		if !passed {
+			return false
+		}
+	case c.Not != nil:
+		if rs.evalCondition(ctx, c.Not, candles, idx, &ruleTrace{}) {
+			return false
+		}
+	case c.Concept != "":
+		if !checkConcept(candles, idx, c.Concept) {
+			return false
+		}
+		if e, ok := locateConcept(candles, idx, c.Concept); ok {
+			trace.evidence = append(trace.evidence, e)
+		}
+	case c.Detector != "":
+		if detector, ok := ruleDetectors[c.Detector]; ok {
+			if !detector(candles, idx) {
+				return false
+			}
+		} else if detector, ok := ruleContextDetectors[c.Detector]; !ok || !detector(ctx, candles, idx) {
+			return false
+		}
+	case c.Compare != nil:
+		if !rs.evalComparison(ctx, c.Compare, candles, idx) {
+			return false
+		}
+	default:
+		return false
+	}
+
+	if label := conditionLabel(c); label != "" {
+		trace.reasons = append(trace.reasons, label)
+	}
+	return true
+}
+
+func (rs *ruleStrategy) evalComparison(ctx *EvalContext, cmp *RuleComparison, candles []Candle, idx int) bool {
+	left, ok := rs.operandValue(ctx, &cmp.Left, candles, idx, 0)
+	if !ok {
+		return false
+	}
+	right, ok := rs.operandValue(ctx, &cmp.Right, candles, idx, 0)
+	if !ok {
+		return false
+	}
+
+	switch cmp.Op {
+	case ">":
+		return left > right
+	case ">=":
+		return left >= right
+	case "<":
+		return left < right
+	case "<=":
+		return left <= right
+	case "crosses_above", "crosses_below":
+		prevLeft, ok := rs.operandValue(ctx, &cmp.Left, candles, idx, 1)
+		if !ok {
+			return false
+		}
+		prevRight, ok := rs.operandValue(ctx, &cmp.Right, candles, idx, 1)
+		if !ok {
+			return false
+		}
+		if cmp.Op == "crosses_above" {
+			return prevLeft <= prevRight && left > right
+		}
+		return prevLeft >= prevRight && left < right
+	}
+	return false
+}
+
+// operandValue reads an operand at idx, shifted back by the operand offset plus extraOffset
+func (rs *ruleStrategy) operandValue(ctx *EvalContext, o *RuleOperand, candles []Candle, idx, extraOffset int) (float64, bool) {
+	var value float64
+
+	switch {
+	case o.Value != nil:
+		value = *o.Value
+	case o.Param != "":
+		v, ok := rs.params[o.Param]
+		if !ok {
+			return 0, false
+		}
+		value = v
+	default:
+		i := idx - o.Offset - extraOffset
+		if i < 0 || i < o.Period {
+			return 0, false
+		}
+		window := candles[:i+1]
+
+		switch o.Indicator {
+		case "open":
+			value = candles[i].Open
+		case "high":
+			value = candles[i].High
+		case "low":
+			value = candles[i].Low
+		case "close":
+			value = candles[i].Close
+		case "volume":
+			value = candles[i].Volume
+		case "sma":
+			value = indicators.SMA(window, o.Period)
+		case "ema":
+			value = indicators.EMA(window, o.Period)
+		case "rsi":
+			value = indicators.RSI(window, o.Period)
+		case "atr":
+			value = indicators.ATR(window, o.Period)
+		case "adx":
+			value = indicators.ADX(window, o.Period)
+		case "avg_volume":
+			value = calculateAverageVolume(candles, i, o.Period)
+		case "highest":
+			value = candles[i-o.Period].High
+			for j := i - o.Period + 1; j < i; j++ {
+				value = math.Max(value, candles[j].High)
+			}
+		case "lowest":
+			value = candles[i-o.Period].Low
+			for j := i - o.Period + 1; j < i; j++ {
+				value = math.Min(value, candles[j].Low)
+			}
+		case "naked_poc_above", "naked_poc_below", "naked_level_above", "naked_level_below", "prior_poc", "prior_vah", "prior_val":
+			v, ok := profileLevel(ctx, candles, i, o.Indicator)
+			if !ok {
+				return 0, false
+			}
+			value = v
+		case "avwap_session", "avwap_day", "avwap_week", "avwap_month", "avwap_swing_high", "avwap_swing_low":
+			bands, ok := avwapAt(ctx, candles, i, ruleAVWAPAnchors[o.Indicator], o.Period)
+			if !ok {
+				return 0, false
+			}
+			value = bands.Band(o.Band)
+		case "liquidity_above", "liquidity_below":
+			v, ok := ledgerLevel(ctx, candles, i, o.Indicator == "liquidity_above")
+			if !ok {
+				return 0, false
+			}
+			value = v
+		default:
+			return 0, false
+		}
+	}
+
+	if o.Multiplier != 0 {
+		value *= o.Multiplier
+	}
+	return value, true
+}
+
+// conditionLabel is the reason text for a passing leaf condition
+func conditionLabel(c *RuleCondition) string {
+	if c.Label != "" {
+		return c.Label
+	}
+	switch {
+	case c.Concept != "":
+		return c.Concept
+	case c.Detector != "":
+		return strings.ReplaceAll(c.Detector, "_", " ")
+	case c.Compare != nil:
+		return fmt.Sprintf("%s %s %s", operandLabel(c.Compare.Left), c.Compare.Op, operandLabel(c.Compare.Right))
+	}
+	return ""
+}
+
+func operandLabel(o RuleOperand) string {
+	var label string
+	switch {
+	case o.Value != nil:
+		label = fmt.Sprintf("%.4g", *o.Value)
+	case o.Param != "":
+		label = o.Param
+	case o.Period > 0:
+		label = fmt.Sprintf("%s(%d)", o.Indicator, o.Period)
+	default:
+		label = o.Indicator
+	}
+	if o.Band != 0 {
+		label = fmt.Sprintf("%s%+.4gsd", label, o.Band)
+	}
+	if o.Offset > 0 {
+		label = fmt.Sprintf("%s[-%d]", label, o.Offset)
+	}
+	if o.Multiplier != 0 {
+		label = fmt.Sprintf("%.4gx %s", o.Multiplier, label)
+	}
+	return label
+}
+
+// collectConcepts gathers unique concept and detector names for strategy metadata
+func collectConcepts(c *RuleCondition, out *[]string) {
+	name := c.Concept
+	if name == "" {
+		name = c.Detector
+	}
+	if name != "" {
+		for _, existing := range *out {
+			if existing == name {
+				return
+			}
+		}
+		*out = append(*out, name)
+	}
+	if c.Not != nil {
+		collectConcepts(c.Not, out)
+	}
+	for i := range c.All {
+		collectConcepts(&c.All[i], out)
+	}
+	for i := range c.Any {
+		collectConcepts(&c.Any[i], out)
+	}
+}
+
+// ==================== DETECTOR HELPERS ====================
+
+func detectorWindow(candles []Candle, idx int) []Candle {
+	start := idx + 1 - ruleDetectorLookback
+	if start < 0 {
+		start = 0
+	}
+	return candles[start : idx+1]
+}
+
+// priceInOrderBlock reports whether the close trades inside an unmitigated order block
+func priceInOrderBlock(candles []Candle, idx int, obType string) bool {
+	price := candles[idx].Close
+	for _, ob := range FindOrderBlocks(detectorWindow(candles, idx)) {
+		if ob.Type == obType && !ob.Mitigated && price >= ob.Low && price <= ob.High {
+			return true
+		}
+	}
+	return false
+}
+
+// priceInFVG reports whether the close trades inside an unfilled fair value gap
+func priceInFVG(candles []Candle, idx int, fvgType string) bool {
+	price := candles[idx].Close
+	for _, fvg := range FindFairValueGaps(detectorWindow(candles, idx)) {
+		if fvg.Type == fvgType && !fvg.Filled && price >= fvg.Low && price <= fvg.High {
+			return true
+		}
+	}
+	return false
+}
+
+// recentSweep reports whether a sweep of the given side happened in the last 10 bars
+func recentSweep(candles []Candle, idx int, sweepType string) bool {
+	analysis := PerformLiquiditySweepAnalysis(detectorWindow(candles, idx))
+	return analysis.RecentSweep != nil && analysis.RecentSweep.Type == sweepType
+}
+
+func premiumDiscountZone(candles []Candle, idx int) string {
+	zone, _ := CalculatePremiumDiscount(detectorWindow(candles, idx))
+	return zone
+}
+
+// ==================== PROFILE HELPERS ====================
+
+// profileAt returns the day and session profiles of the bars up to idx. The
+// bar ctx is synced to is served by its incremental tracker; earlier bars
+// (operand offsets) are analyzed from scratch.
+func profileAt(ctx *EvalContext, candles []Candle, idx int) *profile.Analysis {
+	if back, ok := ctx.barsBack(candles, idx); ok && back == 0 {
+		return ctx.Profile()
+	}
+	from := candles[idx].Timestamp - profileDays*24*60*60*1000
+	start := idx
+	for start > 0 && candles[start-1].Timestamp >= from {
+		start--
+	}
+	analysis, err := profile.Analyze(candles[start:idx+1], profile.DefaultConfig())
+	if err != nil {
+		return nil
+	}
+	return analysis
+}
+
+// profileLevel reads a volume profile operand at idx
+func profileLevel(ctx *EvalContext, candles []Candle, idx int, name string) (float64, bool) {
+	analysis := profileAt(ctx, candles, idx)
+	if analysis == nil {
+		return 0, false
+	}
+
+	var level *profile.Level
+	switch name {
+	case "naked_poc_above":
+		level, _ = analysis.Nearest(analysis.Price, "poc")
+	case "naked_poc_below":
+		_, level = analysis.Nearest(analysis.Price, "poc")
+	case "naked_level_above":
+		level, _ = analysis.Nearest(analysis.Price)
+	case "naked_level_below":
+		_, level = analysis.Nearest(analysis.Price)
+	default:
+		prior := analysis.LastComplete(profile.KindDay)
+		if prior == nil {
+			return 0, false
+		}
+		switch name {
+		case "prior_poc":
+			return prior.POC, true
+		case "prior_vah":
+			return prior.VAH, true
+		case "prior_val":
+			return prior.VAL, true
+		}
+	}
+	if level == nil {
+		return 0, false
+	}
+	return level.Price, true
+}
+
+// priorValuePosition places the close against the previous day's value area
+func priorValuePosition(ctx *EvalContext, candles []Candle, idx int) string {
+	analysis := profileAt(ctx, candles, idx)
+	if analysis == nil {
+		return ""
+	}
+	prior := analysis.LastComplete(profile.KindDay)
+	if prior == nil {
+		return ""
+	}
+	switch price := candles[idx].Close; {
+	case price > prior.VAH:
+		return "above"
+	case price < prior.VAL:
+		return "below"
+	}
+	return "inside"
+}
+
+// applyProfileTargets moves TP1 to the nearest naked level in the trade
+// direction and tightens the stop to just beyond the nearest naked level
+// behind the entry, keeping it at least half an ATR away. Later targets
+// never sit inside TP1.
+func applyProfileTargets(ctx *EvalContext, signal *AdvancedSignal, candles []Candle, idx int, dir, atr float64) {
+	analysis := profileAt(ctx, candles, idx)
+	if analysis == nil {
+		return
+	}
+	target, invalidation := analysis.TradeLevels(signal.Type, signal.Entry)
+	if target != nil {
+		signal.TP1 = target.Price
+		if (signal.TP2-signal.TP1)*dir < 0 {
+			signal.TP2 = signal.TP1
+		}
+		if (signal.TP3-signal.TP2)*dir < 0 {
+			signal.TP3 = signal.TP2
+		}
+		signal.Reasons = append(signal.Reasons, fmt.Sprintf("Target naked %s %s %.4g", target.Profile, strings.ToUpper(target.Kind), target.Price))
+	}
+	if invalidation != nil {
+		stop := invalidation.Price - dir*atr*0.25
+		if (stop-signal.StopLoss)*dir > 0 && math.Abs(signal.Entry-stop) >= atr*0.5 {
+			signal.StopLoss = stop
+			signal.Reasons = append(signal.Reasons, fmt.Sprintf("Invalidation beyond naked %s %s %.4g", invalidation.Profile, strings.ToUpper(invalidation.Kind), invalidation.Price))
+		}
+	}
+	if risk := math.Abs(signal.Entry - signal.StopLoss); risk > 0 {
+		signal.RR = math.Abs(signal.TP1-signal.Entry) / risk
+	}
+}
+
+// ==================== ANCHORED VWAP HELPERS ====================
+
+// ruleAVWAPAnchors maps the avwap_* operands to their anchor kinds
+var ruleAVWAPAnchors = map[string]string{
+	"avwap_session":    indicators.AnchorSession,
+	"avwap_day":        indicators.AnchorDay,
+	"avwap_week":       indicators.AnchorWeek,
+	"avwap_month":      indicators.AnchorMonth,
+	"avwap_swing_high": indicators.AnchorSwingHigh,
+	"avwap_swing_low":  indicators.AnchorSwingLow,
+}
+
+// avwapAt returns the anchored VWAP of the bars up to idx, read from the
+// incremental tracker on ctx. Bars older than its history are scanned.
+// strength is the swing pivot strength (0 = default) and is ignored by
+// calendar anchors.
+func avwapAt(ctx *EvalContext, candles []Candle, idx int, kind string, strength int) (indicators.VWAPBands, bool) {
+	spec := indicators.AnchorSpec{Kind: kind, Strength: strength}
+	if err := spec.Validate(); err != nil {
+		return indicators.VWAPBands{}, false
+	}
+	if back, ok := ctx.barsBack(candles, idx); ok && back < indicators.HistoryLen {
+		return ctx.Indicators.AVWAPAt(spec, back)
+	}
+	return indicators.AnchoredVWAPAt(candles[:idx+1], spec)
+}
+
+// avwapCross reports whether the close crossed the anchored VWAP on bar idx:
+// "above" (a reclaim), "below" (a loss) or "" (no cross)
+func avwapCross(ctx *EvalContext, candles []Candle, idx int, kind string) string {
+	if idx < 1 {
+		return ""
+	}
+	prev, ok := avwapAt(ctx, candles, idx-1, kind, 0)
+	if !ok {
+		return ""
+	}
+	cur, ok := avwapAt(ctx, candles, idx, kind, 0)
+	if !ok {
+		return ""
+	}
+	switch {
+	case candles[idx-1].Close < prev.VWAP && candles[idx].Close > cur.VWAP:
+		return "above"
+	case candles[idx-1].Close > prev.VWAP && candles[idx].Close < cur.VWAP:
+		return "below"
+	}
+	return ""
+}
+
+// ==================== LIQUIDITY LEDGER HELPERS ====================
+
+// ruleSweepBars is how many bars back a *_swept detector accepts a sweep
+const ruleSweepBars = 3
+
+// liquidityAt runs fn with a ledger book that has seen the bars up to idx
+// and the timestamp to query it at. The run's book on ctx answers any bar of
+// the series ctx is synced to from its history; other series get a fresh book.
+func liquidityAt(ctx *EvalContext, candles []Candle, idx int, fn func(book *liquidity.Book, ts int64)) {
+	if _, ok := ctx.barsBack(candles, idx); ok {
+		fn(ctx.Liquidity(), candles[idx].Timestamp)
+		return
+	}
+	book := liquidity.NewBook("", "", liquidity.DefaultConfig())
+	book.Update(candles[:idx+1])
+	fn(book, candles[idx].Timestamp)
+}
+
+// recentLedgerSweep reports whether a level of source and side was swept and
+// rejected (closed back inside) within the last ruleSweepBars bars
+func recentLedgerSweep(ctx *EvalContext, candles []Candle, idx int, source, side string) bool {
+	from := idx - ruleSweepBars + 1
+	if from < 0 {
+		from = 0
+	}
+	found := false
+	liquidityAt(ctx, candles, idx, func(book *liquidity.Book, ts int64) {
+		for _, s := range book.SweptSince(candles[from].Timestamp, source) {
+			if s.Time <= ts && s.Side == side && s.Rejected {
+				found = true
+			}
+		}
+	})
+	return found
+}
+
+// ledgerLevel returns the nearest untouched ledger level above or below the close
+func ledgerLevel(ctx *EvalContext, candles []Candle, idx int, above bool) (float64, bool) {
+	var level *liquidity.Level
+	liquidityAt(ctx, candles, idx, func(book *liquidity.Book, ts int64) {
+		up, down := book.NearestAt(candles[idx].Close, ts)
+		level = down
+		if above {
+			level = up
+		}
+	})
+	if level == nil {
+		return 0, false
+	}
+	return level.Price, true
+}
+
+// ==================== TIMEFRAME HELPERS ====================
+
+// parseTimeframeMillis converts "1m", "15m", "1h", "4h", "1d", "1w" to milliseconds
+func parseTimeframeMillis(tf string) (int64, error) {
+	if len(tf) < 2 {
+		return 0, fmt.Errorf("invalid timeframe %q (use e.g. 5m, 15m, 1h, 4h, 1d)", tf)
+	}
+
+	var n int64
+	if _, err := fmt.Sscanf(tf[:len(tf)-1], "%d", &n); err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid timeframe %q (use e.g. 5m, 15m, 1h, 4h, 1d)", tf)
+	}
+
+	switch tf[len(tf)-1] {
+	case 'm':
+		return n * 60 * 1000, nil
+	case 'h':
+		return n * 60 * 60 * 1000, nil
+	case 'd':
+		return n * 24 * 60 * 60 * 1000, nil
+	case 'w':
+		return n * 7 * 24 * 60 * 60 * 1000, nil
+	}
+	return 0, fmt.Errorf("invalid timeframe %q (use e.g. 5m, 15m, 1h, 4h, 1d)", tf)
+}
+
+// resampleCompleted aggregates candles into a higher timeframe and returns the
+// bars plus the index of the last completed one, so rules never see a bar
+// that is still forming.
+func resampleCompleted(candles []Candle, tf string) ([]Candle, int) {
+	frameMs, err := parseTimeframeMillis(tf)
+	if err != nil {
+		return nil, -1
+	}
+	return (&htfBars{frameMs: frameMs}).update(candles)
+}
+
+// completedBars is resampleCompleted for a frame context: the bars are kept
+// on ctx and only the base bars added since the last call are aggregated.
+func (ctx *EvalContext) completedBars(candles []Candle, tf string) ([]Candle, int) {
+	if ctx.htf == nil {
+		frameMs, err := parseTimeframeMillis(tf)
+		if err != nil {
+			return nil, -1
+		}
+		ctx.htf = &htfBars{frameMs: frameMs}
+	}
+	return ctx.htf.update(candles)
+}
+
+// htfBars aggregates a base window into higher timeframe bars. Like the
+// indicators Tracker, a window that continues the one seen last time only
+// feeds its new bars; anything else (a gap, a rewritten last bar, another
+// symbol) replays the window from scratch.
+type htfBars struct {
+	frameMs   int64
+	bars      []Candle // From the window's first bucket; the last may be forming
+	lastTime  int64
+	lastClose float64
+}
+
+func (h *htfBars) update(candles []Candle) ([]Candle, int) {
+	if len(candles) < 2 {
+		return nil, -1
+	}
+	baseMs := candles[len(candles)-1].Timestamp - candles[len(candles)-2].Timestamp
+	if baseMs <= 0 {
+		return nil, -1
+	}
+
+	start := h.continuation(candles)
+	if start < 0 {
+		h.bars = nil
+		start = 0
+	}
+	for _, c := range candles[start:] {
+		h.feed(c)
+	}
+	lastBar := candles[len(candles)-1]
+	h.lastTime, h.lastClose = lastBar.Timestamp, lastBar.Close
+
+	// Drop the buckets a sliding window has moved past
+	first := candles[0].Timestamp - candles[0].Timestamp%h.frameMs
+	drop := 0
+	for drop < len(h.bars)-1 && h.bars[drop].Timestamp < first {
+		drop++
+	}
+	h.bars = h.bars[drop:]
+
+	last := len(h.bars) - 1
+	if lastBar.Timestamp+baseMs < h.bars[last].Timestamp+h.frameMs {
+		last-- // Still forming
+	}
+	return h.bars[: last+1 : last+1], last
+}
+
+// continuation returns the index of the first unseen bar, or -1 when the
+// window does not continue the previously fed one
+func (h *htfBars) continuation(candles []Candle) int {
+	if len(h.bars) == 0 {
+		return -1
+	}
+	for i := len(candles) - 1; i >= 0 && candles[i].Timestamp >= h.lastTime; i-- {
+		if candles[i].Timestamp == h.lastTime {
+			if candles[i].Close != h.lastClose {
+				return -1 // the last bar was still forming when it was fed
+			}
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func (h *htfBars) feed(c Candle) {
+	bucket := c.Timestamp - c.Timestamp%h.frameMs
+	if n := len(h.bars); n > 0 && h.bars[n-1].Timestamp == bucket {
+		last := &h.bars[n-1]
+		last.High = math.Max(last.High, c.High)
+		last.Low = math.Min(last.Low, c.Low)
+		last.Close = c.Close
+		last.Volume += c.Volume
+		return
+	}
+	h.bars = append(h.bars, Candle{
+		Timestamp: bucket,
+		Open:      c.Open,
+		High:      c.High,
+		Low:       c.Low,
+		Close:     c.Close,
+		Volume:    c.Volume,
+	})
+}
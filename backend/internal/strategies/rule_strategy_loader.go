@@ -0,0 +1,152 @@
+package strategies
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultRuleStrategiesDir is where rule strategy files are loaded from when RULE_STRATEGIES_DIR is unset
+const DefaultRuleStrategiesDir = "./rule_strategies"
+
+// RuleStrategiesDir returns the directory rule strategy files are loaded from
+func RuleStrategiesDir() string {
+	if dir := os.Getenv("RULE_STRATEGIES_DIR"); dir != "" {
+		return dir
+	}
+	return DefaultRuleStrategiesDir
+}
+
+// ParseRuleStrategy decodes a YAML or JSON definition. format may be "yaml",
+// "json" or empty to detect it from the content.
+func ParseRuleStrategy(data []byte, format string) (*RuleStrategyDefinition, error) {
+	if format == "" {
+		format = "yaml"
+		if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+			format = "json"
+		}
+	}
+
+	var def RuleStrategyDefinition
+	switch format {
+	case "json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&def); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+	case "yaml", "yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&def); err != nil {
+			return nil, fmt.Errorf("invalid YAML: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format %q (use yaml or json)", format)
+	}
+
+	return &def, nil
+}
+
+// RegisterRuleStrategy validates a definition and adds it to the shared registry.
+// An existing rule strategy with the same name is replaced; built-ins cannot be overridden.
+func RegisterRuleStrategy(def RuleStrategyDefinition) (Strategy, error) {
+	strategy, err := NewRuleStrategy(def)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing, ok := GetStrategy(def.Name); ok {
+		if _, isRule := existing.(*ruleStrategy); !isRule {
+			return nil, fmt.Errorf("strategy %q is built in and cannot be replaced by a rule strategy", def.Name)
+		}
+		strategyRegistry.Unregister(def.Name)
+	}
+
+	if err := strategyRegistry.Register(strategy); err != nil {
+		return nil, err
+	}
+	return strategy, nil
+}
+
+// UnregisterRuleStrategy removes a rule strategy; built-ins are left untouched
+func UnregisterRuleStrategy(name string) error {
+	existing, ok := GetStrategy(name)
+	if !ok {
+		return fmt.Errorf("strategy not found: %s", name)
+	}
+	if _, isRule := existing.(*ruleStrategy); !isRule {
+		return fmt.Errorf("strategy %q is built in and cannot be removed", name)
+	}
+	strategyRegistry.Unregister(name)
+	return nil
+}
+
+// LoadRuleStrategyFile parses, validates and registers one .yaml/.yml/.json file
+func LoadRuleStrategyFile(path string) (Strategy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	def, err := ParseRuleStrategy(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	strategy, err := RegisterRuleStrategy(*def)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return strategy, nil
+}
+
+// LoadRuleStrategiesDir loads every rule strategy file in dir. A bad file does
+// not stop the others from loading; its error is returned alongside.
+func LoadRuleStrategiesDir(dir string) ([]string, []error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, []error{err}
+	}
+
+	loaded := []string{}
+	errs := []error{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+
+		strategy, err := LoadRuleStrategyFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		loaded = append(loaded, strategy.Metadata().Name)
+	}
+	return loaded, errs
+}
+
+// ListRuleStrategyDefinitions returns the definitions of all registered rule strategies
+func ListRuleStrategyDefinitions() []RuleStrategyDefinition {
+	defs := []RuleStrategyDefinition{}
+	for _, s := range ListStrategies() {
+		if rs, ok := s.(*ruleStrategy); ok {
+			defs = append(defs, rs.Definition())
+		}
+	}
+	return defs
+}
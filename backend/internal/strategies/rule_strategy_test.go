@@ -0,0 +1,306 @@
+package strategies
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const (
+	ruleHourMs = int64(60 * 60 * 1000)
+	ruleT0     = 100000 * 4 * ruleHourMs // On a 4h boundary
+)
+
+// ruleCandles builds hourly bars two points tall around each close, so ATR(14) is 2
+func ruleCandles(closes ...float64) []Candle {
+	candles := make([]Candle, len(closes))
+	for i, c := range closes {
+		candles[i] = Candle{Timestamp: ruleT0 + int64(i)*ruleHourMs, Open: c, High: c + 1, Low: c - 1, Close: c, Volume: 100}
+	}
+	return candles
+}
+
+func flatCandles(n int, close float64) []Candle {
+	closes := make([]float64, n)
+	for i := range closes {
+		closes[i] = close
+	}
+	return ruleCandles(closes...)
+}
+
+func ruleValue(v float64) *float64 { return &v }
+
+// closeAbove is a leaf that passes while the close is above v
+func closeAbove(v float64, label string) RuleCondition {
+	return RuleCondition{
+		Compare: &RuleComparison{Left: RuleOperand{Indicator: "close"}, Op: ">", Right: RuleOperand{Value: ruleValue(v)}},
+		Label:   label,
+	}
+}
+
+func newTestRule(t *testing.T, long, short *RuleSide, cooldown int) *ruleStrategy {
+	t.Helper()
+	s, err := NewRuleStrategy(RuleStrategyDefinition{
+		Name:         "test_rules",
+		Timeframe:    "1h",
+		CooldownBars: cooldown,
+		Long:         long,
+		Short:        short,
+		Exits:        RuleExits{StopATR: 1, TP1ATR: 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s.(*ruleStrategy)
+}
+
+func TestRuleValidateCollectsEveryError(t *testing.T) {
+	def := RuleStrategyDefinition{
+		Name:      "bad_rules",
+		Timeframe: "1h",
+		Params:    []ParamSpec{{Name: "threshold", Default: 30, Min: 0, Max: 100}},
+		Long: &RuleSide{
+			When: RuleCondition{All: []RuleCondition{
+				{Concept: "Moon Phase"},
+				{Detector: "bullish_unicorn"},
+				{Compare: &RuleComparison{Left: RuleOperand{Indicator: "supertrend"}, Op: ">", Right: RuleOperand{Param: "threshold"}}},
+				{Compare: &RuleComparison{Left: RuleOperand{Indicator: "ema"}, Op: ">", Right: RuleOperand{Param: "undeclared"}}},
+				{Detector: "pin_bar", Timeframe: "15m"},
+			}},
+			Confluence:    []RuleCondition{{Concept: "Fair Value Gap"}},
+			MinConfluence: 2,
+		},
+		Exits: RuleExits{StopATR: 1, TP1ATR: 2},
+	}
+
+	want := []string{
+		`long.when.all[0].concept: unknown concept "Moon Phase"`,
+		`long.when.all[1].detector: unknown detector "bullish_unicorn"`,
+		`long.when.all[2].compare.left.indicator: unknown indicator "supertrend"`,
+		`long.when.all[3].compare.left.period: ema needs a period greater than 0`,
+		`long.when.all[3].compare.right.param: "undeclared" is not declared in params`,
+		`long.when.all[4].timeframe: 15m is lower than the strategy timeframe 1h`,
+		`long.minConfluence: must be between 0 and 1`,
+	}
+
+	_, err := NewRuleStrategy(def)
+	var verr *RuleValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("want a *RuleValidationError, got %v", err)
+	}
+	if verr.Strategy != "bad_rules" || len(verr.Errors) != len(want) {
+		t.Fatalf("got %d errors for %q, want %d: %q", len(verr.Errors), verr.Strategy, len(want), verr.Errors)
+	}
+	for i, w := range want {
+		if !strings.HasPrefix(verr.Errors[i], w) {
+			t.Errorf("error %d = %q, want %q", i, verr.Errors[i], w)
+		}
+	}
+}
+
+func TestParseRuleStrategyRejectsUnknownFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		format string
+		ok     bool
+	}{
+		{"yaml", "name: y\ntimeframe: 1h\nlong:\n  when:\n    detector: pin_bar\nexits:\n  stopAtr: 1\n  tp1Atr: 2\n", "yaml", true},
+		{"yaml typo", "name: y\ntimeframe: 1h\nlong:\n  when:\n    detector: pin_bar\n    lable: typo\nexits:\n  stopAtr: 1\n  tp1Atr: 2\n", "yaml", false},
+		{"json detected", `{"name": "j", "timeframe": "1h", "long": {"when": {"detector": "pin_bar"}}, "exits": {"stopAtr": 1, "tp1Atr": 2}}`, "", true},
+		{"json typo", `{"name": "j", "timeframe": "1h", "cooldown": 3, "exits": {"stopAtr": 1, "tp1Atr": 2}}`, "json", false},
+		{"unsupported format", "name = 't'", "toml", false},
+	}
+	for _, tt := range tests {
+		def, err := ParseRuleStrategy([]byte(tt.data), tt.format)
+		if !tt.ok {
+			if err == nil {
+				t.Errorf("%s: expected an error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if def.Long == nil || def.Long.When.Detector != "pin_bar" || def.Exits.TP1ATR != 2 {
+			t.Errorf("%s: decoded %+v", tt.name, def)
+		}
+	}
+}
+
+func TestRuleConditionLogic(t *testing.T) {
+	candles := flatCandles(30, 10)
+	yes, also, no := closeAbove(5, "yes"), closeAbove(8, "also"), closeAbove(15, "no")
+	rs := newTestRule(t, &RuleSide{When: yes}, nil, 0)
+
+	tests := []struct {
+		name    string
+		cond    RuleCondition
+		want    bool
+		reasons []string
+	}{
+		{"all passes", RuleCondition{All: []RuleCondition{yes, also}}, true, []string{"yes", "also"}},
+		{"all fails on one", RuleCondition{All: []RuleCondition{yes, no}}, false, nil},
+		{"any passes", RuleCondition{Any: []RuleCondition{no, yes}}, true, []string{"yes"}},
+		{"any fails", RuleCondition{Any: []RuleCondition{no, no}}, false, nil},
+		{"not of a failure", RuleCondition{Not: &no}, true, nil},
+		{"not of a pass", RuleCondition{Not: &yes}, false, nil},
+		{"nested", RuleCondition{All: []RuleCondition{{Any: []RuleCondition{no, also}}, {Not: &no}}}, true, []string{"also"}},
+	}
+	for _, tt := range tests {
+		trace := &ruleTrace{}
+		got := rs.evalCondition(NewEvalContext(), &tt.cond, candles, 29, trace)
+		if got != tt.want || strings.Join(trace.reasons, ",") != strings.Join(tt.reasons, ",") {
+			t.Errorf("%s: got %v %q, want %v %q", tt.name, got, trace.reasons, tt.want, tt.reasons)
+		}
+	}
+}
+
+func TestRuleSignalSides(t *testing.T) {
+	candles := flatCandles(30, 10)
+	yes, no := &RuleSide{When: closeAbove(5, "yes")}, &RuleSide{When: closeAbove(15, "no")}
+
+	if s := newTestRule(t, yes, yes, 0).Evaluate(candles, 29); s != nil {
+		t.Errorf("both sides firing should give no signal, got %s", s.Type)
+	}
+	if s := newTestRule(t, no, no, 0).Evaluate(candles, 29); s != nil {
+		t.Errorf("neither side firing should give no signal, got %s", s.Type)
+	}
+
+	// ATR(14) is 2: stop 1 ATR and TP1 2 ATR from the close of 10
+	long := newTestRule(t, yes, no, 0).Evaluate(candles, 29)
+	if long == nil || long.Type != "BUY" || long.StopLoss != 8 || long.TP1 != 14 || long.RR != 2 {
+		t.Errorf("long signal %+v", long)
+	}
+	short := newTestRule(t, no, yes, 0).Evaluate(candles, 29)
+	if short == nil || short.Type != "SELL" || short.StopLoss != 12 || short.TP1 != 6 {
+		t.Errorf("short signal %+v", short)
+	}
+}
+
+func TestRuleCrossesWithOffsets(t *testing.T) {
+	candles := ruleCandles(10, 12, 9, 11, 11, 8)
+	rs := newTestRule(t, &RuleSide{When: closeAbove(0, "")}, nil, 0)
+
+	tests := []struct {
+		op     string
+		offset int
+		idx    int
+		want   bool
+	}{
+		{"crosses_above", 0, 3, true},  // 9 -> 11
+		{"crosses_above", 0, 4, false}, // 11 -> 11 was already above
+		{"crosses_above", 1, 4, true},  // Bars 2 -> 3
+		{"crosses_above", 3, 3, false}, // Nothing before bar 0
+		{"crosses_below", 0, 2, true},  // 12 -> 9
+		{"crosses_below", 0, 5, true},  // 11 -> 8
+		{"crosses_below", 2, 4, true},  // Bars 1 -> 2
+		{"crosses_below", 1, 5, false}, // 11 -> 11
+	}
+	for _, tt := range tests {
+		cmp := &RuleComparison{
+			Left:  RuleOperand{Indicator: "close", Offset: tt.offset},
+			Op:    tt.op,
+			Right: RuleOperand{Value: ruleValue(10)},
+		}
+		if got := rs.evalComparison(NewEvalContext(), cmp, candles, tt.idx); got != tt.want {
+			t.Errorf("close[-%d] %s 10 at bar %d = %v, want %v", tt.offset, tt.op, tt.idx, got, tt.want)
+		}
+	}
+}
+
+func TestRuleCooldownLivesOnContext(t *testing.T) {
+	candles := flatCandles(30, 10)
+	rs := newTestRule(t, &RuleSide{When: closeAbove(5, "yes")}, nil, 3)
+
+	ctx := NewEvalContext()
+	fired := []int{}
+	for idx := 20; idx < 30; idx++ {
+		if rs.EvaluateWith(ctx, candles, idx) != nil {
+			fired = append(fired, idx)
+		}
+	}
+	if len(fired) != 4 || fired[0] != 20 || fired[1] != 23 || fired[2] != 26 || fired[3] != 29 {
+		t.Errorf("signals at %v, want every third bar from 20", fired)
+	}
+	if ctx.LastSignal != candles[29].Timestamp {
+		t.Errorf("LastSignal = %d, want bar 29's timestamp", ctx.LastSignal)
+	}
+
+	// Another context, a fresh Evaluate and a rewound context are not held back
+	if rs.EvaluateWith(NewEvalContext(), candles, 21) == nil || rs.Evaluate(candles, 21) == nil {
+		t.Error("a cooldown leaked out of its context")
+	}
+	if rs.EvaluateWith(ctx, candles, 20) == nil {
+		t.Error("a rewound context should not be in cooldown")
+	}
+}
+
+func TestResampleCompletedDropsFormingBar(t *testing.T) {
+	closes := make([]float64, 12)
+	for i := range closes {
+		closes[i] = float64(i + 1)
+	}
+	candles := ruleCandles(closes...)
+	const fourHours = 4 * ruleHourMs
+
+	tests := []struct {
+		name string
+		n    int
+		last int
+	}{
+		{"third bar forming", 10, 1},
+		{"second bar just completed", 8, 1},
+		{"all complete", 12, 2},
+		{"only a forming bar", 3, -1},
+	}
+	for _, tt := range tests {
+		htf, last := resampleCompleted(candles[:tt.n], "4h")
+		if last != tt.last || len(htf) != tt.last+1 {
+			t.Errorf("%s: %d bars, last %d; want last %d", tt.name, len(htf), last, tt.last)
+		}
+	}
+
+	htf, _ := resampleCompleted(candles[:10], "4h")
+	want := []Candle{
+		{Timestamp: ruleT0, Open: 1, High: 5, Low: 0, Close: 4, Volume: 400},
+		{Timestamp: ruleT0 + fourHours, Open: 5, High: 9, Low: 4, Close: 8, Volume: 400},
+	}
+	for i, w := range want {
+		if htf[i] != w {
+			t.Errorf("bar %d = %+v, want %+v", i, htf[i], w)
+		}
+	}
+}
+
+func TestCompletedBarsContinuesWindow(t *testing.T) {
+	closes := make([]float64, 24)
+	for i := range closes {
+		closes[i] = float64(100 + i%5)
+	}
+	candles := ruleCandles(closes...)
+	rewritten := append([]Candle{}, candles[:12]...)
+	rewritten[11].Close = 90
+
+	// Growing, sliding and rewritten windows all match a full resample
+	windows := [][]Candle{}
+	for n := 2; n <= len(candles); n++ {
+		windows = append(windows, candles[:n])
+	}
+	windows = append(windows, candles[4:], candles[8:20], rewritten, candles)
+
+	ctx := NewEvalContext().Frame("4h")
+	for _, window := range windows {
+		got, gotLast := ctx.completedBars(window, "4h")
+		want, wantLast := resampleCompleted(window, "4h")
+		if gotLast != wantLast || len(got) != len(want) {
+			t.Fatalf("window of %d from %d: last %d, want %d", len(window), window[0].Timestamp, gotLast, wantLast)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("window of %d from %d: bar %d = %+v, want %+v", len(window), window[0].Timestamp, i, got[i], want[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package strategies
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// StrategyParams holds resolved parameter values keyed by ParamSpec.Name
+type StrategyParams map[string]float64
+
+// Get returns a parameter value, or fallback when it is not set
+func (p StrategyParams) Get(name string, fallback float64) float64 {
+	if v, ok := p[name]; ok {
+		return v
+	}
+	return fallback
+}
+
+// ConfigurableStrategy is implemented by strategies that accept parameter overrides
+type ConfigurableStrategy interface {
+	Strategy
+	WithParams(params StrategyParams) Strategy
+}
+
+// ParamValidationError lists every override that does not match a schema
+type ParamValidationError struct {
+	Strategy string   `json:"strategy"`
+	Errors   []string `json:"errors"`
+}
+
+func (e *ParamValidationError) Error() string {
+	return fmt.Sprintf("invalid parameters for %s: %s", e.Strategy, strings.Join(e.Errors, "; "))
+}
+
+// ResolveParams validates overrides against a schema and merges them over the defaults
+func ResolveParams(name string, schema []ParamSpec, overrides map[string]float64) (StrategyParams, error) {
+	specs := make(map[string]ParamSpec, len(schema))
+	resolved := make(StrategyParams, len(schema))
+	for _, spec := range schema {
+		specs[spec.Name] = spec
+		resolved[spec.Name] = spec.Default
+	}
+
+	keys := make([]string, 0, len(overrides))
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	errs := []string{}
+	for _, key := range keys {
+		value := overrides[key]
+		spec, ok := specs[key]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("%s: unknown parameter", key))
+			continue
+		}
+
+		switch spec.Type {
+		case "int":
+			if value != math.Trunc(value) {
+				errs = append(errs, fmt.Sprintf("%s: %v must be an integer", key, value))
+				continue
+			}
+		case "bool":
+			if value != 0 && value != 1 {
+				errs = append(errs, fmt.Sprintf("%s: %v must be 0 or 1", key, value))
+				continue
+			}
+		}
+		if (spec.Min != 0 || spec.Max != 0) && (value < spec.Min || value > spec.Max) {
+			errs = append(errs, fmt.Sprintf("%s: %v is outside [%v, %v]", key, value, spec.Min, spec.Max))
+			continue
+		}
+		if !spec.OnStep(value) {
+			errs = append(errs, fmt.Sprintf("%s: %v is not a multiple of %v from %v", key, value, spec.Step, spec.Min))
+			continue
+		}
+		resolved[key] = value
+	}
+
+	if len(errs) > 0 {
+		return nil, &ParamValidationError{Strategy: name, Errors: errs}
+	}
+	return resolved, nil
+}
+
+// OnStep reports whether v lies a whole number of steps above Min. Without a
+// step every value does, and so does the default, which rule strategies may
+// set between steps.
+func (p ParamSpec) OnStep(v float64) bool {
+	if p.Step <= 0 || v == p.Default {
+		return true
+	}
+	steps := (v - p.Min) / p.Step
+	return math.Abs(steps-math.Round(steps)) < 1e-6
+}
+
+// ConfigureStrategy looks up a strategy and applies validated overrides.
+// With no overrides the registered strategy is returned unchanged.
+func ConfigureStrategy(name string, overrides map[string]float64) (Strategy, error) {
+	strategy, ok := GetStrategy(name)
+	if !ok {
+		return nil, fmt.Errorf("strategy not found: %s", name)
+	}
+	if len(overrides) == 0 {
+		return strategy, nil
+	}
+
+	params, err := ResolveParams(name, strategy.ParamSchema(), overrides)
+	if err != nil {
+		return nil, err
+	}
+	configurable, ok := strategy.(ConfigurableStrategy)
+	if !ok {
+		return nil, fmt.Errorf("strategy %s does not accept parameter overrides", name)
+	}
+	return configurable.WithParams(params), nil
+}
+
+// ValidateStrategyParams checks overrides for a registered strategy or a
+// component with a registered schema (see RegisterParamSchema) without building it
+func ValidateStrategyParams(name string, overrides map[string]float64) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+	if _, ok := GetStrategy(name); !ok {
+		if schema, ok := componentParamSchema(name); ok {
+			_, err := ResolveParams(name, schema, overrides)
+			return err
+		}
+	}
+	_, err := ConfigureStrategy(name, overrides)
+	return err
+}
+
+// Parameter schemas for components that are not bar-by-bar registry strategies
+// (e.g. the professional generator) so they are still discoverable through the API.
+var (
+	componentParamSchemas   = make(map[string]func() []ParamSpec)
+	componentParamSchemasMu sync.RWMutex
+)
+
+// RegisterParamSchema exposes a component's parameter schema under a name
+func RegisterParamSchema(name string, schema func() []ParamSpec) {
+	componentParamSchemasMu.Lock()
+	defer componentParamSchemasMu.Unlock()
+	componentParamSchemas[name] = schema
+}
+
+// ParamSchemaFor returns the schema of a registered strategy or component
+func ParamSchemaFor(name string) ([]ParamSpec, bool) {
+	if strategy, ok := GetStrategy(name); ok {
+		return strategy.ParamSchema(), true
+	}
+
+	return componentParamSchema(name)
+}
+
+func componentParamSchema(name string) ([]ParamSpec, bool) {
+	componentParamSchemasMu.RLock()
+	defer componentParamSchemasMu.RUnlock()
+	if schema, ok := componentParamSchemas[name]; ok {
+		return schema(), true
+	}
+	return nil, false
+}
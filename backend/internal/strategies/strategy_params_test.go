@@ -0,0 +1,100 @@
+package strategies
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateComponentParams(t *testing.T) {
+	if err := ValidateStrategyParams("professional", map[string]float64{"min_confluence": 6, "ict": 20}); err != nil {
+		t.Errorf("expected valid professional overrides, got %v", err)
+	}
+	for _, overrides := range []map[string]float64{
+		{"min_confluence": 2.5},
+		{"min_confidence": 120},
+		{"stop_atr": 1},
+	} {
+		if err := ValidateStrategyParams("professional", overrides); err == nil {
+			t.Errorf("expected %v to be rejected", overrides)
+		}
+	}
+}
+
+func TestConfigureProfessionalSignalGenerator(t *testing.T) {
+	psg, err := ConfigureProfessionalSignalGenerator(map[string]float64{"min_confidence": 90, "ict": 25})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if psg.MinConfidence != 90 || psg.Weights.ICT != 25 {
+		t.Errorf("overrides not applied: confidence %v, ict weight %v", psg.MinConfidence, psg.Weights.ICT)
+	}
+	if psg.MinConfluence != 5 || psg.Weights.Institutional != 20 {
+		t.Errorf("expected schema defaults elsewhere, got confluence %d, institutional weight %v", psg.MinConfluence, psg.Weights.Institutional)
+	}
+
+	if _, err := ConfigureProfessionalSignalGenerator(map[string]float64{"po3": 80}); err == nil {
+		t.Error("expected an out-of-range weight to be rejected")
+	}
+}
+
+func TestValidateBuiltinParams(t *testing.T) {
+	tests := []struct {
+		name      string
+		strategy  string
+		overrides map[string]float64
+		errors    []string // Substrings, one per expected error; none means valid
+	}{
+		{"defaults and grid values", "liquidity_hunter", map[string]float64{"stop_atr": 2, "min_conditions": 5, "ema_distance_pct": 0.7}, nil},
+		{"above the max", "liquidity_hunter", map[string]float64{"stop_atr": 6}, []string{"stop_atr: 6 is outside [0.25, 5]"}},
+		{"below the min", "range_master", map[string]float64{"trend_prob_min": 0.2}, []string{"trend_prob_min: 0.2 is outside"}},
+		{"off the step", "liquidity_hunter", map[string]float64{"stop_atr": 1.1}, []string{"stop_atr: 1.1 is not a multiple of 0.25"}},
+		{"int off the step", "session_trader", map[string]float64{"cooldown_bars": 12}, []string{"cooldown_bars: 12 is not a multiple of 5"}},
+		{"fractional int", "liquidity_hunter", map[string]float64{"min_conditions": 5.5}, []string{"min_conditions: 5.5 must be an integer"}},
+		{"unknown parameter", "breakout_master", map[string]float64{"min_conditions": 5}, []string{"min_conditions: unknown parameter"}},
+		{
+			"every problem at once", "scalper_pro",
+			map[string]float64{"tp1_atr": 12, "tp3_atr": 3.25, "max_volatility_spikes": 2.5, "stop_loss": 1},
+			[]string{"max_volatility_spikes: 2.5 must be an integer", "stop_loss: unknown parameter", "tp1_atr: 12 is outside", "tp3_atr: 3.25 is not a multiple of 0.5"},
+		},
+	}
+	for _, tt := range tests {
+		err := ValidateStrategyParams(tt.strategy, tt.overrides)
+		if len(tt.errors) == 0 {
+			if err != nil {
+				t.Errorf("%s: %v", tt.name, err)
+			}
+			continue
+		}
+		var paramErr *ParamValidationError
+		if !errors.As(err, &paramErr) {
+			t.Errorf("%s: want a *ParamValidationError, got %v", tt.name, err)
+			continue
+		}
+		if paramErr.Strategy != tt.strategy || len(paramErr.Errors) != len(tt.errors) {
+			t.Errorf("%s: errors %q for %s, want %d", tt.name, paramErr.Errors, paramErr.Strategy, len(tt.errors))
+			continue
+		}
+		for i, want := range tt.errors {
+			if !strings.Contains(paramErr.Errors[i], want) {
+				t.Errorf("%s: error %q does not mention %q", tt.name, paramErr.Errors[i], want)
+			}
+		}
+	}
+
+	if err := ValidateStrategyParams("no_such_strategy", map[string]float64{"stop_atr": 1}); err == nil {
+		t.Error("expected an unknown strategy to be rejected")
+	}
+}
+
+func TestParamSpecOnStep(t *testing.T) {
+	spec := ParamSpec{Default: 1.3, Min: 0.25, Max: 5, Step: 0.25}
+	for v, want := range map[float64]bool{0.25: true, 1.5: true, 0.7 + 0.3: true, 1.3: true, 1.1: false, 4.9: false} {
+		if got := spec.OnStep(v); got != want {
+			t.Errorf("OnStep(%v) = %v, want %v", v, got, want)
+		}
+	}
+	if !(ParamSpec{Min: 0, Max: 1}).OnStep(0.123) {
+		t.Error("a parameter without a step takes any value")
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"math"
 	"sort"
+
+	"tradebot/backend/internal/indicators"
 )
 
 // ==================== COMPREHENSIVE MULTI-TIMEFRAME CONFLUENCE ====================
@@ -141,11 +143,11 @@ func AnalyzeSingleTimeframe(candles []Candle, timeframe string) *TimeframeAnalys
 	currentPrice := candles[len(candles)-1].Close
 	
 	// Calculate EMAs
-	ema20 := calculateEMA(candles, 20)
-	ema50 := calculateEMA(candles, 50)
+	ema20 := indicators.EMA(candles, 20)
+	ema50 := indicators.EMA(candles, 50)
 	ema200 := 0.0
 	if len(candles) >= 200 {
-		ema200 = calculateEMA(candles, 200)
+		ema200 = indicators.EMA(candles, 200)
 	}
 	
 	ta.AboveEMA20 = currentPrice > ema20
@@ -153,7 +155,7 @@ func AnalyzeSingleTimeframe(candles []Candle, timeframe string) *TimeframeAnalys
 	ta.AboveEMA200 = currentPrice > ema200
 	
 	// Calculate RSI
-	ta.RSI = calculateRSI(candles, 14)
+	ta.RSI = indicators.RSI(candles, 14)
 	if ta.RSI < 30 {
 		ta.RSISignal = "oversold"
 	} else if ta.RSI > 70 {
@@ -195,7 +197,7 @@ func AnalyzeSingleTimeframe(candles []Candle, timeframe string) *TimeframeAnalys
 	
 	// Check for nearby order blocks
 	obs := FindOrderBlocks(candles)
-	atr := calculateATR(candles[len(candles)-14:], 14)
+	atr := indicators.ATR(candles[len(candles)-14:], 14)
 	for _, ob := range obs {
 		dist := math.Abs(currentPrice - ob.MidPoint)
 		if dist < atr*2 {
@@ -284,7 +286,6 @@ func AnalyzeSingleTimeframe(candles []Candle, timeframe string) *TimeframeAnalys
 
 
 // ==================== HELPER FUNCTIONS ====================
-// Note: calculateEMA and calculateRSI are defined in candlestick_patterns.go
 
 // calculateMACDSignal calculates MACD signal
 func calculateMACDSignal(candles []Candle) string {
@@ -292,21 +293,11 @@ func calculateMACDSignal(candles []Candle) string {
 		return "neutral"
 	}
 	
-	ema12 := calculateEMA(candles, 12)
-	ema26 := calculateEMA(candles, 26)
-	macd := ema12 - ema26
-	
-	// Simple signal line approximation
-	prevMACD := 0.0
-	if len(candles) > 27 {
-		prevEMA12 := calculateEMA(candles[:len(candles)-1], 12)
-		prevEMA26 := calculateEMA(candles[:len(candles)-1], 26)
-		prevMACD = prevEMA12 - prevEMA26
-	}
+	macd, signal, _ := indicators.MACD(candles, 12, 26, 9)
 	
-	if macd > 0 && macd > prevMACD {
+	if macd > 0 && macd > signal {
 		return "bullish"
-	} else if macd < 0 && macd < prevMACD {
+	} else if macd < 0 && macd < signal {
 		return "bearish"
 	}
 	
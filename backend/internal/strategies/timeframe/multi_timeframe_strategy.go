@@ -3,6 +3,8 @@ package timeframe
 import (
 	"log"
 	"math"
+
+	"tradebot/backend/internal/indicators"
 )
 
 // MultiTimeframeSignal represents a signal with multi-timeframe confluence
@@ -220,9 +222,9 @@ func analyze4hDirection(candles []Candle) (string, string, float64) {
 	idx := len(candles) - 1
 	
 	// Calculate EMAs
-	ema20 := calculateEMA(candles[:idx+1], 20)
-	ema50 := calculateEMA(candles[:idx+1], 50)
-	ema200 := calculateEMA(candles[:idx+1], 200)
+	ema20 := indicators.EMA(candles[:idx+1], 20)
+	ema50 := indicators.EMA(candles[:idx+1], 50)
+	ema200 := indicators.EMA(candles[:idx+1], 200)
 	
 	currentPrice := candles[idx].Close
 	
@@ -580,8 +582,8 @@ func refineEntry1m(candles []Candle, direction string, entry3m float64, obs []MT
 
 // calculateMultiTimeframeTargets calculates stops and targets
 func calculateMultiTimeframeTargets(entry float64, direction string, candles4h, candles1h []Candle) (float64, float64, float64, float64) {
-	atr4h := calculateATR(candles4h, len(candles4h)-1)
-	atr1h := calculateATR(candles1h, len(candles1h)-1)
+	atr4h := indicators.ATR(candles4h, len(candles4h)-1)
+	atr1h := indicators.ATR(candles1h, len(candles1h)-1)
 	
 	var stopLoss, tp1, tp2, tp3 float64
 	
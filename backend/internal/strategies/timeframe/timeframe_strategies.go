@@ -2,6 +2,8 @@ package timeframe
 
 import (
 	"math"
+
+	"tradebot/backend/internal/indicators"
 )
 
 // TimeframeStrategy defines strategy parameters for each timeframe
@@ -264,7 +266,7 @@ func ApplyTimeframeStrategy(candles []Candle, strategy TimeframeStrategy) []Opti
 		reasons := []string{}
 		
 		// Calculate ATR for dynamic stops
-		atr := calculateATR(candles, i)
+		atr := indicators.ATR(candles, i)
 		
 		// Check volatility filter
 		if strategy.FilterByVolatility {
@@ -398,8 +400,8 @@ func detectTrend(candles []Candle, index, period int) string {
 		return "none"
 	}
 	
-	ema20 := calculateEMA(candles, 20)
-	ema50 := calculateEMA(candles, 50)
+	ema20 := indicators.EMA(candles, 20)
+	ema50 := indicators.EMA(candles, 50)
 	
 	if ema20 > ema50 && candles[index].Close > ema20 {
 		return "bullish"
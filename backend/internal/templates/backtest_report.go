@@ -0,0 +1,436 @@
+package templates
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Chart dimensions for the SVG charts embedded in the report
+const (
+	reportChartWidth  = 1000.0
+	reportChartHeight = 260.0
+	reportChartPad    = 40.0
+)
+
+// BacktestReport is the view model for the standalone HTML backtest report
+type BacktestReport struct {
+	Title       string
+	GeneratedAt time.Time
+	Result      *UnifiedBacktestResult
+	Manifest    []ReportManifestEntry
+	EquitySVG   template.HTML
+	DrawdownSVG template.HTML
+	MonteCarlo  *AdvancedMonteCarloResult
+	BandsSVG    template.HTML
+	Heatmap     ReportHeatmap
+	ExitReasons []ReportExitReason
+	Trades      []ReportTrade
+}
+
+// ReportManifestEntry is one key/value line of the run manifest
+type ReportManifestEntry struct {
+	Key   string
+	Value string
+}
+
+// ReportHeatmap is a year x month grid of returns
+type ReportHeatmap struct {
+	Months []string
+	Rows   []ReportHeatmapRow
+}
+
+// ReportHeatmapRow holds the monthly cells and yearly total for one year
+type ReportHeatmapRow struct {
+	Year  int
+	Cells []ReportHeatmapCell
+	Total float64
+}
+
+// ReportHeatmapCell is a single month cell with its background color
+type ReportHeatmapCell struct {
+	HasData bool
+	Return  float64
+	Color   template.CSS
+}
+
+// ReportExitReason summarizes trades by exit reason
+type ReportExitReason struct {
+	Reason    string
+	Count     int
+	Percent   float64
+	NetProfit float64
+	WinRate   float64
+}
+
+// ReportTrade is a trade row with formatted timestamps
+type ReportTrade struct {
+	Trade
+	Number    int
+	EntryTime string
+	ExitTime  string
+}
+
+// BuildBacktestReport assembles the report view model for a stored backtest
+func BuildBacktestReport(stored *StoredBacktest) *BacktestReport {
+	result := stored.Result
+
+	report := &BacktestReport{
+		Title:       fmt.Sprintf("Backtest Report - %s %s", stored.Config.Symbol, result.StrategyName),
+		GeneratedAt: time.Now().UTC(),
+		Result:      result,
+		Manifest:    buildReportManifest(stored),
+		Heatmap:     buildMonthlyHeatmap(result),
+		ExitReasons: buildExitReasonBreakdown(result),
+		Trades:      buildReportTrades(result),
+	}
+
+	equity, drawdown := equityAndDrawdown(result)
+	report.EquitySVG = renderLineChartSVG(equity, "#667eea", "Equity ($)")
+	report.DrawdownSVG = renderDrawdownSVG(drawdown)
+
+	// Fixed seed so the same stored result always renders the same bands
+	if len(result.Trades) >= 2 {
+		mc, err := RunAdvancedMonteCarlo(result.Trades, result.StartBalance, MonteCarloConfig{
+			Method: MonteCarloBlockBootstrap,
+			Runs:   1000,
+			Seed:   42,
+		})
+		if err == nil {
+			report.MonteCarlo = mc
+			report.BandsSVG = renderMonteCarloBandsSVG(mc.EquityBands)
+		}
+	}
+
+	return report
+}
+
+// RenderBacktestReport writes the report as one self-contained HTML document
+func RenderBacktestReport(w io.Writer, stored *StoredBacktest) error {
+	tmpl, err := template.New("report").Funcs(templateFuncs).Parse(GetBacktestReportTemplate())
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(w, BuildBacktestReport(stored))
+}
+
+// buildReportManifest lists everything needed to reproduce the run
+func buildReportManifest(stored *StoredBacktest) []ReportManifestEntry {
+	cfg := stored.Config
+	result := stored.Result
+
+	engine := "standard"
+	switch {
+	case cfg.EnableParallel && len(cfg.Strategies) > 1:
+		engine = "parallel (" + strings.Join(cfg.Strategies, ", ") + ")"
+	case cfg.UseWalkForward:
+		engine = fmt.Sprintf("walk-forward (%dd train / %dd test)", cfg.TrainingDays, cfg.TestingDays)
+	case cfg.EnablePartialExits:
+		engine = "partial exits"
+	}
+
+	return []ReportManifestEntry{
+		{"Result ID", stored.ID},
+		{"Run At", stored.CreatedAt.UTC().Format("2006-01-02 15:04:05 UTC")},
+		{"Symbol", cfg.Symbol},
+		{"Interval", cfg.Interval},
+		{"Days", fmt.Sprintf("%d", cfg.Days)},
+		{"Strategy", result.StrategyName},
+		{"Engine", engine},
+		{"Window", fmt.Sprintf("%s (min %d / max %d)", cfg.WindowType, cfg.MinWindow, cfg.MaxWindow)},
+		{"Start Balance", fmt.Sprintf("$%.2f", cfg.StartBalance)},
+		{"Risk Per Trade", fmt.Sprintf("%.2f%%", cfg.RiskPercent*100)},
+		{"Slippage", fmt.Sprintf("%.3f%%", cfg.SlippagePercent*100)},
+		{"Fees", fmt.Sprintf("%.3f%%", cfg.FeePercent*100)},
+		{"Max Trades/Day", fmt.Sprintf("%d", cfg.MaxTradesPerDay)},
+		{"Max Consecutive Losses", fmt.Sprintf("%d", cfg.MaxConsecutiveLoss)},
+		{"Trading Hours Only", fmt.Sprintf("%v", cfg.TradingHoursOnly)},
+		{"Run Duration", result.Duration},
+		{"Go Version", runtime.Version()},
+	}
+}
+
+// equityAndDrawdown rebuilds the equity curve and drawdown % from the trade list
+func equityAndDrawdown(result *UnifiedBacktestResult) ([]float64, []float64) {
+	equity := make([]float64, 0, len(result.Trades)+1)
+	drawdown := make([]float64, 0, len(result.Trades)+1)
+
+	balance := result.StartBalance
+	peak := balance
+	equity = append(equity, balance)
+	drawdown = append(drawdown, 0)
+
+	for _, trade := range result.Trades {
+		balance += trade.Profit
+		if balance > peak {
+			peak = balance
+		}
+		dd := 0.0
+		if peak > 0 {
+			dd = (peak - balance) / peak * 100
+		}
+		equity = append(equity, balance)
+		drawdown = append(drawdown, -dd)
+	}
+
+	return equity, drawdown
+}
+
+// buildMonthlyHeatmap groups trade profit by exit month as % of the balance at month start
+func buildMonthlyHeatmap(result *UnifiedBacktestResult) ReportHeatmap {
+	heatmap := ReportHeatmap{
+		Months: []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	}
+
+	type monthKey struct{ year, month int }
+	profit := make(map[monthKey]float64)
+	monthStart := make(map[monthKey]float64)
+
+	balance := result.StartBalance
+	for _, trade := range result.Trades {
+		ts := trade.ExitTime
+		if ts == 0 {
+			ts = trade.EntryTime
+		}
+		if ts == 0 {
+			continue
+		}
+		t := time.Unix(ts/1000, 0).UTC()
+		key := monthKey{t.Year(), int(t.Month())}
+		if _, ok := monthStart[key]; !ok {
+			monthStart[key] = balance
+		}
+		profit[key] += trade.Profit
+		balance += trade.Profit
+	}
+
+	if len(profit) == 0 {
+		return heatmap
+	}
+
+	years := []int{}
+	seen := map[int]bool{}
+	maxAbs := 0.0
+	returns := make(map[monthKey]float64)
+	for key, p := range profit {
+		if !seen[key.year] {
+			seen[key.year] = true
+			years = append(years, key.year)
+		}
+		ret := 0.0
+		if monthStart[key] > 0 {
+			ret = p / monthStart[key] * 100
+		}
+		returns[key] = ret
+		maxAbs = math.Max(maxAbs, math.Abs(ret))
+	}
+	sort.Ints(years)
+
+	for _, year := range years {
+		row := ReportHeatmapRow{Year: year, Cells: make([]ReportHeatmapCell, 12)}
+		compound := 1.0
+		for m := 1; m <= 12; m++ {
+			ret, ok := returns[monthKey{year, m}]
+			if !ok {
+				continue
+			}
+			row.Cells[m-1] = ReportHeatmapCell{
+				HasData: true,
+				Return:  ret,
+				Color:   heatmapColor(ret, maxAbs),
+			}
+			compound *= 1 + ret/100
+		}
+		row.Total = (compound - 1) * 100
+		heatmap.Rows = append(heatmap.Rows, row)
+	}
+
+	return heatmap
+}
+
+// heatmapColor scales green/red intensity by the size of the return
+func heatmapColor(ret, maxAbs float64) template.CSS {
+	if maxAbs == 0 {
+		return template.CSS("background-color: #f5f5f5")
+	}
+	alpha := 0.15 + 0.75*math.Abs(ret)/maxAbs
+	if ret >= 0 {
+		return template.CSS(fmt.Sprintf("background-color: rgba(76, 175, 80, %.2f)", alpha))
+	}
+	return template.CSS(fmt.Sprintf("background-color: rgba(244, 67, 54, %.2f)", alpha))
+}
+
+// buildExitReasonBreakdown aggregates count, net profit and win rate per exit reason
+func buildExitReasonBreakdown(result *UnifiedBacktestResult) []ReportExitReason {
+	byReason := make(map[string]*ReportExitReason)
+	wins := make(map[string]int)
+
+	for _, trade := range result.Trades {
+		entry, ok := byReason[trade.ExitReason]
+		if !ok {
+			entry = &ReportExitReason{Reason: trade.ExitReason}
+			byReason[trade.ExitReason] = entry
+		}
+		entry.Count++
+		entry.NetProfit += trade.Profit
+		if trade.Profit > 0 {
+			wins[trade.ExitReason]++
+		}
+	}
+
+	breakdown := make([]ReportExitReason, 0, len(byReason))
+	for reason, entry := range byReason {
+		entry.Percent = float64(entry.Count) / float64(len(result.Trades)) * 100
+		entry.WinRate = float64(wins[reason]) / float64(entry.Count) * 100
+		breakdown = append(breakdown, *entry)
+	}
+	sort.Slice(breakdown, func(i, j int) bool {
+		return breakdown[i].Count > breakdown[j].Count
+	})
+
+	return breakdown
+}
+
+// buildReportTrades numbers trades and formats their timestamps
+func buildReportTrades(result *UnifiedBacktestResult) []ReportTrade {
+	trades := make([]ReportTrade, len(result.Trades))
+	for i, trade := range result.Trades {
+		trades[i] = ReportTrade{
+			Trade:     trade,
+			Number:    i + 1,
+			EntryTime: formatReportTime(trade.EntryTime),
+			ExitTime:  formatReportTime(trade.ExitTime),
+		}
+	}
+	return trades
+}
+
+func formatReportTime(ms int64) string {
+	if ms == 0 {
+		return "-"
+	}
+	return time.Unix(ms/1000, 0).UTC().Format("2006-01-02 15:04")
+}
+
+// chartScale maps series indices and values onto SVG coordinates
+type chartScale struct {
+	n          int
+	lastTrade  int // Trade number labelled at the right edge
+	minY, maxY float64
+}
+
+func newChartScale(n int, series ...[]float64) chartScale {
+	s := chartScale{n: n, lastTrade: n - 1, minY: math.Inf(1), maxY: math.Inf(-1)}
+	for _, values := range series {
+		for _, v := range values {
+			s.minY = math.Min(s.minY, v)
+			s.maxY = math.Max(s.maxY, v)
+		}
+	}
+	if math.IsInf(s.minY, 0) {
+		s.minY, s.maxY = 0, 1
+	}
+	if s.maxY == s.minY {
+		s.maxY = s.minY + 1
+	}
+	return s
+}
+
+func (s chartScale) x(i int) float64 {
+	if s.n <= 1 {
+		return reportChartPad
+	}
+	return reportChartPad + float64(i)/float64(s.n-1)*(reportChartWidth-2*reportChartPad)
+}
+
+func (s chartScale) y(v float64) float64 {
+	return reportChartHeight - reportChartPad - (v-s.minY)/(s.maxY-s.minY)*(reportChartHeight-2*reportChartPad)
+}
+
+func (s chartScale) points(values []float64) string {
+	var b strings.Builder
+	for i, v := range values {
+		fmt.Fprintf(&b, "%.1f,%.1f ", s.x(i), s.y(v))
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// band returns a closed polygon between a lower and upper series
+func (s chartScale) band(lower, upper []float64) string {
+	var b strings.Builder
+	for i, v := range upper {
+		fmt.Fprintf(&b, "%.1f,%.1f ", s.x(i), s.y(v))
+	}
+	for i := len(lower) - 1; i >= 0; i-- {
+		fmt.Fprintf(&b, "%.1f,%.1f ", s.x(i), s.y(lower[i]))
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// svgFrame draws the axes and min/max labels shared by every chart
+func svgFrame(b *strings.Builder, s chartScale, label, format string) {
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %.0f %.0f" width="100%%" preserveAspectRatio="none">`,
+		reportChartWidth, reportChartHeight)
+	fmt.Fprintf(b, `<rect x="0" y="0" width="%.0f" height="%.0f" fill="#fff"/>`, reportChartWidth, reportChartHeight)
+	fmt.Fprintf(b, `<line x1="%.0f" y1="%.0f" x2="%.0f" y2="%.0f" stroke="#ccc"/>`,
+		reportChartPad, reportChartHeight-reportChartPad, reportChartWidth-reportChartPad, reportChartHeight-reportChartPad)
+	fmt.Fprintf(b, `<line x1="%.0f" y1="%.0f" x2="%.0f" y2="%.0f" stroke="#ccc"/>`,
+		reportChartPad, reportChartPad, reportChartPad, reportChartHeight-reportChartPad)
+	fmt.Fprintf(b, `<text x="%.0f" y="%.0f" font-size="11" fill="#666">`+format+`</text>`, 2.0, reportChartPad-4, s.maxY)
+	fmt.Fprintf(b, `<text x="%.0f" y="%.0f" font-size="11" fill="#666">`+format+`</text>`, 2.0, reportChartHeight-reportChartPad+14, s.minY)
+	fmt.Fprintf(b, `<text x="%.0f" y="%.0f" font-size="11" fill="#666" text-anchor="end">%s</text>`,
+		reportChartWidth-reportChartPad, reportChartPad-4, template.HTMLEscapeString(label))
+	fmt.Fprintf(b, `<text x="%.0f" y="%.0f" font-size="11" fill="#666" text-anchor="end">trade #%d</text>`,
+		reportChartWidth-reportChartPad, reportChartHeight-reportChartPad+14, s.lastTrade)
+}
+
+// renderLineChartSVG draws a single series as an inline SVG polyline
+func renderLineChartSVG(values []float64, color, label string) template.HTML {
+	s := newChartScale(len(values), values)
+	var b strings.Builder
+	svgFrame(&b, s, label, "%.2f")
+	fmt.Fprintf(&b, `<polyline fill="none" stroke="%s" stroke-width="2" points="%s"/>`, color, s.points(values))
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// renderDrawdownSVG draws the (negative) drawdown series as a filled area
+func renderDrawdownSVG(drawdown []float64) template.HTML {
+	zero := make([]float64, len(drawdown))
+	s := newChartScale(len(drawdown), drawdown, zero)
+	var b strings.Builder
+	svgFrame(&b, s, "Drawdown (%)", "%.2f%%")
+	fmt.Fprintf(&b, `<polygon fill="rgba(244, 67, 54, 0.35)" stroke="#f44336" stroke-width="1" points="%s"/>`, s.band(drawdown, zero))
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// renderMonteCarloBandsSVG draws the 5-95 and 25-75 equity bands with the median path
+func renderMonteCarloBandsSVG(bands []EquityBandPoint) template.HTML {
+	p5 := make([]float64, len(bands))
+	p25 := make([]float64, len(bands))
+	p50 := make([]float64, len(bands))
+	p75 := make([]float64, len(bands))
+	p95 := make([]float64, len(bands))
+	for i, band := range bands {
+		p5[i], p25[i], p50[i], p75[i], p95[i] = band.P5, band.P25, band.P50, band.P75, band.P95
+	}
+
+	s := newChartScale(len(bands), p5, p95)
+	if len(bands) > 0 {
+		// Bands are sampled at a stride on long runs
+		s.lastTrade = bands[len(bands)-1].Trade
+	}
+	var b strings.Builder
+	svgFrame(&b, s, "Simulated equity ($)", "%.2f")
+	fmt.Fprintf(&b, `<polygon fill="rgba(102, 126, 234, 0.18)" stroke="none" points="%s"/>`, s.band(p5, p95))
+	fmt.Fprintf(&b, `<polygon fill="rgba(102, 126, 234, 0.35)" stroke="none" points="%s"/>`, s.band(p25, p75))
+	fmt.Fprintf(&b, `<polyline fill="none" stroke="#764ba2" stroke-width="2" points="%s"/>`, s.points(p50))
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
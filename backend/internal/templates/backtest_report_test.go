@@ -0,0 +1,116 @@
+package templates
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// reportFixture builds a stored backtest with trades in Jan and Feb 2024
+func reportFixture() *StoredBacktest {
+	jan := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC).UnixMilli()
+	feb := time.Date(2024, 2, 5, 12, 0, 0, 0, time.UTC).UnixMilli()
+	trades := []Trade{
+		{Type: "BUY", ExitReason: "Take Profit", Profit: 200, EntryTime: jan, ExitTime: jan},
+		{Type: "SELL", ExitReason: "Stop Loss", Profit: -100, EntryTime: jan, ExitTime: jan},
+		{Type: "BUY", ExitReason: "Take Profit", Profit: 300, EntryTime: feb, ExitTime: feb},
+		{Type: "BUY", ExitReason: "Stop Loss", Profit: -510, EntryTime: feb, ExitTime: feb},
+	}
+	return &StoredBacktest{
+		ID:        "bt_1",
+		CreatedAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Config:    UnifiedBacktestConfig{Symbol: "BTCUSDT", Interval: "15m", Days: 60, StartBalance: 1000, RiskPercent: 0.01},
+		Result: &UnifiedBacktestResult{
+			StrategyName: "session_trader",
+			StartBalance: 1000,
+			FinalBalance: 890,
+			TotalTrades:  len(trades),
+			Trades:       trades,
+		},
+	}
+}
+
+func TestEquityAndDrawdown(t *testing.T) {
+	equity, drawdown := equityAndDrawdown(reportFixture().Result)
+
+	wantEquity := []float64{1000, 1200, 1100, 1400, 890}
+	wantDrawdown := []float64{0, 0, -100.0 / 1200 * 100, 0, -510.0 / 1400 * 100}
+	for i := range wantEquity {
+		if math.Abs(equity[i]-wantEquity[i]) > 1e-9 {
+			t.Fatalf("equity[%d]: want %.2f, got %.2f", i, wantEquity[i], equity[i])
+		}
+		if math.Abs(drawdown[i]-wantDrawdown[i]) > 1e-9 {
+			t.Fatalf("drawdown[%d]: want %.4f, got %.4f", i, wantDrawdown[i], drawdown[i])
+		}
+	}
+}
+
+func TestMonthlyHeatmap(t *testing.T) {
+	heatmap := buildMonthlyHeatmap(reportFixture().Result)
+	if len(heatmap.Rows) != 1 || heatmap.Rows[0].Year != 2024 {
+		t.Fatalf("want one 2024 row, got %+v", heatmap.Rows)
+	}
+
+	cells := heatmap.Rows[0].Cells
+	// Jan: +100 on 1000, Feb: -210 on 1100
+	if !cells[0].HasData || math.Abs(cells[0].Return-10) > 1e-9 {
+		t.Fatalf("jan: want +10%%, got %+v", cells[0])
+	}
+	if !cells[1].HasData || math.Abs(cells[1].Return-(-210.0/1100*100)) > 1e-9 {
+		t.Fatalf("feb: want %.4f%%, got %+v", -210.0/1100*100, cells[1])
+	}
+	if cells[2].HasData {
+		t.Fatalf("mar should be empty")
+	}
+	if math.Abs(heatmap.Rows[0].Total-(-11)) > 1e-9 {
+		t.Fatalf("year total: want -11%%, got %.4f", heatmap.Rows[0].Total)
+	}
+	if !strings.Contains(string(cells[0].Color), "76, 175, 80") || !strings.Contains(string(cells[1].Color), "244, 67, 54") {
+		t.Fatalf("want green gain and red loss, got %q / %q", cells[0].Color, cells[1].Color)
+	}
+}
+
+func TestExitReasonBreakdown(t *testing.T) {
+	breakdown := buildExitReasonBreakdown(reportFixture().Result)
+	if len(breakdown) != 2 {
+		t.Fatalf("want 2 exit reasons, got %d", len(breakdown))
+	}
+	for _, reason := range breakdown {
+		switch reason.Reason {
+		case "Take Profit":
+			if reason.Count != 2 || reason.NetProfit != 500 || reason.WinRate != 100 || reason.Percent != 50 {
+				t.Fatalf("take profit: got %+v", reason)
+			}
+		case "Stop Loss":
+			if reason.Count != 2 || reason.NetProfit != -610 || reason.WinRate != 0 {
+				t.Fatalf("stop loss: got %+v", reason)
+			}
+		default:
+			t.Fatalf("unexpected reason %q", reason.Reason)
+		}
+	}
+}
+
+func TestRenderBacktestReportIsSelfContained(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderBacktestReport(&buf, reportFixture()); err != nil {
+		t.Fatal(err)
+	}
+	html := buf.String()
+
+	if got := strings.Count(html, "<svg"); got != 3 {
+		t.Fatalf("want equity, drawdown and Monte Carlo charts, got %d svg elements", got)
+	}
+	for _, want := range []string{"BTCUSDT", "session_trader", "bt_1", "Take Profit", "Risk of ruin"} {
+		if !strings.Contains(html, want) {
+			t.Fatalf("report is missing %q", want)
+		}
+	}
+	for _, external := range []string{"<script src", "<link ", "cdn."} {
+		if strings.Contains(html, external) {
+			t.Fatalf("report references an external asset: %q", external)
+		}
+	}
+}
@@ -90,3 +90,23 @@ func HandleLiveSignalsPage(c *fiber.Ctx) error {
 func HandlePaperTradingPage(c *fiber.Ctx) error {
 	return c.SendFile("../public/paper-trading.html")
 }
+
+// HandleBacktestReport downloads a stored unified backtest as a standalone HTML report
+func HandleBacktestReport(c *fiber.Ctx) error {
+	id := c.Params("id")
+	stored, ok := GetBacktestResultStore().Get(id)
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{
+			"error": "Backtest result not found: " + id,
+		})
+	}
+
+	c.Set("Content-Type", "text/html; charset=utf-8")
+	if c.Query("inline") != "true" {
+		c.Set("Content-Disposition", "attachment; filename=\"backtest_report_"+stored.ID+".html\"")
+	}
+	if err := RenderBacktestReport(c.Response().BodyWriter(), stored); err != nil {
+		return c.Status(500).SendString("Report error: " + err.Error())
+	}
+	return nil
+}
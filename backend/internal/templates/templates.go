@@ -522,6 +522,218 @@ func GetLiveSignalsTemplate() string {
 </html>`
 }
 
+// GetBacktestReportTemplate returns the standalone backtest report template (no external assets)
+func GetBacktestReportTemplate() string {
+	return `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Title}}</title>
+    <style>
+        * { margin: 0; padding: 0; box-sizing: border-box; }
+        body {
+            font-family: 'Segoe UI', Tahoma, Geneva, Verdana, sans-serif;
+            background: #f3f4f8;
+            color: #333;
+            padding: 20px;
+        }
+        .container { max-width: 1200px; margin: 0 auto; }
+        .header {
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            color: white;
+            border-radius: 12px;
+            padding: 24px;
+            margin-bottom: 20px;
+        }
+        .header h1 { font-size: 2em; margin-bottom: 6px; }
+        .header p { opacity: 0.9; }
+        .card {
+            background: white;
+            border-radius: 12px;
+            padding: 24px;
+            margin-bottom: 20px;
+            box-shadow: 0 4px 6px rgba(0,0,0,0.1);
+        }
+        .card h2 { margin-bottom: 16px; font-size: 1.3em; }
+        .stats-grid {
+            display: grid;
+            grid-template-columns: repeat(auto-fit, minmax(160px, 1fr));
+            gap: 12px;
+        }
+        .stat-card {
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            color: white;
+            padding: 16px;
+            border-radius: 8px;
+            text-align: center;
+        }
+        .stat-card h3 { font-size: 12px; opacity: 0.9; margin-bottom: 6px; }
+        .stat-card p { font-size: 22px; font-weight: bold; }
+        .profit { color: #4CAF50; }
+        .loss { color: #f44336; }
+        .table { width: 100%; border-collapse: collapse; font-size: 13px; }
+        .table th, .table td { padding: 8px; text-align: left; border-bottom: 1px solid #eee; }
+        .table th { background: #f5f5f5; font-weight: 600; }
+        .heatmap td, .heatmap th { text-align: center; }
+        .chart { margin-bottom: 12px; }
+        .muted { color: #888; font-size: 12px; }
+        .two-col { display: grid; grid-template-columns: 1fr 1fr; gap: 20px; }
+        @media (max-width: 800px) { .two-col { grid-template-columns: 1fr; } }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>📈 {{.Title}}</h1>
+            <p>Generated at {{.GeneratedAt.Format "2006-01-02 15:04:05"}} UTC</p>
+        </div>
+
+        <div class="card">
+            <h2>Summary</h2>
+            <div class="stats-grid">
+                <div class="stat-card"><h3>Total Trades</h3><p>{{.Result.TotalTrades}}</p></div>
+                <div class="stat-card"><h3>Win Rate</h3><p>{{printf "%.1f" .Result.WinRate}}%</p></div>
+                <div class="stat-card"><h3>Return</h3><p>{{printf "%.2f" .Result.ReturnPercent}}%</p></div>
+                <div class="stat-card"><h3>Profit Factor</h3><p>{{printf "%.2f" .Result.ProfitFactor}}</p></div>
+                <div class="stat-card"><h3>Max Drawdown</h3><p>{{printf "%.2f" .Result.MaxDrawdown}}%</p></div>
+                <div class="stat-card"><h3>Sharpe</h3><p>{{printf "%.2f" .Result.SharpeRatio}}</p></div>
+                <div class="stat-card"><h3>Sortino</h3><p>{{printf "%.2f" .Result.SortinoRatio}}</p></div>
+                <div class="stat-card"><h3>Expectancy</h3><p>${{printf "%.2f" .Result.ExpectancyPerTrade}}</p></div>
+                <div class="stat-card"><h3>Final Balance</h3><p>${{printf "%.2f" .Result.FinalBalance}}</p></div>
+            </div>
+        </div>
+
+        <div class="card">
+            <h2>Equity &amp; Drawdown</h2>
+            <div class="chart">{{.EquitySVG}}</div>
+            <div class="chart">{{.DrawdownSVG}}</div>
+        </div>
+
+        <div class="card">
+            <h2>Monthly Returns</h2>
+            {{if .Heatmap.Rows}}
+            <table class="table heatmap">
+                <thead>
+                    <tr><th>Year</th>{{range .Heatmap.Months}}<th>{{.}}</th>{{end}}<th>Year</th></tr>
+                </thead>
+                <tbody>
+                    {{range .Heatmap.Rows}}
+                    <tr>
+                        <th>{{.Year}}</th>
+                        {{range .Cells}}
+                        {{if .HasData}}<td style="{{.Color}}">{{printf "%.1f" .Return}}%</td>{{else}}<td class="muted">-</td>{{end}}
+                        {{end}}
+                        <th class="{{if ge .Total 0.0}}profit{{else}}loss{{end}}">{{printf "%.1f" .Total}}%</th>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+            {{else}}
+            <p class="muted">No timestamped trades to group by month.</p>
+            {{end}}
+        </div>
+
+        <div class="two-col">
+            <div class="card">
+                <h2>Exit Reasons</h2>
+                <table class="table">
+                    <thead><tr><th>Reason</th><th>Trades</th><th>Share</th><th>Win Rate</th><th>Net Profit</th></tr></thead>
+                    <tbody>
+                        {{range .ExitReasons}}
+                        <tr>
+                            <td>{{.Reason}}</td>
+                            <td>{{.Count}}</td>
+                            <td>{{printf "%.1f" .Percent}}%</td>
+                            <td>{{printf "%.1f" .WinRate}}%</td>
+                            <td class="{{if ge .NetProfit 0.0}}profit{{else}}loss{{end}}">${{printf "%.2f" .NetProfit}}</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+            </div>
+
+            <div class="card">
+                <h2>Run Manifest</h2>
+                <table class="table">
+                    <tbody>
+                        {{range .Manifest}}
+                        <tr><th>{{.Key}}</th><td>{{.Value}}</td></tr>
+                        {{end}}
+                    </tbody>
+                </table>
+            </div>
+        </div>
+
+        {{if .MonteCarlo}}
+        <div class="card">
+            <h2>Monte Carlo ({{.MonteCarlo.Method}}, {{.MonteCarlo.Runs}} runs)</h2>
+            <div class="chart">{{.BandsSVG}}</div>
+            <p class="muted">Shaded bands: 5th-95th and 25th-75th percentile equity; line: median path.</p>
+            <table class="table">
+                <thead><tr><th></th><th>P5</th><th>P25</th><th>Median</th><th>P75</th><th>P95</th></tr></thead>
+                <tbody>
+                    <tr>
+                        <th>Return %</th>
+                        <td>{{printf "%.2f" .MonteCarlo.ReturnPercentiles.P5}}</td>
+                        <td>{{printf "%.2f" .MonteCarlo.ReturnPercentiles.P25}}</td>
+                        <td>{{printf "%.2f" .MonteCarlo.ReturnPercentiles.P50}}</td>
+                        <td>{{printf "%.2f" .MonteCarlo.ReturnPercentiles.P75}}</td>
+                        <td>{{printf "%.2f" .MonteCarlo.ReturnPercentiles.P95}}</td>
+                    </tr>
+                    <tr>
+                        <th>Max Drawdown %</th>
+                        <td>{{printf "%.2f" .MonteCarlo.MaxDrawdownBands.P5}}</td>
+                        <td>{{printf "%.2f" .MonteCarlo.MaxDrawdownBands.P25}}</td>
+                        <td>{{printf "%.2f" .MonteCarlo.MaxDrawdownBands.P50}}</td>
+                        <td>{{printf "%.2f" .MonteCarlo.MaxDrawdownBands.P75}}</td>
+                        <td>{{printf "%.2f" .MonteCarlo.MaxDrawdownBands.P95}}</td>
+                    </tr>
+                </tbody>
+            </table>
+            <p style="margin-top: 10px;">
+                Risk of ruin ({{printf "%.0f" .MonteCarlo.RuinThresholdPercent}}% loss): <strong>{{printf "%.2f" .MonteCarlo.RiskOfRuin}}%</strong>
+                &nbsp;|&nbsp; Probability of profit: <strong>{{printf "%.1f" .MonteCarlo.ProbabilityProfit}}%</strong>
+            </p>
+        </div>
+        {{end}}
+
+        <div class="card">
+            <h2>Trades ({{len .Trades}} total)</h2>
+            <div style="overflow-x: auto;">
+                <table class="table">
+                    <thead>
+                        <tr>
+                            <th>#</th><th>Entry Time</th><th>Exit Time</th><th>Type</th><th>Entry</th><th>Exit</th>
+                            <th>Stop</th><th>Exit Reason</th><th>Bars</th><th>Profit</th><th>RR</th><th>Balance</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {{range .Trades}}
+                        <tr>
+                            <td>{{.Number}}</td>
+                            <td>{{.EntryTime}}</td>
+                            <td>{{.ExitTime}}</td>
+                            <td><strong>{{.Type}}</strong></td>
+                            <td>{{printf "%.4f" .Entry}}</td>
+                            <td>{{printf "%.4f" .Exit}}</td>
+                            <td>{{printf "%.4f" .StopLoss}}</td>
+                            <td>{{.ExitReason}}</td>
+                            <td>{{.CandlesHeld}}</td>
+                            <td class="{{if ge .Profit 0.0}}profit{{else}}loss{{end}}">${{printf "%.2f" .Profit}}</td>
+                            <td>{{printf "%.2f" .RR}}</td>
+                            <td>${{printf "%.2f" .BalanceAfter}}</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+            </div>
+        </div>
+    </div>
+</body>
+</html>`
+}
+
 // Template functions
 var templateFuncs = template.FuncMap{
 	"add": func(a, b int) int {
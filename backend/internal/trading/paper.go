@@ -2,6 +2,7 @@ package trading
 
 import (
 	"encoding/json"
+	"math"
 	"os"
 	"sync"
 	"time"
@@ -10,6 +11,8 @@ import (
 // PaperTrade represents a single paper trade
 type PaperTrade struct {
 	ID           int       `json:"id"`
+	Symbol       string    `json:"symbol,omitempty"`   // Set for trades managed by the session that opened them
+	Strategy     string    `json:"strategy,omitempty"`
 	Signal       string    `json:"signal"`
 	Entry        float64   `json:"entry"`
 	StopLoss     float64   `json:"stopLoss"`
@@ -20,7 +23,7 @@ type PaperTrade struct {
 	EntryTime    time.Time `json:"entryTime"`
 	ExitTime     *time.Time `json:"exitTime,omitempty"`
 	ExitPrice    float64   `json:"exitPrice"`
-	ExitReason   string    `json:"exitReason"` // "TP1", "TP2", "TP3", "StopLoss", "Open"
+	ExitReason   string    `json:"exitReason"` // "TP1", "TP2", "TP3", "StopLoss", "EOD", "Open"
 	Profit       float64   `json:"profit"`
 	ProfitPercent float64  `json:"profitPercent"`
 	Status       string    `json:"status"` // "open", "won", "lost"
@@ -90,11 +93,8 @@ func (ptm *PaperTradingManager) loadTrades() {
 	}
 }
 
-// saveTrades saves trades to file
+// saveTrades saves trades to file; callers hold ptm.mu
 func (ptm *PaperTradingManager) saveTrades() error {
-	ptm.mu.RLock()
-	defer ptm.mu.RUnlock()
-	
 	data := struct {
 		Trades        []PaperTrade `json:"trades"`
 		StartBalance  float64      `json:"startBalance"`
@@ -113,12 +113,21 @@ func (ptm *PaperTradingManager) saveTrades() error {
 	return os.WriteFile(ptm.dataFile, jsonData, 0644)
 }
 
-// AddTrade adds a new paper trade
+// AddTrade adds a new paper trade risking the default share of the balance
 func (ptm *PaperTradingManager) AddTrade(signal *AdvancedSignal, currentPrice float64) *PaperTrade {
+	return ptm.AddTradeAtRisk(signal, currentPrice, 0)
+}
+
+// AddTradeAtRisk adds a new paper trade risking riskPercent of the balance,
+// a fraction such as 0.01; zero uses the default
+func (ptm *PaperTradingManager) AddTradeAtRisk(signal *AdvancedSignal, currentPrice, riskPercent float64) *PaperTrade {
 	ptm.mu.Lock()
 	defer ptm.mu.Unlock()
 	
-	riskAmount := ptm.currentBalance * ptm.riskPercent
+	if riskPercent <= 0 {
+		riskPercent = ptm.riskPercent
+	}
+	riskAmount := ptm.currentBalance * riskPercent
 	
 	trade := PaperTrade{
 		ID:         len(ptm.trades) + 1,
@@ -154,6 +163,11 @@ func (ptm *PaperTradingManager) UpdateOpenTrades(currentPrice float64) []PaperTr
 		
 		trade := &ptm.trades[i]
 		
+		// Symbol trades are closed by the session that opened them
+		if trade.Symbol != "" {
+			continue
+		}
+		
 		if trade.Signal == "BUY" {
 			// Check if TP or SL hit
 			if currentPrice >= trade.TP3 {
@@ -218,6 +232,66 @@ func (ptm *PaperTradingManager) UpdateOpenTrades(currentPrice float64) []PaperTr
 	return closedTrades
 }
 
+// OpenTrade records a filled entry on symbol without take-profit levels.
+// The trade stays open until CloseTrade is called for it.
+func (ptm *PaperTradingManager) OpenTrade(symbol, strategy, side string, entry, stopLoss float64, at time.Time) *PaperTrade {
+	ptm.mu.Lock()
+	defer ptm.mu.Unlock()
+	
+	trade := PaperTrade{
+		ID:         len(ptm.trades) + 1,
+		Symbol:     symbol,
+		Strategy:   strategy,
+		Signal:     side,
+		Entry:      entry,
+		StopLoss:   stopLoss,
+		EntryTime:  at,
+		ExitReason: "Open",
+		Status:     "open",
+		RiskAmount: ptm.currentBalance * ptm.riskPercent,
+	}
+	
+	ptm.trades = append(ptm.trades, trade)
+	ptm.saveTrades()
+	
+	return &trade
+}
+
+// CloseTrade exits an open trade at price. Profit is the trade's risk amount
+// scaled by the R multiple of the exit. Returns nil if the trade is not open.
+func (ptm *PaperTradingManager) CloseTrade(id int, price float64, reason string, at time.Time) *PaperTrade {
+	ptm.mu.Lock()
+	defer ptm.mu.Unlock()
+	
+	if id < 1 || id > len(ptm.trades) || ptm.trades[id-1].Status != "open" {
+		return nil
+	}
+	trade := &ptm.trades[id-1]
+	
+	riskPerUnit := math.Abs(trade.Entry - trade.StopLoss)
+	move := price - trade.Entry
+	if trade.Signal == "SELL" {
+		move = -move
+	}
+	if riskPerUnit > 0 {
+		trade.Profit = trade.RiskAmount * move / riskPerUnit
+	}
+	
+	trade.ExitPrice = price
+	trade.ExitReason = reason
+	trade.ExitTime = &at
+	trade.Status = "won"
+	if trade.Profit < 0 {
+		trade.Status = "lost"
+	}
+	trade.ProfitPercent = (trade.Profit / ptm.currentBalance) * 100
+	ptm.currentBalance += trade.Profit
+	ptm.saveTrades()
+	
+	closed := *trade
+	return &closed
+}
+
 // GetStats returns paper trading statistics
 func (ptm *PaperTradingManager) GetStats() PaperTradingStats {
 	ptm.mu.RLock()
@@ -95,6 +95,31 @@ func main() {
 	log.Println("✅ Signal broadcaster started")
 	LogSystemSuccess("Signal broadcaster started", nil)
 	
+	// Load declarative rule strategies into the strategy registry
+	ruleDir := RuleStrategiesDir()
+	loadedRules, ruleErrs := LoadRuleStrategiesDir(ruleDir)
+	for _, err := range ruleErrs {
+		log.Printf("⚠️  Rule strategy not loaded: %v", err)
+	}
+	if len(loadedRules) > 0 {
+		log.Printf("✅ Loaded %d rule strategies from %s: %v", len(loadedRules), ruleDir, loadedRules)
+	}
+
+	// Load measured pattern stats before anything calibrates confidence with them
+	LoadPatternStats()
+
+	// Load the liquidity ledger so swept levels survive restarts
+	LoadLiquidityLedger()
+
+	// Load the parameter sets promoted from optimization runs
+	LoadLiveParams()
+
+	// Start the pattern scanner if a watchlist is configured
+	StartPatternScannerFromEnv()
+
+	// Start the live ORB session if a stock universe is configured
+	StartORBSessionFromEnv()
+
 	// Initialize Telegram bot
 	InitTelegramBot()
 	
@@ -111,6 +136,10 @@ func main() {
 			strategy = "session_trader"
 		}
 		
+		if err := SetTelegramRiskProfile(os.Getenv("TELEGRAM_RISK_PROFILE")); err != nil {
+			log.Printf("⚠️  Ignoring TELEGRAM_RISK_PROFILE: %v", err)
+		}
+		
 		// Get current filter settings from database (not from env vars)
 		filterBuy, filterSell := GetCurrentFilterSettings()
 		
@@ -48,6 +48,11 @@ ADD COLUMN IF NOT EXISTS tp1 DECIMAL(20, 8),
 ADD COLUMN IF NOT EXISTS tp2 DECIMAL(20, 8),
 ADD COLUMN IF NOT EXISTS tp3 DECIMAL(20, 8);
 
+-- Structured signal evidence (order blocks, FVGs, swept levels) for chart overlays
+ALTER TABLE trading_signals
+ADD COLUMN IF NOT EXISTS reasons JSONB,
+ADD COLUMN IF NOT EXISTS evidence JSONB;
+
 -- ============================================
 -- 2. USER SETTINGS TABLE
 -- ============================================